@@ -35,6 +35,7 @@ const (
 // TODO: adjustPageBreaks, adjustComments, adjustDataValidations, adjustProtectedCells
 //
 func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -0,0 +1,348 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/mohae/deepcopy"
+)
+
+// SheetBuilder defines a fluent builder of the worksheet, which chains the
+// calls of the functions such as SetCellValue, SetCellStyle and MergeCell to
+// make the report generation code shorter. The first error occurred will be
+// kept and the following calls will be skipped, use Err to get it after
+// building.
+type SheetBuilder struct {
+	File    *File
+	Sheet   string
+	err     error
+	pending []*RangeBuilder
+}
+
+// RangeBuilder defines a fluent builder of the cell range of the worksheet.
+// The style functions, such as Bold and Fill, are accumulated on the builder
+// and applied to all the cells of the range once by the next call of the
+// other functions of the builders, such as Value, Range and Err, or by Apply.
+// The existing styles of the cells will be replaced.
+type RangeBuilder struct {
+	sb      *SheetBuilder
+	coords  []int
+	style   Style
+	pending bool
+}
+
+// NewSheetBuilder provides a function to create the fluent builder of the
+// worksheet by given worksheet name, the worksheet will be created if it
+// doesn't exist. For example, set the bold header with the fill color and
+// the values in the worksheet Q1:
+//
+//    sb := f.NewSheetBuilder("Q1")
+//    sb.Range("A1:D1").Bold().Fill("#DDEBF7").Values([]string{"Region", "Jan", "Feb", "Mar"})
+//    sb.Range("A2:D3").Values([][]interface{}{{"East", 10, 20, 30}, {"West", 15, 25, 35}})
+//    sb.Cell("E2").Formula("SUM(B2:D2)").NumFmt(2)
+//    sb.ColWidth("A", "A", 20)
+//    if err := sb.Err(); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) NewSheetBuilder(sheet string) *SheetBuilder {
+	if f.GetSheetIndex(sheet) == -1 {
+		f.NewSheet(sheet)
+	}
+	return &SheetBuilder{File: f, Sheet: sheet}
+}
+
+// Err returns the first error occurred while building the worksheet, the
+// accumulated styles of the cell ranges will be applied before returning.
+func (sb *SheetBuilder) Err() error {
+	sb.flush()
+	return sb.err
+}
+
+// flush provides a function to apply the accumulated styles of the cell
+// ranges in the order of the style function calls.
+func (sb *SheetBuilder) flush() {
+	pending := sb.pending
+	sb.pending = nil
+	for _, rb := range pending {
+		rb.applyStyle()
+	}
+}
+
+// Range provides a function to get the fluent builder of the cell range by
+// given range reference, such as "A1:D1".
+func (sb *SheetBuilder) Range(ref string) *RangeBuilder {
+	sb.flush()
+	rb := &RangeBuilder{sb: sb}
+	if sb.err != nil {
+		return rb
+	}
+	rng := strings.Split(strings.Replace(ref, "$", "", -1), ":")
+	if len(rng) == 1 {
+		rng = append(rng, rng[0])
+	}
+	if len(rng) != 2 {
+		sb.err = errors.New("invalid range reference " + ref)
+		return rb
+	}
+	if rb.coords, sb.err = areaRangeToCoordinates(rng[0], rng[1]); sb.err == nil {
+		_ = sortCoordinates(rb.coords)
+	}
+	return rb
+}
+
+// Cell provides a function to get the fluent builder of the single cell by
+// given cell reference.
+func (sb *SheetBuilder) Cell(cell string) *RangeBuilder {
+	return sb.Range(cell)
+}
+
+// ColWidth provides a function to set the width of the columns by given
+// column range and width.
+func (sb *SheetBuilder) ColWidth(startCol, endCol string, width float64) *SheetBuilder {
+	if sb.flush(); sb.err == nil {
+		sb.err = sb.File.SetColWidth(sb.Sheet, startCol, endCol, width)
+	}
+	return sb
+}
+
+// RowHeight provides a function to set the height of the row by given row
+// number and height.
+func (sb *SheetBuilder) RowHeight(row int, height float64) *SheetBuilder {
+	if sb.flush(); sb.err == nil {
+		sb.err = sb.File.SetRowHeight(sb.Sheet, row, height)
+	}
+	return sb
+}
+
+// Sheet returns the fluent builder of the worksheet of the cell range, the
+// accumulated styles of the cell ranges will be applied.
+func (rb *RangeBuilder) Sheet() *SheetBuilder {
+	rb.sb.flush()
+	return rb.sb
+}
+
+// Err returns the first error occurred while building the worksheet, the
+// accumulated styles of the cell ranges will be applied before returning.
+func (rb *RangeBuilder) Err() error {
+	return rb.sb.Err()
+}
+
+// Apply provides a function to apply the accumulated styles of the cell
+// ranges without ending the chain.
+func (rb *RangeBuilder) Apply() *RangeBuilder {
+	rb.sb.flush()
+	return rb
+}
+
+// cells provides a function to call the given function for each cell of the
+// range until an error occurred.
+func (rb *RangeBuilder) cells(fn func(cell string) error) *RangeBuilder {
+	if rb.sb.flush(); rb.sb.err != nil {
+		return rb
+	}
+	for row := rb.coords[1]; row <= rb.coords[3]; row++ {
+		for col := rb.coords[0]; col <= rb.coords[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			if rb.sb.err = fn(cell); rb.sb.err != nil {
+				return rb
+			}
+		}
+	}
+	return rb
+}
+
+// Value provides a function to set the value of all the cells of the range
+// by given value.
+func (rb *RangeBuilder) Value(value interface{}) *RangeBuilder {
+	return rb.cells(func(cell string) error {
+		return rb.sb.File.SetCellValue(rb.sb.Sheet, cell, value)
+	})
+}
+
+// Formula provides a function to set the formula of all the cells of the
+// range by given formula.
+func (rb *RangeBuilder) Formula(formula string) *RangeBuilder {
+	return rb.cells(func(cell string) error {
+		return rb.sb.File.SetCellFormula(rb.sb.Sheet, cell, formula)
+	})
+}
+
+// Values provides a function to set the cell values starting from the top
+// left cell of the range by given slice. The slice of slices will be set as
+// the rows, and the other slice will be set as a single row.
+func (rb *RangeBuilder) Values(values interface{}) *RangeBuilder {
+	if rb.sb.flush(); rb.sb.err != nil {
+		return rb
+	}
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		rb.sb.err = errors.New("slice expected")
+		return rb
+	}
+	rows := []reflect.Value{v}
+	if v.Len() > 0 && isRowValue(reflect.ValueOf(v.Index(0).Interface())) {
+		rows = rows[:0]
+		for i := 0; i < v.Len(); i++ {
+			rows = append(rows, reflect.ValueOf(v.Index(i).Interface()))
+		}
+	}
+	for r, row := range rows {
+		if !isRowValue(row) {
+			rb.sb.err = errors.New("slice expected")
+			return rb
+		}
+		for c := 0; c < row.Len(); c++ {
+			cell, err := CoordinatesToCellName(rb.coords[0]+c, rb.coords[1]+r)
+			if err == nil {
+				err = rb.sb.File.SetCellValue(rb.sb.Sheet, cell, row.Index(c).Interface())
+			}
+			if rb.sb.err = err; err != nil {
+				return rb
+			}
+		}
+	}
+	return rb
+}
+
+// isRowValue provides a function to check if the given value could be set
+// as a row of the cell values.
+func isRowValue(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8
+}
+
+// Merge provides a function to merge the cells of the range.
+func (rb *RangeBuilder) Merge() *RangeBuilder {
+	if rb.sb.flush(); rb.sb.err == nil {
+		hcell, _ := CoordinatesToCellName(rb.coords[0], rb.coords[1])
+		vcell, _ := CoordinatesToCellName(rb.coords[2], rb.coords[3])
+		rb.sb.err = rb.sb.File.MergeCell(rb.sb.Sheet, hcell, vcell)
+	}
+	return rb
+}
+
+// Style provides a function to set the style of the cells of the range by
+// given style definition, the style settings set by the other style
+// functions of the builder will be replaced.
+func (rb *RangeBuilder) Style(style *Style) *RangeBuilder {
+	if style != nil {
+		rb.style = *deepcopy.Copy(style).(*Style)
+	}
+	return rb.setStyle()
+}
+
+// Bold provides a function to set the font of the cells of the range bold.
+func (rb *RangeBuilder) Bold() *RangeBuilder {
+	rb.font().Bold = true
+	return rb.setStyle()
+}
+
+// Italic provides a function to set the font of the cells of the range
+// italic.
+func (rb *RangeBuilder) Italic() *RangeBuilder {
+	rb.font().Italic = true
+	return rb.setStyle()
+}
+
+// FontColor provides a function to set the font color of the cells of the
+// range by given hex color, such as "#FF0000".
+func (rb *RangeBuilder) FontColor(color string) *RangeBuilder {
+	rb.font().Color = color
+	return rb.setStyle()
+}
+
+// FontSize provides a function to set the font size of the cells of the
+// range by given size.
+func (rb *RangeBuilder) FontSize(size float64) *RangeBuilder {
+	rb.font().Size = size
+	return rb.setStyle()
+}
+
+// Fill provides a function to set the solid fill of the cells of the range
+// by given hex color, such as "#DDEBF7".
+func (rb *RangeBuilder) Fill(color string) *RangeBuilder {
+	rb.style.Fill = Fill{Type: "pattern", Color: []string{color}, Pattern: 1}
+	return rb.setStyle()
+}
+
+// Border provides a function to set the borders of all sides of the cells of
+// the range by given border style index and hex color.
+func (rb *RangeBuilder) Border(style int, color string) *RangeBuilder {
+	rb.style.Border = nil
+	for _, side := range []string{"left", "top", "right", "bottom"} {
+		rb.style.Border = append(rb.style.Border, Border{Type: side, Color: color, Style: style})
+	}
+	return rb.setStyle()
+}
+
+// Align provides a function to set the horizontal and vertical alignment of
+// the cells of the range, such as "center".
+func (rb *RangeBuilder) Align(horizontal, vertical string) *RangeBuilder {
+	if rb.style.Alignment == nil {
+		rb.style.Alignment = &Alignment{}
+	}
+	rb.style.Alignment.Horizontal, rb.style.Alignment.Vertical = horizontal, vertical
+	return rb.setStyle()
+}
+
+// NumFmt provides a function to set the built-in number format of the cells
+// of the range by given number format index.
+func (rb *RangeBuilder) NumFmt(numFmt int) *RangeBuilder {
+	rb.style.NumFmt = numFmt
+	return rb.setStyle()
+}
+
+// CustomNumFmt provides a function to set the custom number format of the
+// cells of the range by given number format code, such as "0.00%".
+func (rb *RangeBuilder) CustomNumFmt(code string) *RangeBuilder {
+	rb.style.CustomNumFmt = &code
+	return rb.setStyle()
+}
+
+// font provides a function to get the font settings of the accumulated
+// style of the builder.
+func (rb *RangeBuilder) font() *Font {
+	if rb.style.Font == nil {
+		rb.style.Font = &Font{}
+	}
+	return rb.style.Font
+}
+
+// setStyle provides a function to mark the accumulated style of the builder
+// to be applied to the cells of the range.
+func (rb *RangeBuilder) setStyle() *RangeBuilder {
+	if !rb.pending {
+		rb.pending = true
+		rb.sb.pending = append(rb.sb.pending, rb)
+	}
+	return rb
+}
+
+// applyStyle provides a function to create the accumulated style of the
+// builder and set it to the cells of the range.
+func (rb *RangeBuilder) applyStyle() *RangeBuilder {
+	if rb.pending = false; rb.sb.err != nil {
+		return rb
+	}
+	style := rb.style
+	styleID, err := rb.sb.File.NewStyle(&style)
+	if err == nil {
+		hcell, _ := CoordinatesToCellName(rb.coords[0], rb.coords[1])
+		vcell, _ := CoordinatesToCellName(rb.coords[2], rb.coords[3])
+		err = rb.sb.File.SetCellStyle(rb.sb.Sheet, hcell, vcell, styleID)
+	}
+	rb.sb.err = err
+	return rb
+}
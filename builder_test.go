@@ -0,0 +1,94 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetBuilder(t *testing.T) {
+	f := NewFile()
+	sb := f.NewSheetBuilder("Q1")
+	assert.Equal(t, 1, f.GetSheetIndex("Q1"))
+	sb.Range("A1:D1").Bold().Fill("#DDEBF7").Align("center", "").Values([]string{"Region", "Jan", "Feb", "Mar"})
+	sb.Range("A2:D3").Values([][]interface{}{{"East", 10, 20, 30}, {"West", 15, 25, 35}})
+	sb.Cell("E2").Formula("SUM(B2:D2)").NumFmt(2).Border(1, "#000000")
+	sb.Range("B5:A4").Value("Total").Merge().Sheet().ColWidth("A", "A", 20).RowHeight(1, 30)
+	sb.Cell("E3").Style(&Style{Font: &Font{Color: "#FF0000"}}).Italic().FontSize(12).FontColor("#00FF00").CustomNumFmt("0.00%")
+	assert.NoError(t, sb.Err())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetBuilder.xlsx")))
+
+	rows, err := f.GetRows("Q1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Region", "Jan", "Feb", "Mar"}, {"East", "10", "20", "30", ""}, {"West", "15", "25", "35", ""}, {"Total", "Total"}, {"Total", "Total"}}, rows)
+	formula, err := f.GetCellFormula("Q1", "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2:D2)", formula)
+	assert.Equal(t, []string{"A4:B5"}, func() (refs []string) {
+		mergeCells, err := f.GetMergeCells("Q1")
+		assert.NoError(t, err)
+		for _, mc := range mergeCells {
+			refs = append(refs, mc[0])
+		}
+		return
+	}())
+	// Test the styles of the cells in the range are the same.
+	styleA1, err := f.GetCellStyle("Q1", "A1")
+	assert.NoError(t, err)
+	styleD1, err := f.GetCellStyle("Q1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA1, styleD1)
+	s := f.stylesReader()
+	assert.True(t, s.Fonts.Font[*s.CellXfs.Xf[styleA1].FontID].B != nil)
+	assert.Equal(t, "center", s.CellXfs.Xf[styleA1].Alignment.Horizontal)
+	styleE3, err := f.GetCellStyle("Q1", "E3")
+	assert.NoError(t, err)
+	font := s.Fonts.Font[*s.CellXfs.Xf[styleE3].FontID]
+	assert.True(t, font.I != nil)
+	assert.Equal(t, "FF00FF00", font.Color.RGB)
+
+	// Test the calls will be skipped after the error occurred.
+	sb.Range("A1:B2:C3").Bold()
+	assert.EqualError(t, sb.Err(), "invalid range reference A1:B2:C3")
+	sb.Cell("A10").Value(1)
+	val, err := f.GetCellValue("Q1", "A10")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	assert.EqualError(t, sb.ColWidth("A", "A", 1).RowHeight(1, 1).Err(), "invalid range reference A1:B2:C3")
+
+	// Test build with the invalid arguments.
+	for _, fn := range []func(sb *SheetBuilder) error{
+		func(sb *SheetBuilder) error { return sb.Cell("A").Err() },
+		func(sb *SheetBuilder) error { return sb.Cell("A1").Values(1).Err() },
+		func(sb *SheetBuilder) error { return sb.Cell("A1").Values([]interface{}{[]int{1}, 2}).Err() },
+		func(sb *SheetBuilder) error { return sb.Cell("XFD1").Values([]int{1, 2}).Err() },
+		func(sb *SheetBuilder) error { sb.Sheet = "SheetN"; return sb.Cell("A1").Value(1).Err() },
+		func(sb *SheetBuilder) error { sb.Sheet = "SheetN"; return sb.Cell("A1").Formula("A2").Err() },
+		func(sb *SheetBuilder) error { sb.Sheet = "SheetN"; return sb.Cell("A1").Merge().Err() },
+		func(sb *SheetBuilder) error { sb.Sheet = "SheetN"; return sb.Cell("A1").Bold().Err() },
+		func(sb *SheetBuilder) error { return sb.ColWidth("A", "A", 256).Err() },
+		func(sb *SheetBuilder) error { return sb.RowHeight(0, 1).Err() },
+	} {
+		assert.Error(t, fn(NewFile().NewSheetBuilder("Sheet1")))
+	}
+
+	// Test the chained style functions only create one style.
+	f = NewFile()
+	sb = f.NewSheetBuilder("Sheet1")
+	count := len(f.stylesReader().CellXfs.Xf)
+	rb := sb.Range("A1:B2").Bold().Fill("#DDEBF7").Border(1, "#000000").Align("center", "center")
+	assert.Len(t, f.stylesReader().CellXfs.Xf, count)
+	styleA1, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Zero(t, styleA1)
+	rb.Apply().Italic()
+	assert.Len(t, f.stylesReader().CellXfs.Xf, count+1)
+	assert.NoError(t, sb.Err())
+	assert.Len(t, f.stylesReader().CellXfs.Xf, count+2)
+	styleB2, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	s = f.stylesReader()
+	assert.True(t, s.Fonts.Font[*s.CellXfs.Xf[styleB2].FontID].I != nil)
+	assert.Equal(t, "center", s.CellXfs.Xf[styleB2].Alignment.Vertical)
+}
@@ -14,6 +14,7 @@ package excelize
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -112,7 +113,8 @@ type formulaArg struct {
 	cellRefs, cellRanges *list.List
 }
 
-// Value returns a string data type of the formula argument.
+// Value returns a string data type of the formula argument. The text kept
+// in the String field will be used for the number if it has been specified.
 func (fa formulaArg) Value() (value string) {
 	switch fa.Type {
 	case ArgNumber:
@@ -122,6 +124,9 @@ func (fa formulaArg) Value() (value string) {
 			}
 			return "TRUE"
 		}
+		if fa.String != "" {
+			return fa.String
+		}
 		return fmt.Sprintf("%g", fa.Number)
 	case ArgString:
 		return fa.String
@@ -373,6 +378,51 @@ var tokenPriority = map[string]int{
 //    VLOOKUP
 //
 func (f *File) CalcCellValue(sheet, cell string) (result string, err error) {
+	var arg formulaArg
+	arg, err = f.calcCellValue(newCalcContext(), sheet, cell)
+	return arg.String, err
+}
+
+// calcContext defines the context of calculating the formulas, which records
+// the cells being calculated for detecting the circular references, and the
+// results of the cells which have been calculated in the same calculation.
+type calcContext struct {
+	stack   map[string]bool
+	results map[string]formulaArg
+}
+
+// newCalcContext provides a function to create a new calculation context.
+func newCalcContext() *calcContext {
+	return &calcContext{stack: map[string]bool{}, results: map[string]formulaArg{}}
+}
+
+// calcCellKey provides a function to get the key of the cell in the
+// calculation by given worksheet name and cell name, the worksheet name is
+// case-insensitive and the absolute reference symbols are ignored.
+func calcCellKey(sheet, cell string) string {
+	if col, row, err := CellNameToCoordinates(strings.Replace(cell, "$", "", -1)); err == nil {
+		cell, _ = CoordinatesToCellName(col, row)
+	}
+	return strings.ToLower(trimSheetName(sheet)) + "!" + cell
+}
+
+// calcCellValue provides a function to calculate the formula of the cell by
+// given calculation context, worksheet name and cell name. The precedent
+// cells which contain formulas will be calculated recursively in the same
+// context, and the results of these cells will be cached if the
+// CacheCalcResults option is specified. The data type of the result is taken
+// from the formula, and the String field of the result always keeps the
+// calculated value as text.
+func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
+	key := calcCellKey(sheet, cell)
+	if result, ok := ctx.results[key]; ok {
+		return result, nil
+	}
+	if result, ok := f.loadCalcCache(key); ok {
+		return result, nil
+	}
+	ctx.stack[key] = true
+	defer delete(ctx.stack, key)
 	var (
 		formula string
 		token   efp.Token
@@ -385,18 +435,229 @@ func (f *File) CalcCellValue(sheet, cell string) (result string, err error) {
 	if tokens == nil {
 		return
 	}
-	if token, err = f.evalInfixExp(sheet, cell, tokens); err != nil {
+	if token, err = f.evalInfixExp(ctx, sheet, cell, tokens); err != nil {
 		return
 	}
-	result = token.TValue
-	isNum, precision := isNumeric(result)
-	if isNum && precision > 15 {
-		num, _ := roundPrecision(result)
-		result = strings.ToUpper(num)
+	value := token.TValue
+	if token.TSubType == efp.TokenSubTypeNumber {
+		isNum, precision := isNumeric(value)
+		if isNum && precision > 15 {
+			num, _ := roundPrecision(value)
+			value = strings.ToUpper(num)
+		}
 	}
+	result = newCalcResultFormulaArg(token.TSubType, value)
+	ctx.results[key] = result
+	f.storeCalcCache(key, result)
 	return
 }
 
+// newCalcResultFormulaArg provides a function to create the formula argument
+// of the calculated result by given token subtype and value, the value will
+// be kept in the String field of the formula argument.
+func newCalcResultFormulaArg(subType, value string) formulaArg {
+	switch subType {
+	case efp.TokenSubTypeText:
+		return newStringFormulaArg(value)
+	case efp.TokenSubTypeLogical:
+		arg := newBoolFormulaArg(value == "TRUE" || value == "1")
+		arg.String = value
+		return arg
+	case efp.TokenSubTypeError:
+		return newErrorFormulaArg(value, value)
+	}
+	if value == "" {
+		return newEmptyFormulaArg()
+	}
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return newStringFormulaArg(value)
+	}
+	return formulaArg{Type: ArgNumber, Number: num, String: value}
+}
+
+// formulaArgSubType provides a function to get the token subtype by given
+// formula argument.
+func formulaArgSubType(arg formulaArg) string {
+	switch arg.Type {
+	case ArgString:
+		return efp.TokenSubTypeText
+	case ArgNumber:
+		if arg.Boolean {
+			return efp.TokenSubTypeLogical
+		}
+	case ArgError:
+		return efp.TokenSubTypeError
+	}
+	return efp.TokenSubTypeNumber
+}
+
+// referenceSubType provides a function to get the token subtype of the
+// reference by given calculation context and the resolved reference. The
+// subtype of the single cell reference is taken from the value of the cell,
+// and the others are numbers.
+func (f *File) referenceSubType(ctx *calcContext, ref formulaArg) string {
+	if ref.cellRanges.Len() > 0 || ref.cellRefs.Len() != 1 {
+		return efp.TokenSubTypeNumber
+	}
+	cr := ref.cellRefs.Front().Value.(cellRef)
+	cell, err := CoordinatesToCellName(cr.Col, cr.Row)
+	if err != nil {
+		return efp.TokenSubTypeNumber
+	}
+	arg, err := f.cellResolver(ctx, cr.Sheet, cell)
+	if err != nil {
+		return efp.TokenSubTypeNumber
+	}
+	return formulaArgSubType(arg)
+}
+
+// cellResolver provides a function to get the value of the precedent cell by
+// given calculation context, worksheet name and cell name. The formula of
+// the cell will be calculated if the cell contains a formula, and the cached
+// value of the cell will be used if the formula can't be calculated or the
+// cell is being calculated, which means the formulas are circular
+// references.
+func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, error) {
+	var formula bool
+	subType := efp.TokenSubTypeNumber
+	value, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		formula = c.F != nil
+		switch c.T {
+		case "b":
+			subType = efp.TokenSubTypeLogical
+		case "e":
+			subType = efp.TokenSubTypeError
+		case "s", "str", "inlineStr":
+			subType = efp.TokenSubTypeText
+		}
+		val, err := c.getValueFrom(f, f.sharedStringsReader())
+		return val, true, err
+	})
+	if err != nil || !formula || ctx.stack[calcCellKey(sheet, cell)] {
+		return newCalcResultFormulaArg(subType, value), err
+	}
+	if result, err := f.calcCellValue(ctx, sheet, cell); err == nil {
+		return result, nil
+	}
+	return newCalcResultFormulaArg(subType, value), nil
+}
+
+// CalcWorkbook provides a function to calculate the formulas of all the cells
+// in the workbook with the context, and update the cached values of these
+// cells by the calculated results. The cells with the formulas which could
+// not be calculated, such as using the unsupported functions, will be kept
+// with the original cached values. The precedent cells which contain
+// formulas are calculated before the cells depend on them, and the cached
+// values are used for the circular references. The calculation will be
+// stopped with the error of the context once the context is canceled or its
+// deadline is exceeded. For example, calculate the workbook in one minute:
+//
+//    ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//    defer cancel()
+//    if err := f.CalcWorkbook(ctx); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) CalcWorkbook(ctx context.Context) error {
+	type formulaCell struct{ sheet, cell string }
+	var cells []formulaCell
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if errors.As(err, &ErrChartSheet{}) {
+				continue
+			}
+			return err
+		}
+		ws.Lock()
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F != nil {
+					cells = append(cells, formulaCell{sheet: sheet, cell: c.R})
+				}
+			}
+		}
+		ws.Unlock()
+	}
+	calcCtx := newCalcContext()
+	for idx, c := range cells {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if result, err := f.calcCellValue(calcCtx, c.sheet, c.cell); err == nil {
+			if err = f.setCellCalcValue(c.sheet, c.cell, result); err != nil {
+				return err
+			}
+		}
+		f.reportProgress("calc", idx+1, len(cells))
+	}
+	return nil
+}
+
+// setCellCalcValue provides a function to set the cached value of the
+// formula cell by given worksheet name, cell name and the calculated result,
+// the type of the cell will be set by the data type of the result.
+func (f *File) setCellCalcValue(sheet, cell string, result formulaArg) error {
+	f.clearCalcCache()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cellData, _, _, err := f.prepareCell(ws, sheet, cell)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	switch result.Type {
+	case ArgString:
+		cellData.T, cellData.V = "str", result.String
+	case ArgError:
+		cellData.T, cellData.V = "e", result.String
+	case ArgNumber:
+		if result.Boolean {
+			cellData.T, cellData.V = setCellBool(result.Number == 1)
+			break
+		}
+		cellData.T, cellData.V = "", result.String
+	default:
+		cellData.T, cellData.V = "", ""
+	}
+	return err
+}
+
+// loadCalcCache provides a function to get the cached result of the cell by
+// given key of the cell in the calculation, if the CacheCalcResults option is
+// specified.
+func (f *File) loadCalcCache(key string) (formulaArg, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result, ok := f.calcCache[key]
+	return result, ok
+}
+
+// storeCalcCache provides a function to cache the calculated result of the
+// cell by given key of the cell in the calculation and the result, if the
+// CacheCalcResults option is specified.
+func (f *File) storeCalcCache(key string, result formulaArg) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calcCache != nil {
+		f.calcCache[key] = result
+	}
+}
+
+// clearCalcCache provides a function to clear the cached results of the
+// cells, it should be called once the spreadsheet has been changed.
+func (f *File) clearCalcCache() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.calcCache) > 0 {
+		f.calcCache = make(map[string]formulaArg)
+	}
+}
+
 // getPriority calculate arithmetic operator priority.
 func getPriority(token efp.Token) (pri int) {
 	pri = tokenPriority[token.TValue]
@@ -465,7 +726,7 @@ func newEmptyFormulaArg() formulaArg {
 //
 // TODO: handle subtypes: Nothing, Text, Logical, Error, Concatenation, Intersection, Union
 //
-func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token, error) {
+func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.Token) (efp.Token, error) {
 	var err error
 	opdStack, optStack, opfStack, opfdStack, opftStack, argsStack := NewStack(), NewStack(), NewStack(), NewStack(), NewStack(), NewStack()
 	for i := 0; i < len(tokens); i++ {
@@ -473,7 +734,7 @@ func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token,
 
 		// out of function stack
 		if opfStack.Len() == 0 {
-			if err = f.parseToken(sheet, token, opdStack, optStack); err != nil {
+			if err = f.parseToken(ctx, sheet, token, opdStack, optStack); err != nil {
 				return efp.Token{}, err
 			}
 		}
@@ -496,7 +757,7 @@ func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token,
 			if token.TSubType == efp.TokenSubTypeRange {
 				if !opftStack.Empty() {
 					// parse reference: must reference at here
-					result, err := f.parseReference(sheet, token.TValue)
+					result, err := f.parseReference(ctx, sheet, token.TValue)
 					if err != nil {
 						return efp.Token{TValue: formulaErrorNAME}, err
 					}
@@ -512,7 +773,7 @@ func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token,
 				}
 				if nextToken.TType == efp.TokenTypeArgument || nextToken.TType == efp.TokenTypeFunction {
 					// parse reference: reference or range at here
-					result, err := f.parseReference(sheet, token.TValue)
+					result, err := f.parseReference(ctx, sheet, token.TValue)
 					if err != nil {
 						return efp.Token{TValue: formulaErrorNAME}, err
 					}
@@ -525,7 +786,7 @@ func (f *File) evalInfixExp(sheet, cell string, tokens []efp.Token) (efp.Token,
 			}
 
 			// check current token is opft
-			if err = f.parseToken(sheet, token, opfdStack, opftStack); err != nil {
+			if err = f.parseToken(ctx, sheet, token, opfdStack, opftStack); err != nil {
 				return efp.Token{}, err
 			}
 
@@ -610,7 +871,7 @@ func (f *File) evalInfixExpFunc(sheet, cell string, token, nextToken efp.Token,
 			argsStack.Peek().(*list.List).PushBack(arg)
 		}
 	} else {
-		opdStack.Push(efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+		opdStack.Push(efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: formulaArgSubType(arg)})
 	}
 	return nil
 }
@@ -632,13 +893,13 @@ func calcPow(rOpd, lOpd string, opdStack *Stack) error {
 
 // calcEq evaluate equal arithmetic operations.
 func calcEq(rOpd, lOpd string, opdStack *Stack) error {
-	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpd == lOpd)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpd == lOpd)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical})
 	return nil
 }
 
 // calcNEq evaluate not equal arithmetic operations.
 func calcNEq(rOpd, lOpd string, opdStack *Stack) error {
-	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpd != lOpd)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpd != lOpd)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical})
 	return nil
 }
 
@@ -652,7 +913,7 @@ func calcL(rOpd, lOpd string, opdStack *Stack) error {
 	if err != nil {
 		return err
 	}
-	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal > lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal > lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical})
 	return nil
 }
 
@@ -666,7 +927,7 @@ func calcLe(rOpd, lOpd string, opdStack *Stack) error {
 	if err != nil {
 		return err
 	}
-	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal >= lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal >= lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical})
 	return nil
 }
 
@@ -680,7 +941,7 @@ func calcG(rOpd, lOpd string, opdStack *Stack) error {
 	if err != nil {
 		return err
 	}
-	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal < lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal < lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical})
 	return nil
 }
 
@@ -694,13 +955,13 @@ func calcGe(rOpd, lOpd string, opdStack *Stack) error {
 	if err != nil {
 		return err
 	}
-	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal <= lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: strings.ToUpper(strconv.FormatBool(rOpdVal <= lOpdVal)), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical})
 	return nil
 }
 
 // calcSplice evaluate splice '&' operations.
 func calcSplice(rOpd, lOpd string, opdStack *Stack) error {
-	opdStack.Push(efp.Token{TValue: lOpd + rOpd, TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber})
+	opdStack.Push(efp.Token{TValue: lOpd + rOpd, TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeText})
 	return nil
 }
 
@@ -893,14 +1154,14 @@ func (f *File) getDefinedNameRefTo(definedNameName string, currentSheet string)
 
 // parseToken parse basic arithmetic operator priority and evaluate based on
 // operators and operands.
-func (f *File) parseToken(sheet string, token efp.Token, opdStack, optStack *Stack) error {
+func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdStack, optStack *Stack) error {
 	// parse reference: must reference at here
 	if token.TSubType == efp.TokenSubTypeRange {
 		refTo := f.getDefinedNameRefTo(token.TValue, sheet)
 		if refTo != "" {
 			token.TValue = refTo
 		}
-		result, err := f.parseReference(sheet, token.TValue)
+		result, err := f.parseReference(ctx, sheet, token.TValue)
 		if err != nil {
 			return errors.New(formulaErrorNAME)
 		}
@@ -909,7 +1170,9 @@ func (f *File) parseToken(sheet string, token efp.Token, opdStack, optStack *Sta
 		}
 		token.TValue = result.String
 		token.TType = efp.TokenTypeOperand
-		token.TSubType = efp.TokenSubTypeNumber
+		token.TSubType = f.referenceSubType(ctx, result)
+		opdStack.Push(token)
+		return nil
 	}
 	if isOperatorPrefixToken(token) {
 		if err := f.parseOperatorPrefixToken(optStack, opdStack, token); err != nil {
@@ -938,7 +1201,7 @@ func (f *File) parseToken(sheet string, token efp.Token, opdStack, optStack *Sta
 
 // parseReference parse reference and extract values by given reference
 // characters and default sheet name.
-func (f *File) parseReference(sheet, reference string) (arg formulaArg, err error) {
+func (f *File) parseReference(ctx *calcContext, sheet, reference string) (arg formulaArg, err error) {
 	reference = strings.Replace(reference, "$", "", -1)
 	refs, cellRanges, cellRefs := list.New(), list.New(), list.New()
 	for _, ref := range strings.Split(reference, ":") {
@@ -982,7 +1245,7 @@ func (f *File) parseReference(sheet, reference string) (arg formulaArg, err erro
 				To:   cellRef{Sheet: sheet, Col: cr.Col, Row: TotalRows},
 			})
 			cellRefs.Init()
-			arg, err = f.rangeResolver(cellRefs, cellRanges)
+			arg, err = f.rangeResolver(ctx, cellRefs, cellRanges)
 			return
 		}
 		e := refs.Back()
@@ -1002,7 +1265,7 @@ func (f *File) parseReference(sheet, reference string) (arg formulaArg, err erro
 		cellRefs.PushBack(e.Value.(cellRef))
 		refs.Remove(e)
 	}
-	arg, err = f.rangeResolver(cellRefs, cellRanges)
+	arg, err = f.rangeResolver(ctx, cellRefs, cellRanges)
 	return
 }
 
@@ -1041,7 +1304,7 @@ func prepareValueRef(cr cellRef, valueRange []int) {
 // rangeResolver extract value as string from given reference and range list.
 // This function will not ignore the empty cell. For example, A1:A2:A2:B3 will
 // be reference A1:B3.
-func (f *File) rangeResolver(cellRefs, cellRanges *list.List) (arg formulaArg, err error) {
+func (f *File) rangeResolver(ctx *calcContext, cellRefs, cellRanges *list.List) (arg formulaArg, err error) {
 	arg.cellRefs, arg.cellRanges = cellRefs, cellRanges
 	// value range order: from row, to row, from column, to column
 	valueRange := []int{0, 0, 0, 0}
@@ -1073,15 +1336,16 @@ func (f *File) rangeResolver(cellRefs, cellRanges *list.List) (arg formulaArg, e
 		for row := valueRange[0]; row <= valueRange[1]; row++ {
 			var matrixRow = []formulaArg{}
 			for col := valueRange[2]; col <= valueRange[3]; col++ {
-				var cell, value string
+				var cell string
 				if cell, err = CoordinatesToCellName(col, row); err != nil {
 					return
 				}
-				if value, err = f.GetCellValue(sheet, cell); err != nil {
+				var value formulaArg
+				if value, err = f.cellResolver(ctx, sheet, cell); err != nil {
 					return
 				}
 				matrixRow = append(matrixRow, formulaArg{
-					String: value,
+					String: value.String,
 					Type:   ArgString,
 				})
 			}
@@ -1096,10 +1360,11 @@ func (f *File) rangeResolver(cellRefs, cellRanges *list.List) (arg formulaArg, e
 		if cell, err = CoordinatesToCellName(cr.Col, cr.Row); err != nil {
 			return
 		}
-		if arg.String, err = f.GetCellValue(cr.Sheet, cell); err != nil {
+		var value formulaArg
+		if value, err = f.cellResolver(ctx, cr.Sheet, cell); err != nil {
 			return
 		}
-		arg.Type = ArgString
+		arg.String, arg.Type = value.String, ArgString
 	}
 	return
 }
@@ -2277,7 +2542,8 @@ func (fn *formulaFuncs) EXP(argsList *list.List) formulaArg {
 	if number.Type == ArgError {
 		return number
 	}
-	return newStringFormulaArg(strings.ToUpper(fmt.Sprintf("%g", math.Exp(number.Number))))
+	val := math.Exp(number.Number)
+	return formulaArg{Type: ArgNumber, Number: val, String: strings.ToUpper(fmt.Sprintf("%g", val))}
 }
 
 // fact returns the factorial of a supplied number.
@@ -2328,7 +2594,7 @@ func (fn *formulaFuncs) FACTDOUBLE(argsList *list.List) formulaArg {
 	for i := math.Trunc(number.Number); i > 1; i -= 2 {
 		val *= i
 	}
-	return newStringFormulaArg(strings.ToUpper(fmt.Sprintf("%g", val)))
+	return formulaArg{Type: ArgNumber, Number: val, String: strings.ToUpper(fmt.Sprintf("%g", val))}
 }
 
 // FLOOR function rounds a supplied number towards zero to the nearest
@@ -2358,7 +2624,8 @@ func (fn *formulaFuncs) FLOOR(argsList *list.List) formulaArg {
 			val--
 		}
 	}
-	return newStringFormulaArg(strings.ToUpper(fmt.Sprintf("%g", val*significance.Number)))
+	val *= significance.Number
+	return formulaArg{Type: ArgNumber, Number: val, String: strings.ToUpper(fmt.Sprintf("%g", val))}
 }
 
 // FLOORMATH function rounds a supplied number down to a supplied multiple of
@@ -4253,16 +4520,16 @@ func (fn *formulaFuncs) ISBLANK(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "ISBLANK requires 1 argument")
 	}
 	token := argsList.Front().Value.(formulaArg)
-	result := "FALSE"
+	result := false
 	switch token.Type {
 	case ArgUnknown:
-		result = "TRUE"
+		result = true
 	case ArgString:
 		if token.String == "" {
-			result = "TRUE"
+			result = true
 		}
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(result)
 }
 
 // ISERR function tests if an initial supplied expression (or value) returns
@@ -4277,7 +4544,7 @@ func (fn *formulaFuncs) ISERR(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "ISERR requires 1 argument")
 	}
 	token := argsList.Front().Value.(formulaArg)
-	result := "FALSE"
+	result := false
 	if token.Type == ArgError {
 		for _, errType := range []string{
 			formulaErrorDIV, formulaErrorNAME, formulaErrorNUM,
@@ -4285,11 +4552,11 @@ func (fn *formulaFuncs) ISERR(argsList *list.List) formulaArg {
 			formulaErrorSPILL, formulaErrorCALC, formulaErrorGETTINGDATA,
 		} {
 			if errType == token.String {
-				result = "TRUE"
+				result = true
 			}
 		}
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(result)
 }
 
 // ISERROR function tests if an initial supplied expression (or value) returns
@@ -4303,7 +4570,7 @@ func (fn *formulaFuncs) ISERROR(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "ISERROR requires 1 argument")
 	}
 	token := argsList.Front().Value.(formulaArg)
-	result := "FALSE"
+	result := false
 	if token.Type == ArgError {
 		for _, errType := range []string{
 			formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
@@ -4311,11 +4578,11 @@ func (fn *formulaFuncs) ISERROR(argsList *list.List) formulaArg {
 			formulaErrorCALC, formulaErrorGETTINGDATA,
 		} {
 			if errType == token.String {
-				result = "TRUE"
+				result = true
 			}
 		}
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(result)
 }
 
 // ISEVEN function tests if a supplied number (or numeric expression)
@@ -4330,7 +4597,6 @@ func (fn *formulaFuncs) ISEVEN(argsList *list.List) formulaArg {
 	}
 	var (
 		token   = argsList.Front().Value.(formulaArg)
-		result  = "FALSE"
 		numeric int
 		err     error
 	)
@@ -4339,10 +4605,10 @@ func (fn *formulaFuncs) ISEVEN(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 		}
 		if numeric == numeric/2*2 {
-			return newStringFormulaArg("TRUE")
+			return newBoolFormulaArg(true)
 		}
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(false)
 }
 
 // ISNA function tests if an initial supplied expression (or value) returns
@@ -4356,11 +4622,11 @@ func (fn *formulaFuncs) ISNA(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "ISNA requires 1 argument")
 	}
 	token := argsList.Front().Value.(formulaArg)
-	result := "FALSE"
+	result := false
 	if token.Type == ArgError && token.String == formulaErrorNA {
-		result = "TRUE"
+		result = true
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(result)
 }
 
 // ISNONTEXT function function tests if a supplied value is text. If not, the
@@ -4374,11 +4640,11 @@ func (fn *formulaFuncs) ISNONTEXT(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorVALUE, "ISNONTEXT requires 1 argument")
 	}
 	token := argsList.Front().Value.(formulaArg)
-	result := "TRUE"
+	result := true
 	if token.Type == ArgString && token.String != "" {
-		result = "FALSE"
+		result = false
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(result)
 }
 
 // ISNUMBER function function tests if a supplied value is a number. If so,
@@ -4412,7 +4678,6 @@ func (fn *formulaFuncs) ISODD(argsList *list.List) formulaArg {
 	}
 	var (
 		token   = argsList.Front().Value.(formulaArg)
-		result  = "FALSE"
 		numeric int
 		err     error
 	)
@@ -4421,10 +4686,10 @@ func (fn *formulaFuncs) ISODD(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 		}
 		if numeric != numeric/2*2 {
-			return newStringFormulaArg("TRUE")
+			return newBoolFormulaArg(true)
 		}
 	}
-	return newStringFormulaArg(result)
+	return newBoolFormulaArg(false)
 }
 
 // ISTEXT function tests if a supplied value is text, and if so, returns TRUE;
@@ -4497,12 +4762,14 @@ func (fn *formulaFuncs) AND(argsList *list.List) formulaArg {
 				continue
 			}
 			if token.String == "FALSE" {
-				return newStringFormulaArg(token.String)
+				return newBoolFormulaArg(false)
 			}
 			if val, err = strconv.ParseFloat(token.String, 64); err != nil {
 				return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 			}
 			and = and && (val != 0)
+		case ArgNumber:
+			and = and && (token.Number != 0)
 		case ArgMatrix:
 			// TODO
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -4603,12 +4870,14 @@ func (fn *formulaFuncs) OR(argsList *list.List) formulaArg {
 				return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 			}
 			or = val != 0
+		case ArgNumber:
+			or = or || token.Number != 0
 		case ArgMatrix:
 			// TODO
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 		}
 	}
-	return newStringFormulaArg(strings.ToUpper(strconv.FormatBool(or)))
+	return newBoolFormulaArg(or)
 }
 
 // TRUE function returns the logical value TRUE. The syntax of the function
@@ -4657,9 +4926,9 @@ func (fn *formulaFuncs) NOW(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NOW accepts no arguments")
 	}
-	now := time.Now()
+	now := fn.now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400)
+	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400 - fn.dateOffset())
 }
 
 // TODAY function returns the current date. The function has no arguments and
@@ -4671,9 +4940,27 @@ func (fn *formulaFuncs) TODAY(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "TODAY accepts no arguments")
 	}
-	now := time.Now()
+	now := fn.now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1)
+	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1 - fn.dateOffset())
+}
+
+// now returns the current time in the TimeLocation of the workbook options if
+// specified, otherwise in the local time zone.
+func (fn *formulaFuncs) now() time.Time {
+	if fn.f != nil && fn.f.timeLocation != nil {
+		return time.Now().In(fn.f.timeLocation)
+	}
+	return time.Now()
+}
+
+// dateOffset returns the offset of the serial date number by the date system
+// of the workbook.
+func (fn *formulaFuncs) dateOffset() float64 {
+	if fn.f != nil && fn.f.date1904() {
+		return date1904Offset
+	}
+	return 0
 }
 
 // makeDate return date as a Unix time, the number of seconds elapsed since
@@ -4929,7 +5216,7 @@ func (fn *formulaFuncs) LEN(argsList *list.List) formulaArg {
 	if argsList.Len() != 1 {
 		return newErrorFormulaArg(formulaErrorVALUE, "LEN requires 1 string argument")
 	}
-	return newStringFormulaArg(strconv.Itoa(len(argsList.Front().Value.(formulaArg).String)))
+	return newNumberFormulaArg(float64(len(argsList.Front().Value.(formulaArg).String)))
 }
 
 // LENB returns the number of bytes used to represent the characters in a text
@@ -4945,7 +5232,7 @@ func (fn *formulaFuncs) LENB(argsList *list.List) formulaArg {
 	if argsList.Len() != 1 {
 		return newErrorFormulaArg(formulaErrorVALUE, "LENB requires 1 string argument")
 	}
-	return newStringFormulaArg(strconv.Itoa(len(argsList.Front().Value.(formulaArg).String)))
+	return newNumberFormulaArg(float64(len(argsList.Front().Value.(formulaArg).String)))
 }
 
 // LOWER converts all characters in a supplied text string to lower case. The
@@ -5246,26 +5533,29 @@ func (fn *formulaFuncs) IF(argsList *list.List) formulaArg {
 	}
 	token := argsList.Front().Value.(formulaArg)
 	var (
-		cond   bool
-		err    error
-		result string
+		cond bool
+		err  error
 	)
 	switch token.Type {
 	case ArgString:
 		if cond, err = strconv.ParseBool(token.String); err != nil {
 			return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 		}
-		if argsList.Len() == 1 {
-			return newBoolFormulaArg(cond)
-		}
-		if cond {
-			return newStringFormulaArg(argsList.Front().Next().Value.(formulaArg).String)
-		}
-		if argsList.Len() == 3 {
-			result = argsList.Back().Value.(formulaArg).String
-		}
+	case ArgNumber:
+		cond = token.Number != 0
+	default:
+		return newStringFormulaArg("")
 	}
-	return newStringFormulaArg(result)
+	if argsList.Len() == 1 {
+		return newBoolFormulaArg(cond)
+	}
+	if cond {
+		return argsList.Front().Next().Value.(formulaArg)
+	}
+	if argsList.Len() == 3 {
+		return argsList.Back().Value.(formulaArg)
+	}
+	return newStringFormulaArg("")
 }
 
 // Lookup and Reference Functions
@@ -5845,7 +6135,7 @@ func (fn *formulaFuncs) ROWS(argsList *list.List) formulaArg {
 		}
 	}
 	if max == TotalRows {
-		return newStringFormulaArg(strconv.Itoa(TotalRows))
+		return formulaArg{Type: ArgNumber, Number: TotalRows, String: strconv.Itoa(TotalRows)}
 	}
 	result := max - min + 1
 	if max == min {
@@ -5854,7 +6144,7 @@ func (fn *formulaFuncs) ROWS(argsList *list.List) formulaArg {
 		}
 		return newNumberFormulaArg(float64(1))
 	}
-	return newStringFormulaArg(strconv.Itoa(result))
+	return formulaArg{Type: ArgNumber, Number: float64(result), String: strconv.Itoa(result)}
 }
 
 // Web Functions
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"container/list"
+	"context"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -692,14 +693,15 @@ func TestCalcCellValue(t *testing.T) {
 		"SHEET()": "1",
 		// Logical Functions
 		// AND
-		"=AND(0)":               "FALSE",
-		"=AND(1)":               "TRUE",
-		"=AND(1,0)":             "FALSE",
-		"=AND(0,1)":             "FALSE",
-		"=AND(1=1)":             "TRUE",
-		"=AND(1<2)":             "TRUE",
-		"=AND(1>2,2<3,2>0,3>1)": "FALSE",
-		"=AND(1=1),1=1":         "TRUE",
+		"=AND(0)":                  "FALSE",
+		"=AND(1)":                  "TRUE",
+		"=AND(1,0)":                "FALSE",
+		"=AND(0,1)":                "FALSE",
+		"=AND(1=1)":                "TRUE",
+		"=AND(1<2)":                "TRUE",
+		"=AND(1>2,2<3,2>0,3>1)":    "FALSE",
+		"=AND(1=1),1=1":            "TRUE",
+		"=AND(ISEVEN(2),ISODD(3))": "TRUE",
 		// FALSE
 		"=FALSE()": "FALSE",
 		// IFERROR
@@ -712,10 +714,11 @@ func TestCalcCellValue(t *testing.T) {
 		"=NOT(\"true\")":    "FALSE",
 		"=NOT(ISBLANK(B1))": "TRUE",
 		// OR
-		"=OR(1)":       "TRUE",
-		"=OR(0)":       "FALSE",
-		"=OR(1=2,2=2)": "TRUE",
-		"=OR(1=2,2=3)": "FALSE",
+		"=OR(1)":                  "TRUE",
+		"=OR(0)":                  "FALSE",
+		"=OR(1=2,2=2)":            "TRUE",
+		"=OR(1=2,2=3)":            "FALSE",
+		"=OR(ISEVEN(3),ISODD(3))": "TRUE",
 		// TRUE
 		"=TRUE()": "TRUE",
 		// Date and Time Functions
@@ -855,6 +858,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=IF(1<>1)":                             "FALSE",
 		"=IF(5<0, \"negative\", \"positive\")":  "positive",
 		"=IF(-2<0, \"negative\", \"positive\")": "negative",
+		"=IF(ISEVEN(2),\"even\",\"odd\")":       "even",
 		// Excel Lookup and Reference Functions
 		// CHOOSE
 		"=CHOOSE(4,\"red\",\"blue\",\"green\",\"brown\")": "brown",
@@ -1749,7 +1753,7 @@ func TestCalcISBLANK(t *testing.T) {
 	})
 	fn := formulaFuncs{}
 	result := fn.ISBLANK(argsList)
-	assert.Equal(t, result.String, "TRUE")
+	assert.Equal(t, result.Value(), "TRUE")
 	assert.Empty(t, result.Error)
 }
 
@@ -1771,7 +1775,7 @@ func TestCalcOR(t *testing.T) {
 	})
 	fn := formulaFuncs{}
 	result := fn.OR(argsList)
-	assert.Equal(t, result.String, "FALSE")
+	assert.Equal(t, result.Value(), "FALSE")
 	assert.Empty(t, result.Error)
 }
 
@@ -1916,3 +1920,158 @@ func TestCalcHLOOKUP(t *testing.T) {
 		assert.Equal(t, "", result, formula)
 	}
 }
+
+func TestCalcCache(t *testing.T) {
+	f := NewFile(Options{CacheCalcResults: true})
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(A1:B1)"))
+	result, err := f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", result)
+	assert.Equal(t, map[string]formulaArg{"sheet1!C1": {Type: ArgNumber, Number: 3, String: "3"}}, f.calcCache)
+	// Test get the cached result without calculation.
+	f.calcCache["sheet1!C1"] = formulaArg{Type: ArgNumber, Number: 4, String: "4"}
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	// Test get the cached result with the different case of the worksheet
+	// name and the absolute reference.
+	result, err = f.CalcCellValue("SHEET1", "$C$1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	// Test get the cached result of the precedent cell.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=C1*2"))
+	f.calcCache["sheet1!C1"] = formulaArg{Type: ArgNumber, Number: 4, String: "4"}
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "8", result)
+	assert.Equal(t, map[string]formulaArg{
+		"sheet1!C1": {Type: ArgNumber, Number: 4, String: "4"},
+		"sheet1!D1": {Type: ArgNumber, Number: 8, String: "8"},
+	}, f.calcCache)
+	// Test clear the cached results after the workbook calculated.
+	assert.NoError(t, f.CalcWorkbook(context.Background()))
+	assert.Empty(t, f.calcCache)
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", result)
+	assert.Equal(t, map[string]formulaArg{
+		"sheet1!C1": {Type: ArgNumber, Number: 3, String: "3"},
+		"sheet1!D1": {Type: ArgNumber, Number: 6, String: "6"},
+	}, f.calcCache)
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.Empty(t, f.calcCache)
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", result)
+	// Test clear the cached results after the cell value changed.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 5))
+	assert.Empty(t, f.calcCache)
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", result)
+	// Test clear the cached results after the formula changed.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=A1*B1"))
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", result)
+	// Test clear the cached results after the row inserted.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Empty(t, f.calcCache)
+	// Test the result with error will not be cached.
+	_, err = f.CalcCellValue("SheetN", "C1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.Empty(t, f.calcCache)
+
+	// Test calculate without the cache.
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=1+2"))
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", result)
+	assert.Nil(t, f.calcCache)
+}
+
+func TestCalcWorkbook(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(A1:B1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=C1>2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=UPPER(\"a\")"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=ISERROR(A1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "=NA()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "=UNSUPPORTED()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "I1", "=CONCATENATE(\"00\",\"123\")"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "=CONCATENATE(\"TR\",\"UE\")"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "K1", "=A1&B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "L1", "=LEN(I1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "M1", "=J1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[7].V = "cached"
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "=Sheet1!C1*2"))
+	var progress [][2]int
+	f.progress = func(stage string, current, total int) {
+		assert.Equal(t, "calc", stage)
+		progress = append(progress, [2]int{current, total})
+	}
+	assert.NoError(t, f.CalcWorkbook(context.Background()))
+	assert.Len(t, progress, 12)
+	assert.Equal(t, [2]int{12, 12}, progress[11])
+	for cell, expected := range map[string][2]string{
+		"C1": {"", "3"}, "D1": {"b", "1"}, "E1": {"str", "A"}, "F1": {"b", "0"}, "H1": {"", "cached"},
+		"I1": {"str", "00123"}, "J1": {"str", "TRUE"}, "K1": {"str", "12"}, "L1": {"", "5"}, "M1": {"str", "TRUE"},
+	} {
+		c := ws.SheetData.Row[0].C[int(cell[0]-'A')]
+		assert.Equal(t, expected, [2]string{c.T, c.V}, cell)
+	}
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", val)
+
+	// Test calculate the workbook with the canceled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.EqualError(t, f.CalcWorkbook(ctx), "context canceled")
+	// Test calculate the workbook with the chart sheet.
+	f.sheetMap["Chart1"] = "xl/chartsheets/sheet1.xml"
+	f.WorkBook.Sheets.Sheet = append(f.WorkBook.Sheets.Sheet, xlsxSheet{Name: "Chart1", SheetID: 3})
+	assert.NoError(t, f.CalcWorkbook(context.Background()))
+	// Test calculate the workbook with the unsupported charset worksheet.
+	f.Sheet["xl/worksheets/sheet2.xml"] = nil
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet2.xml"] = MacintoshCyrillicCharset
+	assert.EqualError(t, f.CalcWorkbook(context.Background()), "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCalcWorkbookDependencyOrder(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=C1*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 1))
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "=SUM(Sheet1!A1:B1)"))
+	assert.NoError(t, f.CalcWorkbook(context.Background()))
+	for _, c := range [][3]string{{"Sheet1", "A1", "3"}, {"Sheet1", "B1", "2"}, {"Sheet2", "A1", "5"}} {
+		val, err := f.GetCellValue(c[0], c[1])
+		assert.NoError(t, err)
+		assert.Equal(t, c[2], val, c[1])
+	}
+	// Test calculate the cell with the precedents which contain formulas.
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 3))
+	result, err := f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", result)
+
+	// Test calculate the circular references with the cached values.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=E1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=D1+1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[3].V = "10"
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "12", result)
+	assert.NoError(t, f.CalcWorkbook(context.Background()))
+}
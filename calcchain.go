@@ -39,7 +39,9 @@ func (f *File) calcChainReader() *xlsxCalcChain {
 func (f *File) calcChainWriter() {
 	if f.CalcChain != nil && f.CalcChain.C != nil {
 		output, _ := xml.Marshal(f.CalcChain)
-		f.saveFileList("xl/calcChain.xml", output)
+		if f.isPartChanged("xl/calcChain.xml", output, new(xlsxCalcChain)) {
+			f.saveFileList("xl/calcChain.xml", output)
+		}
 	}
 }
 
@@ -53,13 +55,19 @@ func (f *File) deleteCalcChain(index int, axis string) {
 		})
 	}
 	if len(calc.C) == 0 {
-		f.CalcChain = nil
-		delete(f.XLSX, "xl/calcChain.xml")
-		content := f.contentTypesReader()
-		for k, v := range content.Overrides {
-			if v.PartName == "/xl/calcChain.xml" {
-				content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
-			}
+		f.deleteCalcChainPart()
+	}
+}
+
+// deleteCalcChainPart provides a function to remove the calculation chain
+// part and its content type from the spreadsheet.
+func (f *File) deleteCalcChainPart() {
+	f.CalcChain = nil
+	delete(f.XLSX, "xl/calcChain.xml")
+	content := f.contentTypesReader()
+	for k, v := range content.Overrides {
+		if v.PartName == "/xl/calcChain.xml" {
+			content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
 		}
 	}
 }
@@ -32,6 +32,60 @@ const (
 	STCellFormulaTypeShared = "shared"
 )
 
+// CellType is the type of the cell value.
+type CellType byte
+
+// This section defines the cell value types.
+const (
+	// CellTypeUnset defined the cell has no value.
+	CellTypeUnset CellType = iota
+	// CellTypeBool defined the cell value is a boolean.
+	CellTypeBool
+	// CellTypeDate defined the cell value is a date, which is a number with
+	// date number format or an ISO 8601 date string.
+	CellTypeDate
+	// CellTypeError defined the cell value is an error value, such as #N/A.
+	CellTypeError
+	// CellTypeNumber defined the cell value is a number.
+	CellTypeNumber
+	// CellTypeString defined the cell value is a string, including the result
+	// of the formula which is a string.
+	CellTypeString
+)
+
+// CellError is the error value of the cell, such as #N/A and #DIV/0!. The
+// typed cell accessors like GetCellFloat return the CellError as error if the
+// cell value is an error value.
+type CellError string
+
+// This section defines the error values of the cell.
+const (
+	CellErrorNull        CellError = "#NULL!"
+	CellErrorDiv0        CellError = "#DIV/0!"
+	CellErrorValue       CellError = "#VALUE!"
+	CellErrorRef         CellError = "#REF!"
+	CellErrorName        CellError = "#NAME?"
+	CellErrorNum         CellError = "#NUM!"
+	CellErrorNA          CellError = "#N/A"
+	CellErrorGettingData CellError = "#GETTING_DATA"
+)
+
+// Error returns the error value of the cell.
+func (e CellError) Error() string {
+	return string(e)
+}
+
+// validCellError provides a function to check if the error value is valid.
+func validCellError(e CellError) bool {
+	for _, v := range []CellError{CellErrorNull, CellErrorDiv0, CellErrorValue,
+		CellErrorRef, CellErrorName, CellErrorNum, CellErrorNA, CellErrorGettingData} {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
 // GetCellValue provides a function to get formatted value from cell by given
 // worksheet name and axis in spreadsheet file. If it is possible to apply a
 // format to the cell value, it will do so, if not then an error will be
@@ -91,6 +145,8 @@ func (f *File) SetCellValue(sheet, axis string, value interface{}) error {
 		err = f.setDefaultTimeStyle(sheet, axis, 21)
 	case time.Time:
 		err = f.setCellTimeFunc(sheet, axis, v)
+	case CellError:
+		err = f.SetCellError(sheet, axis, v)
 	case bool:
 		err = f.SetCellBool(sheet, axis, v)
 	case nil:
@@ -132,6 +188,7 @@ func (f *File) setCellIntFunc(sheet, axis string, value interface{}) error {
 // setCellTimeFunc provides a method to process time type of value for
 // SetCellValue.
 func (f *File) setCellTimeFunc(sheet, axis string, value time.Time) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -141,9 +198,10 @@ func (f *File) setCellTimeFunc(sheet, axis string, value time.Time) error {
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
 
 	var isNum bool
-	cellData.T, cellData.V, isNum, err = setCellTime(value)
+	cellData.T, cellData.V, isNum, err = setCellTime(f.normalizeTime(value), f.date1904())
 	if err != nil {
 		return err
 	}
@@ -156,12 +214,17 @@ func (f *File) setCellTimeFunc(sheet, axis string, value time.Time) error {
 	return err
 }
 
-func setCellTime(value time.Time) (t string, b string, isNum bool, err error) {
+// setCellTime provides a function to convert the time.Time to the cell value
+// by given value and the flag of the 1904 date system.
+func setCellTime(value time.Time, date1904 bool) (t string, b string, isNum bool, err error) {
 	var excelTime float64
 	excelTime, err = timeToExcelTime(value)
 	if err != nil {
 		return
 	}
+	if date1904 {
+		excelTime -= date1904Offset
+	}
 	isNum = excelTime > 0
 	if isNum {
 		t, b = setCellDefault(strconv.FormatFloat(excelTime, 'f', -1, 64))
@@ -176,9 +239,36 @@ func setCellDuration(value time.Duration) (t string, v string) {
 	return
 }
 
+// SetCellError provides a function to set error value of a cell by given
+// worksheet name, cell coordinates and error value, the cell value will be
+// stored as a typed error instead of a string. For example, set the #N/A
+// error value in Sheet1!A1:
+//
+//    err := f.SetCellError("Sheet1", "A1", excelize.CellErrorNA)
+//
+func (f *File) SetCellError(sheet, axis string, value CellError) error {
+	f.clearCalcCache()
+	if !validCellError(value) {
+		return fmt.Errorf("invalid error value %q", value)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cellData, col, _, err := f.prepareCell(ws, sheet, axis)
+	if err != nil {
+		return err
+	}
+	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
+	cellData.T, cellData.V = "e", string(value)
+	return err
+}
+
 // SetCellInt provides a function to set int type value of a cell by given
 // worksheet name, cell coordinates and cell value.
 func (f *File) SetCellInt(sheet, axis string, value int) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -188,6 +278,7 @@ func (f *File) SetCellInt(sheet, axis string, value int) error {
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
 	cellData.T, cellData.V = setCellInt(value)
 	return err
 }
@@ -200,6 +291,7 @@ func setCellInt(value int) (t string, v string) {
 // SetCellBool provides a function to set bool type value of a cell by given
 // worksheet name, cell name and cell value.
 func (f *File) SetCellBool(sheet, axis string, value bool) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -209,6 +301,7 @@ func (f *File) SetCellBool(sheet, axis string, value bool) error {
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
 	cellData.T, cellData.V = setCellBool(value)
 	return err
 }
@@ -233,6 +326,7 @@ func setCellBool(value bool) (t string, v string) {
 //    f.SetCellFloat("Sheet1", "A1", float64(x), 2, 32)
 //
 func (f *File) SetCellFloat(sheet, axis string, value float64, prec, bitSize int) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -242,6 +336,7 @@ func (f *File) SetCellFloat(sheet, axis string, value float64, prec, bitSize int
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
 	cellData.T, cellData.V = setCellFloat(value, prec, bitSize)
 	return err
 }
@@ -254,6 +349,7 @@ func setCellFloat(value float64, prec, bitSize int) (t string, v string) {
 // SetCellStr provides a function to set string type value of a cell. Total
 // number of characters that a cell can contain 32767 characters.
 func (f *File) SetCellStr(sheet, axis, value string) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -263,19 +359,43 @@ func (f *File) SetCellStr(sheet, axis, value string) error {
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
-	cellData.T, cellData.V = f.setCellString(value)
+	cellData.VM = 0
+	f.setCellString(cellData, value)
 	return err
 }
 
 // setCellString provides a function to set string type to shared string
-// table.
-func (f *File) setCellString(value string) (t string, v string) {
+// table, or set it as the inline string of the cell by the shared strings
+// policy specified by the InlineStrings and SharedStringsLimit options.
+func (f *File) setCellString(c *xlsxC, value string) {
 	if len(value) > TotalCellChars {
 		value = value[0:TotalCellChars]
 	}
-	t = "s"
-	v = strconv.Itoa(f.setSharedString(value))
-	return
+	if f.isInlineString(value) {
+		_, _, space := setCellStr(value)
+		c.T, c.V, c.IS = "inlineStr", "", &xlsxSI{T: &xlsxT{Val: value, Space: space}}
+		return
+	}
+	c.T, c.V, c.IS = "s", strconv.Itoa(f.setSharedString(value)), nil
+}
+
+// isInlineString provides a function to check if the string should be set as
+// the inline string instead of adding to the shared string table by given
+// string. The string will be inline if the InlineStrings option is specified,
+// or the string doesn't exist in the shared string table which has reached
+// the SharedStringsLimit.
+func (f *File) isInlineString(value string) bool {
+	if f.inlineStrings {
+		return true
+	}
+	if f.sharedStrLimit <= 0 {
+		return false
+	}
+	sst := f.sharedStringsReader()
+	f.Lock()
+	defer f.Unlock()
+	_, ok := f.sharedStringsMap[value]
+	return !ok && len(sst.SI) >= f.sharedStrLimit
 }
 
 // setSharedString provides a function to add string to the share string table.
@@ -319,9 +439,25 @@ func setCellStr(value string) (t string, v string, ns xml.Attr) {
 	return
 }
 
+// SetCellQuotedStr provides a function to set string type value of a cell
+// with the quote prefix by given worksheet name, cell coordinates and cell
+// value. The value will be stored as is, and the spreadsheet application will
+// not interpret the value which has leading zeros or looks like a number,
+// date or formula. For example, set the value "00123" of Sheet1!A1:
+//
+//    err := f.SetCellQuotedStr("Sheet1", "A1", "00123")
+//
+func (f *File) SetCellQuotedStr(sheet, axis, value string) error {
+	if err := f.SetCellStr(sheet, axis, value); err != nil {
+		return err
+	}
+	return f.SetCellQuotePrefix(sheet, axis, true)
+}
+
 // SetCellDefault provides a function to set string type value of a cell as
 // default format without escaping the cell.
 func (f *File) SetCellDefault(sheet, axis, value string) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -331,6 +467,7 @@ func (f *File) SetCellDefault(sheet, axis, value string) error {
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
 	cellData.T, cellData.V = setCellDefault(value)
 	return err
 }
@@ -363,6 +500,7 @@ type FormulaOpts struct {
 // SetCellFormula provides a function to set cell formula by given string and
 // worksheet name.
 func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -372,7 +510,7 @@ func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts)
 		return err
 	}
 	if formula == "" {
-		cellData.F = nil
+		cellData.F, cellData.CM = nil, 0
 		f.deleteCalcChain(f.getSheetID(sheet), axis)
 		return err
 	}
@@ -431,6 +569,67 @@ func (f *File) GetCellHyperLink(sheet, axis string) (bool, string, error) {
 	return false, "", err
 }
 
+// Hyperlink directly maps the settings of the cell hyperlink. The Type will be
+// "External" for web site, "Email" for email address or "Location" for the
+// cell or defined name in this workbook. The Target is the address of the
+// external hyperlink, and the Location is the cell reference or defined name
+// of the internal hyperlink.
+type Hyperlink struct {
+	Cell     string
+	Type     string
+	Target   string
+	Location string
+	Display  string
+	Tooltip  string
+}
+
+// GetCellHyperLinkDetail provides a function to get the details of the cell
+// hyperlink by given worksheet name and axis. Boolean type value link will be
+// true if the cell has a hyperlink. For example get the tooltip of the
+// hyperlink in Sheet1!H6:
+//
+//    link, hyperlink, err := f.GetCellHyperLinkDetail("Sheet1", "H6")
+//    if link {
+//        fmt.Println(hyperlink.Tooltip)
+//    }
+//
+func (f *File) GetCellHyperLinkDetail(sheet, axis string) (bool, Hyperlink, error) {
+	var hyperlink Hyperlink
+	if _, _, err := SplitCellName(axis); err != nil {
+		return false, hyperlink, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return false, hyperlink, err
+	}
+	if axis, err = f.mergeCellsParser(ws, axis); err != nil {
+		return false, hyperlink, err
+	}
+	if ws.Hyperlinks != nil {
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			if link.Ref != axis {
+				continue
+			}
+			hyperlink = Hyperlink{
+				Cell:     link.Ref,
+				Type:     "Location",
+				Location: link.Location,
+				Display:  link.Display,
+				Tooltip:  link.Tooltip,
+			}
+			if link.RID != "" {
+				hyperlink.Type = "External"
+				hyperlink.Target = f.getSheetRelationshipsTargetByID(sheet, link.RID)
+				if strings.HasPrefix(hyperlink.Target, "mailto:") {
+					hyperlink.Type = "Email"
+				}
+			}
+			return true, hyperlink, err
+		}
+	}
+	return false, hyperlink, err
+}
+
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
 // attributes (e.g. display value)
 type HyperlinkOpts struct {
@@ -439,10 +638,12 @@ type HyperlinkOpts struct {
 }
 
 // SetCellHyperLink provides a function to set cell hyperlink by given
-// worksheet name and link URL address. LinkType defines two types of
-// hyperlink "External" for web site or "Location" for moving to one of cell
-// in this workbook. Maximum limit hyperlinks in a worksheet is 65530. The
-// below is example for external link.
+// worksheet name and link URL address. LinkType defines four types of
+// hyperlink "External" for web site, "Email" for email address, "Location"
+// for moving to one of cell in this workbook or "DefinedName" for moving to
+// the range referred by the defined name in this workbook. The hyperlink
+// already exists in the cell will be replaced. Maximum limit hyperlinks in a
+// worksheet is 65530. The below is example for external link.
 //
 //    err := f.SetCellHyperLink("Sheet1", "A3", "https://github.com/360EntSecGroup-Skylar/excelize", "External")
 //    // Set underline and font color style for the cell.
@@ -453,6 +654,15 @@ type HyperlinkOpts struct {
 //
 //    err := f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A40", "Location")
 //
+// The email address will be prefixed with "mailto:" if not specified, and the
+// display text and tooltip of the hyperlink can be set by HyperlinkOpts:
+//
+//    display, tooltip := "Contact us", "Send an email"
+//    err := f.SetCellHyperLink("Sheet1", "A4", "support@example.com", "Email", excelize.HyperlinkOpts{
+//        Display: &display,
+//        Tooltip: &tooltip,
+//    })
+//
 func (f *File) SetCellHyperLink(sheet, axis, link, linkType string, opts ...HyperlinkOpts) error {
 	// Check for correct cell name
 	if _, _, err := SplitCellName(axis); err != nil {
@@ -479,6 +689,12 @@ func (f *File) SetCellHyperLink(sheet, axis, link, linkType string, opts ...Hype
 	}
 
 	switch linkType {
+	case "Email":
+		if !strings.HasPrefix(link, "mailto:") {
+			link = "mailto:" + link
+		}
+		linkType = "External"
+		fallthrough
 	case "External":
 		linkData = xlsxHyperlink{
 			Ref: axis,
@@ -488,6 +704,15 @@ func (f *File) SetCellHyperLink(sheet, axis, link, linkType string, opts ...Hype
 		rID := f.addRels(sheetRels, SourceRelationshipHyperLink, link, linkType)
 		linkData.RID = "rId" + strconv.Itoa(rID)
 		f.addSheetNameSpace(sheet, SourceRelationship)
+	case "DefinedName":
+		var exist bool
+		for _, dn := range f.GetDefinedName() {
+			exist = exist || dn.Name == link
+		}
+		if !exist {
+			return fmt.Errorf("defined name %s is not exist", link)
+		}
+		fallthrough
 	case "Location":
 		linkData = xlsxHyperlink{
 			Ref:      axis,
@@ -506,10 +731,69 @@ func (f *File) SetCellHyperLink(sheet, axis, link, linkType string, opts ...Hype
 		}
 	}
 
+	f.removeCellHyperLink(ws, sheet, axis)
+	if ws.Hyperlinks == nil {
+		ws.Hyperlinks = new(xlsxHyperlinks)
+	}
 	ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink, linkData)
 	return nil
 }
 
+// RemoveCellHyperLink provides a function to remove the cell hyperlink by
+// given worksheet name and axis, the relationship of the external hyperlink
+// will be removed with the hyperlink. Note that the cell value and style will
+// keep unchanged. For example remove the hyperlink in Sheet1!A3:
+//
+//    err := f.RemoveCellHyperLink("Sheet1", "A3")
+//
+func (f *File) RemoveCellHyperLink(sheet, axis string) error {
+	if _, _, err := SplitCellName(axis); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if axis, err = f.mergeCellsParser(ws, axis); err != nil {
+		return err
+	}
+	f.removeCellHyperLink(ws, sheet, axis)
+	return err
+}
+
+// removeCellHyperLink provides a function to remove the hyperlink by given
+// worksheet and axis, and delete the relationship which not used by other
+// hyperlinks.
+func (f *File) removeCellHyperLink(ws *xlsxWorksheet, sheet, axis string) {
+	if ws.Hyperlinks == nil {
+		return
+	}
+	var rIDs []string
+	hyperlinks := ws.Hyperlinks.Hyperlink[:0]
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		if link.Ref == axis {
+			if link.RID != "" {
+				rIDs = append(rIDs, link.RID)
+			}
+			continue
+		}
+		hyperlinks = append(hyperlinks, link)
+	}
+	ws.Hyperlinks.Hyperlink = hyperlinks
+	for _, rID := range rIDs {
+		var inUse bool
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			inUse = inUse || link.RID == rID
+		}
+		if !inUse {
+			f.deleteSheetRelationships(sheet, rID)
+		}
+	}
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
+	}
+}
+
 // GetCellRichText provides a function to get rich text of cell by given
 // worksheet.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
@@ -521,6 +805,12 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	if err != nil {
 		return
 	}
+	if cellData.T == "inlineStr" && cellData.IS != nil {
+		for _, v := range cellData.IS.R {
+			runs = append(runs, newRichTextRun(v))
+		}
+		return
+	}
 	siIdx, err := strconv.Atoi(cellData.V)
 	if nil != err {
 		return
@@ -531,34 +821,41 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	}
 	si := sst.SI[siIdx]
 	for _, v := range si.R {
-		run := RichTextRun{
-			Text: v.T.Val,
-		}
-		if nil != v.RPr {
-			font := Font{Underline: "none"}
-			font.Bold = v.RPr.B != nil
-			font.Italic = v.RPr.I != nil
-			if v.RPr.U != nil {
-				font.Underline = "single"
-				if v.RPr.U.Val != nil {
-					font.Underline = *v.RPr.U.Val
-				}
-			}
-			if v.RPr.RFont != nil && v.RPr.RFont.Val != nil {
-				font.Family = *v.RPr.RFont.Val
-			}
-			if v.RPr.Sz != nil && v.RPr.Sz.Val != nil {
-				font.Size = *v.RPr.Sz.Val
-			}
-			font.Strike = v.RPr.Strike != nil
-			if nil != v.RPr.Color {
-				font.Color = strings.TrimPrefix(v.RPr.Color.RGB, "FF")
+		runs = append(runs, newRichTextRun(v))
+	}
+	return
+}
+
+// newRichTextRun provides a function to convert the rich text run of the
+// shared string or comment to the RichTextRun.
+func newRichTextRun(v xlsxR) RichTextRun {
+	var run RichTextRun
+	if v.T != nil {
+		run.Text = v.T.Val
+	}
+	if nil != v.RPr {
+		font := Font{Underline: "none"}
+		font.Bold = v.RPr.B != nil
+		font.Italic = v.RPr.I != nil
+		if v.RPr.U != nil {
+			font.Underline = "single"
+			if v.RPr.U.Val != nil {
+				font.Underline = *v.RPr.U.Val
 			}
-			run.Font = &font
 		}
-		runs = append(runs, run)
+		if v.RPr.RFont != nil && v.RPr.RFont.Val != nil {
+			font.Family = *v.RPr.RFont.Val
+		}
+		if v.RPr.Sz != nil && v.RPr.Sz.Val != nil {
+			font.Size = *v.RPr.Sz.Val
+		}
+		font.Strike = v.RPr.Strike != nil
+		if nil != v.RPr.Color {
+			font.Color = strings.TrimPrefix(v.RPr.Color.RGB, "FF")
+		}
+		run.Font = &font
 	}
-	return
+	return run
 }
 
 // SetCellRichText provides a function to set cell with rich text by given
@@ -666,6 +963,7 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 //    }
 //
 func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -675,6 +973,7 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 		return err
 	}
 	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.VM = 0
 	si := xlsxSI{}
 	sst := f.sharedStringsReader()
 	textRuns := []xlsxR{}
@@ -686,43 +985,30 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 		fnt := textRun.Font
 		if fnt != nil {
 			rpr := xlsxRPr{}
-			trueVal := ""
-			if fnt.Bold {
-				rpr.B = &trueVal
-			}
-			if fnt.Italic {
-				rpr.I = &trueVal
-			}
-			if fnt.Strike {
-				rpr.Strike = &trueVal
-			}
-			if fnt.Underline != "" {
-				rpr.U = &attrValString{Val: &fnt.Underline}
-			}
-			if fnt.Family != "" {
-				rpr.RFont = &attrValString{Val: &fnt.Family}
-			}
-			if fnt.Size > 0.0 {
-				rpr.Sz = &attrValFloat{Val: &fnt.Size}
-			}
-			if fnt.Color != "" {
-				rpr.Color = &xlsxColor{RGB: getPaletteColor(fnt.Color)}
-			}
+			setRichTextRunFont(&rpr, fnt)
 			run.RPr = &rpr
 		}
 		textRuns = append(textRuns, run)
 	}
 	si.R = textRuns
+	if f.inlineStrings {
+		cellData.T, cellData.V, cellData.IS = "inlineStr", "", &si
+		return err
+	}
 	for idx, strItem := range sst.SI {
 		if reflect.DeepEqual(strItem, si) {
-			cellData.T, cellData.V = "s", strconv.Itoa(idx)
+			cellData.T, cellData.V, cellData.IS = "s", strconv.Itoa(idx), nil
 			return err
 		}
 	}
+	if f.sharedStrLimit > 0 && len(sst.SI) >= f.sharedStrLimit {
+		cellData.T, cellData.V, cellData.IS = "inlineStr", "", &si
+		return err
+	}
 	sst.SI = append(sst.SI, si)
 	sst.Count++
 	sst.UniqueCount++
-	cellData.T, cellData.V = "s", strconv.Itoa(len(sst.SI)-1)
+	cellData.T, cellData.V, cellData.IS = "s", strconv.Itoa(len(sst.SI)-1), nil
 	return err
 }
 
@@ -763,14 +1049,13 @@ func (f *File) SetSheetRow(sheet, axis string, slice interface{}) error {
 func (f *File) prepareCell(ws *xlsxWorksheet, sheet, cell string) (*xlsxC, int, int, error) {
 	ws.Lock()
 	defer ws.Unlock()
-	var err error
-	cell, err = f.mergeCellsParser(ws, cell)
+	axis, err := f.mergeCellsParser(ws, cell)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, newCellError("set", sheet, cell, err)
 	}
-	col, row, err := CellNameToCoordinates(cell)
+	col, row, err := CellNameToCoordinates(axis)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, newCellError("set", sheet, cell, err)
 	}
 
 	prepareSheetXML(ws, col, row)
@@ -778,6 +1063,300 @@ func (f *File) prepareCell(ws *xlsxWorksheet, sheet, cell string) (*xlsxC, int,
 	return &ws.SheetData.Row[row-1].C[col-1], col, row, err
 }
 
+// setRichTextRunFont provides a function to set the run properties of the
+// rich text run by given font settings.
+func setRichTextRunFont(rpr *xlsxRPr, fnt *Font) {
+	trueVal := ""
+	if fnt.Bold {
+		rpr.B = &trueVal
+	}
+	if fnt.Italic {
+		rpr.I = &trueVal
+	}
+	if fnt.Strike {
+		rpr.Strike = &trueVal
+	}
+	if fnt.Underline != "" {
+		rpr.U = &attrValString{Val: &fnt.Underline}
+	}
+	if fnt.Family != "" {
+		rpr.RFont = &attrValString{Val: &fnt.Family}
+	}
+	if fnt.Size > 0.0 {
+		rpr.Sz = &attrValFloat{Val: &fnt.Size}
+	}
+	if fnt.Color != "" {
+		rpr.Color = &xlsxColor{RGB: getPaletteColor(fnt.Color)}
+	}
+}
+
+// GetCellType provides a function to get the type of the cell value by given
+// worksheet name and axis. The number with date number format will be
+// recognized as CellTypeDate. For example, get the type of Sheet1!A1:
+//
+//    cellType, err := f.GetCellType("Sheet1", "A1")
+//
+func (f *File) GetCellType(sheet, axis string) (CellType, error) {
+	t, v, s, err := f.getCellRawValue(sheet, axis)
+	if err != nil {
+		return CellTypeUnset, err
+	}
+	return f.cellType(t, v, s), err
+}
+
+// cellType provides a function to get the type of the cell value by given
+// type attribute, raw value and style index of the cell.
+func (f *File) cellType(t, v string, s int) CellType {
+	switch t {
+	case "b":
+		return CellTypeBool
+	case "d":
+		return CellTypeDate
+	case "e":
+		return CellTypeError
+	case "s", "str", "inlineStr":
+		if v == "" && t != "str" {
+			return CellTypeUnset
+		}
+		return CellTypeString
+	}
+	if v == "" {
+		return CellTypeUnset
+	}
+	if f.isDateStyle(s) {
+		return CellTypeDate
+	}
+	return CellTypeNumber
+}
+
+// cellTypedValue provides a function to get the typed value of the cell by
+// given cell and shared string table, the value will be a float64,
+// time.Time, bool, CellError or string depends on the type of the cell
+// value, and nil for the cell without value. The raw value will be returned
+// as a string if it could not be converted to the type of the cell.
+func (f *File) cellTypedValue(c *xlsxC, d *xlsxSST) interface{} {
+	v := c.V
+	switch c.T {
+	case "s":
+		if idx, err := strconv.Atoi(c.V); err == nil && idx >= 0 && idx < len(d.SI) {
+			v = d.SI[idx].String()
+		}
+	case "inlineStr":
+		if c.IS != nil {
+			v = c.IS.String()
+		}
+	}
+	switch f.cellType(c.T, v, c.S) {
+	case CellTypeBool:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b
+		}
+	case CellTypeDate:
+		if c.T == "d" {
+			if tm, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(v)); err == nil {
+				if f.timeLocation != nil {
+					tm = tm.In(f.timeLocation)
+				}
+				return tm
+			}
+			break
+		}
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return f.localTime(timeFromExcelTime(n, f.date1904()))
+		}
+	case CellTypeError:
+		return CellError(v)
+	case CellTypeNumber:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return n
+		}
+	case CellTypeUnset:
+		return nil
+	}
+	return v
+}
+
+// cellFormula provides a function to get the formula of the cell by given
+// cell and the formulas of the shared formula master cells which have been
+// read, the master cell of the shared formula will be recorded.
+func cellFormula(c *xlsxC, sharedFormulas map[string]string) string {
+	if c.F == nil {
+		return ""
+	}
+	if c.F.T == STCellFormulaTypeShared {
+		if c.F.Ref != "" {
+			sharedFormulas[c.F.Si] = c.F.Content
+		}
+		return sharedFormulas[c.F.Si]
+	}
+	return c.F.Content
+}
+
+// GetCellInt provides a function to get the value of the cell as an integer
+// by given worksheet name and axis, the decimal part of the number will be
+// truncated. The raw value of the cell is used instead of the formatted value,
+// so the number format and the locale settings of the cell don't affect the
+// result. A blank cell will be treated as 0, and the CellError will be
+// returned as error if the cell value is an error value. For example:
+//
+//    v, err := f.GetCellInt("Sheet1", "A1")
+//    if cellErr, ok := err.(excelize.CellError); ok {
+//        fmt.Println("cell contains error value", cellErr)
+//    }
+//
+func (f *File) GetCellInt(sheet, axis string) (int, error) {
+	v, err := f.GetCellFloat(sheet, axis)
+	return int(v), err
+}
+
+// GetCellFloat provides a function to get the value of the cell as a float64
+// by given worksheet name and axis. The raw value of the cell is used instead
+// of the formatted value, so the number format and the locale settings of the
+// cell don't affect the result. A blank cell will be treated as 0, the
+// boolean value will be converted to 1 or 0, and the CellError will be
+// returned as error if the cell value is an error value.
+func (f *File) GetCellFloat(sheet, axis string) (float64, error) {
+	t, v, _, err := f.getCellRawValue(sheet, axis)
+	if err != nil {
+		return 0, err
+	}
+	switch t {
+	case "e":
+		return 0, CellError(v)
+	case "d":
+		tm, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return 0, err
+		}
+		excelTime, err := timeToExcelTime(f.normalizeTime(tm).UTC())
+		if f.date1904() {
+			excelTime -= date1904Offset
+		}
+		return excelTime, err
+	}
+	if v = strings.TrimSpace(v); v == "" {
+		return 0, err
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// GetCellBool provides a function to get the value of the cell as a boolean
+// by given worksheet name and axis. The number value will be treated as true
+// if it is not zero, the string value "TRUE" and "FALSE" are case-insensitive,
+// and the CellError will be returned as error if the cell value is an error
+// value.
+func (f *File) GetCellBool(sheet, axis string) (bool, error) {
+	t, v, _, err := f.getCellRawValue(sheet, axis)
+	if err != nil {
+		return false, err
+	}
+	switch t {
+	case "e":
+		return false, CellError(v)
+	case "s", "str", "inlineStr":
+		return strconv.ParseBool(strings.TrimSpace(v))
+	}
+	if v == "" {
+		return false, err
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	return n != 0, err
+}
+
+// GetCellTime provides a function to get the value of the cell as a
+// time.Time by given worksheet name and axis. The number value will be
+// converted from the Excel serial date by the date system of the workbook,
+// the string value should be formatted in RFC 3339, and the CellError will be
+// returned as error if the cell value is an error value. A blank cell will be
+// treated as the zero time. The time will be in the TimeLocation of the
+// options if specified, otherwise in UTC.
+func (f *File) GetCellTime(sheet, axis string) (time.Time, error) {
+	t, v, _, err := f.getCellRawValue(sheet, axis)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch t {
+	case "e":
+		return time.Time{}, CellError(v)
+	case "d", "s", "str", "inlineStr":
+		if v = strings.TrimSpace(v); v == "" {
+			return time.Time{}, err
+		}
+		tm, err := time.Parse(time.RFC3339Nano, v)
+		if err == nil && f.timeLocation != nil {
+			tm = tm.In(f.timeLocation)
+		}
+		return tm, err
+	}
+	if v == "" {
+		return time.Time{}, err
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return f.localTime(timeFromExcelTime(n, f.date1904())), err
+}
+
+// getCellRawValue provides a function to get the type, the raw value which
+// is the text of the shared string or inline string, and the style index of
+// the cell by given worksheet name and axis.
+func (f *File) getCellRawValue(sheet, axis string) (t, v string, s int, err error) {
+	_, err = f.getCellStringFunc(sheet, axis, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		t, v, s = c.T, f.cellRawValue(c), c.S
+		return v, true, nil
+	})
+	return
+}
+
+// cellRawValue provides a function to get the raw value of the cell, which
+// is the text of the shared string or inline string.
+func (f *File) cellRawValue(c *xlsxC) string {
+	switch c.T {
+	case "s":
+		if idx, err := strconv.Atoi(c.V); err == nil {
+			if sst := f.sharedStringsReader(); idx >= 0 && idx < len(sst.SI) {
+				return sst.SI[idx].String()
+			}
+		}
+	case "inlineStr":
+		if c.IS != nil {
+			return c.IS.String()
+		}
+	}
+	return c.V
+}
+
+// isDateStyle provides a function to check if the number format of the cell
+// style is a date or time format by given style index.
+func (f *File) isDateStyle(s int) bool {
+	styleSheet := f.stylesReader()
+	if s <= 0 || styleSheet.CellXfs == nil || s >= len(styleSheet.CellXfs.Xf) || styleSheet.CellXfs.Xf[s].NumFmtID == nil {
+		return false
+	}
+	numFmtID := *styleSheet.CellXfs.Xf[s].NumFmtID
+	if (numFmtID >= 14 && numFmtID <= 22) || (numFmtID >= 27 && numFmtID <= 36) ||
+		(numFmtID >= 45 && numFmtID <= 47) || (numFmtID >= 50 && numFmtID <= 58) {
+		return true
+	}
+	if styleSheet.NumFmts != nil {
+		for _, xlsxFmt := range styleSheet.NumFmts.NumFmt {
+			if xlsxFmt.NumFmtID == numFmtID {
+				return isDateFormatCode(xlsxFmt.FormatCode)
+			}
+		}
+	}
+	return false
+}
+
+// isDateFormatCode provides a function to check if the given number format
+// code is a date or time format.
+func isDateFormatCode(format string) bool {
+	format = strings.ToLower(format)
+	return strings.Contains(format, "y") || strings.Contains(format, "m") ||
+		strings.Contains(strings.Replace(format, "red", "", -1), "d") || strings.Contains(format, "h")
+}
+
 // getCellStringFunc does common value extraction workflow for all GetCell*
 // methods. Passed function implements specific part of required logic.
 func (f *File) getCellStringFunc(sheet, axis string, fn func(x *xlsxWorksheet, c *xlsxC) (string, bool, error)) (string, error) {
@@ -785,13 +1364,14 @@ func (f *File) getCellStringFunc(sheet, axis string, fn func(x *xlsxWorksheet, c
 	if err != nil {
 		return "", err
 	}
+	cell := axis
 	axis, err = f.mergeCellsParser(ws, axis)
 	if err != nil {
-		return "", err
+		return "", newCellError("get", sheet, cell, err)
 	}
 	_, row, err := CellNameToCoordinates(axis)
 	if err != nil {
-		return "", err
+		return "", newCellError("get", sheet, cell, err)
 	}
 
 	ws.Lock()
@@ -819,7 +1399,7 @@ func (f *File) getCellStringFunc(sheet, axis string, fn func(x *xlsxWorksheet, c
 			}
 			val, ok, err := fn(ws, colData)
 			if err != nil {
-				return "", err
+				return "", newCellError("get", sheet, cell, err)
 			}
 			if ok {
 				return val, nil
@@ -848,6 +1428,9 @@ func (f *File) formattedValue(s int, v string) string {
 
 	ok := builtInNumFmtFunc[numFmtID]
 	if ok != nil {
+		if (numFmtID >= 14 && numFmtID <= 22) || (numFmtID >= 45 && numFmtID <= 47) {
+			return ok(f.date1904Value(v), builtInNumFmt[numFmtID])
+		}
 		return ok(v, builtInNumFmt[numFmtID])
 	}
 	if styleSheet == nil || styleSheet.NumFmts == nil {
@@ -855,9 +1438,8 @@ func (f *File) formattedValue(s int, v string) string {
 	}
 	for _, xlsxFmt := range styleSheet.NumFmts.NumFmt {
 		if xlsxFmt.NumFmtID == numFmtID {
-			format := strings.ToLower(xlsxFmt.FormatCode)
-			if strings.Contains(format, "y") || strings.Contains(format, "m") || strings.Contains(strings.Replace(format, "red", "", -1), "d") || strings.Contains(format, "h") {
-				return parseTime(v, format)
+			if isDateFormatCode(xlsxFmt.FormatCode) {
+				return parseTime(f.date1904Value(v), strings.ToLower(xlsxFmt.FormatCode))
 			}
 			return v
 		}
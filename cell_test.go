@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
 	"reflect"
@@ -34,6 +35,58 @@ func TestConcurrency(t *testing.T) {
 	assert.Equal(t, "1", val)
 }
 
+func TestConcurrentRead(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 50; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, fmt.Sprintf("text%d", r)}))
+		assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("C%d", r), fmt.Sprintf("A%d*2", r)))
+	}
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.AddPicture("Sheet1", "E1", filepath.Join("test", "images", "excel.png"), ""))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	for _, opts := range []Options{{}, {MaxInMemoryPartSize: 1024}} {
+		f, err = OpenReader(bytes.NewReader(buf.Bytes()), opts)
+		assert.NoError(t, err)
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(row int) {
+				defer wg.Done()
+				rows, err := f.GetRows("Sheet1")
+				assert.NoError(t, err)
+				assert.Len(t, rows, 50)
+				cols, err := f.GetCols("Sheet1")
+				assert.NoError(t, err)
+				assert.Len(t, cols, 3)
+				cell := fmt.Sprintf("B%d", row)
+				val, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("text%d", row), val)
+				result, err := f.CalcCellValue("Sheet1", fmt.Sprintf("C%d", row))
+				assert.NoError(t, err)
+				assert.Equal(t, strconv.Itoa(row*2), result)
+				cells, err := f.SearchSheet("Sheet1", val)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{cell}, cells)
+				_, err = f.GetCellStyle("Sheet1", cell)
+				assert.NoError(t, err)
+				link, target, err := f.GetCellHyperLink("Sheet1", "B1")
+				assert.NoError(t, err)
+				assert.True(t, link)
+				assert.Equal(t, "https://github.com/xuri/excelize", target)
+				assert.Len(t, f.GetComments()["Sheet1"], 1)
+				pics, err := f.GetPictures("Sheet1")
+				assert.NoError(t, err)
+				assert.Len(t, pics, 1)
+			}(i + 1)
+		}
+		wg.Wait()
+		assert.NoError(t, f.Close())
+	}
+}
+
 func TestCheckCellInArea(t *testing.T) {
 	f := NewFile()
 	expectedTrueCellInAreaList := [][2]string{
@@ -276,6 +329,55 @@ func TestGetCellRichText(t *testing.T) {
 	_, err = f.GetCellRichText("Sheet1", "A")
 	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
+func TestSharedStringsPolicy(t *testing.T) {
+	f := NewFile(Options{InlineStrings: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", " text "))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A2", []RichTextRun{{Text: "bold", Font: &Font{Bold: true}}}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "x"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "y"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "C1", MergeCellOpts{Policy: MergeCellConcat, Separator: ","}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for _, c := range []xlsxC{ws.SheetData.Row[0].C[0], ws.SheetData.Row[1].C[0], ws.SheetData.Row[0].C[1]} {
+		assert.Equal(t, "inlineStr", c.T)
+		assert.Empty(t, c.V)
+	}
+	assert.Equal(t, "preserve", ws.SheetData.Row[0].C[0].IS.T.Space.Value)
+	assert.Empty(t, f.sharedStringsReader().SI)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSharedStringsPolicy.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestSharedStringsPolicy.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{" text ", "x,y"}, {"bold"}}, rows)
+	runs, err := f.GetCellRichText("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "bold", runs[0].Text)
+
+	// Test set strings with the limit of the shared string table.
+	f = NewFile(Options{SharedStringsLimit: 2})
+	for idx, val := range []string{"a", "b", "c", "a"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(idx+1), val))
+	}
+	richText := []RichTextRun{{Text: "rich"}}
+	assert.NoError(t, f.SetCellRichText("Sheet1", "B1", richText))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for idx, expected := range []string{"s", "s", "inlineStr", "s"} {
+		assert.Equal(t, expected, ws.SheetData.Row[idx].C[0].T)
+	}
+	assert.Equal(t, "inlineStr", ws.SheetData.Row[0].C[1].T)
+	assert.Len(t, f.sharedStringsReader().SI, 2)
+	// Test set the existing rich text with the limit of the shared string table.
+	f = NewFile(Options{SharedStringsLimit: 1})
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A1", richText))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A2", richText))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0", ws.SheetData.Row[1].C[0].V)
+	assert.Nil(t, ws.SheetData.Row[1].C[0].IS)
+}
+
 func TestSetCellRichText(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 35))
@@ -397,3 +499,145 @@ func TestFormattedValue2(t *testing.T) {
 	v = f.formattedValue(1, "43528")
 	assert.Equal(t, "43528", v)
 }
+
+func TestGetCellType(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"number_format":14}`)
+	assert.NoError(t, err)
+	customStyle, err := f.NewStyle(`{"custom_number_format":"[$-380A]dddd\\,\\ dd\" de \"mmmm\" de \"yyyy;@"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 100))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", CellErrorDiv0))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", 44000))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A6", "A6", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", 44000))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A7", "A7", customStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A8", "2020-10-01T00:00:00Z"))
+	f.Sheet["xl/worksheets/sheet1.xml"].SheetData.Row[7].C[0] = xlsxC{R: "A8", T: "d", V: "2020-10-01T00:00:00Z"}
+	for cell, expected := range map[string]CellType{
+		"A1": CellTypeBool, "A2": CellTypeNumber, "A3": CellTypeString, "A4": CellTypeDate,
+		"A5": CellTypeError, "A6": CellTypeDate, "A7": CellTypeDate, "A8": CellTypeDate, "A9": CellTypeUnset,
+	} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	_, err = f.GetCellType("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestGetCellTypedValue(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 12.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", " 42 "))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "false"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellError("Sheet1", "A6", CellErrorNA))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", "2020-10-01T12:00:00Z"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A8", "text"))
+	style, err := f.NewStyle(`{"number_format":4}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+
+	i, err := f.GetCellInt("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, i)
+	v, err := f.GetCellFloat("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, v)
+	i, err = f.GetCellInt("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, i)
+	v, err = f.GetCellFloat("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, v)
+	v, err = f.GetCellFloat("Sheet1", "A9")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, v)
+	_, err = f.GetCellFloat("Sheet1", "A8")
+	assert.EqualError(t, err, `strconv.ParseFloat: parsing "text": invalid syntax`)
+
+	b, err := f.GetCellBool("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	b, err = f.GetCellBool("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.False(t, b)
+	b, err = f.GetCellBool("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	b, err = f.GetCellBool("Sheet1", "A9")
+	assert.NoError(t, err)
+	assert.False(t, b)
+
+	tm, err := f.GetCellTime("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC), tm)
+	tm, err = f.GetCellTime("Sheet1", "A7")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC), tm)
+	tm, err = f.GetCellTime("Sheet1", "A9")
+	assert.NoError(t, err)
+	assert.True(t, tm.IsZero())
+	f.WorkBook.WorkbookPr = &xlsxWorkbookPr{Date1904: true}
+	tm, err = f.GetCellTime("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1904, 1, 13, 12, 0, 0, 0, time.UTC), tm)
+
+	// Test get typed value of the cell with error value.
+	_, err = f.GetCellInt("Sheet1", "A6")
+	assert.Equal(t, CellErrorNA, err)
+	_, err = f.GetCellBool("Sheet1", "A6")
+	assert.EqualError(t, err, "#N/A")
+	_, err = f.GetCellTime("Sheet1", "A6")
+	assert.EqualError(t, err, "#N/A")
+	val, err := f.GetCellValue("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "#N/A", val)
+
+	// Test get typed value on not exists worksheet.
+	_, err = f.GetCellInt("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, err = f.GetCellBool("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, err = f.GetCellTime("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestSetCellError(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellError("Sheet1", "A1", CellErrorDiv0))
+	assert.Equal(t, xlsxC{R: "A1", T: "e", V: "#DIV/0!"}, f.Sheet["xl/worksheets/sheet1.xml"].SheetData.Row[0].C[0])
+	assert.EqualError(t, f.SetCellError("Sheet1", "A1", CellError("#ERR")), `invalid error value "#ERR"`)
+	assert.EqualError(t, f.SetCellError("SheetN", "A1", CellErrorNA), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetCellError("Sheet1", "A", CellErrorNA), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellError.xlsx")))
+}
+
+func TestSetCellQuotedStr(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]string{"A1": "00123", "A2": "=SUM(1,2)", "A3": "1/2"} {
+		assert.NoError(t, f.SetCellQuotedStr("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellQuotedStr.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestSetCellQuotedStr.xlsx"))
+	assert.NoError(t, err)
+	for cell, value := range map[string]string{"A1": "00123", "A2": "=SUM(1,2)", "A3": "1/2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, val)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeString, cellType)
+		quotePrefix, err := f.GetCellQuotePrefix("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, quotePrefix)
+	}
+	// Test set cell quoted string on not exists worksheet.
+	assert.EqualError(t, f.SetCellQuotedStr("SheetN", "A1", "00123"), "sheet SheetN is not exist")
+}
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"errors"
+	"io/ioutil"
 	"math"
 	"strconv"
 	"strings"
@@ -81,15 +82,50 @@ func (cols *Cols) Error() error {
 
 // Rows return the current column's row values.
 func (cols *Cols) Rows() ([]string, error) {
+	rows, _, err := cols.readCol(false)
+	return rows, err
+}
+
+// Cells return the current column's cells with the typed values, the style
+// index and the formula of each cell, the value of the cell will be a
+// float64, time.Time, bool, CellError or string depends on the type of the
+// cell value, and nil for the blank cell. For example:
+//
+//    cols, err := f.Cols("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for cols.Next() {
+//        cells, err := cols.Cells()
+//        if err != nil {
+//            fmt.Println(err)
+//        }
+//        for _, cell := range cells {
+//            fmt.Println(cell.Value, cell.StyleID, cell.Formula)
+//        }
+//    }
+//
+func (cols *Cols) Cells() ([]Cell, error) {
+	_, cells, err := cols.readCol(true)
+	return cells, err
+}
+
+// readCol provides a function to read the current column of the worksheet,
+// the typed cells will be read instead of the formatted values if typed is
+// true.
+func (cols *Cols) readCol(typed bool) ([]string, []Cell, error) {
 	var (
 		err              error
 		inElement        string
 		cellCol, cellRow int
 		rows             []string
+		cells            []Cell
 	)
 	if cols.stashCol >= cols.curCol {
-		return rows, err
+		return rows, cells, err
 	}
+	sharedFormulas := map[string]string{}
 	d := cols.f.sharedStringsReader()
 	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
 	for {
@@ -113,9 +149,23 @@ func (cols *Cols) Rows() ([]string, error) {
 				for _, attr := range xmlElement.Attr {
 					if attr.Name.Local == "r" {
 						if cellCol, cellRow, err = CellNameToCoordinates(attr.Value); err != nil {
-							return rows, err
+							return rows, cells, err
+						}
+					}
+				}
+				if typed {
+					// The master cell of the shared formula may be in the
+					// other columns, so decode all the cells.
+					colCell := xlsxC{}
+					_ = decoder.DecodeElement(&colCell, &xmlElement)
+					formula := cellFormula(&colCell, sharedFormulas)
+					if cellCol == cols.curCol {
+						for i := len(cells) + 1; i < cellRow; i++ {
+							cells = append(cells, Cell{})
 						}
+						cells = append(cells, Cell{StyleID: colCell.S, Formula: formula, Value: cols.f.cellTypedValue(&colCell, d)})
 					}
+					continue
 				}
 				blank := cellRow - len(rows)
 				for i := 1; i < blank; i++ {
@@ -130,11 +180,11 @@ func (cols *Cols) Rows() ([]string, error) {
 			}
 		case xml.EndElement:
 			if xmlElement.Name.Local == "sheetData" {
-				return rows, err
+				return rows, cells, err
 			}
 		}
 	}
-	return rows, err
+	return rows, cells, err
 }
 
 // columnXMLIterator defined runtime use field for the worksheet column SAX parser.
@@ -200,12 +250,15 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 	if !ok {
 		return nil, ErrSheetNotExist{sheet}
 	}
-	if f.Sheet[name] != nil {
-		output, _ := xml.Marshal(f.Sheet[name])
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+	rc, err := f.readSheetXMLReader(name)
+	if err != nil {
+		return nil, err
 	}
+	defer rc.Close()
 	var colIterator columnXMLIterator
-	colIterator.cols.sheetXML = f.readXML(name)
+	if colIterator.cols.sheetXML, err = ioutil.ReadAll(rc); err != nil {
+		return nil, err
+	}
 	decoder := f.xmlNewDecoder(bytes.NewReader(colIterator.cols.sheetXML))
 	for {
 		token, _ := decoder.Token()
@@ -333,6 +386,64 @@ func (f *File) GetColOutlineLevel(sheet, col string) (uint8, error) {
 	return level, err
 }
 
+// GetHiddenCols provides a function to get all hidden columns by given
+// worksheet name in one call, the result is the ascending sorted column names.
+// For example, get all hidden columns in Sheet1:
+//
+//    cols, err := f.GetHiddenCols("Sheet1")
+//
+func (f *File) GetHiddenCols(sheet string) ([]string, error) {
+	var cols []string
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Cols == nil {
+		return cols, err
+	}
+	hidden := make(map[int]bool)
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		for colNum := colData.Min; colNum <= colData.Max; colNum++ {
+			hidden[colNum] = colData.Hidden
+		}
+	}
+	for colNum := 1; colNum <= TotalColumns; colNum++ {
+		if hidden[colNum] {
+			colName, _ := ColumnNumberToName(colNum)
+			cols = append(cols, colName)
+		}
+	}
+	return cols, err
+}
+
+// GetColOutlineLevels provides a function to get the outline levels of all
+// columns by given worksheet name in one call, the result is a map of the
+// column name to the outline level, columns without outline level are not
+// included. For example, get outline levels of the columns in Sheet1:
+//
+//    levels, err := f.GetColOutlineLevels("Sheet1")
+//
+func (f *File) GetColOutlineLevels(sheet string) (map[string]uint8, error) {
+	levels := make(map[string]uint8)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Cols == nil {
+		return levels, err
+	}
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		for colNum := colData.Min; colNum <= colData.Max; colNum++ {
+			colName, err := ColumnNumberToName(colNum)
+			if err != nil {
+				return levels, err
+			}
+			if colData.OutlineLevel > 0 {
+				levels[colName] = colData.OutlineLevel
+				continue
+			}
+			delete(levels, colName)
+		}
+	}
+	return levels, err
+}
+
 // parseColRange parse and convert column range with column name to the column number.
 func (f *File) parseColRange(columns string) (start, end int, err error) {
 	colsTab := strings.Split(columns, ":")
@@ -407,6 +518,7 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 //    err = f.SetColStyle("Sheet1", "C:F", style)
 //
 func (f *File) SetColStyle(sheet, columns string, styleID int) error {
+	f.clearCalcCache()
 	start, end, err := f.parseColRange(columns)
 	if err != nil {
 		return err
@@ -604,6 +716,37 @@ func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, heigh
 	return col, row, colEnd, rowEnd, x2, y2
 }
 
+// cellAnchorToPixels provides a function to convert the cell anchor of the
+// drawing object to the absolute position in pixels by given worksheet name,
+// zero-based column and row index and the offsets in EMU.
+func (f *File) cellAnchorToPixels(sheet string, col, colOff, row, rowOff int) (int, int) {
+	x, y := colOff/EMU, rowOff/EMU
+	for c := 1; c <= col; c++ {
+		x += f.getColWidth(sheet, c)
+	}
+	for r := 0; r < row; r++ {
+		y += f.getRowHeight(sheet, r)
+	}
+	return x, y
+}
+
+// pixelsToCellAnchor provides a function to convert the absolute position in
+// pixels to the cell anchor of the drawing object by given worksheet name
+// and the position, returns zero-based column and row index and the offsets
+// in EMU.
+func (f *File) pixelsToCellAnchor(sheet string, x, y int) (int, int, int, int) {
+	var col, row int
+	for x >= f.getColWidth(sheet, col+1) {
+		x -= f.getColWidth(sheet, col+1)
+		col++
+	}
+	for y >= f.getRowHeight(sheet, row) {
+		y -= f.getRowHeight(sheet, row)
+		row++
+	}
+	return col, x * EMU, row, y * EMU
+}
+
 // getColWidth provides a function to get column width in pixels by given
 // sheet name and column index.
 func (f *File) getColWidth(sheet string, col int) int {
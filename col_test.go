@@ -285,6 +285,42 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.SetColOutlineLevel("Sheet2", "B", 2))
 }
 
+func TestGetHiddenCols(t *testing.T) {
+	f := NewFile()
+	cols, err := f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cols)
+	assert.NoError(t, f.SetColVisible("Sheet1", "D:F", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", true))
+	cols, err = f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "D", "F"}, cols)
+	// Test get hidden columns on not exists worksheet.
+	_, err = f.GetHiddenCols("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestGetColOutlineLevels(t *testing.T) {
+	f := NewFile()
+	levels, err := f.GetColOutlineLevels("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, levels)
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "B", 2))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "D", 1))
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "E", 20))
+	levels, err = f.GetColOutlineLevels("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]uint8{"B": 2, "D": 1}, levels)
+	// Test get column outline levels on not exists worksheet.
+	_, err = f.GetColOutlineLevels("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get column outline levels with invalid column number.
+	f.Sheet["xl/worksheets/sheet1.xml"].Cols.Col = []xlsxCol{{Min: 0, Max: 1}}
+	_, err = f.GetColOutlineLevels("Sheet1")
+	assert.EqualError(t, err, "incorrect column number 0")
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	style, err := f.NewStyle(`{"fill":{"type":"pattern","color":["#94d3a2"],"pattern":1}}`)
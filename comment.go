@@ -21,6 +21,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf16"
 )
 
 // parseFormatCommentsSet provides a function to parse the format settings of
@@ -35,12 +37,28 @@ func parseFormatCommentsSet(formatSet string) (*formatComment, error) {
 }
 
 // GetComments retrieves all comments and returns a map of worksheet name to
-// the worksheet comments.
+// the worksheet comments. The comments include the legacy comments (notes)
+// and the threaded comments of the worksheet, the threaded comment with the
+// replies and timestamps of the cell will be set in the Thread field of the
+// comment. For example, export the comments of the workbook:
+//
+//    for sheet, comments := range f.GetComments() {
+//        for _, comment := range comments {
+//            fmt.Println(sheet, comment.Ref, comment.Author, comment.Text)
+//            if comment.Thread != nil {
+//                for _, reply := range comment.Thread.Replies {
+//                    fmt.Println(reply.Author, reply.Time, reply.Text)
+//                }
+//            }
+//        }
+//    }
+//
 func (f *File) GetComments() (comments map[string][]Comment) {
 	comments = map[string][]Comment{}
 	for n, path := range f.sheetMap {
-		if d := f.commentsReader("xl" + strings.TrimPrefix(f.getSheetComments(filepath.Base(path)), "..")); d != nil {
-			sheetComments := []Comment{}
+		sheetComments := []Comment{}
+		d := f.commentsReader("xl" + strings.TrimPrefix(f.getSheetComments(filepath.Base(path)), ".."))
+		if d != nil {
 			for _, comment := range d.CommentList.Comment {
 				sheetComment := Comment{}
 				if comment.AuthorID < len(d.Authors) {
@@ -50,14 +68,40 @@ func (f *File) GetComments() (comments map[string][]Comment) {
 				sheetComment.AuthorID = comment.AuthorID
 				if comment.Text.T != nil {
 					sheetComment.Text += *comment.Text.T
+					sheetComment.Runs = append(sheetComment.Runs, RichTextRun{Text: *comment.Text.T})
 				}
 				for _, text := range comment.Text.R {
 					if text.T != nil {
 						sheetComment.Text += text.T.Val
 					}
+					sheetComment.Runs = append(sheetComment.Runs, newRichTextRun(text))
 				}
 				sheetComments = append(sheetComments, sheetComment)
 			}
+		}
+		var threads []ThreadedComment
+		if f.getSheetThreadedComments(n) != "" {
+			threads, _ = f.GetThreadedComments(n)
+		}
+		for i := range threads {
+			thread, found := &threads[i], false
+			for idx := range sheetComments {
+				if sheetComments[idx].Ref == thread.Cell {
+					sheetComments[idx].Thread, found = thread, true
+					break
+				}
+			}
+			if !found {
+				sheetComments = append(sheetComments, Comment{
+					Author: thread.Author,
+					Ref:    thread.Cell,
+					Text:   thread.Text,
+					Runs:   []RichTextRun{{Text: thread.Text}},
+					Thread: thread,
+				})
+			}
+		}
+		if d != nil || len(threads) > 0 {
 			comments[n] = sheetComments
 		}
 	}
@@ -85,17 +129,44 @@ func (f *File) getSheetComments(sheetFile string) string {
 //
 //    err := f.AddComment("Sheet1", "A30", `{"author":"Excelize: ","text":"This is a comment."}`)
 //
+// The text of the comment could be specified by the rich text runs instead,
+// the author will not be prepended to the runs, and the font of the run not
+// specified will use the default font of the comment. The fill_color, width
+// and height in pixels specify the fill color and the size of the comment
+// box. For example, add a comment with the bold author line and the red body
+// in a light blue box:
+//
+//    err := f.AddComment("Sheet1", "A30", `{
+//        "author": "Excelize",
+//        "runs": [
+//            {"text": "Excelize:\n", "font": {"bold": true}},
+//            {"text": "This is a comment.", "font": {"color": "#FF0000"}}
+//        ],
+//        "fill_color": "#DDEBF7",
+//        "width": 200,
+//        "height": 80
+//    }`)
+//
 func (f *File) AddComment(sheet, cell, format string) error {
 	formatSet, err := parseFormatCommentsSet(format)
 	if err != nil {
 		return err
 	}
+	return f.addSheetComment(sheet, cell, formatSet)
+}
+
+// addSheetComment provides a function to add legacy comment in a worksheet by
+// given worksheet name, cell and format set.
+func (f *File) addSheetComment(sheet, cell string, formatSet *formatComment) error {
 	// Read sheet data.
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	commentID := f.countComments() + 1
+	if vmlID := f.countVMLDrawing() + 1; vmlID > commentID {
+		commentID = vmlID
+	}
 	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(commentID) + ".vml"
 	sheetRelationshipsComments := "../comments" + strconv.Itoa(commentID) + ".xml"
 	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(commentID) + ".vml"
@@ -104,6 +175,11 @@ func (f *File) AddComment(sheet, cell, format string) error {
 		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
 		commentID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 		drawingVML = strings.Replace(sheetRelationshipsDrawingVML, "..", "xl", -1)
+		// The legacy drawing may be created by the form controls without
+		// comments relationships.
+		if sheetFile := filepath.Base(f.sheetMap[trimSheetName(sheet)]); f.getSheetComments(sheetFile) == "" {
+			f.addRels("xl/worksheets/_rels/"+sheetFile+".rels", SourceRelationshipComments, "../comments"+strconv.Itoa(commentID)+".xml", "")
+		}
 	} else {
 		// Add first comment for given sheet.
 		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
@@ -113,16 +189,23 @@ func (f *File) AddComment(sheet, cell, format string) error {
 		f.addSheetLegacyDrawing(sheet, rID)
 	}
 	commentsXML := "xl/comments" + strconv.Itoa(commentID) + ".xml"
+	author, text := formatSet.Author, formatSet.Text
+	if len(formatSet.Runs) > 0 {
+		author, text = "", ""
+		for _, run := range formatSet.Runs {
+			text += run.Text
+		}
+	}
 	var colCount int
-	for i, l := range strings.Split(formatSet.Text, "\n") {
+	for i, l := range strings.Split(text, "\n") {
 		if ll := len(l); ll > colCount {
 			if i == 0 {
-				ll += len(formatSet.Author)
+				ll += len(author)
 			}
 			colCount = ll
 		}
 	}
-	err = f.addDrawingVML(commentID, drawingVML, cell, strings.Count(formatSet.Text, "\n")+1, colCount)
+	err = f.addDrawingVML(sheet, commentID, drawingVML, cell, strings.Count(text, "\n")+1, colCount, formatSet)
 	if err != nil {
 		return err
 	}
@@ -133,42 +216,98 @@ func (f *File) AddComment(sheet, cell, format string) error {
 
 // addDrawingVML provides a function to create comment as
 // xl/drawings/vmlDrawing%d.vml by given commit ID and cell.
-func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount, colCount int) error {
+func (f *File) addDrawingVML(sheet string, commentID int, drawingVML, cell string, lineCount, colCount int, formatSet *formatComment) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
 	yAxis := col - 1
 	xAxis := row - 1
+	anchor := fmt.Sprintf(
+		"%d, 23, %d, 0, %d, %d, %d, 5",
+		1+yAxis, 1+xAxis, 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount)
+	if formatSet.Width > 0 && formatSet.Height > 0 {
+		anchor = f.commentAnchor(sheet, col, row, formatSet)
+	}
+	vml := f.commentsVMLReader(commentID, drawingVML)
+	shape := newCommentShape(anchor, xAxis, yAxis, formatSet)
+	shape.ID = "_x0000_s" + strconv.Itoa(getVMLShapeID(vml, commentID))
+	vml.Shape = append(vml.Shape, shape)
+	return err
+}
+
+// commentsVMLReader provides a function to get the pointer to the structure
+// of the comments VML drawing by given comment ID and the path of
+// xl/drawings/vmlDrawing%d.vml, the VML drawing will be created if not exists.
+func (f *File) commentsVMLReader(commentID int, drawingVML string) *vmlDrawing {
 	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			Shapelayout: &xlsxShapelayout{
-				Ext: "edit",
-				IDmap: &xlsxIDmap{
-					Ext:  "edit",
-					Data: commentID,
-				},
+	if vml != nil {
+		return vml
+	}
+	vml = &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		Shapelayout: &xlsxShapelayout{
+			Ext: "edit",
+			IDmap: &xlsxIDmap{
+				Ext:  "edit",
+				Data: commentID,
+			},
+		},
+		Shapetype: []xlsxShapetype{{
+			ID:        "_x0000_t202",
+			Coordsize: "21600,21600",
+			Spt:       202,
+			Path:      "m0,0l0,21600,21600,21600,21600,0xe",
+			Stroke: &xlsxStroke{
+				Joinstyle: "miter",
 			},
-			Shapetype: &xlsxShapetype{
-				ID:        "_x0000_t202",
-				Coordsize: "21600,21600",
-				Spt:       202,
-				Path:      "m0,0l0,21600,21600,21600,21600,0xe",
-				Stroke: &xlsxStroke{
-					Joinstyle: "miter",
-				},
-				VPath: &vPath{
-					Gradientshapeok: "t",
-					Connecttype:     "rect",
-				},
+			VPath: &vPath{
+				Gradientshapeok: "t",
+				Connecttype:     "rect",
 			},
+		}},
+	}
+	if d := f.decodeVMLDrawingReader(drawingVML); d != nil {
+		for _, v := range d.Shape {
+			s := xlsxShape{
+				ID:          "_x0000_s1025",
+				Type:        "#_x0000_t202",
+				Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
+				Fillcolor:   "#fbf6d6",
+				Strokecolor: "#edeaa1",
+				Val:         v.Val,
+			}
+			if v.ID != "" {
+				s.ID = v.ID
+			}
+			if v.Type != "" && v.Type != s.Type {
+				s.Type, s.Button, s.Filled, s.Stroked, s.Strokecolor = v.Type, v.Button, v.Filled, v.Stroked, v.Strokecolor
+				if v.Type == "#_x0000_t201" {
+					addFormControlShapetype(vml)
+				}
+				if v.Type == "#_x0000_t75" {
+					addPictureFrameShapetype(vml)
+				}
+			}
+			if v.Style != "" {
+				s.Style = v.Style
+			}
+			if v.Fillcolor != "" {
+				s.Fillcolor = v.Fillcolor
+			}
+			vml.Shape = append(vml.Shape, s)
 		}
 	}
+	f.VMLDrawing[drawingVML] = vml
+	return vml
+}
+
+// newCommentShape provides a function to create the VML shape of the comment
+// by given anchor, zero-based row and column index and format set.
+func newCommentShape(anchor string, row, col int, formatSet *formatComment) xlsxShape {
 	sp := encodeShape{
 		Fill: &vFill{
 			Color2: "#fbfe82",
@@ -195,45 +334,261 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 		},
 		ClientData: &xClientData{
 			ObjectType: "Note",
-			Anchor: fmt.Sprintf(
-				"%d, 23, %d, 0, %d, %d, %d, 5",
-				1+yAxis, 1+xAxis, 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount),
-			AutoFill: "True",
-			Row:      xAxis,
-			Column:   yAxis,
+			Anchor:     anchor,
+			AutoFill:   "True",
+			Row:        row,
+			Column:     col,
 		},
 	}
+	visibility, fillColor := "hidden", "#fbf6d6"
+	if formatSet.Visible {
+		visibility, sp.ClientData.Visible = "visible", &struct{}{}
+	}
+	if formatSet.FillColor != "" && formatSet.FillColor != fillColor {
+		fillColor = formatSet.FillColor
+		sp.Fill = &vFill{Color2: fillColor}
+	}
+	width, height := "108pt", "59.25pt"
+	if formatSet.Width > 0 && formatSet.Height > 0 {
+		width = strconv.FormatFloat(float64(formatSet.Width)*0.75, 'f', -1, 64) + "pt"
+		height = strconv.FormatFloat(float64(formatSet.Height)*0.75, 'f', -1, 64) + "pt"
+	}
 	s, _ := xml.Marshal(sp)
-	shape := xlsxShape{
+	return xlsxShape{
 		ID:          "_x0000_s1025",
 		Type:        "#_x0000_t202",
-		Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
-		Fillcolor:   "#fbf6d6",
+		Style:       "position:absolute;73.5pt;width:" + width + ";height:" + height + ";z-index:1;visibility:" + visibility,
+		Fillcolor:   fillColor,
 		Strokecolor: "#edeaa1",
 		Val:         string(s[13 : len(s)-14]),
 	}
-	d := f.decodeVMLDrawingReader(drawingVML)
-	if d != nil {
-		for _, v := range d.Shape {
-			s := xlsxShape{
-				ID:          "_x0000_s1025",
-				Type:        "#_x0000_t202",
-				Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
-				Fillcolor:   "#fbf6d6",
-				Strokecolor: "#edeaa1",
-				Val:         v.Val,
+}
+
+// commentAnchor provides a function to calculate the anchor of the comment
+// box by given worksheet name, the column and row number of the cell and the
+// format set. The comment box is placed at the right of the cell by default.
+func (f *File) commentAnchor(sheet string, col, row int, formatSet *formatComment) string {
+	x1, y1 := formatSet.OffsetX, formatSet.OffsetY
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row-1,
+		x1, y1, formatSet.Width, formatSet.Height)
+	for c := col; c < colStart; c++ {
+		x1 -= f.getColWidth(sheet, c)
+	}
+	for r := row - 1; r < rowStart; r++ {
+		y1 -= f.getRowHeight(sheet, r)
+	}
+	return fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, x1, rowStart, y1, colEnd, x2, rowEnd, y2)
+}
+
+// getCommentShape provides a function to get the index of the VML shape of
+// the comment by given VML drawing and zero-based row and column index.
+func getCommentShape(vml *vmlDrawing, row, col int) (int, *decodeVMLClientData) {
+	for idx, shape := range vml.Shape {
+		val := decodeShapeVal{}
+		_ = xml.Unmarshal([]byte("<shape xmlns:v=\"urn:schemas-microsoft-com:vml\" "+
+			"xmlns:o=\"urn:schemas-microsoft-com:office:office\" "+
+			"xmlns:x=\"urn:schemas-microsoft-com:office:excel\">"+shape.Val+"</shape>"), &val)
+		if val.ClientData != nil && val.ClientData.ObjectType == "Note" &&
+			val.ClientData.Row == row && val.ClientData.Column == col {
+			return idx, val.ClientData
+		}
+	}
+	return -1, nil
+}
+
+// getSheetCommentParts provides a function to get the comments part and the
+// VML drawing of the comments by given worksheet name, and returns the
+// comment ID, the path of the comments part and the path of the VML drawing.
+func (f *File) getSheetCommentParts(sheet string) (int, string, string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return 0, "", "", err
+	}
+	target := f.getSheetComments(filepath.Base(f.sheetMap[trimSheetName(sheet)]))
+	if target == "" {
+		return 0, "", "", err
+	}
+	drawingVML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl", -1)
+	commentID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(drawingVML, "xl/drawings/vmlDrawing"), ".vml"))
+	return commentID, "xl" + strings.TrimPrefix(target, ".."), drawingVML, err
+}
+
+// getSheetComment provides a function to get the comments of the worksheet,
+// the index of the comment by given worksheet name and cell, and the comments
+// VML drawing of the worksheet.
+func (f *File) getSheetComment(sheet, cell string) (*xlsxComments, int, *vmlDrawing, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, -1, nil, err
+	}
+	cell, _ = CoordinatesToCellName(col, row)
+	commentID, commentsXML, drawingVML, err := f.getSheetCommentParts(sheet)
+	if err != nil {
+		return nil, -1, nil, err
+	}
+	if comments := f.commentsReader(commentsXML); comments != nil {
+		for idx, c := range comments.CommentList.Comment {
+			if c.Ref == cell {
+				return comments, idx, f.commentsVMLReader(commentID, drawingVML), err
 			}
-			vml.Shape = append(vml.Shape, s)
 		}
 	}
-	vml.Shape = append(vml.Shape, shape)
-	f.VMLDrawing[drawingVML] = vml
+	return nil, -1, nil, fmt.Errorf("comment in cell %s is not exist", cell)
+}
+
+// UpdateComment provides the method to update the comment in a worksheet by
+// given worksheet name, cell and format set. The author, text, fill color and
+// visibility not specified in the format set will keep unchanged, and the rich
+// text runs of the comment will be kept if none of the author, text and runs
+// are specified. The comment box will be resized when both width and height in
+// pixels are specified, and the x_offset and y_offset specify the position of
+// the box relative to the right of the cell in pixels. For example, update the
+// text and box size of the comment in Sheet1!A30:
+//
+//    err := f.UpdateComment("Sheet1", "A30", `{
+//        "text": "This is an updated comment.",
+//        "width": 200,
+//        "height": 100,
+//        "fill_color": "#E0EBF5",
+//        "visible": true
+//    }`)
+//
+func (f *File) UpdateComment(sheet, cell, format string) error {
+	comments, idx, vml, err := f.getSheetComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	comment := &comments.CommentList.Comment[idx]
+	formatSet := formatComment{}
+	if comment.AuthorID < len(comments.Authors) {
+		formatSet.Author = comments.Authors[comment.AuthorID].Author
+	}
+	if comment.Text.T != nil {
+		formatSet.Text = *comment.Text.T
+	}
+	for i, r := range comment.Text.R {
+		if r.T != nil && !(i == 0 && len(comment.Text.R) > 1 && formatSet.Author != "" && strings.HasPrefix(r.T.Val, formatSet.Author)) {
+			formatSet.Text += r.T.Val
+		}
+	}
+	col, row, _ := CellNameToCoordinates(comment.Ref)
+	shapeIdx, clientData := getCommentShape(vml, row-1, col-1)
+	if shapeIdx != -1 {
+		formatSet.FillColor = vml.Shape[shapeIdx].Fillcolor
+		formatSet.Visible = clientData.Visible != nil
+	}
+	if err = json.Unmarshal([]byte(format), &formatSet); err != nil {
+		return err
+	}
+	// Keep the rich text of the comment if the text is not changed.
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal([]byte(format), &fields)
+	text := comment.Text
+	comments.CommentList.Comment[idx] = f.newComment(comments, comment.Ref, &formatSet)
+	if fields["text"] == nil && fields["author"] == nil && fields["runs"] == nil {
+		comments.CommentList.Comment[idx].Text = text
+	}
+	if shapeIdx == -1 {
+		return err
+	}
+	anchor := clientData.Anchor
+	if formatSet.Width > 0 && formatSet.Height > 0 {
+		anchor = f.commentAnchor(sheet, col, row, &formatSet)
+	}
+	vml.Shape[shapeIdx] = newCommentShape(anchor, row-1, col-1, &formatSet)
+	return err
+}
+
+// MoveComment provides the method to move the comment in a worksheet from
+// the given cell to another cell, the comment box will be moved with the
+// comment. For example, move the comment in Sheet1!A30 to Sheet1!C30:
+//
+//    err := f.MoveComment("Sheet1", "A30", "C30")
+//
+func (f *File) MoveComment(sheet, fromCell, toCell string) error {
+	col, row, err := CellNameToCoordinates(toCell)
+	if err != nil {
+		return err
+	}
+	toCell, _ = CoordinatesToCellName(col, row)
+	comments, idx, vml, err := f.getSheetComment(sheet, fromCell)
+	if err != nil {
+		return err
+	}
+	for _, c := range comments.CommentList.Comment {
+		if c.Ref == toCell {
+			return fmt.Errorf("cell %s already has a comment", toCell)
+		}
+	}
+	fromCol, fromRow, _ := CellNameToCoordinates(comments.CommentList.Comment[idx].Ref)
+	if shapeIdx, clientData := getCommentShape(vml, fromRow-1, fromCol-1); shapeIdx != -1 {
+		formatSet := formatComment{FillColor: vml.Shape[shapeIdx].Fillcolor, Visible: clientData.Visible != nil}
+		anchor := strings.Split(clientData.Anchor, ",")
+		for i, offset := range []int{col - fromCol, 0, row - fromRow, 0, col - fromCol, 0, row - fromRow, 0} {
+			if i < len(anchor) && offset != 0 {
+				if v, err := strconv.Atoi(strings.TrimSpace(anchor[i])); err == nil {
+					anchor[i] = " " + strconv.Itoa(v+offset)
+				}
+			}
+		}
+		vml.Shape[shapeIdx] = newCommentShape(strings.TrimSpace(strings.Join(anchor, ",")), row-1, col-1, &formatSet)
+	}
+	if tc := f.threadedCommentsReader(f.getSheetThreadedComments(sheet)); tc != nil {
+		for i := range tc.ThreadedComment {
+			if tc.ThreadedComment[i].Ref == comments.CommentList.Comment[idx].Ref {
+				tc.ThreadedComment[i].Ref = toCell
+			}
+		}
+	}
+	comments.CommentList.Comment[idx].Ref = toCell
+	return err
+}
+
+// DeleteComment provides the method to delete the comment and the comment box
+// in a worksheet by given worksheet name and cell, the comment thread in the
+// cell will be deleted with the comment. For example, delete the comment in
+// Sheet1!A30:
+//
+//    err := f.DeleteComment("Sheet1", "A30")
+//
+func (f *File) DeleteComment(sheet, cell string) error {
+	comments, idx, vml, err := f.getSheetComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	ref := comments.CommentList.Comment[idx].Ref
+	col, row, _ := CellNameToCoordinates(ref)
+	if shapeIdx, _ := getCommentShape(vml, row-1, col-1); shapeIdx != -1 {
+		vml.Shape = append(vml.Shape[:shapeIdx], vml.Shape[shapeIdx+1:]...)
+	}
+	if tc := f.threadedCommentsReader(f.getSheetThreadedComments(sheet)); tc != nil {
+		threads := tc.ThreadedComment[:0]
+		for _, c := range tc.ThreadedComment {
+			if c.Ref != ref {
+				threads = append(threads, c)
+			}
+		}
+		tc.ThreadedComment = threads
+	}
+	comments.CommentList.Comment = append(comments.CommentList.Comment[:idx], comments.CommentList.Comment[idx+1:]...)
 	return err
 }
 
 // addComment provides a function to create chart as xl/comments%d.xml by
 // given cell and format sets.
 func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
+	comments := f.commentsReader(commentsXML)
+	if comments == nil {
+		comments = &xlsxComments{}
+	}
+	comments.CommentList.Comment = append(comments.CommentList.Comment, f.newComment(comments, cell, formatSet))
+	f.Comments[commentsXML] = comments
+}
+
+// newComment provides a function to create the comment by given comments,
+// cell and format sets, the author will be added in the comments if not
+// exists.
+func (f *File) newComment(comments *xlsxComments, cell string, formatSet *formatComment) xlsxComment {
 	a := formatSet.Author
 	t := formatSet.Text
 	if len(a) > 255 {
@@ -242,51 +597,41 @@ func (f *File) addComment(commentsXML, cell string, formatSet *formatComment) {
 	if len(t) > 32512 {
 		t = t[0:32512]
 	}
-	comments := f.commentsReader(commentsXML)
-	if comments == nil {
-		comments = &xlsxComments{
-			Authors: []xlsxAuthor{
-				{
-					Author: formatSet.Author,
-				},
-			},
+	authorID := -1
+	for idx, author := range comments.Authors {
+		if author.Author == a {
+			authorID = idx
 		}
 	}
+	if authorID == -1 {
+		comments.Authors = append(comments.Authors, xlsxAuthor{Author: a})
+		authorID = len(comments.Authors) - 1
+	}
+	runs := formatSet.Runs
+	if len(runs) == 0 {
+		runs = []RichTextRun{{Font: &Font{Bold: true}, Text: a}, {Text: t}}
+	}
 	defaultFont := f.GetDefaultFont()
-	bold := ""
-	cmt := xlsxComment{
-		Ref:      cell,
-		AuthorID: 0,
-		Text: xlsxText{
-			R: []xlsxR{
-				{
-					RPr: &xlsxRPr{
-						B:  &bold,
-						Sz: &attrValFloat{Val: float64Ptr(9)},
-						Color: &xlsxColor{
-							Indexed: 81,
-						},
-						RFont:  &attrValString{Val: stringPtr(defaultFont)},
-						Family: &attrValInt{Val: intPtr(2)},
-					},
-					T: &xlsxT{Val: a},
-				},
-				{
-					RPr: &xlsxRPr{
-						Sz: &attrValFloat{Val: float64Ptr(9)},
-						Color: &xlsxColor{
-							Indexed: 81,
-						},
-						RFont:  &attrValString{Val: stringPtr(defaultFont)},
-						Family: &attrValInt{Val: intPtr(2)},
-					},
-					T: &xlsxT{Val: t},
-				},
+	comment := xlsxComment{Ref: cell, AuthorID: authorID}
+	for _, run := range runs {
+		rpr := &xlsxRPr{
+			Sz: &attrValFloat{Val: float64Ptr(9)},
+			Color: &xlsxColor{
+				Indexed: 81,
 			},
-		},
+			RFont:  &attrValString{Val: stringPtr(defaultFont)},
+			Family: &attrValInt{Val: intPtr(2)},
+		}
+		if run.Font != nil {
+			setRichTextRunFont(rpr, run.Font)
+		}
+		r := xlsxR{RPr: rpr, T: &xlsxT{Val: run.Text}}
+		if len(formatSet.Runs) > 0 && strings.ContainsAny(run.Text, "\r\n ") {
+			r.T.Space = xml.Attr{Name: xml.Name{Space: NameSpaceXML, Local: "space"}, Value: "preserve"}
+		}
+		comment.Text.R = append(comment.Text.R, r)
 	}
-	comments.CommentList.Comment = append(comments.CommentList.Comment, cmt)
-	f.Comments[commentsXML] = comments
+	return comment
 }
 
 // countComments provides a function to get comments files count storage in
@@ -309,16 +654,36 @@ func (f *File) countComments() int {
 	return c1
 }
 
+// countVMLDrawing provides a function to get VML drawing files count storage
+// in the folder xl/drawings.
+func (f *File) countVMLDrawing() int {
+	c1, c2 := 0, 0
+	for k := range f.XLSX {
+		if strings.Contains(k, "xl/drawings/vmlDrawing") {
+			c1++
+		}
+	}
+	for rel := range f.VMLDrawing {
+		if strings.Contains(rel, "xl/drawings/vmlDrawing") {
+			c2++
+		}
+	}
+	if c1 < c2 {
+		return c2
+	}
+	return c1
+}
+
 // decodeVMLDrawingReader provides a function to get the pointer to the
 // structure after deserialization of xl/drawings/vmlDrawing%d.xml.
 func (f *File) decodeVMLDrawingReader(path string) *decodeVmlDrawing {
 	var err error
-
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if f.DecodeVMLDrawing[path] == nil {
-		c, ok := f.XLSX[path]
-		if ok {
+		if _, ok := f.XLSX[path]; ok {
 			f.DecodeVMLDrawing[path] = new(decodeVmlDrawing)
-			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(c))).
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
 				Decode(f.DecodeVMLDrawing[path]); err != nil && err != io.EOF {
 				log.Printf("xml decode error: %s", err)
 			}
@@ -342,12 +707,12 @@ func (f *File) vmlDrawingWriter() {
 // after deserialization of xl/comments%d.xml.
 func (f *File) commentsReader(path string) *xlsxComments {
 	var err error
-
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if f.Comments[path] == nil {
-		content, ok := f.XLSX[path]
-		if ok {
+		if _, ok := f.XLSX[path]; ok {
 			f.Comments[path] = new(xlsxComments)
-			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
 				Decode(f.Comments[path]); err != nil && err != io.EOF {
 				log.Printf("xml decode error: %s", err)
 			}
@@ -366,3 +731,387 @@ func (f *File) commentsWriter() {
 		}
 	}
 }
+
+// threadedCommentPlaceholder defined the text of the legacy comment which is
+// the placeholder of the threaded comment in the earlier versions of Excel.
+const threadedCommentPlaceholder = "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\n"
+
+// threadedCommentTimeLayout defined the layout of the date time of the
+// threaded comment.
+const threadedCommentTimeLayout = "2006-01-02T15:04:05.00"
+
+// AddThreadedComment provides the method to add a threaded comment (modern
+// comment) in a worksheet by given worksheet name, cell and comment settings,
+// and returns the ID of the comment thread. The author and mentioned persons
+// will be added in the persons of the workbook. A legacy comment will be
+// added as the placeholder of the comment thread for the earlier versions of
+// Excel. Only one comment thread is allowed in a cell. For example, add a
+// threaded comment which mentions the person named "Bob" in Sheet1!A1:
+//
+//    id, err := f.AddThreadedComment("Sheet1", "A1", excelize.ThreadedComment{
+//        Author:   "Alice",
+//        Text:     "Please check this value @Bob",
+//        Mentions: []string{"Bob"},
+//    })
+//
+func (f *File) AddThreadedComment(sheet, cell string, comment ThreadedComment) (string, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return "", err
+	}
+	cell, _ = CoordinatesToCellName(col, row)
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return "", err
+	}
+	threadedCommentsXML := f.getSheetThreadedComments(sheet)
+	if tc := f.threadedCommentsReader(threadedCommentsXML); tc != nil {
+		for _, c := range tc.ThreadedComment {
+			if c.Ref == cell {
+				return "", fmt.Errorf("cell %s already has a threaded comment", cell)
+			}
+		}
+	}
+	thread, err := f.newThreadedComment(comment)
+	if err != nil {
+		return "", err
+	}
+	thread.Ref = cell
+	if err = f.addSheetComment(sheet, cell, &formatComment{Author: "tc=" + thread.ID, Text: " "}); err != nil {
+		return "", err
+	}
+	if threadedCommentsXML == "" {
+		threadedCommentsXML = f.addSheetThreadedComments(sheet)
+	}
+	tc := f.threadedCommentsReader(threadedCommentsXML)
+	tc.ThreadedComment = append(tc.ThreadedComment, thread)
+	f.setThreadedCommentPlaceholder(sheet, cell)
+	return thread.ID, err
+}
+
+// AddThreadedCommentReply provides the method to reply a comment thread by
+// given worksheet name, the ID of the comment thread and the reply settings,
+// and returns the ID of the reply. For example, reply the comment thread in
+// Sheet1:
+//
+//    replyID, err := f.AddThreadedCommentReply("Sheet1", id, excelize.ThreadedComment{
+//        Author: "Bob",
+//        Text:   "Done.",
+//    })
+//
+func (f *File) AddThreadedCommentReply(sheet, parentID string, reply ThreadedComment) (string, error) {
+	tc, idx, err := f.getThreadedComment(sheet, parentID)
+	if err != nil {
+		return "", err
+	}
+	parent := tc.ThreadedComment[idx]
+	thread, err := f.newThreadedComment(reply)
+	if err != nil {
+		return "", err
+	}
+	thread.Ref, thread.ParentID = parent.Ref, parent.ID
+	last := idx
+	for i, c := range tc.ThreadedComment {
+		if c.ParentID == parent.ID {
+			last = i
+		}
+	}
+	tc.ThreadedComment = append(tc.ThreadedComment, xlsxThreadedComment{})
+	copy(tc.ThreadedComment[last+2:], tc.ThreadedComment[last+1:])
+	tc.ThreadedComment[last+1] = thread
+	f.setThreadedCommentPlaceholder(sheet, parent.Ref)
+	return thread.ID, err
+}
+
+// SetThreadedCommentResolved provides the method to set the resolved status
+// of the comment thread by given worksheet name, the ID of the comment thread
+// and the status. For example, resolve the comment thread in Sheet1:
+//
+//    err := f.SetThreadedCommentResolved("Sheet1", id, true)
+//
+func (f *File) SetThreadedCommentResolved(sheet, id string, resolved bool) error {
+	tc, idx, err := f.getThreadedComment(sheet, id)
+	if err != nil {
+		return err
+	}
+	tc.ThreadedComment[idx].Done = resolved
+	return err
+}
+
+// GetThreadedComments provides the method to get all comment threads by given
+// worksheet name, the replies of each comment thread are in the Replies field.
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	var comments []ThreadedComment
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return comments, err
+	}
+	tc := f.threadedCommentsReader(f.getSheetThreadedComments(sheet))
+	if tc == nil {
+		return comments, nil
+	}
+	persons := map[string]string{}
+	for _, p := range f.personsReader().Person {
+		persons[p.ID] = p.DisplayName
+	}
+	threads := map[string]int{}
+	for _, c := range tc.ThreadedComment {
+		comment := ThreadedComment{
+			ID:       c.ID,
+			ParentID: c.ParentID,
+			Cell:     c.Ref,
+			Author:   persons[c.PersonID],
+			Text:     c.Text,
+			Done:     c.Done,
+		}
+		for _, layout := range []string{threadedCommentTimeLayout, time.RFC3339} {
+			if t, err := time.Parse(layout, c.DT); err == nil {
+				comment.Time = t
+				break
+			}
+		}
+		if c.Mentions != nil {
+			for _, m := range c.Mentions.Mention {
+				comment.Mentions = append(comment.Mentions, persons[m.MentionPersonID])
+			}
+		}
+		if idx, ok := threads[c.ParentID]; ok && c.ParentID != "" {
+			comments[idx].Replies = append(comments[idx].Replies, comment)
+			continue
+		}
+		threads[c.ID] = len(comments)
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+// newThreadedComment provides a function to create the threaded comment by
+// given comment settings. The mentions of the same person are matched to
+// the occurrences of the person in the comment text in order, each mention
+// is searched after the end of the previous one of the same person.
+func (f *File) newThreadedComment(comment ThreadedComment) (xlsxThreadedComment, error) {
+	thread := xlsxThreadedComment{Text: comment.Text, Done: comment.Done}
+	var err error
+	if thread.ID, err = genGUID(); err != nil {
+		return thread, err
+	}
+	if thread.PersonID, err = f.getPersonID(comment.Author); err != nil {
+		return thread, err
+	}
+	t := comment.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	thread.DT = t.Format(threadedCommentTimeLayout)
+	offsets := map[string]int{}
+	for _, name := range comment.Mentions {
+		mention := "@" + name
+		idx := strings.Index(comment.Text[offsets[name]:], mention)
+		if idx == -1 {
+			return thread, fmt.Errorf("mention %s is not exist in the comment text", name)
+		}
+		idx += offsets[name]
+		offsets[name] = idx + len(mention)
+		m := xlsxMention{
+			StartIndex: len(utf16.Encode([]rune(comment.Text[:idx]))),
+			Length:     len(utf16.Encode([]rune(mention))),
+		}
+		if m.MentionPersonID, err = f.getPersonID(name); err != nil {
+			return thread, err
+		}
+		if m.MentionID, err = genGUID(); err != nil {
+			return thread, err
+		}
+		if thread.Mentions == nil {
+			thread.Mentions = &xlsxMentions{}
+		}
+		thread.Mentions.Mention = append(thread.Mentions.Mention, m)
+	}
+	return thread, err
+}
+
+// getThreadedComment provides a function to get the threaded comments of the
+// worksheet and the index of the first comment of the thread by given
+// worksheet name and the ID of the comment or reply.
+func (f *File) getThreadedComment(sheet, id string) (*xlsxThreadedComments, int, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return nil, -1, err
+	}
+	if tc := f.threadedCommentsReader(f.getSheetThreadedComments(sheet)); tc != nil {
+		for _, c := range tc.ThreadedComment {
+			if c.ID != id {
+				continue
+			}
+			if c.ParentID != "" {
+				id = c.ParentID
+			}
+			for idx, p := range tc.ThreadedComment {
+				if p.ID == id {
+					return tc, idx, nil
+				}
+			}
+		}
+	}
+	return nil, -1, fmt.Errorf("threaded comment %s is not exist", id)
+}
+
+// setThreadedCommentPlaceholder provides a function to update the text of the
+// legacy comment which is the placeholder of the comment thread by given
+// worksheet name and cell.
+func (f *File) setThreadedCommentPlaceholder(sheet, cell string) {
+	tc := f.threadedCommentsReader(f.getSheetThreadedComments(sheet))
+	comments := f.commentsReader("xl" + strings.TrimPrefix(f.getSheetComments(filepath.Base(f.sheetMap[trimSheetName(sheet)])), ".."))
+	if tc == nil || comments == nil {
+		return
+	}
+	text := threadedCommentPlaceholder
+	for _, c := range tc.ThreadedComment {
+		if c.Ref != cell {
+			continue
+		}
+		if c.ParentID == "" {
+			text += "Comment:\n    " + c.Text
+			continue
+		}
+		text += "\nReply:\n    " + c.Text
+	}
+	if runes := []rune(text); len(runes) > 32512 {
+		text = string(runes[:32512])
+	}
+	for idx := range comments.CommentList.Comment {
+		if comments.CommentList.Comment[idx].Ref == cell {
+			comments.CommentList.Comment[idx].Text = xlsxText{T: stringPtr(text)}
+		}
+	}
+}
+
+// getSheetThreadedComments provides a function to get the path of the
+// threaded comments part by given worksheet name.
+func (f *File) getSheetThreadedComments(sheet string) string {
+	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+	if sheetRels := f.relsReader(rels); sheetRels != nil {
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				if strings.HasPrefix(v.Target, "/") {
+					return strings.TrimPrefix(v.Target, "/")
+				}
+				return "xl/" + strings.TrimPrefix(v.Target, "../")
+			}
+		}
+	}
+	return ""
+}
+
+// addSheetThreadedComments provides a function to create the threaded
+// comments part for the worksheet by given worksheet name, and returns the
+// path of the part.
+func (f *File) addSheetThreadedComments(sheet string) string {
+	idx := f.countThreadedComments() + 1
+	threadedCommentsXML := "xl/threadedComments/threadedComment" + strconv.Itoa(idx) + ".xml"
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+	f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(idx)+".xml", "")
+	f.addContentTypePart(idx, "threadedComments")
+	f.threadedComments[threadedCommentsXML] = &xlsxThreadedComments{}
+	return threadedCommentsXML
+}
+
+// countThreadedComments provides a function to get threaded comments files
+// count storage in the folder xl/threadedComments.
+func (f *File) countThreadedComments() int {
+	c1, c2 := 0, 0
+	for k := range f.XLSX {
+		if strings.Contains(k, "xl/threadedComments/threadedComment") {
+			c1++
+		}
+	}
+	for k := range f.threadedComments {
+		if _, ok := f.XLSX[k]; !ok && strings.Contains(k, "xl/threadedComments/threadedComment") {
+			c2++
+		}
+	}
+	return c1 + c2
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) *xlsxThreadedComments {
+	var err error
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.threadedComments[path] == nil {
+		if _, ok := f.XLSX[path]; ok {
+			f.threadedComments[path] = new(xlsxThreadedComments)
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+				Decode(f.threadedComments[path]); err != nil && err != io.EOF {
+				log.Printf("xml decode error: %s", err)
+			}
+		}
+	}
+	return f.threadedComments[path]
+}
+
+// threadedCommentsWriter provides a function to save
+// xl/threadedComments/threadedComment%d.xml after serialize structure.
+func (f *File) threadedCommentsWriter() {
+	for path, tc := range f.threadedComments {
+		if tc != nil {
+			v, _ := xml.Marshal(tc)
+			f.saveFileList(path, v)
+		}
+	}
+}
+
+// personsReader provides a function to get the pointer to the structure
+// after deserialization of xl/persons/person.xml.
+func (f *File) personsReader() *xlsxPersonList {
+	var err error
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.persons == nil {
+		f.persons = new(xlsxPersonList)
+		if _, ok := f.XLSX["xl/persons/person.xml"]; ok {
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("xl/persons/person.xml")))).
+				Decode(f.persons); err != nil && err != io.EOF {
+				log.Printf("xml decode error: %s", err)
+			}
+		}
+	}
+	return f.persons
+}
+
+// personsWriter provides a function to save xl/persons/person.xml after
+// serialize structure.
+func (f *File) personsWriter() {
+	if f.persons != nil && len(f.persons.Person) > 0 {
+		v, _ := xml.Marshal(f.persons)
+		f.saveFileList("xl/persons/person.xml", v)
+	}
+}
+
+// getPersonID provides a function to get the ID of the person by given
+// display name, the person will be created if not exists.
+func (f *File) getPersonID(name string) (string, error) {
+	persons := f.personsReader()
+	for _, p := range persons.Person {
+		if p.DisplayName == name {
+			return p.ID, nil
+		}
+	}
+	id, err := genGUID()
+	if err != nil {
+		return id, err
+	}
+	if len(persons.Person) == 0 {
+		relPath, exist := f.getWorkbookRelsPath(), false
+		if rels := f.relsReader(relPath); rels != nil {
+			for _, rel := range rels.Relationships {
+				exist = exist || rel.Type == SourceRelationshipPerson
+			}
+		}
+		if !exist {
+			f.addRels(relPath, SourceRelationshipPerson, "persons/person.xml", "")
+		}
+		f.addContentTypePart(0, "persons")
+	}
+	person := xlsxPerson{DisplayName: name, ID: id, UserID: name, ProviderID: "None"}
+	persons.Person = append(persons.Person, person)
+	return person.ID, err
+}
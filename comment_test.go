@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -61,3 +62,260 @@ func TestCountComments(t *testing.T) {
 	f.Comments["xl/comments1.xml"] = nil
 	assert.Equal(t, f.countComments(), 1)
 }
+
+func TestAddThreadedComment(t *testing.T) {
+	f := NewFile()
+	id, err := f.AddThreadedComment("Sheet1", "A1", ThreadedComment{
+		Author:   "Alice",
+		Text:     "Please check this value @Bob",
+		Mentions: []string{"Bob"},
+	})
+	assert.NoError(t, err)
+	replyID, err := f.AddThreadedCommentReply("Sheet1", id, ThreadedComment{Author: "Bob", Text: "Done."})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetThreadedCommentResolved("Sheet1", replyID, true))
+	_, err = f.AddThreadedComment("Sheet1", "B2", ThreadedComment{Author: "Bob", Text: "Another thread"})
+	assert.NoError(t, err)
+
+	// Test add threaded comment on the cell which already has a comment thread.
+	_, err = f.AddThreadedComment("Sheet1", "A1", ThreadedComment{Author: "Alice", Text: "Text"})
+	assert.EqualError(t, err, "cell A1 already has a threaded comment")
+	// Test add threaded comment with the mention which not in the text.
+	_, err = f.AddThreadedComment("Sheet1", "C3", ThreadedComment{Author: "Alice", Text: "Text", Mentions: []string{"Bob"}})
+	assert.EqualError(t, err, "mention Bob is not exist in the comment text")
+	// Test add threaded comment on not exists worksheet.
+	_, err = f.AddThreadedComment("SheetN", "A1", ThreadedComment{})
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test add threaded comment with illegal cell coordinates.
+	_, err = f.AddThreadedComment("Sheet1", "A", ThreadedComment{})
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test reply and resolve not exists comment thread.
+	_, err = f.AddThreadedCommentReply("Sheet1", "{ID}", ThreadedComment{})
+	assert.EqualError(t, err, "threaded comment {ID} is not exist")
+	assert.EqualError(t, f.SetThreadedCommentResolved("SheetN", id, true), "sheet SheetN is not exist")
+
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddThreadedComment.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAddThreadedComment.xlsx"))
+	assert.NoError(t, err)
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, id, comments[0].ID)
+		assert.Equal(t, "A1", comments[0].Cell)
+		assert.Equal(t, "Alice", comments[0].Author)
+		assert.Equal(t, []string{"Bob"}, comments[0].Mentions)
+		assert.True(t, comments[0].Done)
+		assert.False(t, comments[0].Time.IsZero())
+		if assert.Len(t, comments[0].Replies, 1) {
+			assert.Equal(t, replyID, comments[0].Replies[0].ID)
+			assert.Equal(t, id, comments[0].Replies[0].ParentID)
+			assert.Equal(t, "Done.", comments[0].Replies[0].Text)
+		}
+		assert.Equal(t, "B2", comments[1].Cell)
+	}
+	legacy := f.GetComments()["Sheet1"]
+	if assert.Len(t, legacy, 2) {
+		assert.Equal(t, "tc="+id, legacy[0].Author)
+		assert.True(t, strings.HasSuffix(legacy[0].Text, "Comment:\n    Please check this value @Bob\nReply:\n    Done."))
+	}
+
+	// Test add threaded comment which mentions the same person repeatedly.
+	_, err = f.AddThreadedComment("Sheet1", "C3", ThreadedComment{Author: "Alice", Text: "@Bob and @Bob", Mentions: []string{"Bob", "Bob"}})
+	assert.NoError(t, err)
+	tc := f.threadedCommentsReader(f.getSheetThreadedComments("Sheet1"))
+	thread := tc.ThreadedComment[len(tc.ThreadedComment)-1]
+	if assert.NotNil(t, thread.Mentions) && assert.Len(t, thread.Mentions.Mention, 2) {
+		assert.Equal(t, 0, thread.Mentions.Mention[0].StartIndex)
+		assert.Equal(t, 9, thread.Mentions.Mention[1].StartIndex)
+	}
+	_, err = f.AddThreadedComment("Sheet1", "D4", ThreadedComment{Author: "Alice", Text: "@Bob", Mentions: []string{"Bob", "Bob"}})
+	assert.EqualError(t, err, "mention Bob is not exist in the comment text")
+
+	// Test the placeholder of the comment thread with long multi-byte text.
+	_, err = f.AddThreadedComment("Sheet1", "E5", ThreadedComment{Author: "Alice", Text: strings.Repeat("中", 32768)})
+	assert.NoError(t, err)
+	legacy = f.GetComments()["Sheet1"]
+	if assert.Len(t, legacy, 4) {
+		assert.Equal(t, "E5", legacy[3].Ref)
+		assert.True(t, utf8.ValidString(legacy[3].Text))
+		assert.Equal(t, 32512, utf8.RuneCountInString(legacy[3].Text))
+	}
+
+	// Test get threaded comments on the worksheet without threaded comments.
+	comments, err = f.GetThreadedComments("Sheet2")
+	assert.EqualError(t, err, "sheet Sheet2 is not exist")
+	assert.Nil(t, comments)
+	f.NewSheet("Sheet2")
+	comments, err = f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, comments)
+}
+
+func TestUpdateComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "B2", `{"author":"Excelize: ","text":"Another comment.","width":120,"height":60}`))
+	assert.NoError(t, f.UpdateComment("Sheet1", "A1", `{"text":"This is an updated comment.","width":200,"height":100,"x_offset":10,"fill_color":"#E0EBF5","visible":true}`))
+	assert.NoError(t, f.UpdateComment("Sheet1", "B2", `{"author":"Reviewer: "}`))
+
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, Comment{Author: "Excelize: ", Ref: "A1", Text: "Excelize: This is an updated comment.", Runs: comments[0].Runs}, comments[0])
+		assert.Equal(t, Comment{Author: "Reviewer: ", AuthorID: 1, Ref: "B2", Text: "Reviewer: Another comment.", Runs: comments[1].Runs}, comments[1])
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 2) {
+		assert.Equal(t, "#E0EBF5", vml.Shape[0].Fillcolor)
+		assert.Contains(t, vml.Shape[0].Style, "visibility:visible")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>1, 10, 0, 0, 4, 18, 5, 0</x:Anchor>")
+		assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>2, 0, 1, 0, 3, 56, 4, 0</x:Anchor>")
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUpdateComment.xlsx")))
+
+	// Test update comment after reopening the workbook.
+	f, err := OpenFile(filepath.Join("test", "TestUpdateComment.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.UpdateComment("Sheet1", "A1", `{"text":"Reopened."}`))
+	assert.Equal(t, "Excelize: Reopened.", f.GetComments()["Sheet1"][0].Text)
+	vml = f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 2) {
+		assert.Equal(t, "#E0EBF5", vml.Shape[0].Fillcolor)
+		assert.Contains(t, vml.Shape[0].Val, "<x:Visible></x:Visible>")
+	}
+
+	// Test update comment with invalid format set.
+	assert.EqualError(t, f.UpdateComment("Sheet1", "A1", `{`), "unexpected end of JSON input")
+	// Test update not exists comment.
+	assert.EqualError(t, f.UpdateComment("Sheet1", "C3", `{}`), "comment in cell C3 is not exist")
+	// Test update comment on not exists worksheet.
+	assert.EqualError(t, f.UpdateComment("SheetN", "A1", `{}`), "sheet SheetN is not exist")
+	// Test update comment with illegal cell coordinates.
+	assert.EqualError(t, f.UpdateComment("Sheet1", "A", `{}`), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestAddCommentRichText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize","runs":[{"text":"Excelize:\n","font":{"bold":true}},{"text":"This is a comment.","font":{"color":"#FF0000"}}],"fill_color":"#DDEBF7","width":200,"height":100}`))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "Excelize", comments[0].Author)
+		assert.Equal(t, "Excelize:\nThis is a comment.", comments[0].Text)
+		if assert.Len(t, comments[0].Runs, 2) {
+			assert.True(t, comments[0].Runs[0].Font.Bold)
+			assert.Equal(t, "FF0000", comments[0].Runs[1].Font.Color)
+		}
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 1) {
+		assert.Equal(t, "#DDEBF7", vml.Shape[0].Fillcolor)
+		assert.Contains(t, vml.Shape[0].Style, "width:150pt;height:75pt")
+	}
+
+	// Test update the box size of the comment keeps the rich text runs.
+	assert.NoError(t, f.UpdateComment("Sheet1", "A1", `{"width":120,"height":60}`))
+	comments = f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "Excelize:\nThis is a comment.", comments[0].Text)
+		assert.Len(t, comments[0].Runs, 2)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentRichText.xlsx")))
+}
+
+func TestMoveComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "B2", `{"author":"Excelize: ","text":"Another comment."}`))
+	assert.NoError(t, f.MoveComment("Sheet1", "A1", "C3"))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, "C3", comments[0].Ref)
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>3, 23, 3, 0, 5, 28, 5, 5</x:Anchor><x:AutoFill>True</x:AutoFill><x:Row>2</x:Row><x:Column>2</x:Column>")
+
+	// Test move comment to the cell which already has a comment.
+	assert.EqualError(t, f.MoveComment("Sheet1", "C3", "B2"), "cell B2 already has a comment")
+	// Test move not exists comment.
+	assert.EqualError(t, f.MoveComment("Sheet1", "A1", "D4"), "comment in cell A1 is not exist")
+	// Test move comment with illegal cell coordinates.
+	assert.EqualError(t, f.MoveComment("Sheet1", "C3", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveComment.xlsx")))
+}
+
+func TestDeleteComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "B2", `{"author":"Excelize: ","text":"Another comment."}`))
+	_, err := f.AddThreadedComment("Sheet1", "C3", ThreadedComment{Author: "Alice", Text: "Thread"})
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
+	assert.NoError(t, f.DeleteComment("Sheet1", "C3"))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "B2", comments[0].Ref)
+	}
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape, 1)
+	threads, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threads, 0)
+
+	// Test delete not exists comment.
+	assert.EqualError(t, f.DeleteComment("Sheet1", "A1"), "comment in cell A1 is not exist")
+	f.NewSheet("Sheet2")
+	assert.EqualError(t, f.DeleteComment("Sheet2", "A1"), "comment in cell A1 is not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteComment.xlsx")))
+}
+
+func TestGetComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	id, err := f.AddThreadedComment("Sheet1", "B2", ThreadedComment{Author: "Alice", Text: "Thread"})
+	assert.NoError(t, err)
+	_, err = f.AddThreadedCommentReply("Sheet1", id, ThreadedComment{Author: "Bob", Text: "Reply"})
+	assert.NoError(t, err)
+	_, err = f.AddThreadedComment("Sheet1", "C3", ThreadedComment{Author: "Bob", Text: "Without note"})
+	assert.NoError(t, err)
+	// Remove the legacy placeholder comment of the thread in cell C3.
+	comments := f.commentsReader("xl/comments1.xml")
+	comments.CommentList.Comment = comments.CommentList.Comment[:2]
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetComments.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetComments.xlsx"))
+	assert.NoError(t, err)
+	result := f.GetComments()
+	assert.Len(t, result, 1)
+	sheetComments := result["Sheet1"]
+	if !assert.Len(t, sheetComments, 3) {
+		t.FailNow()
+	}
+	assert.Equal(t, "A1", sheetComments[0].Ref)
+	assert.Equal(t, "Excelize: This is a comment.", sheetComments[0].Text)
+	if assert.Len(t, sheetComments[0].Runs, 2) {
+		assert.Equal(t, "Excelize: ", sheetComments[0].Runs[0].Text)
+		assert.True(t, sheetComments[0].Runs[0].Font.Bold)
+		assert.Equal(t, "This is a comment.", sheetComments[0].Runs[1].Text)
+	}
+	assert.Nil(t, sheetComments[0].Thread)
+
+	assert.Equal(t, "B2", sheetComments[1].Ref)
+	assert.Equal(t, "tc="+id, sheetComments[1].Author)
+	if assert.NotNil(t, sheetComments[1].Thread) {
+		assert.Equal(t, "Alice", sheetComments[1].Thread.Author)
+		assert.False(t, sheetComments[1].Thread.Time.IsZero())
+		if assert.Len(t, sheetComments[1].Thread.Replies, 1) {
+			assert.Equal(t, "Bob", sheetComments[1].Thread.Replies[0].Author)
+			assert.Equal(t, "Reply", sheetComments[1].Thread.Replies[0].Text)
+		}
+	}
+
+	assert.Equal(t, Comment{
+		Author: "Bob",
+		Ref:    "C3",
+		Text:   "Without note",
+		Runs:   []RichTextRun{{Text: "Without note"}},
+		Thread: sheetComments[2].Thread,
+	}, sheetComments[2])
+	assert.NotNil(t, sheetComments[2].Thread)
+}
@@ -0,0 +1,447 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// CSVOptions directly maps the options for exporting the worksheet as CSV.
+// The Comma is the field delimiter, the default value is ','. Set QuoteAll
+// to quote all the fields, otherwise only the fields which contain the
+// delimiter, quote, line break or leading space will be quoted. Set UseCRLF
+// to use \r\n as the line terminator. The Encoding specifies the character
+// encoding of the output, the value will be one of "UTF-8" (default),
+// "UTF-8-BOM", "UTF-16LE" and "UTF-16BE", the UTF-16 output contains the
+// byte order mark. The DateLayout is the Go time layout used to format the
+// cells with date and time number formats, the number format of the cell will
+// be used if it is empty.
+type CSVOptions struct {
+	Comma      rune
+	QuoteAll   bool
+	UseCRLF    bool
+	Encoding   string
+	DateLayout string
+}
+
+// WriteCSV provides a function to write the cell values of the worksheet to
+// the writer in CSV format by given worksheet name, the writer and the CSV
+// options. The cell values are formatted with the number formats as
+// GetCellValue displays them, and all the rows are padded to the same number
+// of fields. For example, export Sheet1 as tab-separated values in UTF-16
+// encoding with ISO 8601 dates:
+//
+//    file, err := os.Create("Sheet1.tsv")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    defer file.Close()
+//    if err := f.WriteCSV("Sheet1", file, excelize.CSVOptions{
+//        Comma:      '\t',
+//        Encoding:   "UTF-16LE",
+//        DateLayout: "2006-01-02",
+//    }); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) WriteCSV(sheet string, w io.Writer, opts ...CSVOptions) error {
+	options := CSVOptions{Comma: ','}
+	for _, opt := range opts {
+		options = opt
+		if options.Comma == 0 {
+			options.Comma = ','
+		}
+	}
+	if !utf8.ValidRune(options.Comma) || options.Comma == '"' || options.Comma == '\r' ||
+		options.Comma == '\n' || options.Comma == utf8.RuneError {
+		return errors.New("invalid CSV delimiter")
+	}
+	var (
+		writer io.Writer
+		bom    bool
+	)
+	switch strings.ToUpper(options.Encoding) {
+	case "", "UTF-8":
+		writer = w
+	case "UTF-8-BOM":
+		writer, bom = w, true
+	case "UTF-16LE":
+		writer = transform.NewWriter(w, unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder())
+	case "UTF-16BE":
+		writer = transform.NewWriter(w, unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder())
+	default:
+		return errors.New("unsupported CSV encoding")
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	// Get the number of the fields by the maximum column number.
+	var cols int
+	for _, row := range ws.SheetData.Row {
+		for idx, c := range row.C {
+			col := idx + 1
+			if c.R != "" {
+				if col, _, err = CellNameToCoordinates(c.R); err != nil {
+					return err
+				}
+			}
+			if col > cols {
+				cols = col
+			}
+		}
+	}
+	var (
+		buf        = bufio.NewWriter(writer)
+		sst        = f.sharedStringsReader()
+		terminator = "\n"
+		record     = make([]string, cols)
+		lastRow    int
+	)
+	if options.UseCRLF {
+		terminator = "\r\n"
+	}
+	if bom {
+		buf.WriteString("\xEF\xBB\xBF")
+	}
+	for _, row := range ws.SheetData.Row {
+		for r := lastRow + 1; r < row.R; r++ {
+			if err = f.writeCSVRecord(buf, make([]string, cols), terminator, &options); err != nil {
+				return err
+			}
+		}
+		lastRow = row.R
+		for idx := range record {
+			record[idx] = ""
+		}
+		for idx, c := range row.C {
+			col := idx + 1
+			if c.R != "" {
+				col, _, _ = CellNameToCoordinates(c.R)
+			}
+			record[col-1] = f.getCSVCellValue(&row.C[idx], sst, &options)
+		}
+		if err = f.writeCSVRecord(buf, record, terminator, &options); err != nil {
+			return err
+		}
+	}
+	if err = buf.Flush(); err != nil {
+		return err
+	}
+	if closer, ok := writer.(io.Closer); ok && writer != w {
+		return closer.Close()
+	}
+	return err
+}
+
+// getCSVCellValue provides a function to get the formatted value of the cell
+// by given cell, shared strings table and the CSV options.
+func (f *File) getCSVCellValue(c *xlsxC, sst *xlsxSST, options *CSVOptions) string {
+	if options.DateLayout != "" && (c.T == "" || c.T == "n") && f.isDateStyle(c.S) {
+		if excelTime, err := strconv.ParseFloat(c.V, 64); err == nil {
+			return timeFromExcelTime(excelTime, f.date1904()).Format(options.DateLayout)
+		}
+	}
+	val, _ := c.getValueFrom(f, sst)
+	return val
+}
+
+// writeCSVRecord provides a function to write a CSV record with the fields
+// quoted if needed by given writer, the fields, line terminator and the CSV
+// options.
+func (f *File) writeCSVRecord(w *bufio.Writer, record []string, terminator string, options *CSVOptions) error {
+	for idx, field := range record {
+		if idx > 0 {
+			w.WriteRune(options.Comma)
+		}
+		if !options.QuoteAll && !csvFieldNeedsQuotes(field, options.Comma) {
+			w.WriteString(field)
+			continue
+		}
+		w.WriteByte('"')
+		w.WriteString(strings.Replace(field, `"`, `""`, -1))
+		w.WriteByte('"')
+	}
+	_, err := w.WriteString(terminator)
+	return err
+}
+
+// csvFieldNeedsQuotes provides a function to check if the field of the CSV
+// record needs to be quoted by given field and the delimiter, the field
+// contains the delimiter, quote, line break or begins with a space needs to
+// be quoted.
+func csvFieldNeedsQuotes(field string, comma rune) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return r == ' ' || r == '\t'
+}
+
+// CSVImportOptions directly maps the options for importing CSV into the
+// worksheet. The Comma is the field delimiter, the default value is ','. The
+// Cell is the top-left cell of the imported data, the default value is "A1".
+// Set Header to treat the first record as the header row, the header cells
+// will be stored as text with the style specified by HeaderStyle. The
+// TextColumns specifies the header names or the column names of the
+// worksheet which values will be stored as text as is, such as the ZIP codes
+// with leading zeros. The DateLayouts specifies the Go time layouts used to
+// detect the dates, the default layouts are "2006-01-02",
+// "2006-01-02 15:04:05" and time.RFC3339. Set Stream to write the rows with
+// the StreamWriter, which replaces the existing data of the worksheet. Set
+// Table to create a table over the imported data with the TableFormat, see
+// AddTable for details on the table format.
+type CSVImportOptions struct {
+	Comma       rune
+	Cell        string
+	Header      bool
+	HeaderStyle int
+	TextColumns []string
+	DateLayouts []string
+	Stream      bool
+	Table       bool
+	TableFormat string
+}
+
+// csvNumberExp defined the regular expression to detect the numbers in the
+// CSV fields.
+var csvNumberExp = regexp.MustCompile(`^[-+]?(\d+(\.\d*)?|\.\d+)([eE][-+]?\d+)?$`)
+
+// ImportCSV provides a function to read the CSV from the reader and set the
+// values into the worksheet by given worksheet name, the reader and the
+// import options. The type of the values will be detected: the numbers,
+// booleans TRUE and FALSE and the dates will be stored as the numeric,
+// boolean and date values, and the other values will be stored as text. The
+// numbers with more than 15 significant digits will be stored as text to
+// keep the precision. For example, import the tab-separated values into
+// Sheet1 with the bold header, keep the leading zeros of the "ZIP" column and
+// create a table over the result:
+//
+//    file, err := os.Open("Book1.tsv")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    defer file.Close()
+//    style, err := f.NewStyle(`{"font":{"bold":true}}`)
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if err := f.ImportCSV("Sheet1", file, excelize.CSVImportOptions{
+//        Comma:       '\t',
+//        Header:      true,
+//        HeaderStyle: style,
+//        TextColumns: []string{"ZIP"},
+//        Table:       true,
+//    }); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) ImportCSV(sheet string, r io.Reader, opts ...CSVImportOptions) error {
+	options := CSVImportOptions{Comma: ','}
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Comma == 0 {
+		options.Comma = ','
+	}
+	if options.Cell == "" {
+		options.Cell = "A1"
+	}
+	if len(options.DateLayouts) == 0 {
+		options.DateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339}
+	}
+	col, row, err := CellNameToCoordinates(options.Cell)
+	if err != nil {
+		return err
+	}
+	var sw *StreamWriter
+	if options.Stream {
+		if sw, err = f.NewStreamWriter(sheet); err != nil {
+			return err
+		}
+	} else if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && string(bom) == "\xEF\xBB\xBF" {
+		_, _ = br.Discard(3)
+	}
+	reader := csv.NewReader(br)
+	reader.Comma = options.Comma
+	reader.FieldsPerRecord = -1
+	var (
+		rows, cols  int
+		textColumns = map[int]bool{}
+		styles      = map[string]int{}
+	)
+	for _, name := range options.TextColumns {
+		if textCol, err := ColumnNameToNumber(name); err == nil && textCol >= col {
+			textColumns[textCol-col] = true
+		}
+	}
+	for ; ; rows++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(record))
+		for idx, field := range record {
+			if rows == 0 && options.Header {
+				for _, name := range options.TextColumns {
+					if field == name {
+						textColumns[idx] = true
+					}
+				}
+				values[idx] = Cell{StyleID: options.HeaderStyle, Value: field}
+				continue
+			}
+			if values[idx], err = f.inferCSVCellValue(field, textColumns[idx], styles, &options); err != nil {
+				return err
+			}
+		}
+		if len(record) > cols {
+			cols = len(record)
+		}
+		axis, err := CoordinatesToCellName(col, row+rows)
+		if err != nil {
+			return err
+		}
+		if sw != nil {
+			err = sw.SetRow(axis, values)
+		} else {
+			err = f.setCSVRow(sheet, col, row+rows, values)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if options.Table && rows > 0 && cols > 0 {
+		vcell, err := CoordinatesToCellName(col+cols-1, row+rows-1)
+		if err != nil {
+			return err
+		}
+		if sw != nil {
+			err = sw.AddTable(options.Cell, vcell, options.TableFormat)
+		} else {
+			err = f.AddTable(sheet, options.Cell, vcell, options.TableFormat)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if sw != nil {
+		return sw.Flush()
+	}
+	return err
+}
+
+// inferCSVCellValue provides a function to detect the type of the CSV field
+// and convert it to the cell value by given field, if it's a text column,
+// the styles cache and the import options.
+func (f *File) inferCSVCellValue(field string, text bool, styles map[string]int, options *CSVImportOptions) (interface{}, error) {
+	getStyle := func(style string) (int, error) {
+		if styleID, ok := styles[style]; ok {
+			return styleID, nil
+		}
+		styleID, err := f.NewStyle(style)
+		styles[style] = styleID
+		return styleID, err
+	}
+	if field == "" {
+		return nil, nil
+	}
+	if text {
+		styleID, err := getStyle(`{"quote_prefix":true}`)
+		return Cell{StyleID: styleID, Value: field}, err
+	}
+	if strings.EqualFold(field, "true") || strings.EqualFold(field, "false") {
+		return strings.EqualFold(field, "true"), nil
+	}
+	if csvNumberExp.MatchString(field) {
+		mantissa := strings.TrimLeft(strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, strings.SplitN(strings.ToLower(field), "e", 2)[0]), "0")
+		if len(mantissa) <= 15 {
+			if num, err := strconv.ParseFloat(field, 64); err == nil {
+				return num, nil
+			}
+		}
+		return field, nil
+	}
+	loc := time.UTC
+	if f.timeLocation != nil {
+		loc = f.timeLocation
+	}
+	for _, layout := range options.DateLayouts {
+		if tm, err := time.ParseInLocation(layout, field, loc); err == nil {
+			style := `{"number_format":22}`
+			if tm.Hour() == 0 && tm.Minute() == 0 && tm.Second() == 0 && tm.Nanosecond() == 0 {
+				style = `{"number_format":14}`
+			}
+			styleID, err := getStyle(style)
+			return Cell{StyleID: styleID, Value: tm}, err
+		}
+	}
+	return field, nil
+}
+
+// setCSVRow provides a function to set the values of the imported CSV record
+// by given worksheet name, the coordinates of the first cell and the values.
+func (f *File) setCSVRow(sheet string, col, row int, values []interface{}) error {
+	for idx, value := range values {
+		if value == nil {
+			continue
+		}
+		axis, err := CoordinatesToCellName(col+idx, row)
+		if err != nil {
+			return err
+		}
+		var styleID int
+		if cell, ok := value.(Cell); ok {
+			styleID, value = cell.StyleID, cell.Value
+		}
+		if err = f.SetCellValue(sheet, axis, value); err != nil {
+			return err
+		}
+		if styleID != 0 {
+			if err = f.SetCellStyle(sheet, axis, axis, styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
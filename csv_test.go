@@ -0,0 +1,138 @@
+package excelize
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/unicode"
+)
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestWriteCSV(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Price", "Date"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Apple, Red", 1.5, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{`Say "Hi"`, 2}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D5", " leading"))
+	style, err := f.NewStyle(`{"number_format":2}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B4", style))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteCSV("Sheet1", &buf))
+	assert.Equal(t, "Name,Price,Date,\n\"Apple, Red\",1.50,3/4/21 12:00,\n,,,\n\"Say \"\"Hi\"\"\",2.00,,\n,,,\" leading\"\n", buf.String())
+
+	// Test write CSV with custom delimiter, date layout and line terminator.
+	buf.Reset()
+	assert.NoError(t, f.WriteCSV("Sheet1", &buf, CSVOptions{Comma: '\t', DateLayout: "2006-01-02", UseCRLF: true}))
+	assert.Equal(t, "Name\tPrice\tDate\t\r\nApple, Red\t1.50\t2021-03-04\t\r\n\t\t\t\r\n\"Say \"\"Hi\"\"\"\t2.00\t\t\r\n\t\t\t\" leading\"\r\n", buf.String())
+
+	// Test write CSV with all fields quoted.
+	buf.Reset()
+	assert.NoError(t, f.WriteCSV("Sheet1", &buf, CSVOptions{Comma: ';', QuoteAll: true}))
+	assert.True(t, strings.HasPrefix(buf.String(), "\"Name\";\"Price\";\"Date\";\"\"\n"))
+
+	// Test write CSV with byte order mark.
+	buf.Reset()
+	assert.NoError(t, f.WriteCSV("Sheet1", &buf, CSVOptions{Encoding: "UTF-8-BOM"}))
+	assert.True(t, strings.HasPrefix(buf.String(), "\xEF\xBB\xBFName,Price"))
+
+	// Test write CSV in UTF-16 encoding.
+	for encoding, endianness := range map[string]unicode.Endianness{"UTF-16LE": unicode.LittleEndian, "UTF-16BE": unicode.BigEndian} {
+		buf.Reset()
+		assert.NoError(t, f.WriteCSV("Sheet1", &buf, CSVOptions{Encoding: encoding}))
+		decoded, err := unicode.UTF16(endianness, unicode.ExpectBOM).NewDecoder().Bytes(buf.Bytes())
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(decoded), "Name,Price,Date,\n"), encoding)
+	}
+
+	// Test write CSV with invalid options.
+	for _, comma := range []rune{'"', '\r', '\n', -1} {
+		assert.EqualError(t, f.WriteCSV("Sheet1", &buf, CSVOptions{Comma: comma}), "invalid CSV delimiter")
+	}
+	assert.EqualError(t, f.WriteCSV("Sheet1", &buf, CSVOptions{Encoding: "GBK"}), "unsupported CSV encoding")
+	// Test write CSV on not exists worksheet.
+	assert.EqualError(t, f.WriteCSV("SheetN", &buf), "sheet SheetN is not exist")
+	buf.Reset()
+	assert.EqualError(t, f.WriteCSV("SheetN", &buf, CSVOptions{Encoding: "UTF-8-BOM"}), "sheet SheetN is not exist")
+	assert.Empty(t, buf.Bytes())
+	// Test write CSV with failing writer.
+	assert.EqualError(t, f.WriteCSV("Sheet1", errWriter{}), "write error")
+	assert.EqualError(t, f.WriteCSV("Sheet1", errWriter{}, CSVOptions{Encoding: "UTF-8-BOM"}), "write error")
+	// Test write CSV with invalid cell reference.
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].R = "A"
+	assert.EqualError(t, f.WriteCSV("Sheet1", &buf), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestImportCSV(t *testing.T) {
+	data := "\xEF\xBB\xBFName,ZIP,Price,Active,Date,Time,ID\n" +
+		"Alice,00123,1.5,TRUE,2021-03-04,2021-03-04 08:30:00,12345678901234567890\n" +
+		"Bob,02134,-2e3,false,N/A,,\n"
+	f := NewFile()
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader(data), CSVImportOptions{
+		Cell:        "B2",
+		Header:      true,
+		HeaderStyle: style,
+		TextColumns: []string{"ZIP"},
+		Table:       true,
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Name", "ZIP", "Price", "Active", "Date", "Time", "ID"},
+		{"", "Alice", "00123", "1.5", "1", "03-04-21", "3/4/21 8:29", "12345678901234567890"},
+		{"", "Bob", "02134", "-2000", "0", "N/A"},
+	}, rows)
+	for cell, expected := range map[string]CellType{"C3": CellTypeString, "D3": CellTypeNumber, "E3": CellTypeBool, "F3": CellTypeDate, "H3": CellTypeString} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	headerStyle, err := f.GetCellStyle("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, headerStyle)
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `ref="B2:H4"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestImportCSV.xlsx")))
+
+	// Test import CSV with the stream writer and custom date layouts.
+	f = NewFile()
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("Day;Value\n04/03/2021;1\n05/03/2021;2\n"), CSVImportOptions{
+		Comma:       ';',
+		Header:      true,
+		DateLayouts: []string{"02/01/2006"},
+		Stream:      true,
+		Table:       true,
+		TableFormat: `{"table_name":"Values"}`,
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestImportCSVStream.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestImportCSVStream.xlsx"))
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Day", "Value"}, {"03-04-21", "1"}, {"03-05-21", "2"}}, rows)
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `name="Values"`)
+
+	// Test import CSV with invalid options.
+	f = NewFile()
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a"), CSVImportOptions{Cell: "A"}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader("a")), "sheet SheetN is not exist")
+	assert.EqualError(t, f.ImportCSV("SheetN", strings.NewReader("a"), CSVImportOptions{Stream: true}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b")), `parse error on line 1, column 2: bare " in non-quoted-field`)
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a,a\n1,2"), CSVImportOptions{Table: true, TableFormat: `{`}), "unexpected end of JSON input")
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a"), CSVImportOptions{Cell: "XFD1048577"}), "row number exceeds maximum limit")
+}
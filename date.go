@@ -14,12 +14,16 @@ package excelize
 import (
 	"errors"
 	"math"
+	"strconv"
 	"time"
 )
 
 const (
 	dayNanoseconds = 24 * time.Hour
 	maxDuration    = 290 * 364 * dayNanoseconds
+	// date1904Offset is the number of days between the epoch of the 1900 date
+	// system and the 1904 date system.
+	date1904Offset = 1462
 )
 
 var (
@@ -27,6 +31,41 @@ var (
 	excelBuggyPeriodStart = time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
 )
 
+// normalizeTime provides a function to convert the time to the wall clock
+// time in the TimeLocation of the options, and represent it in UTC. The time
+// will be returned as is if the TimeLocation is not specified.
+func (f *File) normalizeTime(t time.Time) time.Time {
+	if f.timeLocation == nil {
+		return t
+	}
+	t = t.In(f.timeLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// localTime provides a function to convert the wall clock time represented
+// in UTC to the time in the TimeLocation of the options, this is the inverse
+// of normalizeTime.
+func (f *File) localTime(t time.Time) time.Time {
+	if f.timeLocation == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), f.timeLocation)
+}
+
+// date1904Value provides a function to convert the serial date number of the
+// 1904 date system to the 1900 date system if the workbook uses the 1904 date
+// system.
+func (f *File) date1904Value(v string) string {
+	if !f.date1904() {
+		return v
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+	return strconv.FormatFloat(n+date1904Offset, 'f', -1, 64)
+}
+
 // timeToExcelTime provides a function to convert time to Excel time.
 func timeToExcelTime(t time.Time) (float64, error) {
 	// TODO in future this should probably also handle date1904 and like TimeFromExcelTime
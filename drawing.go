@@ -14,10 +14,12 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -95,12 +97,14 @@ func (f *File) addChart(formatSet *formatChart, comboCharts []*formatChart) {
 									},
 								},
 							},
-							R: &aR{
-								RPr: aRPr{
-									Lang:    "en-US",
-									AltLang: "en-US",
+							R: []*aR{
+								{
+									RPr: aRPr{
+										Lang:    "en-US",
+										AltLang: "en-US",
+									},
+									T: formatSet.Title.Name,
 								},
-								T: formatSet.Title.Name,
 							},
 						},
 					},
@@ -1145,7 +1149,8 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int) {
 		err error
 		ok  bool
 	)
-
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if f.Drawings[path] == nil {
 		content := xlsxWsDr{}
 		content.A = NameSpaceDrawingML.Value
@@ -1157,6 +1162,12 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int) {
 				log.Printf("xml decode error: %s", err)
 			}
 			content.R = decodeWsDr.R
+			for _, v := range decodeWsDr.AbsoluteAnchor {
+				content.AbsoluteAnchor = append(content.AbsoluteAnchor, &xdrCellAnchor{
+					EditAs:       v.EditAs,
+					GraphicFrame: v.Content,
+				})
+			}
 			for _, v := range decodeWsDr.OneCellAnchor {
 				content.OneCellAnchor = append(content.OneCellAnchor, &xdrCellAnchor{
 					EditAs:       v.EditAs,
@@ -1173,7 +1184,75 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int) {
 		f.Drawings[path] = &content
 	}
 	wsDr := f.Drawings[path]
-	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2
+	return wsDr, len(wsDr.AbsoluteAnchor) + len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2
+}
+
+// decodeDrawingAnchor provides a function to get the decoded cell anchor by
+// given drawing cell anchor. The anchor parsed from the existing drawing part
+// will be decoded from the inner XML, and the anchor created by the library
+// will be converted from the fields.
+func (f *File) decodeDrawingAnchor(anchor *xdrCellAnchor) (*decodeTwoCellAnchor, error) {
+	deAnchor := new(decodeTwoCellAnchor)
+	if anchor.Pos == nil && anchor.From == nil && anchor.Pic == nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+			Decode(deAnchor); err != nil && err != io.EOF {
+			return deAnchor, fmt.Errorf("xml decode error: %s", err)
+		}
+		return deAnchor, nil
+	}
+	if anchor.Pos != nil {
+		deAnchor.Pos = &decodePos{X: anchor.Pos.X, Y: anchor.Pos.Y}
+	}
+	if anchor.From != nil {
+		deAnchor.From = &decodeFrom{Col: anchor.From.Col, ColOff: anchor.From.ColOff, Row: anchor.From.Row, RowOff: anchor.From.RowOff}
+	}
+	if anchor.To != nil {
+		deAnchor.To = &decodeTo{Col: anchor.To.Col, ColOff: anchor.To.ColOff, Row: anchor.To.Row, RowOff: anchor.To.RowOff}
+	}
+	if anchor.Ext != nil {
+		deAnchor.Ext = &decodeExt{Cx: anchor.Ext.Cx, Cy: anchor.Ext.Cy}
+	}
+	if pic := anchor.Pic; pic != nil {
+		deAnchor.Pic = &decodePic{}
+		deAnchor.Pic.NvPicPr.CNvPr = decodeCNvPr{
+			ID:    pic.NvPicPr.CNvPr.ID,
+			Name:  pic.NvPicPr.CNvPr.Name,
+			Descr: pic.NvPicPr.CNvPr.Descr,
+			Title: pic.NvPicPr.CNvPr.Title,
+		}
+		deAnchor.Pic.BlipFill.Blip.Embed = pic.BlipFill.Blip.Embed
+		if extLst := pic.BlipFill.Blip.ExtLst; extLst != nil {
+			deAnchor.Pic.BlipFill.Blip.ExtLst = &decodeBlipExtLst{}
+			for _, ext := range extLst.Ext {
+				deExt := decodeBlipExt{URI: ext.URI}
+				if ext.SVGBlip != nil {
+					deExt.SVGBlip = &decodeSVGBlip{Embed: ext.SVGBlip.Embed}
+				}
+				deAnchor.Pic.BlipFill.Blip.ExtLst.Ext = append(deAnchor.Pic.BlipFill.Blip.ExtLst.Ext, deExt)
+			}
+		}
+		deAnchor.Pic.SpPr.Xfrm.Ext = decodeExt{Cx: pic.SpPr.Xfrm.Ext.Cx, Cy: pic.SpPr.Xfrm.Ext.Cy}
+	}
+	if anchor.Sp != nil || anchor.GrpSp != nil || anchor.CxnSp != nil {
+		output, err := xml.Marshal(xdrCellAnchor{Sp: anchor.Sp, GrpSp: anchor.GrpSp, CxnSp: anchor.CxnSp})
+		if err != nil {
+			return deAnchor, err
+		}
+		deShape := new(decodeTwoCellAnchor)
+		if err = f.xmlNewDecoder(bytes.NewReader(output)).Decode(deShape); err != nil && err != io.EOF {
+			return deAnchor, fmt.Errorf("xml decode error: %s", err)
+		}
+		deAnchor.Sp, deAnchor.GrpSp, deAnchor.CxnSp = deShape.Sp, deShape.GrpSp, deShape.CxnSp
+	}
+	if anchor.GraphicFrame != "" {
+		deGraphicFrame := new(decodeTwoCellAnchor)
+		if err := f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + anchor.GraphicFrame + "</decodeTwoCellAnchor>")).
+			Decode(deGraphicFrame); err != nil && err != io.EOF {
+			return deAnchor, fmt.Errorf("xml decode error: %s", err)
+		}
+		deAnchor.GraphicFrame = deGraphicFrame.GraphicFrame
+	}
+	return deAnchor, nil
 }
 
 // addDrawingChart provides a function to add chart graphic frame by given
@@ -1282,36 +1361,380 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) (err
 		deTwoCellAnchor *decodeTwoCellAnchor
 	)
 	xdrCellAnchorFuncs := map[string]func(anchor *xdrCellAnchor) bool{
-		"Chart": func(anchor *xdrCellAnchor) bool { return anchor.Pic == nil },
-		"Pic":   func(anchor *xdrCellAnchor) bool { return anchor.Pic != nil },
+		"Chart": func(anchor *xdrCellAnchor) bool {
+			return anchor.Pic == nil && anchor.Sp == nil && anchor.GrpSp == nil && anchor.CxnSp == nil
+		},
+		"Pic": func(anchor *xdrCellAnchor) bool { return anchor.Pic != nil },
+		"Shape": func(anchor *xdrCellAnchor) bool {
+			return anchor.Sp != nil || anchor.GrpSp != nil || anchor.CxnSp != nil
+		},
 	}
 	decodeTwoCellAnchorFuncs := map[string]func(anchor *decodeTwoCellAnchor) bool{
-		"Chart": func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic == nil },
-		"Pic":   func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic != nil },
+		"Chart": func(anchor *decodeTwoCellAnchor) bool {
+			return anchor.Pic == nil && anchor.Sp == nil && anchor.GrpSp == nil && anchor.CxnSp == nil
+		},
+		"Pic": func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic != nil },
+		"Shape": func(anchor *decodeTwoCellAnchor) bool {
+			return anchor.Sp != nil || anchor.GrpSp != nil || anchor.CxnSp != nil
+		},
 	}
 	wsDr, _ = f.drawingParser(drawingXML)
-	for idx := 0; idx < len(wsDr.TwoCellAnchor); idx++ {
-		if err = nil; wsDr.TwoCellAnchor[idx].From != nil && xdrCellAnchorFuncs[drawingType](wsDr.TwoCellAnchor[idx]) {
-			if wsDr.TwoCellAnchor[idx].From.Col == col && wsDr.TwoCellAnchor[idx].From.Row == row {
-				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
-				idx--
+	for _, anchors := range []*[]*xdrCellAnchor{&wsDr.OneCellAnchor, &wsDr.TwoCellAnchor} {
+		for idx := 0; idx < len(*anchors); idx++ {
+			if err = nil; (*anchors)[idx].From != nil && xdrCellAnchorFuncs[drawingType]((*anchors)[idx]) {
+				if (*anchors)[idx].From.Col == col && (*anchors)[idx].From.Row == row {
+					*anchors = append((*anchors)[:idx], (*anchors)[idx+1:]...)
+					idx--
+				}
 			}
 		}
-	}
-	for idx := 0; idx < len(wsDr.TwoCellAnchor); idx++ {
-		deTwoCellAnchor = new(decodeTwoCellAnchor)
-		if err = f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + wsDr.TwoCellAnchor[idx].GraphicFrame + "</decodeTwoCellAnchor>")).
-			Decode(deTwoCellAnchor); err != nil && err != io.EOF {
-			err = fmt.Errorf("xml decode error: %s", err)
-			return
-		}
-		if err = nil; deTwoCellAnchor.From != nil && decodeTwoCellAnchorFuncs[drawingType](deTwoCellAnchor) {
-			if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
-				wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor[:idx], wsDr.TwoCellAnchor[idx+1:]...)
-				idx--
+		for idx := 0; idx < len(*anchors); idx++ {
+			deTwoCellAnchor = new(decodeTwoCellAnchor)
+			if err = f.xmlNewDecoder(strings.NewReader("<decodeTwoCellAnchor>" + (*anchors)[idx].GraphicFrame + "</decodeTwoCellAnchor>")).
+				Decode(deTwoCellAnchor); err != nil && err != io.EOF {
+				err = fmt.Errorf("xml decode error: %s", err)
+				return
+			}
+			if err = nil; deTwoCellAnchor.From != nil && decodeTwoCellAnchorFuncs[drawingType](deTwoCellAnchor) {
+				if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
+					*anchors = append((*anchors)[:idx], (*anchors)[idx+1:]...)
+					idx--
+				}
 			}
 		}
 	}
 	f.Drawings[drawingXML] = wsDr
 	return err
 }
+
+// GetDrawings retrieves all the drawing objects in a worksheet by given
+// worksheet name, including the pictures, shapes, connectors, group shapes,
+// charts and the legacy form controls. The Cell, OffsetX and OffsetY are the
+// top-left cell and the offsets of the object in pixels, the Width and Height
+// are the size of the object in pixels. The Positioning is the anchor type of
+// the object, the value will be one of "twoCell", "oneCell" and "absolute".
+// For example, list the drawing objects in Sheet1:
+//
+//    drawings, err := f.GetDrawings("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    for _, drawing := range drawings {
+//        fmt.Println(drawing.Type, drawing.Name, drawing.Cell, drawing.Width, drawing.Height)
+//    }
+//
+func (f *File) GetDrawings(sheet string) ([]Drawing, error) {
+	var drawings []Drawing
+	objects, err := f.getDrawingObjects(sheet)
+	for _, object := range objects {
+		drawings = append(drawings, object.Drawing)
+	}
+	return drawings, err
+}
+
+// MoveDrawing provides a function to move the drawing object in a worksheet
+// by given worksheet name, the name of the object, the new top-left cell and
+// the offsets in pixels, the size of the object will be kept. For example,
+// move the chart named "Chart 1" in Sheet1 to the cell D5:
+//
+//    err := f.MoveDrawing("Sheet1", "Chart 1", "D5", 0, 0)
+//
+func (f *File) MoveDrawing(sheet, name, cell string, offsetX, offsetY int) error {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	return f.setDrawingObject(sheet, name, func(drawing *Drawing) {
+		drawing.Cell, drawing.OffsetX, drawing.OffsetY = cell, offsetX, offsetY
+	})
+}
+
+// ResizeDrawing provides a function to resize the drawing object in a
+// worksheet by given worksheet name, the name of the object, and the width
+// and height in pixels, the top-left position of the object will be kept.
+// For example, resize the picture named "Picture 2" in Sheet1:
+//
+//    err := f.ResizeDrawing("Sheet1", "Picture 2", 320, 240)
+//
+func (f *File) ResizeDrawing(sheet, name string, width, height int) error {
+	if width <= 0 || height <= 0 {
+		return errors.New("the width and height of the drawing object must be positive")
+	}
+	return f.setDrawingObject(sheet, name, func(drawing *Drawing) {
+		drawing.Width, drawing.Height = width, height
+	})
+}
+
+// drawingObject defined the drawing object of the worksheet with the cell
+// anchor of the drawing part, or the shape ID of the form control in the VML
+// drawing.
+type drawingObject struct {
+	Drawing
+	drawingXML string
+	anchor     *xdrCellAnchor
+	shapeID    int
+}
+
+// getDrawingObjects provides a function to get all the drawing objects in a
+// worksheet by given worksheet name.
+func (f *File) getDrawingObjects(sheet string) ([]drawingObject, error) {
+	var objects []drawingObject
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return objects, err
+	}
+	if ws.Drawing != nil {
+		drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
+		wsDr, _ := f.drawingParser(drawingXML)
+		for positioning, anchors := range [][]*xdrCellAnchor{wsDr.AbsoluteAnchor, wsDr.OneCellAnchor, wsDr.TwoCellAnchor} {
+			for _, anchor := range anchors {
+				deAnchor, err := f.decodeDrawingAnchor(anchor)
+				if err != nil {
+					return objects, err
+				}
+				object := drawingObject{drawingXML: drawingXML, anchor: anchor}
+				object.Positioning = []string{"absolute", "oneCell", "twoCell"}[positioning]
+				if positioning == 2 && anchor.EditAs != "" {
+					object.Positioning = anchor.EditAs
+				}
+				if object.Type, object.Name = getDrawingObjectName(deAnchor); object.Type == "" {
+					continue
+				}
+				f.setDrawingObjectPosition(sheet, &object, deAnchor)
+				objects = append(objects, object)
+			}
+		}
+	}
+	if ws.LegacyDrawing == nil {
+		return objects, err
+	}
+	controls, err := f.getFormControlNames(ws)
+	if err != nil {
+		return objects, err
+	}
+	drawingVML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl", -1)
+	var shapes []decodeShape
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, shape := range vml.Shape {
+			shapes = append(shapes, decodeShape{ID: shape.ID, Val: shape.Val})
+		}
+	} else if d := f.decodeVMLDrawingReader(drawingVML); d != nil {
+		shapes = d.Shape
+	}
+	for _, shape := range shapes {
+		val := decodeShapeVal{}
+		_ = xml.Unmarshal([]byte("<shape xmlns:v=\"urn:schemas-microsoft-com:vml\" "+
+			"xmlns:o=\"urn:schemas-microsoft-com:office:office\" "+
+			"xmlns:x=\"urn:schemas-microsoft-com:office:excel\">"+shape.Val+"</shape>"), &val)
+		if val.ClientData == nil {
+			continue
+		}
+		control, ok := f.newFormControl(sheet, &val)
+		if !ok {
+			continue
+		}
+		anchor, _ := parseVMLAnchor(val.ClientData.Anchor)
+		object := drawingObject{Drawing: Drawing{
+			Type:        "FormControl",
+			Name:        shape.ID,
+			Cell:        control.Cell,
+			OffsetX:     anchor[1],
+			OffsetY:     anchor[3],
+			Width:       control.Width,
+			Height:      control.Height,
+			Positioning: "oneCell",
+		}}
+		object.shapeID, _ = strconv.Atoi(strings.TrimPrefix(shape.ID, "_x0000_s"))
+		if name, ok := controls[object.shapeID]; ok {
+			object.Name = name
+		}
+		objects = append(objects, object)
+	}
+	return objects, err
+}
+
+// getDrawingObjectName provides a function to get the type and the name of
+// the drawing object by given decoded cell anchor, the type will be empty if
+// the object is not supported.
+func getDrawingObjectName(deAnchor *decodeTwoCellAnchor) (string, string) {
+	switch {
+	case deAnchor.Pic != nil:
+		return "Picture", deAnchor.Pic.NvPicPr.CNvPr.Name
+	case deAnchor.Sp != nil && deAnchor.Sp.NvSpPr != nil && deAnchor.Sp.NvSpPr.CNvPr != nil:
+		return "Shape", deAnchor.Sp.NvSpPr.CNvPr.Name
+	case deAnchor.CxnSp != nil && deAnchor.CxnSp.NvCxnSpPr != nil && deAnchor.CxnSp.NvCxnSpPr.CNvPr != nil:
+		return "Connector", deAnchor.CxnSp.NvCxnSpPr.CNvPr.Name
+	case deAnchor.GrpSp != nil && deAnchor.GrpSp.NvGrpSpPr != nil && deAnchor.GrpSp.NvGrpSpPr.CNvPr != nil:
+		return "GroupShape", deAnchor.GrpSp.NvGrpSpPr.CNvPr.Name
+	case deAnchor.GraphicFrame != nil && deAnchor.GraphicFrame.NvGraphicFramePr.CNvPr != nil:
+		if deAnchor.GraphicFrame.Graphic.GraphicData.URI == NameSpaceDrawingMLChart.Value {
+			return "Chart", deAnchor.GraphicFrame.NvGraphicFramePr.CNvPr.Name
+		}
+		return "GraphicFrame", deAnchor.GraphicFrame.NvGraphicFramePr.CNvPr.Name
+	}
+	return "", ""
+}
+
+// setDrawingObjectPosition provides a function to set the top-left cell, the
+// offsets and the size in pixels of the drawing object by given worksheet
+// name and the decoded cell anchor.
+func (f *File) setDrawingObjectPosition(sheet string, object *drawingObject, deAnchor *decodeTwoCellAnchor) {
+	var x, y int
+	if deAnchor.Pos != nil {
+		x, y = deAnchor.Pos.X/EMU, deAnchor.Pos.Y/EMU
+	}
+	if deAnchor.From != nil {
+		x, y = f.cellAnchorToPixels(sheet, deAnchor.From.Col, deAnchor.From.ColOff, deAnchor.From.Row, deAnchor.From.RowOff)
+	}
+	col, colOff, row, rowOff := f.pixelsToCellAnchor(sheet, x, y)
+	object.Cell, _ = CoordinatesToCellName(col+1, row+1)
+	object.OffsetX, object.OffsetY = colOff/EMU, rowOff/EMU
+	if deAnchor.Ext != nil {
+		object.Width, object.Height = deAnchor.Ext.Cx/EMU, deAnchor.Ext.Cy/EMU
+	}
+	if deAnchor.To != nil {
+		x2, y2 := f.cellAnchorToPixels(sheet, deAnchor.To.Col, deAnchor.To.ColOff, deAnchor.To.Row, deAnchor.To.RowOff)
+		object.Width, object.Height = x2-x, y2-y
+	}
+}
+
+// setDrawingObject provides a function to update the anchor of the drawing
+// object by given worksheet name, the name of the object and the function to
+// change the position and size of the object.
+func (f *File) setDrawingObject(sheet, name string, fn func(drawing *Drawing)) error {
+	objects, err := f.getDrawingObjects(sheet)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if object.Name != name {
+			continue
+		}
+		fn(&object.Drawing)
+		col, row, err := CellNameToCoordinates(object.Cell)
+		if err != nil {
+			return err
+		}
+		x, y := f.cellAnchorToPixels(sheet, col-1, object.OffsetX*EMU, row-1, object.OffsetY*EMU)
+		from, to := xlsxFrom{}, xlsxTo{}
+		from.Col, from.ColOff, from.Row, from.RowOff = f.pixelsToCellAnchor(sheet, x, y)
+		to.Col, to.ColOff, to.Row, to.RowOff = f.pixelsToCellAnchor(sheet, x+object.Width, y+object.Height)
+		if object.anchor == nil {
+			return f.setFormControlAnchor(sheet, object.shapeID, &from, &to)
+		}
+		return setDrawingAnchor(object, x, y, &from, &to)
+	}
+	return fmt.Errorf("drawing object %s is not exist", name)
+}
+
+// setDrawingAnchor provides a function to set the position elements of the
+// cell anchor of the drawing object by given position in pixels and the
+// starting and ending anchor. The anchor parsed from the existing drawing
+// part will be updated in the inner XML.
+func setDrawingAnchor(object drawingObject, x, y int, from *xlsxFrom, to *xlsxTo) error {
+	anchor := object.anchor
+	pos := xlsxPoint2D{X: x * EMU, Y: y * EMU}
+	ext := xlsxExt{Cx: object.Width * EMU, Cy: object.Height * EMU}
+	if anchor.Pos == nil && anchor.From == nil && anchor.Pic == nil {
+		values := map[string]map[string]int{
+			"pos":  {"x": pos.X, "y": pos.Y},
+			"from": {"col": from.Col, "colOff": from.ColOff, "row": from.Row, "rowOff": from.RowOff},
+			"to":   {"col": to.Col, "colOff": to.ColOff, "row": to.Row, "rowOff": to.RowOff},
+			"ext":  {"cx": ext.Cx, "cy": ext.Cy},
+		}
+		content, err := replaceXMLElements(anchor.GraphicFrame, func(ancestors []string, start xml.StartElement) bool {
+			return len(ancestors) == 0 && values[start.Name.Local] != nil
+		}, func(element string, start xml.StartElement) string {
+			return setXMLElementValues(element, values[start.Name.Local])
+		})
+		anchor.GraphicFrame = content
+		return err
+	}
+	switch {
+	case anchor.Pos != nil:
+		anchor.Pos, anchor.Ext = &pos, &ext
+	case anchor.To != nil:
+		anchor.From, anchor.To = from, to
+	default:
+		anchor.From, anchor.Ext = from, &ext
+	}
+	var xfrm *xlsxXfrm
+	switch {
+	case anchor.Pic != nil:
+		xfrm = &anchor.Pic.SpPr.Xfrm
+	case anchor.Sp != nil && anchor.Sp.SpPr != nil:
+		xfrm = &anchor.Sp.SpPr.Xfrm
+	case anchor.CxnSp != nil && anchor.CxnSp.SpPr != nil:
+		xfrm = &anchor.CxnSp.SpPr.Xfrm
+	case anchor.GrpSp != nil && anchor.GrpSp.GrpSpPr != nil && anchor.GrpSp.GrpSpPr.Xfrm != nil:
+		xfrm = anchor.GrpSp.GrpSpPr.Xfrm
+	default:
+		return nil
+	}
+	xfrm.Off, xfrm.Ext = xlsxOff{X: pos.X, Y: pos.Y}, ext
+	return nil
+}
+
+// replaceXMLElements provides a function to replace the elements in the XML
+// content by given match function and replace function. The match function
+// will be called with the local names of the ancestor elements for each
+// start element, and the replace function returns the new XML of the matched
+// element.
+func replaceXMLElements(content string, match func(ancestors []string, start xml.StartElement) bool, replace func(element string, start xml.StartElement) string) (string, error) {
+	var (
+		dec       = xml.NewDecoder(strings.NewReader(content))
+		buf       strings.Builder
+		ancestors []string
+		last      int64
+	)
+	for {
+		offset := dec.InputOffset()
+		token, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return content, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !match(ancestors, t) {
+				ancestors = append(ancestors, t.Name.Local)
+				continue
+			}
+			for depth := 1; depth > 0; {
+				if token, err = dec.RawToken(); err != nil {
+					return content, err
+				}
+				switch token.(type) {
+				case xml.StartElement:
+					depth++
+				case xml.EndElement:
+					depth--
+				}
+			}
+			end := dec.InputOffset()
+			buf.WriteString(content[last:offset])
+			buf.WriteString(replace(content[offset:end], t))
+			last = end
+		case xml.EndElement:
+			if len(ancestors) > 0 {
+				ancestors = ancestors[:len(ancestors)-1]
+			}
+		}
+	}
+	buf.WriteString(content[last:])
+	return buf.String(), nil
+}
+
+// setXMLElementValues provides a function to set the integer values of the
+// child elements or the attributes in the XML element by given names and
+// values.
+func setXMLElementValues(element string, values map[string]int) string {
+	for name, value := range values {
+		element = regexp.MustCompile(`(<(?:\w+:)?`+name+`>)\s*-?\d+\s*(</)`).
+			ReplaceAllString(element, "${1}"+strconv.Itoa(value)+"${2}")
+		element = regexp.MustCompile(`(\s`+name+`=")-?\d+(")`).
+			ReplaceAllString(element, "${1}"+strconv.Itoa(value)+"${2}")
+	}
+	return element
+}
@@ -10,7 +10,10 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDrawingParser(t *testing.T) {
@@ -25,3 +28,112 @@ func TestDrawingParser(t *testing.T) {
 	// Test with unsupport charset
 	f.drawingParser("charset")
 }
+
+func TestGetDrawings(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), `{"x_scale":0.5,"y_scale":0.5}`))
+	assert.NoError(t, f.AddChart("Sheet1", "E2", `{"type":"col","series":[{"name":"Sheet1!$A$1","values":"Sheet1!$A$2:$A$4"}],"dimension":{"width":320,"height":240}}`))
+	assert.NoError(t, f.AddShape("Sheet1", "J2", `{"type":"rect","width":100,"height":60}`))
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "B20", Type: FormControlButton, Text: "Run"}))
+	assert.NoError(t, f.AddWatermark("Sheet1", `{"text":"DRAFT","x_offset":64,"y_offset":40}`))
+	expected := []Drawing{
+		{Type: "Shape", Name: "Watermark 5", Cell: "B3", Width: 308, Height: 154, Positioning: "absolute"},
+		{Type: "Picture", Name: "Picture 2", Cell: "B2", Width: 100, Height: 64, Positioning: "twoCell"},
+		{Type: "Chart", Name: "Chart 3", Cell: "E2", Width: 320, Height: 240, Positioning: "twoCell"},
+		{Type: "Shape", Name: "Shape 4", Cell: "J2", Width: 100, Height: 60, Positioning: "twoCell"},
+		{Type: "FormControl", Name: "Button 1", Cell: "B20", Width: 96, Height: 24, Positioning: "oneCell"},
+	}
+	drawings, err := f.GetDrawings("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, drawings)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetDrawings.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetDrawings.xlsx"))
+	assert.NoError(t, err)
+	drawings, err = f.GetDrawings("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, drawings)
+	// Test get drawings on the worksheet without drawing objects.
+	f.NewSheet("Sheet2")
+	drawings, err = f.GetDrawings("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, drawings, 0)
+	// Test get drawings on not exists worksheet.
+	_, err = f.GetDrawings("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get drawings with invalid drawing and controls.
+	f.Drawings["xl/drawings/drawing1.xml"].TwoCellAnchor[0].GraphicFrame = "<xdr:pic"
+	_, err = f.GetDrawings("Sheet1")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: expected attribute name in element")
+	f.Drawings["xl/drawings/drawing1.xml"].TwoCellAnchor = nil
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Controls.Content = "<control"
+	_, err = f.GetDrawings("Sheet1")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: unexpected EOF")
+}
+
+func TestMoveAndResizeDrawing(t *testing.T) {
+	for _, reopen := range []bool{false, true} {
+		f := NewFile()
+		assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), `{"x_scale":0.5,"y_scale":0.5}`))
+		assert.NoError(t, f.AddChart("Sheet1", "E2", `{"type":"col","series":[{"name":"Sheet1!$A$1","values":"Sheet1!$A$2:$A$4"}],"dimension":{"width":320,"height":240}}`))
+		assert.NoError(t, f.AddShape("Sheet1", "J2", `{"type":"rect","width":100,"height":60}`))
+		assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "B20", Type: FormControlButton, Text: "Run"}))
+		assert.NoError(t, f.AddWatermark("Sheet1", `{"text":"DRAFT","x_offset":64,"y_offset":40}`))
+		if reopen {
+			assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveAndResizeDrawing.xlsx")))
+			var err error
+			f, err = OpenFile(filepath.Join("test", "TestMoveAndResizeDrawing.xlsx"))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, f.MoveDrawing("Sheet1", "Picture 2", "D10", 5, 6))
+		assert.NoError(t, f.ResizeDrawing("Sheet1", "Chart 3", 400, 300))
+		assert.NoError(t, f.MoveDrawing("Sheet1", "Shape 4", "A30", 0, 0))
+		assert.NoError(t, f.ResizeDrawing("Sheet1", "Shape 4", 200, 50))
+		assert.NoError(t, f.MoveDrawing("Sheet1", "Watermark 5", "C4", 10, 0))
+		assert.NoError(t, f.ResizeDrawing("Sheet1", "Watermark 5", 300, 100))
+		assert.NoError(t, f.MoveDrawing("Sheet1", "Button 1", "F20", 0, 0))
+		assert.NoError(t, f.ResizeDrawing("Sheet1", "Button 1", 128, 32))
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveAndResizeDrawing.xlsx")))
+
+		f, err := OpenFile(filepath.Join("test", "TestMoveAndResizeDrawing.xlsx"))
+		assert.NoError(t, err)
+		drawings, err := f.GetDrawings("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []Drawing{
+			{Type: "Shape", Name: "Watermark 5", Cell: "C4", OffsetX: 10, Width: 300, Height: 100, Positioning: "absolute"},
+			{Type: "Picture", Name: "Picture 2", Cell: "D10", OffsetX: 5, OffsetY: 6, Width: 100, Height: 64, Positioning: "twoCell"},
+			{Type: "Chart", Name: "Chart 3", Cell: "E2", Width: 400, Height: 300, Positioning: "twoCell"},
+			{Type: "Shape", Name: "Shape 4", Cell: "A30", Width: 200, Height: 50, Positioning: "twoCell"},
+			{Type: "FormControl", Name: "Button 1", Cell: "F20", Width: 128, Height: 32, Positioning: "oneCell"},
+		}, drawings, reopen)
+		controls, err := f.GetFormControls("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, controls, 1)
+		assert.Equal(t, "F20", controls[0].Cell)
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		assert.Contains(t, ws.Controls.Content, "<from><xdr:col>5</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>19</xdr:row><xdr:rowOff>0</xdr:rowOff></from>")
+		assert.Contains(t, ws.Controls.Content, "<to><xdr:col>7</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>20</xdr:row><xdr:rowOff>114300</xdr:rowOff></to>")
+		file, raw, err := f.GetPicture("Sheet1", "D10")
+		assert.NoError(t, err)
+		assert.Equal(t, "image1.png", file)
+		assert.NotEmpty(t, raw)
+		assert.NoError(t, f.DeletePicture("Sheet1", "D10"))
+		drawings, err = f.GetDrawings("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, drawings, 4)
+	}
+
+	f := NewFile()
+	// Test move and resize drawing object with invalid parameters.
+	assert.EqualError(t, f.MoveDrawing("Sheet1", "Picture 1", "A", 0, 0), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.ResizeDrawing("Sheet1", "Picture 1", 0, 10), "the width and height of the drawing object must be positive")
+	assert.EqualError(t, f.MoveDrawing("SheetN", "Picture 1", "A1", 0, 0), "sheet SheetN is not exist")
+	assert.EqualError(t, f.MoveDrawing("Sheet1", "Picture 1", "A1", 0, 0), "drawing object Picture 1 is not exist")
+	// Test move drawing object with invalid drawing XML.
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{"type":"rect"}`))
+	f.Drawings["xl/drawings/drawing1.xml"].TwoCellAnchor[0] = &xdrCellAnchor{GraphicFrame: "<xdr:from/><xdr:sp><xdr:nvSpPr><xdr:cNvPr id=\"2\" name=\"Shape 2\"/></xdr:nvSpPr></xdr:sp><xdr:clientData"}
+	assert.EqualError(t, f.MoveDrawing("Sheet1", "Shape 2", "B1", 0, 0), "xml decode error: XML syntax error on line 1: expected attribute name in element")
+}
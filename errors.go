@@ -13,16 +13,108 @@ package excelize
 
 import "fmt"
 
+// ErrSheetNotExist defines an error of sheet is not exist
+type ErrSheetNotExist struct {
+	SheetName string
+}
+
+func (err ErrSheetNotExist) Error() string {
+	return fmt.Sprintf("sheet %s is not exist", string(err.SheetName))
+}
+
+// ErrChartSheet defines an error of the worksheet operation on the chart
+// sheet, which could be checked by errors.As to get the sheet name.
+type ErrChartSheet struct {
+	SheetName string
+}
+
+func (err ErrChartSheet) Error() string {
+	return fmt.Sprintf("sheet %s is chart sheet", err.SheetName)
+}
+
+// ErrInvalidCellName defines an error of the invalid cell name, which could
+// be checked by errors.As to get the cell name.
+type ErrInvalidCellName struct {
+	Cell string
+}
+
+func (err ErrInvalidCellName) Error() string {
+	return fmt.Sprintf("invalid cell name %q", err.Cell)
+}
+
+// ErrInvalidColumnName defines an error of the invalid column name, which
+// could be checked by errors.As to get the column name.
+type ErrInvalidColumnName struct {
+	Column string
+}
+
+func (err ErrInvalidColumnName) Error() string {
+	return fmt.Sprintf("invalid column name %q", err.Column)
+}
+
+// ErrInvalidRowNumber defines an error of the invalid row number, which
+// could be checked by errors.As to get the row number.
+type ErrInvalidRowNumber struct {
+	Row int
+}
+
+func (err ErrInvalidRowNumber) Error() string {
+	return fmt.Sprintf("invalid row number %d", err.Row)
+}
+
+// ErrCell defines an error of getting or setting the cell, which records the
+// operation "get" or "set", the worksheet name and the cell reference where
+// the error occurred. The message of the error is the same as the underlying
+// error, use errors.As to get the location and the underlying error, for
+// example:
+//
+//    if err := f.SetCellValue("Sheet1", "A0", 1); err != nil {
+//        var cellErr excelize.ErrCell
+//        if errors.As(err, &cellErr) {
+//            fmt.Println(cellErr.Op, cellErr.Sheet, cellErr.Cell)
+//        }
+//        var nameErr excelize.ErrInvalidCellName
+//        if errors.As(err, &nameErr) {
+//            fmt.Println("invalid cell name", nameErr.Cell)
+//        }
+//    }
+//
+type ErrCell struct {
+	Op    string
+	Sheet string
+	Cell  string
+	Err   error
+}
+
+func (err ErrCell) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err ErrCell) Unwrap() error {
+	return err.Err
+}
+
+// newCellError provides a function to wrap the error of getting or setting
+// the cell with the location by given operation, worksheet name, cell
+// reference and the error.
+func newCellError(op, sheet, cell string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return ErrCell{Op: op, Sheet: sheet, Cell: cell, Err: err}
+}
+
 func newInvalidColumnNameError(col string) error {
-	return fmt.Errorf("invalid column name %q", col)
+	return ErrInvalidColumnName{Column: col}
 }
 
 func newInvalidRowNumberError(row int) error {
-	return fmt.Errorf("invalid row number %d", row)
+	return ErrInvalidRowNumber{Row: row}
 }
 
 func newInvalidCellNameError(cell string) error {
-	return fmt.Errorf("invalid cell name %q", cell)
+	return ErrInvalidCellName{Cell: cell}
 }
 
 func newInvalidExcelDateError(dateValue float64) error {
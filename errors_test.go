@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,3 +24,48 @@ func TestNewInvalidCellNameError(t *testing.T) {
 func TestNewInvalidExcelDateError(t *testing.T) {
 	assert.EqualError(t, newInvalidExcelDateError(-1), "invalid date value -1.000000, negative values are not supported supported")
 }
+
+func TestErrCell(t *testing.T) {
+	f := NewFile()
+	err := f.SetCellValue("Sheet1", "A0", 1)
+	assert.EqualError(t, err, `cannot convert cell "A0" to coordinates: invalid cell name "A0"`)
+	var cellErr ErrCell
+	assert.True(t, errors.As(err, &cellErr))
+	assert.Equal(t, "set", cellErr.Op)
+	assert.Equal(t, "Sheet1", cellErr.Sheet)
+	assert.Equal(t, "A0", cellErr.Cell)
+	var nameErr ErrInvalidCellName
+	assert.True(t, errors.As(err, &nameErr))
+	assert.Equal(t, "A0", nameErr.Cell)
+
+	_, err = f.GetCellValue("Sheet1", "A")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.True(t, errors.As(err, &cellErr))
+	assert.Equal(t, ErrCell{Op: "get", Sheet: "Sheet1", Cell: "A", Err: cellErr.Err}, cellErr)
+	assert.True(t, errors.As(err, &nameErr))
+	assert.Equal(t, "A", nameErr.Cell)
+	var colErr ErrInvalidColumnName
+	_, err = ColumnNameToNumber("-")
+	assert.True(t, errors.As(err, &colErr))
+	assert.Equal(t, "-", colErr.Column)
+
+	// Test the error of the worksheet operation on the chart sheet.
+	assert.NoError(t, f.AddChartSheet("Chart1", `{"type":"col","series":[{"name":"Sheet1!$A$1","values":"Sheet1!$B$1"}]}`))
+	var chartErr ErrChartSheet
+	assert.True(t, errors.As(f.SetCellValue("Chart1", "A1", 1), &chartErr))
+	assert.Equal(t, "Chart1", chartErr.SheetName)
+
+	// Test the error of the worksheet which is not exist.
+	err = f.SetCellValue("SheetN", "A1", 1)
+	var sheetErr ErrSheetNotExist
+	assert.True(t, errors.As(err, &sheetErr))
+	assert.Equal(t, "SheetN", sheetErr.SheetName)
+	assert.False(t, errors.As(err, &cellErr))
+	_, err = f.NewStreamWriter("SheetN")
+	assert.True(t, errors.As(err, &sheetErr))
+
+	var rowErr ErrInvalidRowNumber
+	assert.True(t, errors.As(f.SetRowHeight("Sheet1", 0, 10), &rowErr))
+	assert.Equal(t, 0, rowErr.Row)
+	assert.Nil(t, newCellError("get", "Sheet1", "A1", nil))
+}
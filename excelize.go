@@ -15,6 +15,7 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -25,20 +26,45 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/net/html/charset"
 )
 
-// File define a populated spreadsheet file struct.
+// File define a populated spreadsheet file struct. The functions which read
+// the spreadsheet, such as GetRows, GetCols, GetCellValue, GetCellFormula,
+// GetCellStyle, SearchSheet and CalcCellValue, are safe to be called by
+// multiple goroutines concurrently on the same File, the worksheets, shared
+// strings and the other parts will be deserialized only once on first access.
+// The Rows and Cols iterators could be created concurrently, but each of
+// them should be used by a single goroutine. The functions which modify the
+// spreadsheet, except setting the cell values, should not be called
+// concurrently with any other functions.
 type File struct {
 	sync.Mutex
+	mu               sync.Mutex
 	options          *Options
+	timeLocation     *time.Location
 	xmlAttr          map[string][]xml.Attr
 	checked          map[string]bool
+	lazyParts        map[string]*zip.File
+	source           *os.File
+	tempFiles        map[string]string
+	maxInMemoryPart  int64
+	inlineStrings    bool
+	sharedStrLimit   int
+	calcCache        map[string]formulaArg
+	progress         func(stage string, current, total int)
 	sheetMap         map[string]string
 	streams          map[string]*StreamWriter
 	CalcChain        *xlsxCalcChain
 	Comments         map[string]*xlsxComments
+	threadedComments map[string]*xlsxThreadedComments
+	persons          *xlsxPersonList
+	metadata         *xlsxMetadata
+	richValue        *xlsxRichValueData
+	richValueStruct  *xlsxRichValueStructures
+	richValueRels    *xlsxRichValueRels
 	ContentTypes     *xlsxTypes
 	Drawings         map[string]*xlsxWsDr
 	Path             string
@@ -58,9 +84,113 @@ type File struct {
 
 type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, err error)
 
-// Options define the options for open spreadsheet.
+// Options define the options for open and create spreadsheet. Password
+// specifies the password of the encrypted spreadsheet. TimeLocation specifies
+// the time zone used to normalize the time.Time values: the values will be
+// converted to the wall clock time in this location when writing, and the
+// times read by GetCellTime will be in this location. The values of
+// time.Time must be in UTC if the TimeLocation is not specified. For example,
+// store the time.Time values in the local time zone:
+//
+//    f := excelize.NewFile(excelize.Options{TimeLocation: time.Local})
+//
+// MaxInMemoryPartSize specifies the maximum size in bytes of the decompressed
+// or serialized package part kept in memory, the larger parts will be stored
+// in the temporary files when opening and saving the spreadsheet, and the
+// StreamWriter will use it as the size of the in-memory buffer. The default
+// value 0 means no limit. The file opened by OpenFile with this option will
+// be read in place instead of being loaded into memory. Call Close to remove
+// the temporary files and close the file after using the spreadsheet. For
+// example, open a large spreadsheet with 16 MB memory limit of each part:
+//
+//    f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{MaxInMemoryPartSize: 16 << 20})
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    defer f.Close()
+//
+// InlineStrings specifies to set the string cells as the inline strings
+// instead of adding them to the shared string table, and SharedStringsLimit
+// specifies the maximum number of the unique strings in the shared string
+// table, the new strings beyond it will be set as the inline strings, the
+// default value 0 means no limit. The shared string table deduplicates the
+// repeated strings, but it costs a lot of time and memory for the workbook
+// with a large number of unique strings. For example, set the string cells
+// as the inline strings:
+//
+//    f := excelize.NewFile(excelize.Options{InlineStrings: true})
+//
+// CacheCalcResults specifies to cache the results of CalcCellValue by the
+// cell, including the results of the precedent cells which contain formulas,
+// so the cell will not be calculated again when getting its result or the
+// result of its dependents repeatedly. The cached results will be cleared
+// once the spreadsheet has been changed by the functions which set the cell
+// values, formulas or styles, defined names or the structure of the
+// worksheets, such as SetCellValue, SetCellFormula, InsertRow and
+// CalcWorkbook. For example, calculate the cells of a spreadsheet with the
+// cache:
+//
+//    f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{CacheCalcResults: true})
+//
+// Minify specifies to minimize the spreadsheet when saving it by SaveAs. The
+// empty cells and rows, the redundant column definitions, the entries of the
+// calculation chain without formulas, the unused cell styles, fonts, fills,
+// borders and number formats, the relationships to the missing parts and the
+// media which are not referenced will be removed. The cell styles will be
+// renumbered, so the style indexes got before saving should not be used
+// after that. For example:
+//
+//    err := f.SaveAs("Book1.xlsx", excelize.Options{Minify: true})
+//
+// Progress specifies the function to be called to report the progress of
+// the long running operations by given stage, the number of the processed
+// items and the total number of the items. The stage "open" reports the
+// package parts read by OpenFile and OpenReader, "save" reports the package
+// parts written by Save, SaveAs and the Write functions, "rows" reports the
+// rows read by GetRows and GetRowsContext, and "calc" reports the formula
+// cells calculated by CalcWorkbook. The function will be called in the
+// goroutine which runs the operation. Use the context functions, such as
+// OpenReaderContext, WriteContext, GetRowsContext and CalcWorkbook to abort
+// these operations. For example, print the progress of saving the
+// spreadsheet:
+//
+//    err := f.SaveAs("Book1.xlsx", excelize.Options{
+//        Progress: func(stage string, current, total int) {
+//            fmt.Printf("%s: %d/%d\n", stage, current, total)
+//        },
+//    })
+//
+// Repair specifies to repair the common corruptions of the spreadsheet
+// generated by the third-party applications when opening it by OpenFile and
+// OpenReader, instead of failing or producing the spreadsheet which can't be
+// opened by Excel. The characters which are not allowed in XML will be
+// removed, the dimension references of the worksheets which are out of range
+// will be fixed, the duplicate relationship IDs will be renamed or removed,
+// and the missing content type overrides of the parts will be added. Note
+// that the repaired parts will be kept in memory. RepairLog specifies the
+// function to be called with the path of the part and the description of
+// each repair. For example, open a spreadsheet in repair mode and print the
+// repairs:
+//
+//    f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{
+//        Repair: true,
+//        RepairLog: func(part, description string) {
+//            fmt.Printf("%s: %s\n", part, description)
+//        },
+//    })
+//
 type Options struct {
-	Password string
+	Password            string
+	TimeLocation        *time.Location
+	MaxInMemoryPartSize int64
+	InlineStrings       bool
+	SharedStringsLimit  int
+	CacheCalcResults    bool
+	Minify              bool
+	Progress            func(stage string, current, total int)
+	Repair              bool
+	RepairLog           func(part, description string)
 }
 
 // OpenFile take the name of an spreadsheet file and returns a populated spreadsheet file struct
@@ -73,16 +203,60 @@ type Options struct {
 //
 // Note that the excelize just support decrypt and not support encrypt currently, the spreadsheet
 // saved by Save and SaveAs will be without password unprotected.
+//
+// The spreadsheet larger than 4 GB or containing the parts larger than 4 GB
+// with the ZIP64 extensions is supported, use the MaxInMemoryPartSize option
+// to keep such large parts out of memory. The file will be read into memory
+// and closed before returning, unless the MaxInMemoryPartSize option is
+// specified, the file will be kept open and read in place, call Close to
+// close it after using the spreadsheet, and the file should not be changed
+// until then.
+//
+// The OpenDocument Spreadsheet (.ods) file will be converted to the workbook
+// with the values, formulas, merged cells and hidden rows and columns, so the
+// functions such as GetRows and GetCellValue can be used on it. The XLSB
+// binary workbook (.xlsb) will be converted in the same way with the values,
+// shared strings and styles, and the formulas will be kept as the cached
+// values. Note that the workbook will be saved in XLSX format, use SaveAs with the .xlsx extension
+// instead of Save to keep the original file.
 func OpenFile(filename string, opt ...Options) (*File, error) {
+	var inPlace bool
+	for _, o := range opt {
+		inPlace = o.MaxInMemoryPartSize > 0
+	}
+	if !inPlace {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		f, err := openReaderAt(bytes.NewReader(b), int64(len(b)), opt...)
+		if err != nil {
+			return nil, err
+		}
+		f.Path = filename
+		return f, nil
+	}
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-	f, err := OpenReader(file, opt...)
+	stat, err := file.Stat()
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
+	f, err := openReaderAt(file, stat.Size(), opt...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	// Keep the file open for reading the parts which have not been
+	// decompressed, it will be closed by the Close function.
+	if len(f.lazyParts) == 0 {
+		file.Close()
+	} else {
+		f.source = file
+	}
 	f.Path = filename
 	return f, nil
 }
@@ -94,6 +268,7 @@ func newFile() *File {
 		checked:          make(map[string]bool),
 		sheetMap:         make(map[string]string),
 		Comments:         make(map[string]*xlsxComments),
+		threadedComments: make(map[string]*xlsxThreadedComments),
 		Drawings:         make(map[string]*xlsxWsDr),
 		sharedStringsMap: make(map[string]int),
 		Sheet:            make(map[string]*xlsxWorksheet),
@@ -105,32 +280,91 @@ func newFile() *File {
 }
 
 // OpenReader read data stream from io.Reader and return a populated
-// spreadsheet file.
+// spreadsheet file. The worksheet parts are kept compressed and will only be
+// decompressed and parsed when the worksheet is first accessed, use
+// ReleaseSheet to release the parsed worksheet which is no longer used. If
+// the reader implements io.ReaderAt with the Size method, such as
+// *bytes.Reader and *io.SectionReader, the package will be read in place
+// without loading the whole package into memory, and the reader should not
+// be changed until the spreadsheet is no longer used.
 func OpenReader(r io.Reader, opt ...Options) (*File, error) {
+	if ra, ok := r.(sizeReaderAt); ok {
+		return openReaderAt(ra, ra.Size(), opt...)
+	}
 	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
+	return openReaderAt(bytes.NewReader(b), int64(len(b)), opt...)
+}
+
+// sizeReaderAt directly maps the io.ReaderAt with the size of the content.
+type sizeReaderAt interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// openReaderAt provides a function to read the package from io.ReaderAt by
+// given size of the package and return a populated spreadsheet file. The
+// ZIP central directory is read in place, so the package larger than 4 GB
+// with the ZIP64 extensions will not be loaded into memory, only the
+// encrypted package will be read entirely for decrypting.
+func openReaderAt(r io.ReaderAt, size int64, opt ...Options) (*File, error) {
 	f := newFile()
-	if bytes.Contains(b, oleIdentifier) && len(opt) > 0 {
+	for _, o := range opt {
+		f.setOptions(o)
+	}
+	header := make([]byte, len(oleIdentifier))
+	if n, _ := r.ReadAt(header, 0); n == len(header) && bytes.Equal(header, oleIdentifier) && len(opt) > 0 {
 		for _, o := range opt {
 			f.options = &o
 		}
-		b, err = Decrypt(b, f.options)
+		b, err := ioutil.ReadAll(io.NewSectionReader(r, 0, size))
 		if err != nil {
+			return nil, err
+		}
+		if b, err = Decrypt(b, f.options); err != nil {
 			return nil, fmt.Errorf("decrypted file failed")
 		}
+		r, size = bytes.NewReader(b), int64(len(b))
 	}
-	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, err
 	}
 
-	file, sheetCount, err := ReadZipReader(zr)
+	file, lazyParts, sheetCount, err := readZipReader(zr, func(name string, size int64) bool {
+		return isWorksheetPart(name) || (f.maxInMemoryPart > 0 && size > f.maxInMemoryPart)
+	}, func(current, total int) {
+		f.reportProgress("open", current, total)
+	})
 	if err != nil {
 		return nil, err
 	}
-	f.SheetCount, f.XLSX = sheetCount, file
+	if isODS(file) || isXLSB(file) {
+		for name, part := range lazyParts {
+			if file[name], err = readFile(part); err != nil {
+				return nil, err
+			}
+		}
+		if isODS(file) {
+			return openODS(file, opt...)
+		}
+		return openXLSB(file, opt...)
+	}
+	f.SheetCount, f.XLSX, f.lazyParts = sheetCount, file, lazyParts
+	for _, o := range opt {
+		if !o.Repair {
+			continue
+		}
+		if err = f.repairPackage(o.RepairLog); err != nil {
+			return nil, err
+		}
+	}
+	if err = f.extractTempFiles(); err != nil {
+		f.Close()
+		return nil, err
+	}
 	f.CalcChain = f.calcChainReader()
 	f.sheetMap = f.getSheetMap()
 	f.Styles = f.stylesReader()
@@ -138,6 +372,53 @@ func OpenReader(r io.Reader, opt ...Options) (*File, error) {
 	return f, nil
 }
 
+// setOptions provides a function to set the options of the spreadsheet which
+// are kept for the whole life cycle of the file.
+func (f *File) setOptions(o Options) {
+	f.timeLocation, f.maxInMemoryPart = o.TimeLocation, o.MaxInMemoryPartSize
+	f.inlineStrings, f.sharedStrLimit = o.InlineStrings, o.SharedStringsLimit
+	f.progress = o.Progress
+	f.calcCache = nil
+	if o.CacheCalcResults {
+		f.calcCache = make(map[string]formulaArg)
+	}
+}
+
+// reportProgress provides a function to report the progress of the long
+// running operation by given stage, the number of the processed items and
+// the total number of the items, if the Progress option is specified.
+func (f *File) reportProgress(stage string, current, total int) {
+	if f.progress != nil {
+		f.progress(stage, current, total)
+	}
+}
+
+// OpenReaderContext read data stream from io.Reader with the context and
+// return a populated spreadsheet file. The reading will be stopped with the
+// error of the context once the context is canceled or its deadline is
+// exceeded. For example, open the spreadsheet uploaded by the HTTP request
+// with the request context:
+//
+//    func handler(w http.ResponseWriter, r *http.Request) {
+//        f, err := excelize.OpenReaderContext(r.Context(), r.Body)
+//        if err != nil {
+//            http.Error(w, err.Error(), http.StatusBadRequest)
+//            return
+//        }
+//        // ...
+//    }
+//
+func OpenReaderContext(ctx context.Context, r io.Reader, opt ...Options) (*File, error) {
+	f, err := OpenReader(contextReader{ctx: ctx, r: r}, opt...)
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
 // CharsetTranscoder Set user defined codepage transcoder function for open
 // XLSX from non UTF-8 encoding.
 func (f *File) CharsetTranscoder(fn charsetTranscoderFn) *File { f.CharsetReader = fn; return f }
@@ -175,20 +456,25 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	)
 
 	if name, ok = f.sheetMap[trimSheetName(sheet)]; !ok {
-		err = fmt.Errorf("sheet %s is not exist", sheet)
+		err = ErrSheetNotExist{sheet}
 		return
 	}
 	if ws = f.Sheet[name]; f.Sheet[name] == nil {
 		if strings.HasPrefix(name, "xl/chartsheets") {
-			err = fmt.Errorf("sheet %s is chart sheet", sheet)
+			err = ErrChartSheet{sheet}
 			return
 		}
 		ws = new(xlsxWorksheet)
+		var content []byte
+		if content, err = f.readPart(name); err != nil {
+			return
+		}
+		content = namespaceStrictToTransitional(content)
 		if _, ok := f.xmlAttr[name]; !ok {
-			d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(name))))
+			d := f.xmlNewDecoder(bytes.NewReader(content))
 			f.xmlAttr[name] = append(f.xmlAttr[name], getRootElement(d)...)
 		}
-		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(name)))).
+		if err = f.xmlNewDecoder(bytes.NewReader(content)).
 			Decode(ws); err != nil && err != io.EOF {
 			err = fmt.Errorf("xml decode error: %s", err)
 			return
@@ -308,13 +594,14 @@ func (f *File) addRels(relPath, relType, target, targetMode string) int {
 //    </row>
 //
 func (f *File) UpdateLinkedValue() error {
+	f.clearCalcCache()
 	wb := f.workbookReader()
 	// recalculate formulas
 	wb.CalcPr = nil
 	for _, name := range f.GetSheetList() {
 		xlsx, err := f.workSheetReader(name)
 		if err != nil {
-			if err.Error() == fmt.Sprintf("sheet %s is chart sheet", trimSheetName(name)) {
+			if errors.As(err, &ErrChartSheet{}) {
 				continue
 			}
 			return err
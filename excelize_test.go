@@ -1,14 +1,17 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"image/color"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
+	"io"
 	"io/ioutil"
 	"math"
 	"os"
@@ -247,6 +250,129 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, "zip: unsupported compression algorithm")
 }
 
+func TestOpenFileWithoutClose(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	path := filepath.Join("test", "TestOpenFileWithoutClose.xlsx")
+	assert.NoError(t, ioutil.WriteFile(path, b, 0644))
+	f, err := OpenFile(path)
+	assert.NoError(t, err)
+	// Test the file is not kept open, so it could be removed without Close.
+	assert.Nil(t, f.source)
+	assert.NoError(t, os.Remove(path))
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.SaveAs(path))
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet2", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "17-20 Inch", val)
+}
+
+func TestOpenReaderLazyWorksheets(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	// Test the worksheet parts are not decompressed at open.
+	assert.Nil(t, f.XLSX["xl/worksheets/sheet1.xml"])
+	assert.Contains(t, f.lazyParts, "xl/worksheets/sheet1.xml")
+	assert.NotNil(t, f.XLSX["xl/worksheets/_rels/sheet1.xml.rels"])
+	assert.Empty(t, f.Sheet)
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.Contains(t, f.Sheet, "xl/worksheets/sheet1.xml")
+	assert.Nil(t, f.XLSX["xl/worksheets/sheet1.xml"])
+	rows, err := f.Rows("Sheet2")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	assert.Nil(t, f.XLSX["xl/worksheets/sheet2.xml"])
+	// Test save the workbook with the worksheets which have not been parsed.
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderLazyWorksheets.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestOpenReaderLazyWorksheets.xlsx"))
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet2", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "17-20 Inch", val)
+
+	// Test open and save the workbook with unsupported compression method of
+	// the worksheet.
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	zw.RegisterCompressor(99, func(w io.Writer) (io.WriteCloser, error) { return nopWriteCloser{w}, nil })
+	fi, err := zw.CreateHeader(&zip.FileHeader{Name: "xl/worksheets/sheet1.xml", Method: 99})
+	assert.NoError(t, err)
+	_, err = fi.Write([]byte("<worksheet/>"))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	b := buf.Bytes()
+	_, err = OpenReader(bytes.NewReader(b))
+	assert.EqualError(t, err, "zip: unsupported compression algorithm")
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	f = NewFile()
+	f.XLSX["xl/worksheets/sheet2.xml"], f.lazyParts = nil, map[string]*zip.File{"xl/worksheets/sheet2.xml": zr.File[0]}
+	_, err = f.WriteToBuffer()
+	assert.EqualError(t, err, "zip: unsupported compression algorithm")
+
+	// Test read the worksheet which could not be decompressed.
+	buf = new(bytes.Buffer)
+	zw = zip.NewWriter(buf)
+	fi, err = zw.Create("xl/worksheets/sheet1.xml")
+	assert.NoError(t, err)
+	_, err = fi.Write([]byte(`<worksheet><sheetData><row r="1"><c r="A1"><v>1</v></c></row></sheetData></worksheet>`))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	b = buf.Bytes()
+	zr, err = zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	offset, err := zr.File[0].DataOffset()
+	assert.NoError(t, err)
+	b[offset+4] ^= 0xFF
+	f = NewFile()
+	f.XLSX["xl/worksheets/sheet1.xml"], f.Sheet = nil, map[string]*xlsxWorksheet{}
+	f.lazyParts = map[string]*zip.File{"xl/worksheets/sheet1.xml": zr.File[0]}
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.Error(t, err)
+	_, err = f.GetRows("Sheet1")
+	assert.Error(t, err)
+	_, err = f.Rows("Sheet1")
+	assert.Error(t, err)
+	assert.Nil(t, f.Sheet["xl/worksheets/sheet1.xml"])
+}
+
+// nopWriteCloser directly maps the io.Writer with a no-op Close method.
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestOpenReaderContext(t *testing.T) {
+	buf, err := NewFile().WriteToBuffer()
+	assert.NoError(t, err)
+	f, err := OpenReaderContext(context.Background(), bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+
+	// Test open reader with the canceled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = OpenReaderContext(ctx, bytes.NewReader(buf.Bytes()))
+	assert.EqualError(t, err, "context canceled")
+	// Test open reader with the context canceled after reading.
+	ctx, cancel = context.WithCancel(context.Background())
+	_, err = OpenReaderContext(ctx, io.MultiReader(bytes.NewReader(buf.Bytes()), readerFunc(func([]byte) (int, error) {
+		cancel()
+		return 0, io.EOF
+	})))
+	assert.EqualError(t, err, "context canceled")
+}
+
+// readerFunc directly maps the function as io.Reader.
+type readerFunc func(p []byte) (int, error)
+
+func (fn readerFunc) Read(p []byte) (int, error) { return fn(p) }
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct.
 	f := File{}
@@ -312,7 +438,7 @@ func TestNewFile(t *testing.T) {
 func TestAddDrawingVML(t *testing.T) {
 	// Test addDrawingVML with illegal cell coordinates.
 	f := NewFile()
-	assert.EqualError(t, f.addDrawingVML(0, "", "*", 0, 0), `cannot convert cell "*" to coordinates: invalid cell name "*"`)
+	assert.EqualError(t, f.addDrawingVML("Sheet1", 0, "", "*", 0, 0, &formatComment{}), `cannot convert cell "*" to coordinates: invalid cell name "*"`)
 }
 
 func TestSetCellHyperLink(t *testing.T) {
@@ -392,6 +518,70 @@ func TestGetCellHyperLink(t *testing.T) {
 
 }
 
+func TestGetCellHyperLinkDetail(t *testing.T) {
+	f := NewFile()
+	display, tooltip := "Contact us", "Send an email"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "support@example.com", "Email", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!D9", "Location"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1:$A$10"}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "Amount", "DefinedName"))
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "A4", "Price", "DefinedName"), "defined name Price is not exist")
+
+	link, hyperlink, err := f.GetCellHyperLinkDetail("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, Hyperlink{Cell: "A1", Type: "Email", Target: "mailto:support@example.com", Display: display, Tooltip: tooltip}, hyperlink)
+	link, hyperlink, err = f.GetCellHyperLinkDetail("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, Hyperlink{Cell: "A2", Type: "Location", Location: "Sheet1!D9"}, hyperlink)
+	link, hyperlink, err = f.GetCellHyperLinkDetail("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Amount", hyperlink.Location)
+	link, _, err = f.GetCellHyperLinkDetail("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	// Test the relationship of the replaced external hyperlink has been removed.
+	assert.Len(t, f.relsReader("xl/worksheets/_rels/sheet1.xml.rels").Relationships, 1)
+	assert.Len(t, f.Sheet["xl/worksheets/sheet1.xml"].Hyperlinks.Hyperlink, 3)
+
+	_, _, err = f.GetCellHyperLinkDetail("Sheet1", "")
+	assert.EqualError(t, err, `invalid cell name ""`)
+	_, _, err = f.GetCellHyperLinkDetail("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	_, _, err = f.GetCellHyperLinkDetail("Sheet1", "A1")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestRemoveCellHyperLink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!D9", "Location"))
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "A1"))
+	link, _, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	assert.Len(t, f.relsReader("xl/worksheets/_rels/sheet1.xml.rels").Relationships, 0)
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "A2"))
+	assert.Nil(t, f.Sheet["xl/worksheets/sheet1.xml"].Hyperlinks)
+	// Test remove not exists hyperlink.
+	assert.NoError(t, f.RemoveCellHyperLink("Sheet1", "A3"))
+
+	assert.EqualError(t, f.RemoveCellHyperLink("Sheet1", ""), `invalid cell name ""`)
+	assert.EqualError(t, f.RemoveCellHyperLink("SheetN", "A1"), "sheet SheetN is not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCellHyperLink.xlsx")))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.EqualError(t, f.RemoveCellHyperLink("Sheet1", "A1"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
 func TestSetCellFormula(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -0,0 +1,186 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/xuri/efp"
+)
+
+// ExportSheets provides a function to create a new workbook which only
+// contains the given worksheets of the workbook, the workbook will not be
+// changed. The styles, the defined names, the media and the other parts used
+// by the given worksheets will be carried over. The formulas referencing the
+// other worksheets will be frozen to the values: the cached values of the
+// cells will be kept, and the cells without the cached values will be
+// calculated by CalcCellValue before removing the other worksheets, the cells
+// which could not be calculated will be blank. The formulas referencing
+// the given worksheets will be kept as they are, and the defined names
+// referencing the other worksheets will be removed. The unused styles and
+// media could be removed by saving the new workbook with the Minify option.
+// For example, send the worksheet of a customer in the master workbook:
+//
+//    nf, err := f.ExportSheets("ACME")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    err = nf.SaveAs("ACME.xlsx", excelize.Options{Minify: true})
+//
+func (f *File) ExportSheets(sheets ...string) (*File, error) {
+	if len(sheets) == 0 {
+		return nil, errors.New("no worksheets to export")
+	}
+	keep := map[string]bool{}
+	for _, sheet := range sheets {
+		if f.GetSheetIndex(sheet) == -1 {
+			return nil, ErrSheetNotExist{sheet}
+		}
+		keep[strings.ToLower(trimSheetName(sheet))] = true
+	}
+	// Write the workbook without the options of the last saving, such as
+	// the password and the Minify option.
+	options, buf := f.options, new(bytes.Buffer)
+	f.options = nil
+	err := f.writeToZip(context.Background(), zip.NewWriter(buf))
+	f.options = options
+	if err != nil {
+		return nil, err
+	}
+	nf, err := OpenReader(buf)
+	if err != nil {
+		return nil, err
+	}
+	nf.CharsetReader, nf.timeLocation, nf.progress = f.CharsetReader, f.timeLocation, f.progress
+	nf.inlineStrings, nf.sharedStrLimit = f.inlineStrings, f.sharedStrLimit
+	var kept, removed []string
+	removedSheets, removedNames := map[string]bool{}, map[string]bool{}
+	for _, sheet := range nf.GetSheetList() {
+		if keep[strings.ToLower(sheet)] {
+			kept = append(kept, sheet)
+			continue
+		}
+		removed = append(removed, sheet)
+		removedSheets[strings.ToLower(sheet)] = true
+	}
+	// The defined names referencing the removed worksheets or the other
+	// removed defined names will be removed.
+	wb := nf.workbookReader()
+	if wb.DefinedNames != nil {
+		for changed := true; changed; {
+			changed = false
+			for _, dn := range wb.DefinedNames.DefinedName {
+				name := strings.ToLower(dn.Name)
+				if !removedNames[name] && formulaReferencesRemoved(dn.Data, removedSheets, removedNames) {
+					removedNames[name], changed = true, true
+				}
+			}
+		}
+	}
+	for _, sheet := range kept {
+		if err = nf.freezeSheetFormulas(sheet, removedSheets, removedNames); err != nil {
+			return nil, err
+		}
+	}
+	if wb.DefinedNames != nil {
+		var definedNames []xlsxDefinedName
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if !formulaReferencesRemoved(dn.Data, removedSheets, removedNames) {
+				definedNames = append(definedNames, dn)
+			}
+		}
+		wb.DefinedNames.DefinedName = definedNames
+	}
+	for _, sheet := range removed {
+		nf.DeleteSheet(sheet)
+	}
+	return nf, nil
+}
+
+// freezeSheetFormulas provides a function to replace the formulas which
+// reference the removed worksheets or defined names by the values in the
+// worksheet by given worksheet name, the lower case names of the removed
+// worksheets and defined names.
+func (f *File) freezeSheetFormulas(sheet string, sheets, names map[string]bool) error {
+	if strings.HasPrefix(f.sheetMap[sheet], "xl/chartsheets") {
+		return nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var cells []string
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F != nil {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	// Check all the formulas before freezing, since the shared formulas
+	// depend on the master cells.
+	var frozen []string
+	for _, cell := range cells {
+		formula, err := f.GetCellFormula(sheet, cell)
+		if err != nil {
+			return err
+		}
+		if formulaReferencesRemoved(formula, sheets, names) {
+			frozen = append(frozen, cell)
+		}
+	}
+	for _, cell := range frozen {
+		c, _, _, err := f.prepareCell(ws, sheet, cell)
+		if err != nil {
+			return err
+		}
+		if c.V == "" {
+			if result, err := f.calcCellValue(newCalcContext(), sheet, cell); err == nil {
+				if err = f.setCellCalcValue(sheet, cell, result); err != nil {
+					return err
+				}
+			}
+		}
+		c.F = nil
+	}
+	return nil
+}
+
+// formulaReferencesRemoved provides a function to check if the formula
+// references any of the removed worksheets or defined names by given formula,
+// the lower case names of the removed worksheets and defined names, such as
+// Sheet1!A1, 'Sheet 1'!A1:B2 or the defined name which refers to them. The
+// names of the worksheets and the defined names are case-insensitive.
+func formulaReferencesRemoved(formula string, sheets, names map[string]bool) bool {
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		ref := strings.ToLower(token.TValue)
+		if idx := strings.LastIndex(ref, "!"); idx != -1 {
+			if sheets[ref[:idx]] {
+				return true
+			}
+			continue
+		}
+		if names[ref] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSheets(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Data Sheet")
+	f.NewSheet("ACME")
+	assert.NoError(t, f.SetSheetRow("Data Sheet", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("ACME", "A1", &[]interface{}{10, 20}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	for cell, formula := range map[string]string{
+		"A2": "'Data Sheet'!A1+A1", "B2": "SUM(A1:B1)", "C2": "Sheet1!A1+5", "D2": "'Data Sheet'!C1*2",
+	} {
+		assert.NoError(t, f.SetCellFormula("ACME", cell, formula))
+	}
+	ws, err := f.workSheetReader("ACME")
+	assert.NoError(t, err)
+	ws.SheetData.Row[1].C[0].V = "11"
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Data", RefersTo: "'Data Sheet'!$A$1:$C$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Values", RefersTo: "ACME!$A$1:$B$1"}))
+	assert.NoError(t, f.AddPicture("ACME", "E1", filepath.Join("test", "images", "excel.png"), ""))
+	f.options = &Options{Minify: true}
+
+	nf, err := f.ExportSheets("ACME")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ACME"}, nf.GetSheetList())
+	assert.Equal(t, []string{"Sheet1", "Data Sheet", "ACME"}, f.GetSheetList())
+	assert.Equal(t, &Options{Minify: true}, f.options)
+	assert.NoError(t, nf.SaveAs(filepath.Join("test", "TestExportSheets.xlsx"), Options{Minify: true}))
+
+	nf, err = OpenFile(filepath.Join("test", "TestExportSheets.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string][2]string{
+		"A2": {"", "11"}, "B2": {"SUM(A1:B1)", ""}, "C2": {"", "6"}, "D2": {"", "6"},
+	} {
+		formula, err := nf.GetCellFormula("ACME", cell)
+		assert.NoError(t, err)
+		val, err := nf.GetCellValue("ACME", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, [2]string{formula, val}, cell)
+	}
+	assert.Equal(t, []DefinedName{{Name: "Values", RefersTo: "ACME!$A$1:$B$1", Scope: "Workbook"}}, nf.GetDefinedName())
+	pic, _, err := nf.GetPicture("ACME", "E1")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pic)
+	assert.NoError(t, nf.Close())
+
+	// Test export the worksheets which are not exist.
+	_, err = f.ExportSheets()
+	assert.EqualError(t, err, "no worksheets to export")
+	_, err = f.ExportSheets("ACME", "SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test export the worksheets with the invalid part.
+	f.XLSX["/d/"] = []byte("s")
+	_, err = f.ExportSheets("ACME")
+	assert.EqualError(t, err, "zip: write to directory")
+	delete(f.XLSX, "/d/")
+	// Test export the worksheets with the unsupported charset worksheet.
+	f = NewFile()
+	f.NewSheet("Sheet2")
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	f.Sheet = map[string]*xlsxWorksheet{}
+	f.checked = nil
+	_, err = f.ExportSheets("Sheet1")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestFormulaReferencesRemoved(t *testing.T) {
+	sheets, names := map[string]bool{"sheet1": true, "it's": true}, map[string]bool{"rate": true}
+	assert.True(t, formulaReferencesRemoved("Sheet1!A1", sheets, names))
+	assert.True(t, formulaReferencesRemoved("SUM('It''s'!A1:B2)", sheets, names))
+	assert.True(t, formulaReferencesRemoved("'Sheet1'!A1", sheets, names))
+	assert.True(t, formulaReferencesRemoved("sheet1!A1", sheets, names))
+	assert.True(t, formulaReferencesRemoved("RATE*2", sheets, names))
+	assert.False(t, formulaReferencesRemoved("MySheet1!A1+A1", sheets, names))
+	assert.False(t, formulaReferencesRemoved("'My Sheet1'!A1", sheets, names))
+	assert.False(t, formulaReferencesRemoved("\"Sheet1!A1\"&Rates", sheets, names))
+}
+
+func TestExportSheetsWithDefinedNames(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Data")
+	assert.NoError(t, f.SetCellValue("Data", "A1", 3))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "Data!$A$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Double", RefersTo: "Rate*2"}))
+	for cell, formula := range map[string]string{"A1": "data!A1+1", "B1": "Rate*2", "C1": "Double+1"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	nf, err := f.ExportSheets("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1"}, nf.GetSheetList())
+	assert.Empty(t, nf.GetDefinedName())
+	for _, cell := range []string{"A1", "B1", "C1"} {
+		formula, err := nf.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	val, err := nf.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", val)
+}
@@ -0,0 +1,247 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ExternalReference directly maps a formula or a defined name which refers
+// to an external workbook. For the cell formulas, Sheet is the worksheet name
+// and Cell is the cell reference, the formula of the cells in a shared formula
+// is the formula of the master cell. For the defined names, DefinedName is
+// the name and Sheet is the scope of it. Value is the cached value of the
+// formula, the defined names have no cached value.
+type ExternalReference struct {
+	Sheet       string
+	Cell        string
+	DefinedName string
+	Formula     string
+	Value       string
+}
+
+// FreezeOptions directly maps the settings of freezing the external
+// references. Value is called with each external reference, the returned
+// value will be used instead of the cached value, the cached value will be
+// kept if it returns nil.
+type FreezeOptions struct {
+	Value func(ref ExternalReference) (interface{}, error)
+}
+
+// FreezeExternalReferences provides a function to replace the formulas which
+// refer to the external workbooks with their cached values or the values
+// returned by the Value function in the options, and remove the external
+// workbook references, which produces a self-contained workbook that could be
+// distributed without the linked files. The defined names which refer to the
+// external workbooks will be replaced with the constant values returned by
+// the Value function, or the #REF! error if there is no value. It returns the
+// external references which have been frozen. For example, freeze the
+// external references and use the value 0 for the defined names:
+//
+//    refs, err := f.FreezeExternalReferences(excelize.FreezeOptions{
+//        Value: func(ref excelize.ExternalReference) (interface{}, error) {
+//            if ref.DefinedName != "" {
+//                return 0, nil
+//            }
+//            return nil, nil
+//        },
+//    })
+//
+func (f *File) FreezeExternalReferences(opts ...FreezeOptions) ([]ExternalReference, error) {
+	var options FreezeOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	f.clearCalcCache()
+	var refs []ExternalReference
+	for _, sheet := range f.GetSheetList() {
+		name := f.sheetMap[trimSheetName(sheet)]
+		if strings.HasPrefix(name, "xl/chartsheets") {
+			continue
+		}
+		if _, ok := f.streams[name]; ok {
+			continue
+		}
+		sheetRefs, err := f.freezeSheetExternalReferences(sheet, options)
+		refs = append(refs, sheetRefs...)
+		if err != nil {
+			return refs, err
+		}
+	}
+	wb := f.workbookReader()
+	if wb.DefinedNames != nil {
+		for idx := range wb.DefinedNames.DefinedName {
+			dn := &wb.DefinedNames.DefinedName[idx]
+			if !hasExternalReference(dn.Data) {
+				continue
+			}
+			ref := ExternalReference{Sheet: "Workbook", DefinedName: dn.Name, Formula: dn.Data}
+			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
+				ref.Sheet = f.getSheetNameByID(*dn.LocalSheetID + 1)
+			}
+			var value interface{}
+			if options.Value != nil {
+				var err error
+				if value, err = options.Value(ref); err != nil {
+					return refs, err
+				}
+			}
+			dn.Data = definedNameConstant(value)
+			refs = append(refs, ref)
+		}
+	}
+	f.deleteExternalLinks()
+	return refs, nil
+}
+
+// freezeSheetExternalReferences provides a function to replace the formulas
+// which refer to the external workbooks in the worksheet with the values by
+// given worksheet name and the freeze options.
+func (f *File) freezeSheetExternalReferences(sheet string, options FreezeOptions) ([]ExternalReference, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		refs           []ExternalReference
+		sharedFormulas = map[string]string{}
+		sheetID        = f.getSheetID(sheet)
+	)
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		for colIdx := range row.C {
+			c := &row.C[colIdx]
+			formula := cellFormula(c, sharedFormulas)
+			if !hasExternalReference(formula) {
+				continue
+			}
+			cell := c.R
+			if cell == "" {
+				cell, _ = CoordinatesToCellName(colIdx+1, row.R)
+			}
+			refs = append(refs, ExternalReference{Sheet: sheet, Cell: cell, Formula: formula, Value: f.cellRawValue(c)})
+			c.F, c.CM = nil, 0
+			if c.T == "str" {
+				f.setCellString(c, c.V)
+			}
+			f.deleteCalcChain(sheetID, cell)
+		}
+	}
+	if options.Value == nil {
+		return refs, nil
+	}
+	for _, ref := range refs {
+		value, err := options.Value(ref)
+		if err != nil {
+			return refs, err
+		}
+		if value == nil {
+			continue
+		}
+		if err = f.SetCellValue(sheet, ref.Cell, value); err != nil {
+			return refs, err
+		}
+	}
+	return refs, nil
+}
+
+// hasExternalReference provides a function to check if the formula refers to
+// an external workbook, which is stored as the index of the external workbook
+// reference in the square brackets, such as [1]Sheet1!A1 or '[1]Sheet 1'!A1.
+// The square brackets in the string literals and the structured references
+// of the tables will be ignored.
+func hasExternalReference(formula string) bool {
+	var inString bool
+	var depth int
+	for i := 0; i < len(formula); i++ {
+		switch formula[i] {
+		case '"':
+			inString = !inString
+		case '[':
+			if inString {
+				continue
+			}
+			if depth > 0 || (i > 0 && isReferenceNameChar(formula[i-1])) {
+				depth++
+				continue
+			}
+			j := i + 1
+			for j < len(formula) && formula[j] >= '0' && formula[j] <= '9' {
+				j++
+			}
+			if j > i+1 && j < len(formula) && formula[j] == ']' {
+				return true
+			}
+			depth++
+		case ']':
+			if !inString && depth > 0 {
+				depth--
+			}
+		}
+	}
+	return false
+}
+
+// isReferenceNameChar provides a function to check if the character could be
+// a part of the table name in a structured reference.
+func isReferenceNameChar(c byte) bool {
+	return c == '_' || c == '.' || c == '\\' || c == ']' ||
+		(c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || c >= 0x80
+}
+
+// definedNameConstant provides a function to convert the value to the
+// constant formula of the defined name, it returns the #REF! error if the
+// value is nil.
+func definedNameConstant(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return string(CellErrorRef)
+	case string:
+		return `"` + strings.Replace(v, `"`, `""`, -1) + `"`
+	case []byte:
+		return `"` + strings.Replace(string(v), `"`, `""`, -1) + `"`
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// deleteExternalLinks provides a function to remove the external workbook
+// references and the external link parts of the workbook.
+func (f *File) deleteExternalLinks() {
+	wb := f.workbookReader()
+	if wb.ExternalReferences == nil {
+		return
+	}
+	for _, ref := range wb.ExternalReferences.ExternalReference {
+		target := f.deleteSheetFromWorkbookRels(ref.RID)
+		if target == "" {
+			continue
+		}
+		linkXML := path.Join("xl", target)
+		if strings.HasPrefix(target, "/") {
+			linkXML = strings.TrimPrefix(target, "/")
+		}
+		rels := path.Join(path.Dir(linkXML), "_rels", path.Base(linkXML)+".rels")
+		f.deleteSheetFromContentTypes(strings.TrimPrefix(linkXML, "xl/"))
+		delete(f.XLSX, linkXML)
+		delete(f.XLSX, rels)
+		delete(f.Relationships, rels)
+	}
+	wb.ExternalReferences = nil
+}
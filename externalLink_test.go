@@ -0,0 +1,147 @@
+package excelize
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareExternalLinkBook(t *testing.T) *File {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SUM('[1]Sales 2020'!B1:B10)", FormulaOpts{Type: stringPtr(STCellFormulaTypeShared), Ref: stringPtr("A2:A3")}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", ""))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", `IF(C1="[1]",Table1[[#This Row],[1]],1)`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", `CONCATENATE([1]Sheet1!C1,"x")`))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].V = "10"
+	ws.SheetData.Row[1].C[0].V, ws.SheetData.Row[1].C[0].F.Si = "55", "0"
+	ws.SheetData.Row[2].C[0].V, ws.SheetData.Row[2].C[0].F = "65", &xlsxF{T: STCellFormulaTypeShared, Si: "0"}
+	ws.SheetData.Row[1].C[1].T, ws.SheetData.Row[1].C[1].V = "str", "abcx"
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "[1]Sheet1!$D$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "Sheet1!$A$1"}))
+
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationship.Value+"/externalLink", "externalLinks/externalLink1.xml", "")
+	f.workbookReader().ExternalReferences = &xlsxExternalReferences{
+		ExternalReference: []xlsxExternalReference{{RID: "rId" + strconv.Itoa(rID)}},
+	}
+	content := f.contentTypesReader()
+	content.Overrides = append(content.Overrides, xlsxOverride{
+		PartName:    "/xl/externalLinks/externalLink1.xml",
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml",
+	})
+	f.XLSX["xl/externalLinks/externalLink1.xml"] = []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><externalBook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId1"/></externalLink>`)
+	f.XLSX["xl/externalLinks/_rels/externalLink1.xml.rels"] = []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath" Target="Book2.xlsx" TargetMode="External"/></Relationships>`)
+	return f
+}
+
+func TestFreezeExternalReferences(t *testing.T) {
+	f := prepareExternalLinkBook(t)
+	refs, err := f.FreezeExternalReferences()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalReference{
+		{Sheet: "Sheet1", Cell: "A1", Formula: "[1]Sheet1!A1*2", Value: "10"},
+		{Sheet: "Sheet1", Cell: "A2", Formula: "SUM('[1]Sales 2020'!B1:B10)", Value: "55"},
+		{Sheet: "Sheet1", Cell: "B2", Formula: `CONCATENATE([1]Sheet1!C1,"x")`, Value: "abcx"},
+		{Sheet: "Sheet1", Cell: "A3", Formula: "SUM('[1]Sales 2020'!B1:B10)", Value: "65"},
+		{Sheet: "Workbook", DefinedName: "Rate", Formula: "[1]Sheet1!$D$1"},
+	}, refs)
+	for cell, expected := range map[string]string{"A1": "", "A2": "", "A3": "", "B1": `IF(C1="[1]",Table1[[#This Row],[1]],1)`, "B2": ""} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	for cell, expected := range map[string]string{"A1": "10", "A2": "55", "A3": "65", "B2": "abcx"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.Equal(t, []DefinedName{
+		{Name: "Rate", RefersTo: "#REF!", Scope: "Workbook"},
+		{Name: "Local", RefersTo: "Sheet1!$A$1", Scope: "Workbook"},
+	}, f.GetDefinedName())
+	assert.Nil(t, f.workbookReader().ExternalReferences)
+	assert.NotContains(t, f.XLSX, "xl/externalLinks/externalLink1.xml")
+	assert.NotContains(t, f.XLSX, "xl/externalLinks/_rels/externalLink1.xml.rels")
+	for _, rel := range f.relsReader(f.getWorkbookRelsPath()).Relationships {
+		assert.NotEqual(t, "externalLinks/externalLink1.xml", rel.Target)
+	}
+	for _, override := range f.contentTypesReader().Overrides {
+		assert.NotEqual(t, "/xl/externalLinks/externalLink1.xml", override.PartName)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFreezeExternalReferences.xlsx")))
+
+	// Test freeze external references with the values returned by the function.
+	f = prepareExternalLinkBook(t)
+	refs, err = f.FreezeExternalReferences(FreezeOptions{
+		Value: func(ref ExternalReference) (interface{}, error) {
+			switch {
+			case ref.DefinedName != "":
+				return `5"%`, nil
+			case ref.Cell == "A1":
+				return 42, nil
+			case ref.Cell == "B2":
+				return true, nil
+			}
+			return nil, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, refs, 5)
+	for cell, expected := range map[string]string{"A1": "42", "A2": "55"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	boolValue, err := f.GetCellBool("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.True(t, boolValue)
+	assert.Equal(t, `"5""%"`, f.GetDefinedName()[0].RefersTo)
+
+	// Test freeze external references with the error returned by the function.
+	for _, name := range []string{"", "Rate"} {
+		f = prepareExternalLinkBook(t)
+		_, err = f.FreezeExternalReferences(FreezeOptions{
+			Value: func(ref ExternalReference) (interface{}, error) {
+				if ref.DefinedName == name {
+					return nil, errors.New("no value")
+				}
+				return nil, nil
+			},
+		})
+		assert.EqualError(t, err, "no value")
+	}
+
+	// Test freeze external references with the unsupported charset worksheet.
+	f = NewFile()
+	f.Sheet = map[string]*xlsxWorksheet{}
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	_, err = f.FreezeExternalReferences()
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestHasExternalReference(t *testing.T) {
+	for formula, expected := range map[string]bool{
+		"[1]Sheet1!A1":                  true,
+		"'[12]Sheet 1'!A1+1":            true,
+		"SUM(A1,[2]!Rate)":              true,
+		`"[1]Sheet1!A1"`:                false,
+		"Table1[[#This Row],[1]]":       false,
+		"Table1[1]":                     false,
+		"[Sales]*2":                     false,
+		"SUM(Table1[Sales])+[3]Data!A1": true,
+		"":                              false,
+		"[1":                            false,
+		"A1]":                           false,
+	} {
+		assert.Equal(t, expected, hasExternalReference(formula), formula)
+	}
+	assert.Equal(t, "1.5", definedNameConstant(1.5))
+	assert.Equal(t, `"a"`, definedNameConstant([]byte("a")))
+	assert.Equal(t, "FALSE", definedNameConstant(false))
+}
@@ -14,18 +14,21 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
-// NewFile provides a function to create new file by default template. For
-// example:
+// NewFile provides a function to create new file by default template, the
+// options are optional. For example:
 //
 //    f := NewFile()
 //
-func NewFile() *File {
+func NewFile(opt ...Options) *File {
 	file := make(map[string][]byte)
 	file["_rels/.rels"] = []byte(XMLHeader + templateRels)
 	file["docProps/app.xml"] = []byte(XMLHeader + templateDocpropsApp)
@@ -51,6 +54,9 @@ func NewFile() *File {
 	f.Sheet["xl/worksheets/sheet1.xml"], _ = f.workSheetReader("Sheet1")
 	f.sheetMap["Sheet1"] = "xl/worksheets/sheet1.xml"
 	f.Theme = f.themeReader()
+	for _, o := range opt {
+		f.setOptions(o)
+	}
 	return f
 }
 
@@ -63,31 +69,92 @@ func (f *File) Save() error {
 }
 
 // SaveAs provides a function to create or update to an spreadsheet at the
-// provided path.
+// provided path. The ZIP64 extensions will be used if the spreadsheet is
+// larger than 4 GB, contains the parts larger than 4 GB or more than 65535
+// parts, and the large parts stored in the temporary files will be
+// compressed as streams.
 func (f *File) SaveAs(name string, opt ...Options) error {
 	if len(name) > MaxFileNameLength {
 		return errors.New("file name length exceeds maximum limit")
 	}
+	f.options = nil
+	for _, o := range opt {
+		f.options = &o
+		if o.MaxInMemoryPartSize > 0 {
+			f.maxInMemoryPart = o.MaxInMemoryPartSize
+		}
+		if o.Progress != nil {
+			f.progress = o.Progress
+		}
+	}
+	if f.isSourceFile(name) {
+		return f.replaceSourceFile(name)
+	}
 	file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0666)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
-	f.options = nil
-	for _, o := range opt {
-		f.options = &o
-	}
 	return f.Write(file)
 }
 
+// isSourceFile provides a function to check if the given path is the file
+// which the spreadsheet was opened from and still being read.
+func (f *File) isSourceFile(name string) bool {
+	if f.source == nil {
+		return false
+	}
+	stat, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	source, err := f.source.Stat()
+	return err == nil && os.SameFile(stat, source)
+}
+
+// replaceSourceFile provides a function to save the spreadsheet to a
+// temporary file in the same directory and replace the file which the
+// spreadsheet was opened from, since the parts which have not been
+// decompressed are still read from it while saving.
+func (f *File) replaceSourceFile(name string) error {
+	stat, err := f.source.Stat()
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(name), "excelize-")
+	if err != nil {
+		return err
+	}
+	if err = f.Write(tmp); err == nil {
+		err = tmp.Chmod(stat.Mode())
+	}
+	if e := tmp.Close(); e != nil && err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
 // Write provides a function to write to an io.Writer.
 func (f *File) Write(w io.Writer) error {
 	_, err := f.WriteTo(w)
 	return err
 }
 
-// WriteTo implements io.WriterTo to write the file.
+// WriteTo implements io.WriterTo to write the file. The file will be written
+// to the writer directly without buffering the whole package in memory if
+// the MaxInMemoryPartSize is specified and it doesn't need to be encrypted.
 func (f *File) WriteTo(w io.Writer) (int64, error) {
+	if f.maxInMemoryPart > 0 && (f.options == nil || f.options.Password == "") {
+		cw := &countWriter{w: w}
+		err := f.writeToZip(context.Background(), zip.NewWriter(cw))
+		return cw.n, err
+	}
 	buf, err := f.WriteToBuffer()
 	if err != nil {
 		return 0, err
@@ -99,61 +166,237 @@ func (f *File) WriteTo(w io.Writer) (int64, error) {
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	zw := zip.NewWriter(buf)
+	if err := f.writeToZip(context.Background(), zw); err != nil {
+		return buf, err
+	}
+	if f.options != nil && f.options.Password != "" {
+		b, err := Encrypt(buf.Bytes(), f.options)
+		if err != nil {
+			return buf, err
+		}
+		buf.Reset()
+		buf.Write(b)
+	}
+	return buf, nil
+}
+
+// WriteContext provides a function to write the spreadsheet to an io.Writer
+// with the context. The writing will be stopped with the error of the
+// context once the context is canceled or its deadline is exceeded, which
+// could be used to release the request-scoped resources as soon as possible.
+// The spreadsheet will be written to the writer directly without buffering
+// the whole package in memory unless it needs to be encrypted, so the data
+// written before the cancellation should be discarded. For example, write
+// the spreadsheet to the HTTP response with the request context:
+//
+//    func handler(w http.ResponseWriter, r *http.Request) {
+//        f := excelize.NewFile()
+//        // ...
+//        w.Header().Set("Content-Disposition", "attachment; filename=Book1.xlsx")
+//        if err := f.WriteContext(r.Context(), w); err != nil {
+//            fmt.Println(err)
+//        }
+//    }
+//
+func (f *File) WriteContext(ctx context.Context, w io.Writer) error {
+	if f.options != nil && f.options.Password != "" {
+		buf, err := f.WriteToBuffer()
+		if err != nil {
+			return err
+		}
+		_, err = buf.WriteTo(contextWriter{ctx: ctx, w: w})
+		return err
+	}
+	return f.writeToZip(ctx, zip.NewWriter(contextWriter{ctx: ctx, w: w}))
+}
+
+// writeToZip provides a function to write the parts of the spreadsheet to
+// the ZIP writer with the context, the ZIP writer will be closed after
+// writing.
+func (f *File) writeToZip(ctx context.Context, zw *zip.Writer) error {
+	minify := f.options != nil && f.options.Minify
+	if minify {
+		if err := f.minifyWorkbook(); err != nil {
+			zw.Close()
+			return err
+		}
+	}
 	f.calcChainWriter()
 	f.commentsWriter()
+	f.threadedCommentsWriter()
+	f.personsWriter()
+	f.metadataWriter()
+	f.richValueWriter()
 	f.contentTypesWriter()
 	f.drawingsWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
-	f.workSheetWriter()
+	if err := f.workSheetWriter(); err != nil {
+		zw.Close()
+		return err
+	}
+	if minify {
+		f.deleteOrphanedParts()
+	}
 	f.relsWriter()
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 
+	current, total := 0, len(f.streams)+len(f.XLSX)
 	for path, stream := range f.streams {
+		if err := ctx.Err(); err != nil {
+			zw.Close()
+			return err
+		}
 		fi, err := zw.Create(path)
 		if err != nil {
 			zw.Close()
-			return buf, err
+			return err
 		}
 		var from io.Reader
 		from, err = stream.rawData.Reader()
 		if err != nil {
 			stream.rawData.Close()
-			return buf, err
+			return err
 		}
 		_, err = io.Copy(fi, from)
 		if err != nil {
 			zw.Close()
-			return buf, err
+			return err
 		}
 		stream.rawData.Close()
+		current++
+		f.reportProgress("save", current, total)
 	}
 
 	for path, content := range f.XLSX {
+		if err := ctx.Err(); err != nil {
+			zw.Close()
+			return err
+		}
+		if file, ok := f.lazyParts[path]; ok && content == nil {
+			if _, ok = f.tempFiles[path]; !ok {
+				if err := writeZipFile(zw, path, file); err != nil {
+					zw.Close()
+					return err
+				}
+				current++
+				f.reportProgress("save", current, total)
+				continue
+			}
+		}
 		fi, err := zw.Create(path)
 		if err != nil {
 			zw.Close()
-			return buf, err
+			return err
+		}
+		if tmp, ok := f.tempFiles[path]; ok && content == nil {
+			err = copyTempFile(fi, tmp)
+		} else {
+			_, err = fi.Write(content)
 		}
-		_, err = fi.Write(content)
 		if err != nil {
 			zw.Close()
-			return buf, err
+			return err
 		}
+		current++
+		f.reportProgress("save", current, total)
 	}
+	return zw.Close()
+}
 
-	if f.options != nil && f.options.Password != "" {
-		if err := zw.Close(); err != nil {
-			return buf, err
+// copyZipFile provides a function to copy the decompressed content of the
+// ZIP file entry to the writer.
+func copyZipFile(w io.Writer, file *zip.File) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// copyTempFile provides a function to copy the content of the temporary
+// file to the writer.
+func copyTempFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// Close closes and removes the temporary files of the parts which are larger
+// than the MaxInMemoryPartSize and the unsaved data of the StreamWriter, and
+// closes the file opened by OpenFile. The content of these parts and the
+// parts which have not been decompressed will be lost after calling this
+// function.
+func (f *File) Close() error {
+	var err error
+	if f.source != nil {
+		err = f.source.Close()
+		f.source = nil
+	}
+	for _, stream := range f.streams {
+		if e := stream.rawData.Close(); e != nil && !errors.Is(e, os.ErrClosed) && err == nil {
+			err = e
 		}
-		b, err := Encrypt(buf.Bytes(), f.options)
-		if err != nil {
-			return buf, err
+	}
+	for name, path := range f.tempFiles {
+		if e := os.Remove(path); e != nil && err == nil {
+			err = e
 		}
-		buf.Reset()
-		buf.Write(b)
-		return buf, nil
+		delete(f.tempFiles, name)
+	}
+	return err
+}
+
+// countWriter directly maps the io.Writer which counts the number of the
+// written bytes.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write implements the io.Writer interface to write the bytes and count the
+// number of the written bytes.
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// contextReader directly maps the io.Reader which stops reading with the
+// error of the context once the context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader to read from the underlying reader until the
+// context is done.
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// contextWriter directly maps the io.Writer which stops writing with the
+// error of the context once the context is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+// Write implements io.Writer to write to the underlying writer until the
+// context is done.
+func (w contextWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
 	}
-	return buf, zw.Close()
+	return w.w.Write(p)
 }
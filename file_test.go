@@ -1,8 +1,14 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -46,3 +52,384 @@ func TestWriteTo(t *testing.T) {
 	_, err = f.WriteTo(bufio.NewWriter(&buf))
 	assert.EqualError(t, err, "zip: FileHeader.Name too long")
 }
+
+func TestWriteContext(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteContext(context.Background(), &buf))
+	f, err := OpenReader(&buf)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", val)
+
+	// Test write with the canceled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.EqualError(t, f.WriteContext(ctx, &buf), "context canceled")
+	// Test write with the context canceled while writing the stream.
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Hello"}))
+	assert.NoError(t, sw.Flush())
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.EqualError(t, f.writeToZip(ctx, zip.NewWriter(ioutil.Discard)), "context canceled")
+	// Test write with the password.
+	f = NewFile()
+	f.options = &Options{Password: "password"}
+	assert.EqualError(t, f.WriteContext(context.Background(), &buf), "not support encryption currently")
+	// Test write with invalid part name.
+	f.options = nil
+	f.XLSX["/d/"] = []byte("s")
+	assert.EqualError(t, f.WriteContext(context.Background(), &buf), "zip: write to directory")
+}
+
+func TestSaveProgress(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Hello"}))
+	assert.NoError(t, sw.Flush())
+	var progress [][2]int
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSaveProgress.xlsx"), Options{
+		Progress: func(stage string, current, total int) {
+			assert.Equal(t, "save", stage)
+			progress = append(progress, [2]int{current, total})
+		},
+	}))
+	total := len(f.streams) + len(f.XLSX)
+	assert.Len(t, progress, total)
+	for idx, p := range progress {
+		assert.Equal(t, [2]int{idx + 1, total}, p)
+	}
+	// Test report the progress of reading the parts when opening.
+	progress = nil
+	f, err = OpenFile(filepath.Join("test", "TestSaveProgress.xlsx"), Options{
+		Progress: func(stage string, current, total int) {
+			assert.Equal(t, "open", stage)
+			progress = append(progress, [2]int{current, total})
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, progress, total)
+	assert.Equal(t, [2]int{total, total}, progress[total-1])
+	assert.NoError(t, f.Close())
+}
+
+func TestMaxInMemoryPartSize(t *testing.T) {
+	f := NewFile(Options{MaxInMemoryPartSize: 1024})
+	for row := 1; row <= 100; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, "text" + strconv.Itoa(row)}))
+	}
+	// Test the worksheet and shared strings are stored in the temporary files.
+	buf := new(bytes.Buffer)
+	n, err := f.WriteTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Nil(t, f.XLSX["xl/worksheets/sheet1.xml"])
+	assert.Nil(t, f.XLSX["xl/sharedStrings.xml"])
+	assert.Contains(t, f.tempFiles, "xl/worksheets/sheet1.xml")
+	assert.Contains(t, f.tempFiles, "xl/sharedStrings.xml")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMaxInMemoryPartSize.xlsx")))
+	assert.NoError(t, f.Close())
+	assert.Empty(t, f.tempFiles)
+
+	// Test open the spreadsheet with the memory limit of the part.
+	f, err = OpenFile(filepath.Join("test", "TestMaxInMemoryPartSize.xlsx"), Options{MaxInMemoryPartSize: 1024})
+	assert.NoError(t, err)
+	assert.Contains(t, f.tempFiles, "xl/worksheets/sheet1.xml")
+	assert.Contains(t, f.tempFiles, "xl/sharedStrings.xml")
+	tempFile := f.tempFiles["xl/worksheets/sheet1.xml"]
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var count int
+	for rows.Next() {
+		row, err := rows.Columns()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{strconv.Itoa(count + 1), "text" + strconv.Itoa(count+1)}, row)
+		count++
+	}
+	assert.Equal(t, 100, count)
+	assert.NoError(t, rows.Close())
+	val, err := f.GetCellValue("Sheet1", "B100")
+	assert.NoError(t, err)
+	assert.Equal(t, "text100", val)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "updated"))
+	assert.NoError(t, f.ReleaseSheet("Sheet1"))
+	assert.NotEqual(t, tempFile, f.tempFiles["xl/worksheets/sheet1.xml"])
+	_, err = os.Stat(tempFile)
+	assert.True(t, os.IsNotExist(err))
+	val, err = f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMaxInMemoryPartSize.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test read and write with the removed temporary file.
+	f, err = OpenFile(filepath.Join("test", "TestMaxInMemoryPartSize.xlsx"), Options{MaxInMemoryPartSize: 1024})
+	assert.NoError(t, err)
+	assert.NoError(t, os.Remove(f.tempFiles["xl/worksheets/sheet1.xml"]))
+	_, err = f.Rows("Sheet1")
+	assert.True(t, os.IsNotExist(err))
+	_, err = f.WriteToBuffer()
+	assert.True(t, os.IsNotExist(err))
+	assert.True(t, os.IsNotExist(f.Close()))
+
+	// Test open the spreadsheet without the available temporary directory.
+	tmpDir := os.Getenv("TMPDIR")
+	assert.NoError(t, os.Setenv("TMPDIR", filepath.Join("test", "TestMaxInMemoryPartSize")))
+	_, err = OpenFile(filepath.Join("test", "TestMaxInMemoryPartSize.xlsx"), Options{MaxInMemoryPartSize: 1024})
+	assert.Error(t, err)
+	// Test save the worksheet in memory without the available temporary directory.
+	f = NewFile(Options{MaxInMemoryPartSize: 1024})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", strings.Repeat("a", 2048)))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMaxInMemoryPartSize.xlsx")))
+	assert.NotNil(t, f.XLSX["xl/worksheets/sheet1.xml"])
+	assert.NoError(t, os.Setenv("TMPDIR", tmpDir))
+}
+
+func TestMaxInMemoryPartSizeParts(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 50; row++ {
+		assert.NoError(t, f.AddComment("Sheet1", "A"+strconv.Itoa(row), `{"author":"Excelize: ","text":"This is a comment."}`))
+	}
+	assert.NoError(t, f.AddPicture("Sheet1", "C2", filepath.Join("test", "images", "excel.png"), ""))
+	icon, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	book, err := ioutil.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "F2", FileName: "Book1.xlsx", File: book, Icon: icon}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "SEQUENCE(3)"))
+	f.XLSX["xl/metadata.xml"] = []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"><metadataTypes count="1"><metadataType name="XLDAPR" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1" cellMeta="1"/></metadataTypes><futureMetadata name="XLDAPR" count="1"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext></extLst></bk></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata></metadata>`)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[len(ws.SheetData.Row[0].C)-1].CM = 1
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMaxInMemoryPartSizeParts.xlsx")))
+
+	// Test read and round-trip the comments, pictures, OLE objects and
+	// metadata which are stored in the temporary files.
+	check := func(path string) {
+		f, err := OpenFile(path, Options{MaxInMemoryPartSize: 128})
+		assert.NoError(t, err)
+		for _, part := range []string{"xl/comments1.xml", "xl/media/image1.png", "xl/embeddings/Microsoft_Excel_Worksheet1.xlsx", "xl/metadata.xml"} {
+			assert.Contains(t, f.tempFiles, part)
+		}
+		assert.Len(t, f.GetComments()["Sheet1"], 50)
+		pics, err := f.GetPictures("Sheet1")
+		assert.NoError(t, err)
+		if assert.Len(t, pics, 1) {
+			assert.Len(t, pics[0].File, 13233)
+		}
+		objects, err := f.GetOLEObjects("Sheet1")
+		assert.NoError(t, err)
+		if assert.Len(t, objects, 1) {
+			assert.Equal(t, book, objects[0].File)
+			assert.Equal(t, icon, objects[0].Icon)
+		}
+		metadata, err := f.GetCellMetadata("Sheet1", "H1")
+		assert.NoError(t, err)
+		assert.True(t, metadata.DynamicArray)
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMaxInMemoryPartSizeParts2.xlsx")))
+		assert.NoError(t, f.Close())
+	}
+	check(filepath.Join("test", "TestMaxInMemoryPartSizeParts.xlsx"))
+	check(filepath.Join("test", "TestMaxInMemoryPartSizeParts2.xlsx"))
+}
+
+func BenchmarkOpenFileLargeArchive(b *testing.B) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	if err != nil {
+		b.Fatal(err)
+	}
+	row := make([]interface{}, 20)
+	for idx := range row {
+		row[idx] = strings.Repeat("excelize", 4)
+	}
+	for r := 1; r <= 20000; r++ {
+		cell, _ := CoordinatesToCellName(1, r)
+		if err = sw.SetRow(cell, row); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err = sw.Flush(); err != nil {
+		b.Fatal(err)
+	}
+	path := filepath.Join("test", "BenchmarkOpenFileLargeArchive.xlsx")
+	if err = f.SaveAs(path); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f, err := OpenFile(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(f.GetSheetList()) != 1 {
+			b.Fatal("unexpected worksheets")
+		}
+		if err = f.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestZIP64(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "ZIP64"))
+	for idx := 0; idx <= 65535; idx++ {
+		f.XLSX["customXml/item"+strconv.Itoa(idx)+".xml"] = []byte{}
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	// Test the ZIP64 end of central directory record is used for the package
+	// which contains more than 65535 parts.
+	assert.True(t, bytes.Contains(buf.Bytes(), []byte("PK\x06\x06")))
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Len(t, f.XLSX, 65536+10)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ZIP64", val)
+
+	// Test open the ZIP64 package from the file in place, and save it to
+	// the same file with the worksheet which has not been decompressed.
+	path := filepath.Join("test", "TestZIP64.xlsx")
+	assert.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+	f, err = OpenFile(path, Options{MaxInMemoryPartSize: 1 << 20})
+	assert.NoError(t, err)
+	assert.NotNil(t, f.source)
+	assert.Contains(t, f.lazyParts, "xl/worksheets/sheet1.xml")
+	assert.NoError(t, f.Save())
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ZIP64", val)
+	assert.NoError(t, f.Close())
+	assert.Nil(t, f.source)
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	assert.Len(t, f.XLSX, 65536+10)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ZIP64", val)
+	assert.NoError(t, f.Close())
+
+	// Test read the corrupted part of the package.
+	buf, err = NewFile().WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, part := range []string{"xl/workbook.xml", "xl/worksheets/sheet1.xml"} {
+		buf = new(bytes.Buffer)
+		zw := zip.NewWriter(buf)
+		for _, file := range zr.File {
+			content, err := readFile(file)
+			assert.NoError(t, err)
+			fi, err := zw.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Store})
+			assert.NoError(t, err)
+			_, err = fi.Write(content)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, zw.Close())
+		b := buf.Bytes()
+		corrupted, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		assert.NoError(t, err)
+		for _, file := range corrupted.File {
+			if file.Name == part {
+				offset, err := file.DataOffset()
+				assert.NoError(t, err)
+				b[offset]++
+			}
+		}
+		f, err = OpenReader(bytes.NewReader(b))
+		if part == "xl/workbook.xml" {
+			assert.EqualError(t, err, "zip: checksum error")
+			continue
+		}
+		if assert.NoError(t, err) {
+			_, err = f.workSheetReader("Sheet1")
+			assert.EqualError(t, err, "zip: checksum error")
+		}
+	}
+}
+
+func TestWriteUnchangedParts(t *testing.T) {
+	buf, err := NewFile().WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	// Add the elements which are not supported by the structures of the parts.
+	extensions := map[string]string{
+		"xl/workbook.xml":            "</workbook>",
+		"xl/styles.xml":              "</styleSheet>",
+		"xl/_rels/workbook.xml.rels": "</Relationships>",
+	}
+	buf = new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, file := range zr.File {
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		if end, ok := extensions[file.Name]; ok {
+			content = bytes.Replace(content, []byte(end), []byte(`<ext xmlns="urn:test"/>`+end), 1)
+		}
+		fi, err := zw.Create(file.Name)
+		assert.NoError(t, err)
+		_, err = fi.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	source := buf.Bytes()
+
+	f, err := OpenReader(bytes.NewReader(source))
+	assert.NoError(t, err)
+	// Test the deserialized parts are kept as is if they are not changed.
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	_, err = f.NewStyle(&Style{NumFmt: 0})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	parts := readZipParts(t, buf.Bytes())
+	for name := range extensions {
+		assert.Contains(t, string(parts[name]), `<ext xmlns="urn:test"/>`, name)
+	}
+	assert.Contains(t, string(parts["xl/worksheets/sheet1.xml"]), `<c r="A1"><v>1</v></c>`)
+
+	// Test the unparsed worksheet is copied verbatim.
+	f, err = OpenReader(bytes.NewReader(source))
+	assert.NoError(t, err)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	parts, sourceParts := readZipParts(t, buf.Bytes()), readZipParts(t, source)
+	assert.Equal(t, sourceParts["xl/worksheets/sheet1.xml"], parts["xl/worksheets/sheet1.xml"])
+
+	// Test the changed parts are serialized again.
+	f, err = OpenReader(bytes.NewReader(source))
+	assert.NoError(t, err)
+	f.SetSheetName("Sheet1", "Sheet2")
+	_, err = f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	parts = readZipParts(t, buf.Bytes())
+	for _, name := range []string{"xl/workbook.xml", "xl/styles.xml"} {
+		assert.NotContains(t, string(parts[name]), "urn:test", name)
+	}
+	assert.Contains(t, string(parts["xl/workbook.xml"]), `name="Sheet2"`)
+}
+
+// readZipParts provides a function to read the decompressed parts of the ZIP
+// archive by given bytes of the archive.
+func readZipParts(t *testing.T, b []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	parts := map[string][]byte{}
+	for _, file := range zr.File {
+		parts[file.Name], err = readFile(file)
+		assert.NoError(t, err)
+	}
+	return parts
+}
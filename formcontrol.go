@@ -0,0 +1,505 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// formControlTypes defined the VML object type, the control properties
+// object type, the name prefix and the default size of the form controls.
+var formControlTypes = map[FormControlType]struct {
+	objectType, ctrlPropType, name string
+	width, height                  int
+}{
+	FormControlButton:       {"Button", "Button", "Button", 96, 24},
+	FormControlCheckBox:     {"Checkbox", "CheckBox", "Check Box", 96, 20},
+	FormControlOptionButton: {"Radio", "Radio", "Option Button", 96, 20},
+	FormControlSpinButton:   {"Spin", "Spin", "Spinner", 16, 40},
+	FormControlScrollBar:    {"Scroll", "Scroll", "Scroll Bar", 16, 160},
+	FormControlListBox:      {"List", "List", "List Box", 96, 80},
+}
+
+// AddFormControl provides the method to add the legacy form control in a
+// worksheet by given worksheet name and form control options. The supported
+// form control types are button, check box, option button, spin button,
+// scroll bar and list box. The form control will be stored in the VML
+// drawing and the control properties part of the worksheet. The Width and
+// Height are the size of the control in pixels, the default size of the
+// control type will be used if they are not set. For example, add a button
+// which runs the macro "Button1_Click", and a check box linked with the cell
+// Sheet1!A1:
+//
+//    err := f.AddFormControl("Sheet1", excelize.FormControl{
+//        Cell:  "B2",
+//        Type:  excelize.FormControlButton,
+//        Text:  "Run",
+//        Macro: "Button1_Click",
+//    })
+//    err = f.AddFormControl("Sheet1", excelize.FormControl{
+//        Cell:     "B4",
+//        Type:     excelize.FormControlCheckBox,
+//        Text:     "Enabled",
+//        Checked:  true,
+//        CellLink: "$A$1",
+//    })
+//
+// The CurrentVal, MinVal, MaxVal, IncChange and PageChange specify the value
+// of the spin button and the scroll bar, the values should be in the range
+// 0 to 30000, and the maximum value 100 will be used if both the minimum and
+// maximum values are zero. For example, add a horizontal scroll bar linked
+// with the cell Sheet1!A2:
+//
+//    err := f.AddFormControl("Sheet1", excelize.FormControl{
+//        Cell:         "B6",
+//        Type:         excelize.FormControlScrollBar,
+//        Width:        160,
+//        Height:       16,
+//        CurrentVal:   10,
+//        MaxVal:       50,
+//        Horizontally: true,
+//        CellLink:     "$A$2",
+//    })
+//
+// The InputRange specifies the reference of the list items of the list box,
+// for example, add a list box with the items in Sheet1!D1:D5:
+//
+//    err := f.AddFormControl("Sheet1", excelize.FormControl{
+//        Cell:       "B8",
+//        Type:       excelize.FormControlListBox,
+//        InputRange: "$D$1:$D$5",
+//        CellLink:   "$A$3",
+//    })
+//
+// Note that the macro assigned to the button requires the VBA project in the
+// workbook, and the workbook should be saved with the extension ".xlsm".
+func (f *File) AddFormControl(sheet string, opts FormControl) error {
+	ctrlType, ok := formControlTypes[opts.Type]
+	if !ok {
+		return errors.New("unsupported form control type")
+	}
+	if err := prepareFormControlOptions(&opts); err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if opts.Width <= 0 {
+		opts.Width = ctrlType.width
+	}
+	if opts.Height <= 0 {
+		opts.Height = ctrlType.height
+	}
+	vmlID, drawingVML := f.prepareVMLDrawing(sheet, ws)
+	vml := f.commentsVMLReader(vmlID, drawingVML)
+	addFormControlShapetype(vml)
+	shapeID := getVMLShapeID(vml, vmlID)
+	name := ctrlType.name + " " + strconv.Itoa(shapeID-vmlID*1024)
+	if opts.Text == "" && opts.Type <= FormControlOptionButton {
+		opts.Text = name
+	}
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col-1, row-1, 0, 0, opts.Width, opts.Height)
+	vml.Shape = append(vml.Shape, newFormControlShape(shapeID, len(vml.Shape)+1,
+		fmt.Sprintf("%d, 0, %d, 0, %d, %d, %d, %d", colStart, rowStart, colEnd, x2, rowEnd, y2), &opts))
+	// Add the control properties part and the control of the worksheet.
+	ctrlPropID := f.countCtrlProps() + 1
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipCtrlProp, "../ctrlProps/ctrlProp"+strconv.Itoa(ctrlPropID)+".xml", "")
+	ctrlProp, _ := xml.Marshal(newFormControlPr(ctrlType.ctrlPropType, &opts))
+	f.saveFileList("xl/ctrlProps/ctrlProp"+strconv.Itoa(ctrlPropID)+".xml", ctrlProp)
+	f.addContentTypePart(ctrlPropID, "ctrlProps")
+	control, _ := xml.Marshal(xlsxControlAlternateContent{
+		XMLNSMC:  SourceRelationshipCompatibility.Value,
+		XMLNSX14: NameSpaceSpreadSheetX14.Value,
+		XMLNSXdr: NameSpaceDrawingMLSpreadSheet.Value,
+		Choice: xlsxControlChoice{
+			Requires: "x14",
+			Control: xlsxControl{
+				ShapeID: shapeID,
+				RID:     "rId" + strconv.Itoa(rID),
+				Name:    name,
+				ControlPr: &xlsxControlPr{
+					Macro: formControlMacro(opts.Macro),
+					Anchor: &xlsxControlAnchor{
+						MoveWithCells: true,
+						From:          xlsxFrom{Col: colStart, Row: rowStart},
+						To:            xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+					},
+				},
+			},
+		},
+	})
+	if ws.Controls == nil {
+		ws.Controls = &xlsxInnerXML{}
+	}
+	ws.Controls.Content += string(control)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// prepareFormControlOptions provides a function to check and set the
+// default value settings of the form control options.
+func prepareFormControlOptions(opts *FormControl) error {
+	if opts.Type != FormControlSpinButton && opts.Type != FormControlScrollBar {
+		return nil
+	}
+	if opts.MinVal == 0 && opts.MaxVal == 0 {
+		opts.MaxVal = 100
+	}
+	if opts.IncChange == 0 {
+		opts.IncChange = 1
+	}
+	if opts.PageChange == 0 {
+		opts.PageChange = 10
+	}
+	if opts.MinVal < 0 || opts.MaxVal > 30000 || opts.MinVal > opts.MaxVal ||
+		opts.CurrentVal < opts.MinVal || opts.CurrentVal > opts.MaxVal ||
+		opts.IncChange < 0 || opts.IncChange > 30000 || opts.PageChange < 0 || opts.PageChange > 30000 {
+		return errors.New("invalid form control value settings")
+	}
+	return nil
+}
+
+// prepareVMLDrawing provides a function to get the ID and the path of
+// the VML drawing of the worksheet, the VML drawing will be created if the
+// worksheet doesn't have the legacy drawing.
+func (f *File) prepareVMLDrawing(sheet string, ws *xlsxWorksheet) (int, string) {
+	if ws.LegacyDrawing != nil {
+		target := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+		vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(target, "../drawings/vmlDrawing"), ".vml"))
+		return vmlID, strings.Replace(target, "..", "xl", -1)
+	}
+	vmlID := f.countVMLDrawing() + 1
+	if commentID := f.countComments() + 1; commentID > vmlID {
+		vmlID = commentID
+	}
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, "../drawings/vmlDrawing"+strconv.Itoa(vmlID)+".vml", "")
+	f.addSheetLegacyDrawing(sheet, rID)
+	f.setContentTypePartVMLExtensions()
+	return vmlID, "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+}
+
+// addFormControlShapetype provides a function to add the VML shape type of
+// the form controls if it doesn't exist in the VML drawing.
+func addFormControlShapetype(vml *vmlDrawing) {
+	for _, shapetype := range vml.Shapetype {
+		if shapetype.ID == "_x0000_t201" {
+			return
+		}
+	}
+	vml.Shapetype = append(vml.Shapetype, xlsxShapetype{
+		ID:        "_x0000_t201",
+		Coordsize: "21600,21600",
+		Spt:       201,
+		Path:      "m,l,21600r21600,l21600,xe",
+		Stroke:    &xlsxStroke{Joinstyle: "miter"},
+		VPath:     &vPath{Connecttype: "rect"},
+	})
+}
+
+// getVMLShapeID provides a function to get a new unique shape ID in the VML
+// drawing by given VML drawing and the ID of the VML drawing, the shape IDs
+// of the VML drawing start from the ID of the drawing multiplied by 1024.
+func getVMLShapeID(vml *vmlDrawing, vmlID int) int {
+	shapeID := vmlID * 1024
+	for _, shape := range vml.Shape {
+		if ID, err := strconv.Atoi(strings.TrimPrefix(shape.ID, "_x0000_s")); err == nil && ID > shapeID {
+			shapeID = ID
+		}
+	}
+	return shapeID + 1
+}
+
+// newFormControlShape provides a function to create the VML shape of the
+// form control by given shape ID, z-index, anchor and form control options.
+func newFormControlShape(shapeID, zIndex int, anchor string, opts *FormControl) xlsxShape {
+	sp := encodeFormControl{
+		ClientData: &xFormControlClientData{
+			ObjectType: formControlTypes[opts.Type].objectType,
+			Anchor:     anchor,
+			AutoFill:   "False",
+			FmlaLink:   opts.CellLink,
+		},
+	}
+	shape := xlsxShape{
+		ID:          "_x0000_s" + strconv.Itoa(shapeID),
+		Type:        "#_x0000_t201",
+		Style:       fmt.Sprintf("position:absolute;width:%dpt;height:%dpt;z-index:%d;mso-wrap-style:tight", opts.Width*3/4, opts.Height*3/4, zIndex),
+		Filled:      "f",
+		Fillcolor:   "window [65]",
+		Stroked:     "f",
+		Strokecolor: "windowText [64]",
+	}
+	if opts.Text != "" {
+		sp.Textbox = &vFormControlTextbox{Style: "mso-direction-alt:auto", SingleClick: "f"}
+		sp.Textbox.Div.Style = "text-align:left"
+		sp.Textbox.Div.Font.Face, sp.Textbox.Div.Font.Size, sp.Textbox.Div.Font.Color = "Segoe UI", 160, "auto"
+		sp.Textbox.Div.Font.Val = opts.Text
+	}
+	switch opts.Type {
+	case FormControlButton:
+		shape.Button, shape.Filled, shape.Fillcolor, shape.Stroked = "t", "", "buttonFace [67]", ""
+		if sp.Textbox != nil {
+			sp.Textbox.Div.Style = "text-align:center"
+		}
+		sp.ClientData.PrintObject, sp.ClientData.FmlaLink = "False", ""
+		sp.ClientData.FmlaMacro = formControlMacro(opts.Macro)
+		sp.ClientData.TextHAlign, sp.ClientData.TextVAlign = "Center", "Center"
+	case FormControlCheckBox, FormControlOptionButton:
+		sp.ClientData.AutoLine, sp.ClientData.TextVAlign = "False", "Center"
+		sp.ClientData.NoThreeD = &struct{}{}
+		if opts.Checked {
+			sp.ClientData.Checked = 1
+		}
+	case FormControlSpinButton, FormControlScrollBar:
+		sp.ClientData.PrintObject, sp.ClientData.Dx = "False", 16
+		sp.ClientData.Val, sp.ClientData.Min, sp.ClientData.Max = intPtr(opts.CurrentVal), intPtr(opts.MinVal), intPtr(opts.MaxVal)
+		sp.ClientData.Inc, sp.ClientData.Page = intPtr(opts.IncChange), intPtr(opts.PageChange)
+		if opts.Horizontally {
+			sp.ClientData.Horiz = &struct{}{}
+		}
+	case FormControlListBox:
+		shape.Filled, shape.Stroked = "", ""
+		sp.ClientData.PrintObject, sp.ClientData.Dx = "False", 16
+		sp.ClientData.FmlaRange, sp.ClientData.SelType = opts.InputRange, "Single"
+		sp.ClientData.NoThreeD = &struct{}{}
+	}
+	s, _ := xml.Marshal(sp)
+	shape.Val = string(s[19 : len(s)-20])
+	return shape
+}
+
+// newFormControlPr provides a function to create the control properties of
+// the form control by given control properties object type and form control
+// options.
+func newFormControlPr(objectType string, opts *FormControl) *xlsxFormControlPr {
+	ctrlPr := &xlsxFormControlPr{ObjectType: objectType, FmlaLink: opts.CellLink}
+	switch opts.Type {
+	case FormControlButton:
+		ctrlPr.FmlaLink, ctrlPr.LockText = "", true
+	case FormControlCheckBox, FormControlOptionButton:
+		ctrlPr.LockText, ctrlPr.NoThreeD = true, true
+		if opts.Checked {
+			ctrlPr.Checked = "Checked"
+		}
+	case FormControlSpinButton, FormControlScrollBar:
+		ctrlPr.Dx, ctrlPr.Val, ctrlPr.Min, ctrlPr.Max = 16, opts.CurrentVal, opts.MinVal, opts.MaxVal
+		ctrlPr.Inc, ctrlPr.Page, ctrlPr.Horiz = opts.IncChange, opts.PageChange, opts.Horizontally
+	case FormControlListBox:
+		ctrlPr.Dx, ctrlPr.FmlaRange, ctrlPr.SelType, ctrlPr.NoThreeD = 16, opts.InputRange, "single", true
+	}
+	return ctrlPr
+}
+
+// formControlMacro provides a function to get the formula of the macro
+// assigned to the form control by given macro name.
+func formControlMacro(macro string) string {
+	if macro == "" || strings.Contains(macro, "!") {
+		return macro
+	}
+	return "[0]!" + macro
+}
+
+// countCtrlProps provides a function to get control properties files count
+// storage in the folder xl/ctrlProps.
+func (f *File) countCtrlProps() int {
+	count := 0
+	for k := range f.XLSX {
+		if strings.Contains(k, "xl/ctrlProps/ctrlProp") {
+			count++
+		}
+	}
+	return count
+}
+
+// GetFormControls retrieves all the legacy form controls in a worksheet by
+// given worksheet name. The Width and Height of the form controls are
+// calculated by the anchor of the controls. For example, get the cell links
+// of the form controls in Sheet1:
+//
+//    controls, err := f.GetFormControls("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    for _, control := range controls {
+//        fmt.Println(control.Cell, control.CellLink)
+//    }
+//
+func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
+	var controls []FormControl
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return controls, err
+	}
+	drawingVML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl", -1)
+	var shapes []string
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, shape := range vml.Shape {
+			shapes = append(shapes, shape.Val)
+		}
+	} else if d := f.decodeVMLDrawingReader(drawingVML); d != nil {
+		for _, shape := range d.Shape {
+			shapes = append(shapes, shape.Val)
+		}
+	}
+	for _, shape := range shapes {
+		val := decodeShapeVal{}
+		_ = xml.Unmarshal([]byte("<shape xmlns:v=\"urn:schemas-microsoft-com:vml\" "+
+			"xmlns:o=\"urn:schemas-microsoft-com:office:office\" "+
+			"xmlns:x=\"urn:schemas-microsoft-com:office:excel\">"+shape+"</shape>"), &val)
+		if val.ClientData == nil {
+			continue
+		}
+		if control, ok := f.newFormControl(sheet, &val); ok {
+			controls = append(controls, control)
+		}
+	}
+	return controls, err
+}
+
+// newFormControl provides a function to get the form control options by
+// given worksheet name and the decoded VML shape, the boolean value will be
+// false if the shape is not a supported form control.
+func (f *File) newFormControl(sheet string, val *decodeShapeVal) (FormControl, bool) {
+	var control FormControl
+	ok := false
+	for ctrlType, v := range formControlTypes {
+		if v.objectType == val.ClientData.ObjectType {
+			control.Type, ok = ctrlType, true
+		}
+	}
+	anchor, valid := parseVMLAnchor(val.ClientData.Anchor)
+	if !ok || !valid {
+		return control, false
+	}
+	control.Cell, _ = CoordinatesToCellName(anchor[0]+1, anchor[2]+1)
+	control.Width, control.Height = anchor[5]-anchor[1], anchor[7]-anchor[3]
+	for col := anchor[0]; col < anchor[4]; col++ {
+		control.Width += f.getColWidth(sheet, col+1)
+	}
+	for row := anchor[2]; row < anchor[6]; row++ {
+		control.Height += f.getRowHeight(sheet, row)
+	}
+	if val.Textbox != nil {
+		for _, font := range val.Textbox.Div.Font {
+			control.Text += font.Val
+		}
+	}
+	clientData := val.ClientData
+	control.Macro = strings.TrimPrefix(clientData.FmlaMacro, "[0]!")
+	control.Checked = clientData.Checked == 1
+	control.CellLink, control.InputRange = clientData.FmlaLink, clientData.FmlaRange
+	if control.Type == FormControlSpinButton || control.Type == FormControlScrollBar {
+		control.CurrentVal, control.MinVal, control.MaxVal = clientData.Val, clientData.Min, clientData.Max
+		control.IncChange, control.PageChange = clientData.Inc, clientData.Page
+		control.Horizontally = clientData.Horiz != nil
+	}
+	return control, true
+}
+
+// parseVMLAnchor provides a function to parse the anchor of the VML shape,
+// the anchor consists of the left column, left offset, top row, top offset,
+// right column, right offset, bottom row and bottom offset, and the offsets
+// are in pixels. The boolean value will be false if the anchor is invalid.
+func parseVMLAnchor(anchor string) ([]int, bool) {
+	var values []int
+	for _, v := range strings.Split(anchor, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			values = append(values, n)
+		}
+	}
+	return values, len(values) == 8
+}
+
+// getFormControlNames provides a function to get the names of the form
+// controls in the controls element of the worksheet, the key of the map is
+// the shape ID of the form control.
+func (f *File) getFormControlNames(ws *xlsxWorksheet) (map[int]string, error) {
+	names := make(map[int]string)
+	if ws.Controls == nil {
+		return names, nil
+	}
+	_, err := replaceXMLElements(ws.Controls.Content, func(ancestors []string, start xml.StartElement) bool {
+		if start.Name.Local == "control" {
+			var shapeID int
+			var name string
+			for _, attr := range start.Attr {
+				switch attr.Name.Local {
+				case "shapeId":
+					shapeID, _ = strconv.Atoi(attr.Value)
+				case "name":
+					name = attr.Value
+				}
+			}
+			names[shapeID] = name
+		}
+		return false
+	}, nil)
+	if err != nil {
+		return names, fmt.Errorf("xml decode error: %s", err)
+	}
+	return names, nil
+}
+
+// setFormControlAnchor provides a function to set the anchor of the form
+// control by given worksheet name, the shape ID of the form control and the
+// starting and ending anchor, both the anchor of the VML shape and the
+// anchor in the controls element of the worksheet will be updated.
+func (f *File) setFormControlAnchor(sheet string, shapeID int, from *xlsxFrom, to *xlsxTo) error {
+	ws, _ := f.workSheetReader(sheet)
+	vmlID, drawingVML := f.prepareVMLDrawing(sheet, ws)
+	vml := f.commentsVMLReader(vmlID, drawingVML)
+	anchor := fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", from.Col, from.ColOff/EMU, from.Row, from.RowOff/EMU,
+		to.Col, to.ColOff/EMU, to.Row, to.RowOff/EMU)
+	for idx, shape := range vml.Shape {
+		if shape.ID == "_x0000_s"+strconv.Itoa(shapeID) {
+			vml.Shape[idx].Val = regexp.MustCompile(`(<(?:\w+:)?Anchor>)[^<]*(</)`).ReplaceAllString(shape.Val, "${1}"+anchor+"${2}")
+		}
+	}
+	if ws.Controls == nil {
+		return nil
+	}
+	var controlShapeID int
+	content, err := replaceXMLElements(ws.Controls.Content, func(ancestors []string, start xml.StartElement) bool {
+		if start.Name.Local == "control" {
+			controlShapeID = 0
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "shapeId" {
+					controlShapeID, _ = strconv.Atoi(attr.Value)
+				}
+			}
+		}
+		return controlShapeID == shapeID && len(ancestors) > 0 && ancestors[len(ancestors)-1] == "anchor" &&
+			(start.Name.Local == "from" || start.Name.Local == "to")
+	}, func(element string, start xml.StartElement) string {
+		if start.Name.Local == "from" {
+			return setXMLElementValues(element, map[string]int{"col": from.Col, "colOff": from.ColOff, "row": from.Row, "rowOff": from.RowOff})
+		}
+		return setXMLElementValues(element, map[string]int{"col": to.Col, "colOff": to.ColOff, "row": to.Row, "rowOff": to.RowOff})
+	})
+	if err != nil {
+		return fmt.Errorf("xml decode error: %s", err)
+	}
+	ws.Controls.Content = content
+	return nil
+}
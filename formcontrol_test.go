@@ -0,0 +1,109 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFormControl(t *testing.T) {
+	f := NewFile()
+	for _, opts := range []FormControl{
+		{Cell: "B2", Type: FormControlButton, Text: "Run", Macro: "Button1_Click"},
+		{Cell: "B4", Type: FormControlCheckBox, Checked: true, CellLink: "$A$1"},
+		{Cell: "B6", Type: FormControlOptionButton, Text: "Option", CellLink: "$A$2"},
+		{Cell: "B8", Type: FormControlSpinButton, CurrentVal: 5, MinVal: 1, MaxVal: 10, CellLink: "$A$3"},
+		{Cell: "D2", Type: FormControlScrollBar, Width: 160, Height: 16, CurrentVal: 10, MaxVal: 50, IncChange: 2, PageChange: 5, Horizontally: true, CellLink: "$A$4"},
+		{Cell: "D4", Type: FormControlListBox, InputRange: "$F$1:$F$5", CellLink: "$A$5"},
+	} {
+		assert.NoError(t, f.AddFormControl("Sheet1", opts))
+	}
+	// Test add comment on the worksheet which contains the form controls.
+	assert.NoError(t, f.AddComment("Sheet1", "H1", `{"author":"Excelize: ","text":"This is a comment."}`))
+
+	check := func(f *File) {
+		controls, err := f.GetFormControls("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, controls, 6) {
+			return
+		}
+		assert.Equal(t, FormControl{Cell: "B2", Type: FormControlButton, Text: "Run", Macro: "Button1_Click", Width: 96, Height: 24}, controls[0])
+		assert.Equal(t, FormControl{Cell: "B4", Type: FormControlCheckBox, Text: "Check Box 2", Width: 96, Height: 20, Checked: true, CellLink: "$A$1"}, controls[1])
+		assert.Equal(t, FormControl{Cell: "B6", Type: FormControlOptionButton, Text: "Option", Width: 96, Height: 20, CellLink: "$A$2"}, controls[2])
+		assert.Equal(t, FormControl{Cell: "B8", Type: FormControlSpinButton, Width: 16, Height: 40, CurrentVal: 5, MinVal: 1, MaxVal: 10, IncChange: 1, PageChange: 10, CellLink: "$A$3"}, controls[3])
+		assert.Equal(t, FormControl{Cell: "D2", Type: FormControlScrollBar, Width: 160, Height: 16, CurrentVal: 10, MaxVal: 50, IncChange: 2, PageChange: 5, Horizontally: true, CellLink: "$A$4"}, controls[4])
+		assert.Equal(t, FormControl{Cell: "D4", Type: FormControlListBox, Width: 96, Height: 80, CellLink: "$A$5", InputRange: "$F$1:$F$5"}, controls[5])
+		comments := f.GetComments()
+		assert.Len(t, comments["Sheet1"], 1)
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddFormControl.xlsm")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddFormControl.xlsm"))
+	assert.NoError(t, err)
+	check(f)
+	assert.NotNil(t, f.XLSX["xl/ctrlProps/ctrlProp1.xml"])
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, strings.Count(ws.Controls.Content, "<control "))
+	assert.Contains(t, string(f.XLSX["xl/ctrlProps/ctrlProp2.xml"]), `objectType="CheckBox" checked="Checked" fmlaLink="$A$1"`)
+	assert.Contains(t, string(f.readXML("[Content_Types].xml")), `<Override PartName="/xl/ctrlProps/ctrlProp6.xml" ContentType="application/vnd.ms-excel.controlproperties+xml">`)
+
+	// Test add form control on the worksheet loaded with the form controls and
+	// comments, the shape IDs should be unique.
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "F8", Type: FormControlCheckBox}))
+	controls, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, controls, 7)
+	assert.Equal(t, "Check Box 8", controls[6].Text)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	shapeIDs := map[string]bool{}
+	for _, shape := range vml.Shape {
+		shapeIDs[shape.ID] = true
+	}
+	assert.Len(t, shapeIDs, 8)
+	assert.Len(t, vml.Shapetype, 2)
+
+	// Test add form control with unsupported form control type.
+	assert.EqualError(t, f.AddFormControl("Sheet1", FormControl{Cell: "A1", Type: 0xff}), "unsupported form control type")
+	// Test add form control with invalid value settings.
+	for _, opts := range []FormControl{
+		{MinVal: 10, MaxVal: 5},
+		{MinVal: -1, MaxVal: 5},
+		{MaxVal: 30001},
+		{CurrentVal: 101},
+		{IncChange: -1},
+		{PageChange: 30001},
+	} {
+		opts.Cell, opts.Type = "A1", FormControlScrollBar
+		assert.EqualError(t, f.AddFormControl("Sheet1", opts), "invalid form control value settings")
+	}
+	// Test add form control with invalid cell coordinates.
+	assert.EqualError(t, f.AddFormControl("Sheet1", FormControl{Cell: "A", Type: FormControlButton}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test add form control on not exist worksheet.
+	assert.EqualError(t, f.AddFormControl("SheetN", FormControl{Cell: "A1", Type: FormControlButton}), "sheet SheetN is not exist")
+}
+
+func TestGetFormControls(t *testing.T) {
+	f := NewFile()
+	// Test get form controls on the worksheet without legacy drawing.
+	controls, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, controls, 0)
+	// Test get form controls on the worksheet only contains comments.
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	controls, err = f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, controls, 0)
+	// Test get form controls with invalid anchor.
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape = append(f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape,
+		xlsxShape{Val: `<x:ClientData ObjectType="Checkbox"><x:Anchor>1, 0, 1</x:Anchor></x:ClientData>`})
+	controls, err = f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, controls, 0)
+	// Test get form controls on not exist worksheet.
+	_, err = f.GetFormControls("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
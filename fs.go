@@ -0,0 +1,42 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+//go:build go1.16
+// +build go1.16
+
+package excelize
+
+import "io/fs"
+
+// OpenFS provides a function to open the spreadsheet by given the file system
+// and the name of the file in it, such as the embedded templates and the file
+// systems of the cloud storage backends. This function requires Go version
+// 1.16 or later. Note that the path of the returned spreadsheet is not set,
+// use SaveAs, Write or WriteContext instead of Save to save it. For example,
+// open the embedded template:
+//
+//    //go:embed templates
+//    var templates embed.FS
+//
+//    f, err := excelize.OpenFS(templates, "templates/Book1.xlsx")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//
+func OpenFS(fsys fs.FS, name string, opt ...Options) (*File, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return OpenReader(file, opt...)
+}
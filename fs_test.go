@@ -0,0 +1,30 @@
+//go:build go1.16
+// +build go1.16
+
+package excelize
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenFS(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	fsys := fstest.MapFS{"templates/Book1.xlsx": &fstest.MapFile{Data: data}}
+	f, err := OpenFS(fsys, "templates/Book1.xlsx")
+	assert.NoError(t, err)
+	assert.Equal(t, "", f.Path)
+	val, err := f.GetCellValue("Sheet2", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "17-20 Inch", val)
+	assert.EqualError(t, f.Save(), "no path defined for file, consider File.WriteTo or File.Write")
+
+	// Test open file system with not exists file.
+	_, err = OpenFS(fsys, "templates/Book2.xlsx")
+	assert.EqualError(t, err, "open templates/Book2.xlsx: file does not exist")
+}
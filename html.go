@@ -0,0 +1,449 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// HTMLOptions directly maps the options for exporting the worksheet as HTML.
+// The Range is the cell range to be exported, such as "A1:D10", the used
+// range of the worksheet will be exported if it is empty. Set Document to
+// write a complete HTML document instead of the table element only.
+type HTMLOptions struct {
+	Range    string
+	Document bool
+}
+
+// indexedColors defined the default legacy indexed color palette.
+var indexedColors = []string{
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"000000", "FFFFFF", "FF0000", "00FF00", "0000FF", "FFFF00", "FF00FF", "00FFFF",
+	"800000", "008000", "000080", "808000", "800080", "008080", "C0C0C0", "808080",
+	"9999FF", "993366", "FFFFCC", "CCFFFF", "660066", "FF8080", "0066CC", "CCCCFF",
+	"000080", "FF00FF", "FFFF00", "00FFFF", "800080", "800000", "008080", "0000FF",
+	"00CCFF", "CCFFFF", "CCFFCC", "FFFF99", "99CCFF", "FF99CC", "CC99FF", "FFCC99",
+	"3366FF", "33CCCC", "99CC00", "FFCC00", "FF9900", "FF6600", "666699", "969696",
+	"003366", "339966", "003300", "333300", "993300", "993366", "333399", "333333",
+}
+
+// htmlBorderStyles defined the CSS border styles of the cell border styles.
+var htmlBorderStyles = map[string]string{
+	"thin":             "1px solid",
+	"hair":             "1px solid",
+	"dotted":           "1px dotted",
+	"dashed":           "1px dashed",
+	"dashDot":          "1px dashed",
+	"dashDotDot":       "1px dotted",
+	"medium":           "2px solid",
+	"mediumDashed":     "2px dashed",
+	"mediumDashDot":    "2px dashed",
+	"mediumDashDotDot": "2px dotted",
+	"slantDashDot":     "2px dashed",
+	"thick":            "3px solid",
+	"double":           "3px double",
+}
+
+// htmlAttrEscaper escapes the characters which could terminate the double
+// quoted attribute value or start a new element in the inline CSS.
+var htmlAttrEscaper = strings.NewReplacer(`&`, "&amp;", `"`, "&quot;", `<`, "&lt;", `>`, "&gt;")
+
+// htmlMergeCell directly maps the merged cell range of the HTML table.
+type htmlMergeCell struct {
+	c    *xlsxC
+	rect []int
+}
+
+// WriteHTML provides a function to write the worksheet or the cell range to
+// the writer as an HTML table with inline CSS by given worksheet name, the
+// writer and the HTML options. The fonts, fills, borders, alignments, merged
+// cells, column widths, row heights and hidden rows and columns are
+// preserved, and the cell values are formatted with the number formats as
+// GetCellValue displays them, which is suitable for the email bodies and the
+// web previews of the generated reports. For example, write the cell range
+// A1:D10 of Sheet1 as HTML document:
+//
+//    var buf bytes.Buffer
+//    if err := f.WriteHTML("Sheet1", &buf, excelize.HTMLOptions{
+//        Range:    "A1:D10",
+//        Document: true,
+//    }); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) WriteHTML(sheet string, w io.Writer, opts ...HTMLOptions) error {
+	var options HTMLOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	cells, area := map[int]map[int]*xlsxC{}, []int{1, 1, 0, 0}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		for idx := range row.C {
+			col, rowNum := idx+1, row.R
+			if row.C[idx].R != "" {
+				if col, rowNum, err = CellNameToCoordinates(row.C[idx].R); err != nil {
+					return err
+				}
+			}
+			if cells[rowNum] == nil {
+				cells[rowNum] = map[int]*xlsxC{}
+			}
+			cells[rowNum][col] = &row.C[idx]
+			if col > area[2] {
+				area[2] = col
+			}
+			if rowNum > area[3] {
+				area[3] = rowNum
+			}
+		}
+	}
+	mergeCells := map[int]map[int]*htmlMergeCell{}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			rect, err := f.areaRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			merged := &htmlMergeCell{c: &xlsxC{}, rect: rect}
+			if c, ok := cells[rect[1]][rect[0]]; ok {
+				merged.c = c
+			}
+			for row := rect[1]; row <= rect[3]; row++ {
+				if mergeCells[row] == nil {
+					mergeCells[row] = map[int]*htmlMergeCell{}
+				}
+				for col := rect[0]; col <= rect[2]; col++ {
+					mergeCells[row][col] = merged
+				}
+			}
+			if rect[2] > area[2] {
+				area[2] = rect[2]
+			}
+			if rect[3] > area[3] {
+				area[3] = rect[3]
+			}
+		}
+	}
+	if options.Range != "" {
+		ref := options.Range
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		if area, err = f.areaRefToCoordinates(ref); err != nil {
+			return err
+		}
+		_ = sortCoordinates(area)
+	}
+	var (
+		buf    = bufio.NewWriter(w)
+		sst    = f.sharedStringsReader()
+		styles = map[int]string{}
+		rows   = map[int]*xlsxRow{}
+	)
+	for idx := range ws.SheetData.Row {
+		rows[ws.SheetData.Row[idx].R] = &ws.SheetData.Row[idx]
+	}
+	if options.Document {
+		buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+		buf.WriteString(html.EscapeString(sheet))
+		buf.WriteString("</title>\n</head>\n<body>\n")
+	}
+	// The declarations of the default style are applied on the table, and
+	// only the different declarations will be applied on the cells.
+	baseCSS := f.getHTMLCellStyle(0)
+	baseDecls := map[string]bool{}
+	for _, decl := range strings.SplitAfter(baseCSS, ";") {
+		baseDecls[decl] = decl != ""
+	}
+	buf.WriteString(`<table style="border-collapse:collapse;table-layout:fixed;` + htmlAttrEscaper.Replace(baseCSS) + `">` + "\n<colgroup>")
+	hiddenCols, hiddenRows := map[int]bool{}, map[int]bool{}
+	for col := area[0]; col <= area[2]; col++ {
+		width, hidden := getHTMLColWidth(ws, col)
+		if hiddenCols[col] = hidden; hidden {
+			continue
+		}
+		fmt.Fprintf(buf, `<col style="width:%dpx">`, width)
+	}
+	buf.WriteString("</colgroup>\n")
+	for rowNum := area[1]; rowNum <= area[3]; rowNum++ {
+		if row, ok := rows[rowNum]; ok && row.Hidden {
+			hiddenRows[rowNum] = true
+		}
+	}
+	// visibleRange returns the first visible index and the number of the
+	// visible indexes in the given range clipped by the exported area.
+	visibleRange := func(from, to, min, max int, hidden map[int]bool) (int, int) {
+		first, count := 0, 0
+		for idx := from; idx <= to; idx++ {
+			if idx < min || idx > max || hidden[idx] {
+				continue
+			}
+			if count == 0 {
+				first = idx
+			}
+			count++
+		}
+		return first, count
+	}
+	for rowNum := area[1]; rowNum <= area[3]; rowNum++ {
+		if hiddenRows[rowNum] {
+			continue
+		}
+		height := int(defaultRowHeightPixels)
+		if row, ok := rows[rowNum]; ok && row.Ht != 0 {
+			height = int(convertRowHeightToPixels(row.Ht))
+		}
+		fmt.Fprintf(buf, `<tr style="height:%dpx">`, height)
+		for col := area[0]; col <= area[2]; col++ {
+			if hiddenCols[col] {
+				continue
+			}
+			c, ok := cells[rowNum][col]
+			if !ok {
+				c = &xlsxC{}
+			}
+			var attrs string
+			if merged, ok := mergeCells[rowNum][col]; ok {
+				firstRow, rowSpan := visibleRange(merged.rect[1], merged.rect[3], area[1], area[3], hiddenRows)
+				firstCol, colSpan := visibleRange(merged.rect[0], merged.rect[2], area[0], area[2], hiddenCols)
+				if firstRow != rowNum || firstCol != col {
+					continue
+				}
+				if rowSpan > 1 {
+					attrs += fmt.Sprintf(` rowspan="%d"`, rowSpan)
+				}
+				if colSpan > 1 {
+					attrs += fmt.Sprintf(` colspan="%d"`, colSpan)
+				}
+				c = merged.c
+			}
+			buf.WriteString("<td" + attrs)
+			styleID := f.prepareCellStyle(ws, col, c.S)
+			css, ok := styles[styleID]
+			if !ok {
+				var decls []string
+				for _, decl := range strings.SplitAfter(f.getHTMLCellStyle(styleID), ";") {
+					if !baseDecls[decl] {
+						decls = append(decls, decl)
+					}
+				}
+				css = strings.Join(decls, "")
+				styles[styleID] = css
+			}
+			if (c.T == "" || c.T == "n") && c.V != "" && !strings.Contains(css, "text-align:") {
+				css += "text-align:right;"
+			}
+			if css != "" {
+				fmt.Fprintf(buf, ` style="%s"`, htmlAttrEscaper.Replace(css))
+			}
+			buf.WriteByte('>')
+			val, err := c.getValueFrom(f, sst)
+			if err != nil {
+				return err
+			}
+			if c.T == "b" {
+				val = strings.ToUpper(strconv.FormatBool(val == "1"))
+			}
+			buf.WriteString(strings.Replace(html.EscapeString(val), "\n", "<br>", -1))
+			buf.WriteString("</td>")
+		}
+		buf.WriteString("</tr>\n")
+	}
+	buf.WriteString("</table>\n")
+	if options.Document {
+		buf.WriteString("</body>\n</html>\n")
+	}
+	return buf.Flush()
+}
+
+// getHTMLColWidth provides a function to get the column width in pixels and
+// if the column is hidden by given worksheet and column number.
+func getHTMLColWidth(ws *xlsxWorksheet, col int) (int, bool) {
+	width := int(defaultColWidthPixels)
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= col && col <= c.Max {
+				if c.Hidden {
+					return 0, true
+				}
+				if c.Width != 0 {
+					width = int(convertColWidthToPixels(c.Width))
+				}
+			}
+		}
+	}
+	return width, false
+}
+
+// getHTMLCellStyle provides a function to get the inline CSS of the cell by
+// given style index.
+func (f *File) getHTMLCellStyle(styleID int) string {
+	s := f.stylesReader()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return ""
+	}
+	var (
+		css   strings.Builder
+		xf    = s.CellXfs.Xf[styleID]
+		theme = f.themeReader()
+	)
+	if xf.FontID != nil && s.Fonts != nil && *xf.FontID < len(s.Fonts.Font) {
+		font := s.Fonts.Font[*xf.FontID]
+		if font.Name != nil && font.Name.Val != nil {
+			if name := getHTMLFontFamily(*font.Name.Val); name != "" {
+				fmt.Fprintf(&css, "font-family:'%s';", name)
+			}
+		}
+		if font.Sz != nil && font.Sz.Val != nil {
+			fmt.Fprintf(&css, "font-size:%spt;", strconv.FormatFloat(*font.Sz.Val, 'f', -1, 64))
+		}
+		if font.B != nil && *font.B {
+			css.WriteString("font-weight:bold;")
+		}
+		if font.I != nil && *font.I {
+			css.WriteString("font-style:italic;")
+		}
+		var decorations []string
+		if font.U != nil && (font.U.Val == nil || *font.U.Val != "none") {
+			decorations = append(decorations, "underline")
+		}
+		if font.Strike != nil && *font.Strike {
+			decorations = append(decorations, "line-through")
+		}
+		if len(decorations) > 0 {
+			fmt.Fprintf(&css, "text-decoration:%s;", strings.Join(decorations, " "))
+		}
+		if color := getHTMLColor(font.Color, theme); color != "" {
+			fmt.Fprintf(&css, "color:%s;", color)
+		}
+	}
+	if xf.FillID != nil && s.Fills != nil && *xf.FillID < len(s.Fills.Fill) {
+		fill := s.Fills.Fill[*xf.FillID]
+		if fill.PatternFill != nil && fill.PatternFill.PatternType != "" && fill.PatternFill.PatternType != "none" {
+			if color := getHTMLColor(fill.PatternFill.FgColor, theme); color != "" {
+				fmt.Fprintf(&css, "background-color:%s;", color)
+			}
+		}
+		if fill.GradientFill != nil && len(fill.GradientFill.Stop) > 0 {
+			if color := getHTMLColor(&fill.GradientFill.Stop[0].Color, theme); color != "" {
+				fmt.Fprintf(&css, "background-color:%s;", color)
+			}
+		}
+	}
+	if xf.BorderID != nil && s.Borders != nil && *xf.BorderID < len(s.Borders.Border) {
+		border := s.Borders.Border[*xf.BorderID]
+		for _, line := range []struct {
+			side string
+			line xlsxLine
+		}{{"top", border.Top}, {"right", border.Right}, {"bottom", border.Bottom}, {"left", border.Left}} {
+			style, ok := htmlBorderStyles[line.line.Style]
+			if !ok {
+				continue
+			}
+			color := getHTMLColor(line.line.Color, theme)
+			if color == "" {
+				color = "#000000"
+			}
+			fmt.Fprintf(&css, "border-%s:%s %s;", line.side, style, color)
+		}
+	}
+	if xf.Alignment != nil {
+		switch xf.Alignment.Horizontal {
+		case "left", "center", "right", "justify":
+			fmt.Fprintf(&css, "text-align:%s;", xf.Alignment.Horizontal)
+		case "centerContinuous", "distributed":
+			css.WriteString("text-align:center;")
+		}
+		switch xf.Alignment.Vertical {
+		case "top":
+			css.WriteString("vertical-align:top;")
+		case "center", "distributed", "justify":
+			css.WriteString("vertical-align:middle;")
+		}
+		if xf.Alignment.Indent > 0 {
+			fmt.Fprintf(&css, "padding-left:%dpx;", xf.Alignment.Indent*9)
+		}
+		if xf.Alignment.WrapText {
+			css.WriteString("white-space:pre-wrap;")
+		}
+	}
+	return css.String()
+}
+
+// getHTMLFontFamily provides a function to get the font family name which is
+// safe for the inline CSS by given font name, only the letters, digits,
+// spaces, hyphens, underscores, periods and commas are kept, so the font name
+// in the spreadsheet can't terminate the CSS declaration or the attribute.
+func getHTMLFontFamily(name string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(" -_.,", r) {
+			return r
+		}
+		return -1
+	}, name))
+}
+
+// getHTMLColor provides a function to get the CSS color by given color and
+// the theme, the empty string will be returned for the automatic color.
+func getHTMLColor(color *xlsxColor, theme *xlsxTheme) string {
+	if color == nil || color.Auto {
+		return ""
+	}
+	var baseColor string
+	switch {
+	case color.RGB != "":
+		baseColor = strings.ToUpper(color.RGB)
+		if len(baseColor) == 8 {
+			baseColor = baseColor[2:]
+		}
+	case color.Theme != nil:
+		// The first two pairs of the theme colors are swapped: lt1, dk1,
+		// lt2, dk2, accent1-6, hlink and folHlink.
+		idx := *color.Theme
+		if idx < 4 {
+			idx ^= 1
+		}
+		children := theme.ThemeElements.ClrScheme.Children
+		if idx < 0 || idx >= len(children) {
+			return ""
+		}
+		if children[idx].SrgbClr != nil && children[idx].SrgbClr.Val != nil {
+			baseColor = *children[idx].SrgbClr.Val
+		}
+		if children[idx].SysClr != nil {
+			baseColor = children[idx].SysClr.LastClr
+		}
+	case color.Indexed < len(indexedColors):
+		baseColor = indexedColors[color.Indexed]
+	}
+	if len(baseColor) != 6 {
+		return ""
+	}
+	if _, err := strconv.ParseUint(baseColor, 16, 32); err != nil {
+		return ""
+	}
+	return "#" + ThemeColor(baseColor, color.Tint)[2:]
+}
@@ -0,0 +1,122 @@
+package excelize
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHTML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Item", "Price", "Note", "Hidden"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"<Apple>", 1.5, "line1\nline2", "x"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Hidden row", 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{true, 3}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Total"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "B6"))
+	header, err := f.NewStyle(`{
+		"font":{"bold":true,"italic":true,"underline":"single","strike":true,"family":"Arial","size":12,"color":"#FF0000"},
+		"fill":{"type":"pattern","pattern":1,"color":["#FFFF00"]},
+		"border":[{"type":"left","color":"0000FF","style":1},{"type":"bottom","style":3}],
+		"alignment":{"horizontal":"center","vertical":"center","indent":1,"wrap_text":true}
+	}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C1", header))
+	price, err := f.NewStyle(`{"number_format":2}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", price))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf))
+	assert.Equal(t, `<table style="border-collapse:collapse;table-layout:fixed;font-family:'Calibri';font-size:11pt;color:#000000;">`+"\n"+
+		`<colgroup><col style="width:146px"><col style="width:64px"><col style="width:64px"></colgroup>`+"\n"+
+		`<tr style="height:20px"><td style="font-family:'Arial';font-size:12pt;font-weight:bold;font-style:italic;text-decoration:underline line-through;color:#FF0000;background-color:#FFFF00;border-bottom:1px dashed #000000;border-left:1px solid #0000FF;text-align:center;vertical-align:middle;padding-left:9px;white-space:pre-wrap;">Item</td>`,
+		buf.String()[:strings.Index(buf.String(), "</td>")+5])
+	for _, expected := range []string{
+		`<tr style="height:40px"><td>&lt;Apple&gt;</td><td style="text-align:right;">1.50</td><td>line1<br>line2</td></tr>`,
+		`<tr style="height:20px"><td>TRUE</td><td style="text-align:right;">3</td><td></td></tr>`,
+		`<tr style="height:20px"><td rowspan="2" colspan="2">Total</td><td></td></tr>` + "\n" + `<tr style="height:20px"><td></td></tr>`,
+	} {
+		assert.Contains(t, buf.String(), expected)
+	}
+	assert.NotContains(t, buf.String(), "Hidden")
+
+	// Test write HTML document with the range cross the merged cell.
+	buf.Reset()
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf, HTMLOptions{Range: "B6:B4", Document: true}))
+	assert.True(t, strings.HasPrefix(buf.String(), "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Sheet1</title>"))
+	assert.Contains(t, buf.String(), `<tr style="height:20px"><td style="text-align:right;">3</td></tr>`+"\n"+`<tr style="height:20px"><td rowspan="2">Total</td></tr>`+"\n"+`<tr style="height:20px"></tr>`)
+	assert.True(t, strings.HasSuffix(buf.String(), "</table>\n</body>\n</html>\n"))
+	// Test write HTML with single cell range.
+	buf.Reset()
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf, HTMLOptions{Range: "C2"}))
+	assert.Contains(t, buf.String(), `<tr style="height:40px"><td>line1<br>line2</td></tr>`)
+
+	// Test write HTML with invalid range.
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf, HTMLOptions{Range: "A"}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test write HTML on not exists worksheet.
+	assert.EqualError(t, f.WriteHTML("SheetN", &buf), "sheet SheetN is not exist")
+	// Test write HTML with failing writer.
+	assert.EqualError(t, f.WriteHTML("Sheet1", errWriter{}), "write error")
+	// Test write HTML with invalid merged cell reference.
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.MergeCells.Cells[0].Ref = "A:B"
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test write HTML with invalid cell reference.
+	ws.SheetData.Row[0].C[0].R = "A"
+	assert.EqualError(t, f.WriteHTML("Sheet1", &buf), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestGetHTMLColor(t *testing.T) {
+	f := NewFile()
+	theme := f.themeReader()
+	for _, c := range []struct {
+		color    *xlsxColor
+		expected string
+	}{
+		{nil, ""},
+		{&xlsxColor{Auto: true}, ""},
+		{&xlsxColor{RGB: "ff00ff00"}, "#00FF00"},
+		{&xlsxColor{RGB: "123"}, ""},
+		{&xlsxColor{RGB: "GGGGGG"}, ""},
+		{&xlsxColor{RGB: `FF"><b>`}, ""},
+		{&xlsxColor{Theme: intPtr(0)}, "#FFFFFF"},
+		{&xlsxColor{Theme: intPtr(1)}, "#000000"},
+		{&xlsxColor{Theme: intPtr(4)}, "#5B9BD5"},
+		{&xlsxColor{Theme: intPtr(4), Tint: 0.5}, "#ADCDEA"},
+		{&xlsxColor{Theme: intPtr(20)}, ""},
+		{&xlsxColor{Indexed: 10}, "#FF0000"},
+		{&xlsxColor{Indexed: 64}, ""},
+	} {
+		assert.Equal(t, c.expected, getHTMLColor(c.color, theme))
+	}
+	// Test get HTML cell style with invalid style index.
+	assert.Equal(t, "", f.getHTMLCellStyle(-1))
+	f.Styles.Fills.Fill = append(f.Styles.Fills.Fill, &xlsxFill{GradientFill: &xlsxGradientFill{Stop: []*xlsxGradientFillStop{{Color: xlsxColor{RGB: "FF0000FF"}}}}})
+	fillID := len(f.Styles.Fills.Fill) - 1
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{FillID: &fillID, Alignment: &xlsxAlignment{Horizontal: "distributed", Vertical: "top"}})
+	assert.Equal(t, "background-color:#0000FF;text-align:center;vertical-align:top;", f.getHTMLCellStyle(len(f.Styles.CellXfs.Xf)-1))
+}
+
+func TestWriteHTMLHostileStyle(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "text"))
+	name := `x";onmouseover="alert(1)'<script>`
+	f.Styles.Fonts.Font = append(f.Styles.Fonts.Font, &xlsxFont{Name: &attrValString{Val: &name}})
+	fontID := len(f.Styles.Fonts.Font) - 1
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{FontID: &fontID})
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", len(f.Styles.CellXfs.Xf)-1))
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteHTML("Sheet1", &buf))
+	assert.Contains(t, buf.String(), `<td style="font-family:'xonmouseoveralert1script';">text</td>`)
+	assert.NotContains(t, buf.String(), "onmouseover=")
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.Equal(t, "", getHTMLFontFamily(`";<>'{}`))
+}
@@ -0,0 +1,408 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONOptions directly maps the options for the JSON import and export of
+// the worksheet data. The Separator is used to join the keys of the nested
+// fields into the column headers, the default value is ".". Set Nested to
+// rebuild the nested objects and arrays from the column headers on export.
+type JSONOptions struct {
+	Separator string
+	Nested    bool
+}
+
+// jsonObject directly maps the JSON object which keeps the order of the keys.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// newJSONObject provides a function to create an empty ordered JSON object.
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: map[string]interface{}{}}
+}
+
+// set provides a function to set the value of the ordered JSON object by
+// given key and value, the new key will be appended to the end.
+func (o *jsonObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON implements the json.Marshaler interface to encode the object
+// with the keys in order.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for idx, key := range o.keys {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// getJSONOptions provides a function to get the JSON options with default
+// value.
+func getJSONOptions(opts []JSONOptions) JSONOptions {
+	options := JSONOptions{}
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Separator == "" {
+		options.Separator = "."
+	}
+	return options
+}
+
+// GetSheetAsJSON provides a function to get the data of the worksheet as a
+// JSON array of objects by given worksheet name and the JSON options. The
+// first row of the worksheet is used as the header row, the values of the
+// header cells are used as the keys of the objects, the column name will be
+// used if the header cell is empty. The booleans and numbers are encoded as
+// the JSON booleans and numbers, the dates are encoded as the strings in RFC
+// 3339 format, the empty cells are encoded as null, and the empty rows will
+// be skipped. For example, the worksheet:
+//
+//     |    A    |        B       |      C
+//    -+---------+----------------+--------------
+//    1| Name    | address.city   | tags.0
+//    2| Alice   | Paris          | admin
+//
+// will be exported as the following with the Nested option:
+//
+//    [{"Name":"Alice","address":{"city":"Paris"},"tags":["admin"]}]
+//
+// and will be exported as the following without the Nested option:
+//
+//    [{"Name":"Alice","address.city":"Paris","tags.0":"admin"}]
+//
+func (f *File) GetSheetAsJSON(sheet string, opts ...JSONOptions) ([]byte, error) {
+	options := getJSONOptions(opts)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	var (
+		sst     = f.sharedStringsReader()
+		header  []string
+		records = []interface{}{}
+	)
+	for rowIdx, row := range ws.SheetData.Row {
+		values := map[int]interface{}{}
+		for idx := range row.C {
+			c := &row.C[idx]
+			col := idx + 1
+			if c.R != "" {
+				if col, _, err = CellNameToCoordinates(c.R); err != nil {
+					return nil, err
+				}
+			}
+			if rowIdx == 0 {
+				for len(header) < col {
+					name, _ := ColumnNumberToName(len(header) + 1)
+					header = append(header, name)
+				}
+				if name, _ := c.getValueFrom(f, sst); name != "" {
+					header[col-1] = name
+				}
+				continue
+			}
+			value, err := f.getJSONCellValue(c, sst)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				values[col] = value
+			}
+		}
+		if rowIdx == 0 {
+			continue
+		}
+		record := newJSONObject()
+		empty := true
+		for col, key := range header {
+			value := values[col+1]
+			if value != nil {
+				empty = false
+			}
+			record.set(key, value)
+		}
+		if empty {
+			continue
+		}
+		if options.Nested {
+			records = append(records, nestJSONObject(record, options.Separator))
+			continue
+		}
+		records = append(records, record)
+	}
+	return json.Marshal(records)
+}
+
+// getJSONCellValue provides a function to get the value of the cell for the
+// JSON export by given cell and the shared strings table.
+func (f *File) getJSONCellValue(c *xlsxC, sst *xlsxSST) (interface{}, error) {
+	switch c.T {
+	case "b":
+		return c.V == "1", nil
+	case "e":
+		return c.V, nil
+	case "", "n":
+		if c.V == "" {
+			return nil, nil
+		}
+		if isNum, precision := isNumeric(c.V); isNum {
+			if f.isDateStyle(c.S) {
+				excelTime, _ := strconv.ParseFloat(c.V, 64)
+				return timeFromExcelTime(excelTime, f.date1904()).Round(time.Millisecond).Format(time.RFC3339), nil
+			}
+			if precision > 15 {
+				val, _ := roundPrecision(c.V)
+				return json.Number(val), nil
+			}
+			return json.Number(c.V), nil
+		}
+	}
+	val, err := c.getValueFrom(f, sst)
+	if val == "" {
+		return nil, err
+	}
+	return val, err
+}
+
+// nestJSONObject provides a function to rebuild the nested objects and
+// arrays from the flattened object by given object and the separator of the
+// keys. The key will be kept as is if any of its parent keys has a value, and
+// the later value will overwrite the earlier one with the same key.
+func nestJSONObject(flat *jsonObject, sep string) interface{} {
+	root := newJSONObject()
+	for _, key := range flat.keys {
+		parts, obj := strings.Split(key, sep), root
+		for idx, part := range parts {
+			if idx == len(parts)-1 {
+				if _, ok := obj.values[part]; ok {
+					root.set(key, flat.values[key])
+					break
+				}
+				obj.set(part, flat.values[key])
+				break
+			}
+			child, ok := obj.values[part].(*jsonObject)
+			if !ok {
+				if _, exist := obj.values[part]; exist {
+					root.set(key, flat.values[key])
+					break
+				}
+				child = newJSONObject()
+				obj.set(part, child)
+			}
+			obj = child
+		}
+	}
+	return toJSONArrays(root)
+}
+
+// toJSONArrays provides a function to convert the objects which keys are the
+// consecutive indexes starting from 0 into arrays recursively.
+func toJSONArrays(value interface{}) interface{} {
+	obj, ok := value.(*jsonObject)
+	if !ok {
+		return value
+	}
+	isArray := len(obj.keys) > 0
+	for idx, key := range obj.keys {
+		obj.values[key] = toJSONArrays(obj.values[key])
+		if key != strconv.Itoa(idx) {
+			isArray = false
+		}
+	}
+	if !isArray {
+		return obj
+	}
+	arr := make([]interface{}, len(obj.keys))
+	for idx, key := range obj.keys {
+		arr[idx] = obj.values[key]
+	}
+	return arr
+}
+
+// SetSheetFromJSON provides a function to set the worksheet data by given
+// worksheet name, the top-left cell, the JSON array of objects and the JSON
+// options. The keys of the objects will be written as the header row in the
+// order they first appear, and each object will be written as a row below
+// the header. The nested fields are flattened by the following rules: the
+// keys of the nested object are joined with the parent key by the separator,
+// the elements of the array are keyed by the indexes starting from 0, the
+// empty objects, empty arrays and null values are skipped. The JSON strings,
+// numbers and booleans will be stored as the text, numeric and boolean cell
+// values. For example, set the data start from Sheet1!A1:
+//
+//    err := f.SetSheetFromJSON("Sheet1", "A1", []byte(`[
+//        {"Name": "Alice", "address": {"city": "Paris"}, "tags": ["admin"]},
+//        {"Name": "Bob", "age": 30}
+//    ]`))
+//
+// The result of the worksheet:
+//
+//     |    A    |        B       |      C      |  D
+//    -+---------+----------------+-------------+-----
+//    1| Name    | address.city   | tags.0      | age
+//    2| Alice   | Paris          | admin       |
+//    3| Bob     |                |             | 30
+//
+func (f *File) SetSheetFromJSON(sheet, cell string, data []byte, opts ...JSONOptions) error {
+	options := getJSONOptions(opts)
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeJSONValue(dec)
+	if err != nil {
+		return err
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return errors.New("the JSON data must be an array of objects")
+	}
+	header, records := newJSONObject(), make([]*jsonObject, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(*jsonObject)
+		if !ok {
+			return errors.New("the JSON data must be an array of objects")
+		}
+		record := newJSONObject()
+		flattenJSONValue("", options.Separator, obj, record)
+		for _, key := range record.keys {
+			header.set(key, nil)
+		}
+		records = append(records, record)
+	}
+	values := make([]interface{}, len(header.keys))
+	for idx, key := range header.keys {
+		values[idx] = key
+	}
+	if err = f.setCSVRow(sheet, col, row, values); err != nil {
+		return err
+	}
+	for idx, record := range records {
+		for i, key := range header.keys {
+			values[i] = nil
+			switch value := record.values[key].(type) {
+			case json.Number:
+				if num, err := strconv.ParseFloat(string(value), 64); err == nil {
+					values[i] = num
+					continue
+				}
+				values[i] = string(value)
+			case string, bool:
+				values[i] = value
+			}
+		}
+		if err = f.setCSVRow(sheet, col, row+idx+1, values); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// decodeJSONValue provides a function to decode the JSON value with the
+// order of the object keys by given JSON decoder.
+func decodeJSONValue(dec *json.Decoder) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, err
+	}
+	switch delim {
+	case '{':
+		obj := newJSONObject()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.set(key.(string), value)
+		}
+		_, err = dec.Token()
+		return obj, err
+	default:
+		arr := []interface{}{}
+		for dec.More() {
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+}
+
+// flattenJSONValue provides a function to flatten the nested JSON value into
+// the record by given key prefix, the separator, JSON value and the record.
+func flattenJSONValue(prefix, sep string, value interface{}, record *jsonObject) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + sep + key
+	}
+	switch value := value.(type) {
+	case *jsonObject:
+		for _, key := range value.keys {
+			flattenJSONValue(join(key), sep, value.values[key], record)
+		}
+	case []interface{}:
+		for idx, v := range value {
+			flattenJSONValue(join(strconv.Itoa(idx)), sep, v, record)
+		}
+	case nil:
+	default:
+		record.set(prefix, value)
+	}
+}
@@ -0,0 +1,101 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSheetFromJSON(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetFromJSON("Sheet1", "B2", []byte(`[
+		{"Name": "Alice", "address": {"city": "Paris", "zip": "75001"}, "tags": ["admin", "dev"], "active": true},
+		{"Name": "Bob", "age": 30, "address": {}, "tags": [], "note": null, "id": 12345678901234567890e400}
+	]`)))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Name", "address.city", "address.zip", "tags.0", "tags.1", "active", "age", "id"},
+		{"", "Alice", "Paris", "75001", "admin", "dev", "1"},
+		{"", "Bob", "", "", "", "", "", "30", "12345678901234567890e400"},
+	}, rows)
+	for cell, expected := range map[string]CellType{"D3": CellTypeString, "G3": CellTypeBool, "H4": CellTypeNumber, "I4": CellTypeString} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	// Test set sheet from JSON with custom separator.
+	assert.NoError(t, f.SetSheetFromJSON("Sheet1", "A10", []byte(`[{"a": {"b": [1]}}]`), JSONOptions{Separator: "_"}))
+	val, err := f.GetCellValue("Sheet1", "A10")
+	assert.NoError(t, err)
+	assert.Equal(t, "a_b_0", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetFromJSON.xlsx")))
+
+	// Test set sheet from JSON with invalid data.
+	for _, data := range []string{`{}`, `[1]`, `"a"`} {
+		assert.EqualError(t, f.SetSheetFromJSON("Sheet1", "A1", []byte(data)), "the JSON data must be an array of objects")
+	}
+	for _, data := range []string{``, `[`, `[{"a"`, `[{"a":}]`, `[{"a":[}]`, `[{]`} {
+		assert.Error(t, f.SetSheetFromJSON("Sheet1", "A1", []byte(data)), data)
+	}
+	assert.EqualError(t, f.SetSheetFromJSON("Sheet1", "A", []byte(`[]`)), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.SetSheetFromJSON("SheetN", "A1", []byte(`[]`)), "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetSheetFromJSON("Sheet1", "XFD1", []byte(`[{"a":1,"b":2}]`)), "column number exceeds maximum limit")
+	assert.EqualError(t, f.SetSheetFromJSON("Sheet1", "A1048576", []byte(`[{"a":1}]`)), "row number exceeds maximum limit")
+}
+
+func TestGetSheetAsJSON(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetFromJSON("Sheet1", "A1", []byte(`[
+		{"Name": "Alice", "address": {"city": "Paris"}, "tags": ["admin", "dev"], "active": true, "score": 1.5},
+		{"Name": "Bob", "score": 0.1234567890123456789}
+	]`)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H1", "Date"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H2", time.Date(2021, 3, 4, 8, 30, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "J3", "#N/A"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Carol"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "J5", "ignored"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[2].C[len(ws.SheetData.Row[2].C)-1].T = "e"
+	ws.SheetData.Row[2].C[len(ws.SheetData.Row[2].C)-1].V = "#N/A"
+	assert.NoError(t, f.SetCellValue("Sheet1", "I1", ""))
+	assert.NoError(t, f.SetCellValue("Sheet1", "J1", "Error"))
+
+	data, err := f.GetSheetAsJSON("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Name":"Alice","address.city":"Paris","tags.0":"admin","tags.1":"dev","active":true,"score":1.5,"G":null,"Date":"2021-03-04T08:30:00Z","I":null,"Error":null},`+
+		`{"Name":"Bob","address.city":null,"tags.0":null,"tags.1":null,"active":null,"score":0.123456789012346,"G":null,"Date":null,"I":null,"Error":"#N/A"},`+
+		`{"Name":"Carol","address.city":null,"tags.0":null,"tags.1":null,"active":null,"score":null,"G":null,"Date":null,"I":null,"Error":"ignored"}]`, string(data))
+
+	data, err = f.GetSheetAsJSON("Sheet1", JSONOptions{Nested: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Name":"Alice","address":{"city":"Paris"},"tags":["admin","dev"],"active":true,"score":1.5,"G":null,"Date":"2021-03-04T08:30:00Z","I":null,"Error":null},`+
+		`{"Name":"Bob","address":{"city":null},"tags":[null,null],"active":null,"score":0.123456789012346,"G":null,"Date":null,"I":null,"Error":"#N/A"},`+
+		`{"Name":"Carol","address":{"city":null},"tags":[null,null],"active":null,"score":null,"G":null,"Date":null,"I":null,"Error":"ignored"}]`, string(data))
+
+	// Test get sheet as JSON with conflicting nested keys.
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"a", "a.b", "c.d", "c.d.e", "c"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{1, 2, 3, 4, 5}))
+	data, err = f.GetSheetAsJSON("Sheet1", JSONOptions{Nested: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"a":1,"a.b":2,"c":5,"c.d.e":4}]`, string(data))
+
+	// Test get sheet as JSON on the empty worksheet.
+	data, err = f.GetSheetAsJSON(f.GetSheetName(f.NewSheet("Sheet2")))
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+	// Test get sheet as JSON on not exists worksheet.
+	_, err = f.GetSheetAsJSON("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get sheet as JSON with invalid cell reference.
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].R = "A"
+	_, err = f.GetSheetAsJSON("Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
@@ -18,46 +18,232 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
+// cellReferenceRegexp matches the cell references and the cell ranges in the
+// formulas, the submatches are the leading character, the optional worksheet
+// name with the exclamation mark, the column and the row of the first cell,
+// and the column and the row of the last cell.
+var cellReferenceRegexp = regexp.MustCompile(`(^|[^A-Za-z0-9_.!$'])('(?:[^']|'')+'!|[A-Za-z0-9_.]+!)?(\$?[A-Za-z]{1,3}\$?)(\d+)(?::(\$?[A-Za-z]{1,3}\$?)(\d+))?`)
+
 // ReadZipReader can be used to read the spreadsheet in memory without touching the
 // filesystem.
 func ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
+	fileList, _, worksheets, err := readZipReader(r, nil, nil)
+	return fileList, worksheets, err
+}
+
+// readZipReader provides a function to read the parts of the package by
+// given ZIP reader and the function to check if the part should be read
+// lazily by given path and the decompressed size of the part. The lazy parts
+// will not be decompressed, and their ZIP file entries will be returned for
+// reading on demand, the values of these parts in the file list are nil. The
+// progress function will be called after each part has been read.
+func readZipReader(r *zip.Reader, lazy func(name string, size int64) bool, progress func(current, total int)) (map[string][]byte, map[string]*zip.File, int, error) {
 	var err error
 	var docPart = map[string]string{
 		"[content_types].xml":  "[Content_Types].xml",
 		"xl/sharedstrings.xml": "xl/sharedStrings.xml",
 	}
-	fileList := make(map[string][]byte, len(r.File))
+	fileList, lazyParts := make(map[string][]byte, len(r.File)), map[string]*zip.File{}
 	worksheets := 0
-	for _, v := range r.File {
+	for idx, v := range r.File {
 		fileName := strings.Replace(v.Name, "\\", "/", -1)
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
-		if fileList[fileName], err = readFile(v); err != nil {
-			return nil, 0, err
-		}
 		if strings.HasPrefix(fileName, "xl/worksheets/sheet") {
 			worksheets++
 		}
+		if lazy != nil && lazy(fileName, int64(v.UncompressedSize64)) {
+			rc, err := v.Open()
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			rc.Close()
+			fileList[fileName], lazyParts[fileName] = nil, v
+		} else if fileList[fileName], err = readFile(v); err != nil {
+			return nil, nil, 0, err
+		}
+		if progress != nil {
+			progress(idx+1, len(r.File))
+		}
 	}
-	return fileList, worksheets, nil
+	return fileList, lazyParts, worksheets, nil
+}
+
+// isWorksheetPart provides a function to check if the part of the given path
+// is the XML part of the worksheet.
+func isWorksheetPart(name string) bool {
+	return strings.HasPrefix(name, "xl/worksheets/") && !strings.Contains(name, "/_rels/") && strings.HasSuffix(name, ".xml")
 }
 
-// readXML provides a function to read XML content as string.
+// readXML provides a function to read XML content as string. The part which
+// has not been decompressed or stored in the temporary file will be read on
+// demand, and the content will not be kept in memory. The error of reading
+// the part will be ignored, use readPart for the parts which could not be
+// treated as empty, such as the worksheets.
 func (f *File) readXML(name string) []byte {
-	if content, ok := f.XLSX[name]; ok {
-		return content
+	content, _ := f.readPart(name)
+	return content
+}
+
+// readPart provides a function to read the content of the part by given
+// path of the part, and returns the error if the part which has not been
+// decompressed or stored in the temporary file can't be read.
+func (f *File) readPart(name string) ([]byte, error) {
+	content, ok := f.XLSX[name]
+	if !ok {
+		return []byte{}, nil
+	}
+	if content == nil {
+		if path, ok := f.tempFiles[name]; ok {
+			return ioutil.ReadFile(path)
+		}
+		if file, ok := f.lazyParts[name]; ok {
+			return readFile(file)
+		}
+	}
+	return content, nil
+}
+
+// isPartChanged provides a function to check if the deserialized part has
+// been changed by given path of the part, the serialized content of the part
+// and the pointer to a new structure of the part. The original content of
+// the part will be deserialized into the given structure and serialized
+// again to compare with the content, so the unchanged part can be kept as
+// is in the package, including the elements and attributes which are not
+// supported by the structure.
+func (f *File) isPartChanged(path string, output []byte, v interface{}) bool {
+	content, err := f.readPart(path)
+	if err != nil || len(content) == 0 {
+		return true
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(v); err != nil && err != io.EOF {
+		return true
+	}
+	original, err := xml.Marshal(v)
+	return err != nil || !bytes.Equal(original, output)
+}
+
+// readXMLReader provides a function to get the reader of the XML content by
+// given path of the part, the part which has not been decompressed or stored
+// in the temporary file will be read as a stream, and the reader should be
+// closed after reading.
+func (f *File) readXMLReader(name string) (io.ReadCloser, error) {
+	if content, ok := f.XLSX[name]; ok && content == nil {
+		if path, ok := f.tempFiles[name]; ok {
+			return os.Open(path)
+		}
+		if file, ok := f.lazyParts[name]; ok {
+			return file.Open()
+		}
+	}
+	return ioutil.NopCloser(bytes.NewReader(f.readXML(name))), nil
+}
+
+// readSheetXMLReader provides a function to get the reader of the XML
+// content by given path of the worksheet part. The worksheet which has been
+// deserialized will be serialized into a new buffer instead of saving it to
+// the file list, so the worksheet could be read by multiple goroutines
+// concurrently.
+func (f *File) readSheetXMLReader(name string) (io.ReadCloser, error) {
+	f.Lock()
+	ws := f.Sheet[name]
+	f.Unlock()
+	if ws == nil {
+		return f.readXMLReader(name)
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	output, err := xml.Marshal(ws)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(output)), nil
+}
+
+// extractTempFiles provides a function to extract the parts which have not
+// been decompressed and larger than the memory limit of the part into the
+// temporary files.
+func (f *File) extractTempFiles() error {
+	if f.maxInMemoryPart <= 0 {
+		return nil
+	}
+	for name, file := range f.lazyParts {
+		if int64(file.UncompressedSize64) <= f.maxInMemoryPart {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = f.writeTempFile(name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		delete(f.lazyParts, name)
+	}
+	return nil
+}
+
+// writeTempFile provides a function to write the content of the part to a
+// temporary file by given path of the part and the reader of the content, the
+// content of the part in the file list will be released.
+func (f *File) writeTempFile(name string, r io.Reader) error {
+	tmp, err := ioutil.TempFile(os.TempDir(), "excelize-")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	f.setTempFile(name, tmp.Name())
+	return nil
+}
+
+// setTempFile provides a function to set the temporary file which stores
+// the content of the part by given path of the part and the temporary file.
+func (f *File) setTempFile(name, path string) {
+	f.removeTempFile(name)
+	if f.tempFiles == nil {
+		f.tempFiles = make(map[string]string)
+	}
+	f.tempFiles[name], f.XLSX[name] = path, nil
+}
+
+// removeTempFile provides a function to remove the temporary file of the
+// part by given path of the part.
+func (f *File) removeTempFile(name string) {
+	if path, ok := f.tempFiles[name]; ok {
+		os.Remove(path)
+		delete(f.tempFiles, name)
 	}
-	return []byte{}
 }
 
 // saveFileList provides a function to update given file content in file list
-// of XLSX.
+// of XLSX. The content larger than the memory limit of the part will be
+// stored in a temporary file.
 func (f *File) saveFileList(name string, content []byte) {
+	if f.maxInMemoryPart > 0 && int64(len(XMLHeader)+len(content)) > f.maxInMemoryPart {
+		if err := f.writeTempFile(name, io.MultiReader(strings.NewReader(XMLHeader), bytes.NewReader(content))); err == nil {
+			return
+		}
+	}
+	f.removeTempFile(name)
 	newContent := make([]byte, 0, len(XMLHeader)+len(content))
 	newContent = append(newContent, []byte(XMLHeader)...)
 	newContent = append(newContent, content...)
@@ -70,10 +256,12 @@ func readFile(file *zip.File) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer rc.Close()
 	dat := make([]byte, 0, file.FileInfo().Size())
 	buff := bytes.NewBuffer(dat)
-	_, _ = io.Copy(buff, rc)
-	rc.Close()
+	if _, err = io.Copy(buff, rc); err != nil {
+		return nil, err
+	}
 	return buff.Bytes(), nil
 }
 
@@ -182,7 +370,7 @@ func ColumnNumberToName(num int) (string, error) {
 //    excelize.CellNameToCoordinates("Z3") // returns 26, 3, nil
 //
 func CellNameToCoordinates(cell string) (int, int, error) {
-	const msg = "cannot convert cell %q to coordinates: %v"
+	const msg = "cannot convert cell %q to coordinates: %w"
 
 	colname, row, err := SplitCellName(cell)
 	if err != nil {
@@ -217,6 +405,45 @@ func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
 	return sign + colname + sign + strconv.Itoa(row), err
 }
 
+// replaceCellReferences provides a function to replace the cell references
+// and the cell ranges out of the string literals in the formula by given
+// formula and replace function. The replace function receives the unquoted
+// worksheet name of the reference, which is empty for the reference without
+// the worksheet name, and the column and row pairs of the cells, and returns
+// the new cells and if the reference should be replaced.
+func replaceCellReferences(formula string, replace func(sheet string, cells [][]string) (string, bool)) string {
+	parts := strings.Split(formula, "\"")
+	for i := 0; i < len(parts); i += 2 {
+		var b strings.Builder
+		part, last := parts[i], 0
+		for _, loc := range cellReferenceRegexp.FindAllStringSubmatchIndex(part, -1) {
+			if loc[1] < len(part) && strings.ContainsAny(part[loc[1]:loc[1]+1], "(_ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz") {
+				continue
+			}
+			var name string
+			if loc[4] != -1 {
+				if name = strings.TrimSuffix(part[loc[4]:loc[5]], "!"); strings.HasPrefix(name, "'") {
+					name = strings.Replace(name[1:len(name)-1], "''", "'", -1)
+				}
+			}
+			cells := [][]string{{part[loc[6]:loc[7]], part[loc[8]:loc[9]]}}
+			if loc[10] != -1 {
+				cells = append(cells, []string{part[loc[10]:loc[11]], part[loc[12]:loc[13]]})
+			}
+			refs, ok := replace(name, cells)
+			if !ok {
+				continue
+			}
+			b.WriteString(part[last:loc[6]])
+			b.WriteString(refs)
+			last = loc[1]
+		}
+		b.WriteString(part[last:])
+		parts[i] = b.String()
+	}
+	return strings.Join(parts, "\"")
+}
+
 // boolPtr returns a pointer to a bool with the given value.
 func boolPtr(b bool) *bool { return &b }
 
@@ -448,6 +675,18 @@ func isNumeric(s string) (bool, int) {
 	return true, p
 }
 
+// genGUID provides a function to generate a random GUID in the registry
+// format, such as {8A3C5E2B-7D41-4F0E-9B6A-2C8D1E4F7A90}.
+func genGUID() (string, error) {
+	b, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return strings.ToUpper(fmt.Sprintf("{%x-%x-%x-%x-%x}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])), err
+}
+
 // Stack defined an abstract data type that serves as a collection of elements.
 type Stack struct {
 	list *list.List
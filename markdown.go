@@ -0,0 +1,200 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MarkdownOptions directly maps the options for exporting the worksheet as
+// Markdown. The Range is the cell range to be exported, such as "A1:D10",
+// the used range of the worksheet will be exported if it is empty.
+type MarkdownOptions struct {
+	Range string
+}
+
+// markdownAlignments defined the delimiter cells of the Markdown table by the
+// horizontal alignment of the cells.
+var markdownAlignments = map[string]string{
+	"":                 "---",
+	"left":             ":---",
+	"center":           ":---:",
+	"centerContinuous": ":---:",
+	"right":            "---:",
+}
+
+// WriteMarkdown provides a function to write the worksheet or the cell range
+// to the writer as a GitHub Flavored Markdown table by given worksheet name,
+// the writer and the Markdown options. The first row of the range will be
+// used as the header row, the cell values are formatted with the number
+// formats as GetCellValue displays them, and the hidden rows and columns are
+// skipped. The alignment of each column is inferred from the horizontal
+// alignment of the cells below the header, or right-aligned if all of those
+// values are numbers. Note that the merged cells are not supported by the
+// Markdown table, so the value of a merged cell only appears in its top-left
+// cell. For example, write the cell range A1:D10 of Sheet1 as Markdown:
+//
+//    var buf bytes.Buffer
+//    if err := f.WriteMarkdown("Sheet1", &buf, excelize.MarkdownOptions{
+//        Range: "A1:D10",
+//    }); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) WriteMarkdown(sheet string, w io.Writer, opts ...MarkdownOptions) error {
+	var options MarkdownOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	cells, rows, area := map[int]map[int]*xlsxC{}, map[int]*xlsxRow{}, []int{1, 1, 0, 0}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		rows[row.R] = row
+		for idx := range row.C {
+			col, rowNum := idx+1, row.R
+			if row.C[idx].R != "" {
+				if col, rowNum, err = CellNameToCoordinates(row.C[idx].R); err != nil {
+					return err
+				}
+			}
+			if cells[rowNum] == nil {
+				cells[rowNum] = map[int]*xlsxC{}
+			}
+			cells[rowNum][col] = &row.C[idx]
+			if row.C[idx].hasData() {
+				if col > area[2] {
+					area[2] = col
+				}
+				if rowNum > area[3] {
+					area[3] = rowNum
+				}
+			}
+		}
+	}
+	if options.Range != "" {
+		ref := options.Range
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		if area, err = f.areaRefToCoordinates(ref); err != nil {
+			return err
+		}
+		_ = sortCoordinates(area)
+	}
+	var cols, rowNums []int
+	for col := area[0]; col <= area[2]; col++ {
+		if _, hidden := getHTMLColWidth(ws, col); !hidden {
+			cols = append(cols, col)
+		}
+	}
+	for rowNum := area[1]; rowNum <= area[3]; rowNum++ {
+		if row, ok := rows[rowNum]; !ok || !row.Hidden {
+			rowNums = append(rowNums, rowNum)
+		}
+	}
+	if len(cols) == 0 || len(rowNums) == 0 {
+		return nil
+	}
+	var (
+		buf    = bufio.NewWriter(w)
+		sst    = f.sharedStringsReader()
+		values = make([][]string, len(rowNums))
+	)
+	for rowIdx, rowNum := range rowNums {
+		values[rowIdx] = make([]string, len(cols))
+		for colIdx, col := range cols {
+			c, ok := cells[rowNum][col]
+			if !ok {
+				continue
+			}
+			val, err := c.getValueFrom(f, sst)
+			if err != nil {
+				return err
+			}
+			if c.T == "b" {
+				val = strings.ToUpper(strconv.FormatBool(val == "1"))
+			}
+			values[rowIdx][colIdx] = escapeMarkdownCell(val)
+		}
+	}
+	writeMarkdownRow(buf, values[0])
+	delimiters := make([]string, len(cols))
+	for colIdx, col := range cols {
+		delimiters[colIdx] = markdownAlignments[f.getMarkdownAlignment(ws, cells, rowNums, col)]
+	}
+	writeMarkdownRow(buf, delimiters)
+	for _, row := range values[1:] {
+		writeMarkdownRow(buf, row)
+	}
+	return buf.Flush()
+}
+
+// getMarkdownAlignment provides a function to get the horizontal alignment
+// of the column of the Markdown table by given worksheet, the cells, the row
+// numbers of the table and the column number. The first explicit alignment
+// of the cells below the header will be used, the numeric column will be
+// right-aligned, and the header cell is used if there are no other rows.
+func (f *File) getMarkdownAlignment(ws *xlsxWorksheet, cells map[int]map[int]*xlsxC, rowNums []int, col int) string {
+	s := f.stylesReader()
+	if len(rowNums) > 1 {
+		rowNums = rowNums[1:]
+	}
+	var numbers, values int
+	for _, rowNum := range rowNums {
+		c, ok := cells[rowNum][col]
+		if !ok {
+			c = &xlsxC{}
+		}
+		styleID := f.prepareCellStyle(ws, col, c.S)
+		if s.CellXfs != nil && styleID >= 0 && styleID < len(s.CellXfs.Xf) && s.CellXfs.Xf[styleID].Alignment != nil {
+			if alignment, ok := markdownAlignments[s.CellXfs.Xf[styleID].Alignment.Horizontal]; ok && alignment != "---" {
+				return s.CellXfs.Xf[styleID].Alignment.Horizontal
+			}
+		}
+		if c.V == "" && c.IS == nil {
+			continue
+		}
+		if values++; c.T == "" || c.T == "n" {
+			numbers++
+		}
+	}
+	if values > 0 && numbers == values {
+		return "right"
+	}
+	return ""
+}
+
+// escapeMarkdownCell provides a function to escape the pipes and backslashes
+// of the cell value of the Markdown table and convert the line breaks to the
+// HTML line break elements.
+func escapeMarkdownCell(val string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>").Replace(val)
+}
+
+// writeMarkdownRow provides a function to write the cells of the Markdown
+// table row by given writer and the cells.
+func writeMarkdownRow(buf *bufio.Writer, cells []string) {
+	buf.WriteString("|")
+	for _, cell := range cells {
+		buf.WriteString(" " + cell + " |")
+	}
+	buf.WriteString("\n")
+}
@@ -0,0 +1,59 @@
+package excelize
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Item", "Price", "Note", "Hidden", "Status"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Apple|Pear", 1.5, "line1\nline2", "x", "ok"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Hidden row", 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{`C:\temp`, 3, true, nil, "done"}))
+	price, err := f.NewStyle(`{"number_format":2}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B4", price))
+	center, err := f.NewStyle(`{"alignment":{"horizontal":"center"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E4", "E4", center))
+	left, err := f.NewStyle(`{"alignment":{"horizontal":"left"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", left))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "F10", "F10", price))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteMarkdown("Sheet1", &buf))
+	assert.Equal(t, "| Item | Price | Note | Status |\n"+
+		"| --- | ---: | --- | :---: |\n"+
+		"| Apple\\|Pear | 1.50 | line1<br>line2 | ok |\n"+
+		"| C:\\\\temp | 3.00 | TRUE | done |\n", buf.String())
+
+	// Test write Markdown with the range of the header row only.
+	buf.Reset()
+	assert.NoError(t, f.WriteMarkdown("Sheet1", &buf, MarkdownOptions{Range: "B1:A1"}))
+	assert.Equal(t, "| Item | Price |\n| :--- | --- |\n", buf.String())
+	// Test write Markdown with single cell range.
+	buf.Reset()
+	assert.NoError(t, f.WriteMarkdown("Sheet1", &buf, MarkdownOptions{Range: "D2"}))
+	assert.Equal(t, "", buf.String())
+	// Test write Markdown on the empty worksheet.
+	assert.NoError(t, f.WriteMarkdown(f.GetSheetName(f.NewSheet("Sheet2")), &buf))
+	assert.Equal(t, "", buf.String())
+
+	// Test write Markdown with invalid range.
+	assert.EqualError(t, f.WriteMarkdown("Sheet1", &buf, MarkdownOptions{Range: "A"}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test write Markdown on not exists worksheet.
+	assert.EqualError(t, f.WriteMarkdown("SheetN", &buf), "sheet SheetN is not exist")
+	// Test write Markdown with failing writer.
+	assert.EqualError(t, f.WriteMarkdown("Sheet1", errWriter{}), "write error")
+	// Test write Markdown with invalid cell reference.
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].R = "A"
+	assert.EqualError(t, f.WriteMarkdown("Sheet1", &buf), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
@@ -13,9 +13,37 @@ package excelize
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
+)
+
+// MergeCellPolicy defined the policy of processing the values of the cells in
+// the merged area.
+type MergeCellPolicy int
+
+// Merge cell policies.
+const (
+	// MergeCellKeepTopLeft keeps the value of the top-left cell and clears the
+	// values of other cells in the merged area.
+	MergeCellKeepTopLeft MergeCellPolicy = iota
+	// MergeCellKeepFirstNonEmpty moves the first non-empty value in the merged
+	// area by row-major order to the top-left cell.
+	MergeCellKeepFirstNonEmpty
+	// MergeCellConcat concatenates the formatted values of all non-empty cells
+	// in the merged area by row-major order with the separator, and sets the
+	// result in the top-left cell.
+	MergeCellConcat
 )
 
+// MergeCellOpts can be passed to MergeCell to process the values of the cells
+// in the merged area, which will be ignored by Excel otherwise.
+type MergeCellOpts struct {
+	Policy    MergeCellPolicy
+	Separator string
+}
+
 // MergeCell provides a function to merge cells by given coordinate area and
 // sheet name. For example create a merged cell of D3:E9 on Sheet1:
 //
@@ -24,6 +52,16 @@ import (
 // If you create a merged cell that overlaps with another existing merged cell,
 // those merged cells that already exist will be removed.
 //
+// Only the value of the top-left cell will be displayed in a merged cell, the
+// values of other cells are left in the worksheet by default. Use the optional
+// merge cell options to process these values, for example, concatenate the
+// values in the area A1:C1 with a space and set the result in the cell A1:
+//
+//    err := f.MergeCell("Sheet1", "A1", "C1", excelize.MergeCellOpts{
+//        Policy:    excelize.MergeCellConcat,
+//        Separator: " ",
+//    })
+//
 //                 B1(x1,y1)      D1(x2,y1)
 //               +------------------------+
 //               |                        |
@@ -36,7 +74,8 @@ import (
 //    |A8(x3,y4)      C8(x4,y4)|
 //    +------------------------+
 //
-func (f *File) MergeCell(sheet, hcell, vcell string) error {
+func (f *File) MergeCell(sheet, hcell, vcell string, opts ...MergeCellOpts) error {
+	defer f.clearCalcCache()
 	rect1, err := f.areaRefToCoordinates(hcell + ":" + vcell)
 	if err != nil {
 		return err
@@ -93,14 +132,164 @@ func (f *File) MergeCell(sheet, hcell, vcell string) error {
 				ref = hcell + ":" + vcell
 			}
 		}
-		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref})
 	} else {
-		ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: ref}}}
+		ws.MergeCells = &xlsxMergeCells{}
 	}
+	// Calculate the formulas before the merged area has been added, otherwise
+	// the references in the merged area will be redirected to the top-left cell.
+	calculated := f.mergeCellResults(ws, sheet, rect1, opts)
+	ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref})
 	ws.MergeCells.Count = len(ws.MergeCells.Cells)
+	for _, opt := range opts {
+		f.mergeCellValues(ws, sheet, rect1, opt, calculated)
+	}
 	return err
 }
 
+// mergeCellValues provides a function to process the values of the cells in
+// the merged area by given worksheet name, coordinates of the merged area,
+// merge cell options and the calculated results of the formula cells. The
+// formula moved to the top-left cell will be adjusted by the offset of the
+// cells.
+func (f *File) mergeCellValues(ws *xlsxWorksheet, sheet string, rect []int, opt MergeCellOpts, calculated map[string]string) {
+	ws.Lock()
+	defer ws.Unlock()
+	prepareSheetXML(ws, rect[0], rect[1])
+	var (
+		topLeft = &ws.SheetData.Row[rect[1]-1].C[rect[0]-1]
+		sst     = f.sharedStringsReader()
+		sheetID = f.getSheetID(sheet)
+		first   *xlsxC
+		values  []string
+	)
+	for row := rect[1]; row <= rect[3] && row <= len(ws.SheetData.Row); row++ {
+		rowData := &ws.SheetData.Row[row-1]
+		for col := rect[0]; col <= rect[2] && col <= len(rowData.C); col++ {
+			c := &rowData.C[col-1]
+			if !c.hasData() {
+				continue
+			}
+			var formula string
+			if c.F != nil {
+				if formula = c.F.Content; c.F.T == STCellFormulaTypeShared {
+					formula = getSharedForumula(ws, c.F.Si)
+				}
+			}
+			if first == nil {
+				first = &xlsxC{T: c.T, V: c.V, IS: c.IS}
+				if c.F != nil {
+					first.F = &xlsxF{Content: shiftFormulaReferences(formula, rect[0]-col, rect[1]-row)}
+					if c.F.T == STCellFormulaTypeArray {
+						first.F.T, first.F.Ref = c.F.T, shiftFormulaReferences(c.F.Ref, rect[0]-col, rect[1]-row)
+					}
+				}
+			}
+			if opt.Policy == MergeCellConcat {
+				val, _ := c.getValueFrom(f, sst)
+				if result, ok := calculated[c.R]; ok {
+					val = result
+				}
+				if val != "" {
+					values = append(values, val)
+				}
+			}
+			if c != topLeft {
+				if c.F != nil {
+					f.deleteCalcChain(sheetID, c.R)
+				}
+				c.T, c.F, c.V, c.IS = "", nil, "", nil
+			}
+		}
+	}
+	if opt.Policy == MergeCellConcat && len(values) > 1 {
+		if topLeft.F != nil {
+			f.deleteCalcChain(sheetID, topLeft.R)
+		}
+		topLeft.F, topLeft.IS = nil, nil
+		f.setCellString(topLeft, strings.Join(values, opt.Separator))
+		return
+	}
+	if opt.Policy != MergeCellKeepTopLeft && first != nil {
+		topLeft.T, topLeft.F, topLeft.V, topLeft.IS = first.T, first.F, first.V, first.IS
+	}
+}
+
+// mergeCellResults provides a function to calculate the formula cells
+// without the cached values in the merged area by given worksheet, worksheet
+// name, coordinates of the merged area and merge cell options. The formula
+// cells which could not be calculated will be skipped.
+func (f *File) mergeCellResults(ws *xlsxWorksheet, sheet string, rect []int, opts []MergeCellOpts) map[string]string {
+	calculated := map[string]string{}
+	var concat bool
+	for _, opt := range opts {
+		concat = concat || opt.Policy == MergeCellConcat
+	}
+	if !concat {
+		return calculated
+	}
+	var cells []string
+	ws.Lock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F == nil || c.V != "" {
+				continue
+			}
+			col, r, err := CellNameToCoordinates(c.R)
+			if err == nil && col >= rect[0] && col <= rect[2] && r >= rect[1] && r <= rect[3] {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	ws.Unlock()
+	for _, cell := range cells {
+		if result, err := f.CalcCellValue(sheet, cell); err == nil {
+			calculated[cell] = result
+		}
+	}
+	return calculated
+}
+
+// shiftFormulaReferences provides a function to shift the relative cell
+// references of the formula by given formula, the number of the columns and
+// rows to shift. The absolute references and the references inside the string
+// literals will not be changed, and the references shifted out of the
+// worksheet will be replaced by the #REF! error.
+func shiftFormulaReferences(formula string, cols, rows int) string {
+	if cols == 0 && rows == 0 {
+		return formula
+	}
+	shift := func(col, row string) string {
+		name := strings.Trim(col, "$")
+		if !strings.HasPrefix(col, "$") {
+			num, _ := ColumnNameToNumber(name)
+			var err error
+			if name, err = ColumnNumberToName(num + cols); err != nil {
+				return "#REF!"
+			}
+		}
+		r, _ := strconv.Atoi(row)
+		if !strings.HasSuffix(col, "$") {
+			if r += rows; r < 1 || r > TotalRows {
+				return "#REF!"
+			}
+		}
+		if strings.HasPrefix(col, "$") {
+			name = "$" + name
+		}
+		if strings.HasSuffix(col, "$") {
+			name += "$"
+		}
+		return name + strconv.Itoa(r)
+	}
+	return replaceCellReferences(formula, func(_ string, cells [][]string) (string, bool) {
+		refs := make([]string, len(cells))
+		for i, cell := range cells {
+			refs[i] = shift(cell[0], cell[1])
+		}
+		return strings.Join(refs, ":"), true
+	})
+}
+
 // UnmergeCell provides a function to unmerge a given coordinate area.
 // For example unmerge area D3:E9 on Sheet1:
 //
@@ -108,6 +297,7 @@ func (f *File) MergeCell(sheet, hcell, vcell string) error {
 //
 // Attention: overlapped areas will also be unmerged.
 func (f *File) UnmergeCell(sheet string, hcell, vcell string) error {
+	f.clearCalcCache()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -154,6 +344,69 @@ func (f *File) UnmergeCell(sheet string, hcell, vcell string) error {
 	return nil
 }
 
+// UnmergeAll provides a function to unmerge all merged cells which overlap
+// with the given coordinate area, and fill each cell of the former merged
+// areas with the value and style of the top-left cell. For example unmerge
+// all merged cells in area A1:D10 on Sheet1 and fill the unmerged cells:
+//
+//    err := f.UnmergeAll("Sheet1", "A1", "D10")
+//
+func (f *File) UnmergeAll(sheet, hcell, vcell string) error {
+	f.clearCalcCache()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	rect1, err := f.areaRefToCoordinates(hcell + ":" + vcell)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(rect1)
+	if ws.MergeCells == nil {
+		return nil
+	}
+	var areas [][]int
+	for _, cellData := range ws.MergeCells.Cells {
+		if cellData == nil {
+			continue
+		}
+		if len(strings.Split(cellData.Ref, ":")) != 2 {
+			return fmt.Errorf("invalid area %q", cellData.Ref)
+		}
+		rect2, err := f.areaRefToCoordinates(cellData.Ref)
+		if err != nil {
+			return err
+		}
+		if isOverlap(rect1, rect2) {
+			areas = append(areas, rect2)
+		}
+	}
+	if err = f.UnmergeCell(sheet, hcell, vcell); err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	for _, area := range areas {
+		_ = sortCoordinates(area)
+		prepareSheetXML(ws, area[2], area[3])
+		makeContiguousColumns(ws, area[1], area[3], area[2])
+		topLeft := ws.SheetData.Row[area[1]-1].C[area[0]-1]
+		for row := area[1]; row <= area[3]; row++ {
+			for col := area[0]; col <= area[2]; col++ {
+				if col == area[0] && row == area[1] {
+					continue
+				}
+				c := &ws.SheetData.Row[row-1].C[col-1]
+				c.S, c.T, c.V, c.IS = topLeft.S, topLeft.T, topLeft.V, nil
+				if topLeft.IS != nil {
+					c.IS = deepcopy.Copy(topLeft.IS).(*xlsxSI)
+				}
+			}
+		}
+	}
+	return err
+}
+
 // GetMergeCells provides a function to get all merged cells from a worksheet
 // currently.
 func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
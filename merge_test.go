@@ -167,3 +167,125 @@ func TestUnmergeCell(t *testing.T) {
 	assert.EqualError(t, f.UnmergeCell("Sheet1", "A2", "B3"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 
 }
+
+func TestMergeCellWithOpts(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Hello"))
+		assert.NoError(t, f.SetCellValue("Sheet1", "C1", "World"))
+		assert.NoError(t, f.SetCellValue("Sheet1", "A2", 100))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "SUM(A2,1)"))
+		return f
+	}
+	ws := func(f *File) *xlsxWorksheet { return f.Sheet["xl/worksheets/sheet1.xml"] }
+
+	f := prepare()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2", MergeCellOpts{Policy: MergeCellKeepTopLeft}))
+	for _, row := range ws(f).SheetData.Row {
+		for _, c := range row.C {
+			assert.False(t, c.hasData(), c.R)
+		}
+	}
+
+	f = prepare()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1", MergeCellOpts{Policy: MergeCellKeepFirstNonEmpty}))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", val)
+	assert.False(t, ws(f).SheetData.Row[0].C[1].hasData())
+	assert.False(t, ws(f).SheetData.Row[0].C[2].hasData())
+
+	f = prepare()
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "A1", MergeCellOpts{Policy: MergeCellConcat, Separator: " "}))
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello World 100 101", val)
+	assert.Nil(t, ws(f).SheetData.Row[1].C[1].F)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeCellWithOpts.xlsx")))
+
+	// Test concatenate with the formula which could not be calculated.
+	f = prepare()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "UNSUPPORTED(A2)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2", MergeCellOpts{Policy: MergeCellConcat, Separator: ","}))
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello,World,100", val)
+
+	// Test move the formula to the top-left cell with the relative
+	// references shifted.
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", `SUM(C3,$A$2,C3:D$4)&"C3"`))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "D2", MergeCellOpts{Policy: MergeCellKeepFirstNonEmpty}))
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, `SUM(A2,$A$2,A2:B$4)&"C3"`, formula)
+	assert.Nil(t, ws(f).SheetData.Row[1].C[3].F)
+
+	// Test concatenate with only one non-empty cell.
+	f = prepare()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B1", MergeCellOpts{Policy: MergeCellConcat}))
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", val)
+}
+
+func TestShiftFormulaReferences(t *testing.T) {
+	assert.Equal(t, "A1+B2", shiftFormulaReferences("A1+B2", 0, 0))
+	assert.Equal(t, "B3+$A$1+Sheet1!C$1+'Sheet 1'!$A4", shiftFormulaReferences("A2+$A$1+Sheet1!B$1+'Sheet 1'!$A3", 1, 1))
+	assert.Equal(t, "#REF!+LOG10(A1)", shiftFormulaReferences("A2+LOG10(B2)", -1, -1))
+	assert.Equal(t, "SUM(C1:#REF!)", shiftFormulaReferences("SUM(B1:XFD1)", 1, 0))
+}
+
+func TestUnmergeAll(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "merged"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "G5", "H6"))
+
+	assert.NoError(t, f.UnmergeAll("Sheet1", "B2", "E1"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	for _, cell := range []string{"A1", "A2", "A3", "B1", "B2", "B3"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "merged", val, cell)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID, cell)
+	}
+	val, err := f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnmergeAll.xlsx")))
+
+	// Test unmerge all with the inline string, the value of each cell should
+	// be copied.
+	f = NewFile(Options{InlineStrings: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "inline"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B1"))
+	assert.NoError(t, f.UnmergeAll("Sheet1", "A1", "B1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotSame(t, ws.SheetData.Row[0].C[0].IS, ws.SheetData.Row[0].C[1].IS)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "changed"))
+	val, err = f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", val)
+
+	// Test unmerge all without merged cells.
+	f = NewFile()
+	assert.NoError(t, f.UnmergeAll("Sheet1", "A1", "B2"))
+	// Test unmerge all on not exists worksheet.
+	assert.EqualError(t, f.UnmergeAll("SheetN", "A1", "A1"), "sheet SheetN is not exist")
+	assert.EqualError(t, f.UnmergeAll("Sheet1", "A", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+
+	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{nil, {Ref: "A1"}}}
+	assert.EqualError(t, f.UnmergeAll("Sheet1", "A2", "B3"), `invalid area "A1"`)
+	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.EqualError(t, f.UnmergeAll("Sheet1", "A2", "B3"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
@@ -0,0 +1,590 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// getMetadataPath provides a function to get the path of the metadata part
+// in the spreadsheet, the default path xl/metadata.xml will be returned if
+// the relationship of the metadata part doesn't exist in the workbook.
+func (f *File) getMetadataPath() string {
+	return f.getWorkbookPartPath(SourceRelationshipSheetMetadata, "xl/metadata.xml")
+}
+
+// metadataReader provides a function to get the pointer to the structure of
+// the metadata part after deserialization. The cell metadata and value
+// metadata referenced by the cells will be preserved on save.
+func (f *File) metadataReader() *xlsxMetadata {
+	f.Lock()
+	defer f.Unlock()
+	if f.metadata == nil {
+		f.metadata = new(xlsxMetadata)
+		f.decodeWorkbookPart(SourceRelationshipSheetMetadata, "xl/metadata.xml", f.metadata)
+	}
+	return f.metadata
+}
+
+// GetCellMetadata provides a function to get the metadata of the cell by
+// given worksheet name and axis. The cell metadata is used by the dynamic
+// array formulas, and the value metadata is used by the rich values, such as
+// the stock and geography linked data types. For example, get the metadata
+// of Sheet1!A1:
+//
+//    metadata, err := f.GetCellMetadata("Sheet1", "A1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    if metadata.DynamicArray {
+//        fmt.Println("A1 contains a dynamic array formula")
+//    }
+//
+func (f *File) GetCellMetadata(sheet, axis string) (CellMetadata, error) {
+	metadata := CellMetadata{RichValueIndex: -1}
+	var cm, vm uint
+	_, err := f.getCellStringFunc(sheet, axis, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		cm, vm = c.CM, c.VM
+		return "", true, nil
+	})
+	if err != nil || cm == 0 && vm == 0 {
+		return metadata, err
+	}
+	md := f.metadataReader()
+	metadata.CellMetadataIndex, metadata.ValueMetadataIndex = int(cm), int(vm)
+	for _, blocks := range []struct {
+		idx    uint
+		blocks *xlsxMetadataBlocks
+	}{{cm, md.CellMetadata}, {vm, md.ValueMetadata}} {
+		if blocks.idx == 0 {
+			continue
+		}
+		if blocks.blocks == nil || int(blocks.idx) > len(blocks.blocks.Bk) {
+			return metadata, fmt.Errorf("metadata %d of cell %s is not exist", blocks.idx, axis)
+		}
+		for _, rc := range blocks.blocks.Bk[blocks.idx-1].Rc {
+			if md.MetadataTypes == nil || rc.T < 1 || rc.T > len(md.MetadataTypes.MetadataType) {
+				return metadata, fmt.Errorf("metadata type %d is not exist", rc.T)
+			}
+			name := md.MetadataTypes.MetadataType[rc.T-1].Name
+			metadata.Types = append(metadata.Types, name)
+			for _, ext := range md.futureMetadataExt(name, rc.V) {
+				if ext.DynamicArrayProperties != nil {
+					metadata.DynamicArray = ext.DynamicArrayProperties.FDynamic
+				}
+				if ext.RichValueBlock != nil {
+					metadata.RichValueIndex = ext.RichValueBlock.I
+				}
+			}
+		}
+	}
+	return metadata, err
+}
+
+// futureMetadataExt provides a function to get the decoded extensions of the
+// future metadata block by given metadata type name and zero-based block
+// index.
+func (md *xlsxMetadata) futureMetadataExt(name string, idx int) []decodeFutureMetadataExt {
+	var exts []decodeFutureMetadataExt
+	for _, fm := range md.FutureMetadata {
+		if fm.Name != name || idx < 0 || idx >= len(fm.Bk) || fm.Bk[idx].ExtLst == nil {
+			continue
+		}
+		for _, ext := range fm.Bk[idx].ExtLst.Ext {
+			exts = append(exts, ext.decode())
+		}
+	}
+	return exts
+}
+
+// decode provides a function to decode the content of the extension of the
+// future metadata block.
+func (ext xlsxFutureMetadataExt) decode() decodeFutureMetadataExt {
+	var deExt decodeFutureMetadataExt
+	if err := xml.NewDecoder(strings.NewReader("<ext>" + ext.Content + "</ext>")).
+		Decode(&deExt); err != nil && err != io.EOF {
+		log.Printf("xml decode error: %s", err)
+	}
+	return deExt
+}
+
+// metadataWriter provides a function to save the metadata part after
+// serialize structure, the part will be kept as is if it has not been
+// changed.
+func (f *File) metadataWriter() {
+	if f.metadata != nil && f.metadata.MetadataTypes != nil {
+		output, _ := xml.Marshal(f.metadata)
+		if metadataPath := f.getMetadataPath(); f.isPartChanged(metadataPath, output, new(xlsxMetadata)) {
+			metadata := *f.metadata
+			metadata.XMLNSXDA, metadata.XMLNSXLRD = NameSpaceSpreadSheetDynamicArray, NameSpaceSpreadSheetRichData
+			output, _ = xml.Marshal(metadata)
+			f.saveFileList(metadataPath, output)
+		}
+	}
+}
+
+// getWorkbookPartPath provides a function to get the path of the workbook
+// level part by given relationship type, the default path will be returned
+// if the relationship doesn't exist in the workbook.
+func (f *File) getWorkbookPartPath(relType, defaultPath string) string {
+	if rels := f.relsReader(f.getWorkbookRelsPath()); rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type != relType {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/")
+			}
+			return filepath.ToSlash(filepath.Clean(filepath.Dir(f.getWorkbookPath()) + "/" + rel.Target))
+		}
+	}
+	return defaultPath
+}
+
+// decodeWorkbookPart provides a function to decode the workbook level part
+// by given relationship type, default path and the pointer to the structure.
+// Boolean type value ok will be false if the part doesn't exist.
+func (f *File) decodeWorkbookPart(relType, defaultPath string, v interface{}) bool {
+	partPath := f.getWorkbookPartPath(relType, defaultPath)
+	_, ok := f.XLSX[partPath]
+	if ok {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(partPath)))).
+			Decode(v); err != nil && err != io.EOF {
+			log.Printf("xml decode error: %s", err)
+		}
+	}
+	return ok
+}
+
+// addWorkbookPart provides a function to add the relationship of the
+// workbook level part and the content type of the part by given relationship
+// type, path and content type, if the relationship doesn't exist.
+func (f *File) addWorkbookPart(relType, partPath, contentType string) {
+	wbRels := f.getWorkbookRelsPath()
+	if rels := f.relsReader(wbRels); rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == relType {
+				return
+			}
+		}
+	}
+	target := strings.TrimPrefix(partPath, strings.TrimPrefix(filepath.ToSlash(filepath.Dir(f.getWorkbookPath()))+"/", "./"))
+	f.addRels(wbRels, relType, target, "")
+	content := f.contentTypesReader()
+	for _, v := range content.Overrides {
+		if v.PartName == "/"+partPath {
+			return
+		}
+	}
+	content.Overrides = append(content.Overrides, xlsxOverride{
+		PartName:    "/" + partPath,
+		ContentType: contentType,
+	})
+}
+
+// richValueReader provides a function to get the pointers to the structures
+// of the rich value parts after deserialization, including the rich values,
+// the rich value structures and the rich value relationships.
+func (f *File) richValueReader() (*xlsxRichValueData, *xlsxRichValueStructures, *xlsxRichValueRels) {
+	f.Lock()
+	defer f.Unlock()
+	if f.richValue == nil {
+		f.richValue = new(xlsxRichValueData)
+		f.decodeWorkbookPart(SourceRelationshipRichValue, "xl/richData/rdrichvalue.xml", f.richValue)
+	}
+	if f.richValueStruct == nil {
+		f.richValueStruct = new(xlsxRichValueStructures)
+		f.decodeWorkbookPart(SourceRelationshipRichValueStructure, "xl/richData/rdrichvaluestructure.xml", f.richValueStruct)
+	}
+	if f.richValueRels == nil {
+		f.richValueRels = new(xlsxRichValueRels)
+		f.decodeWorkbookPart(SourceRelationshipRichValueRel, "xl/richData/richValueRel.xml", f.richValueRels)
+	}
+	return f.richValue, f.richValueStruct, f.richValueRels
+}
+
+// richValueWriter provides a function to save the rich value parts after
+// serialize structure. The existing parts will be kept as is if they have
+// not been changed, and will be saved even if all the values in them have
+// been deleted.
+func (f *File) richValueWriter() {
+	if f.richValue != nil {
+		f.richValue.Count = len(f.richValue.Rv)
+		f.richValuePartWriter(SourceRelationshipRichValue, "xl/richData/rdrichvalue.xml", f.richValue, new(xlsxRichValueData), len(f.richValue.Rv))
+	}
+	if f.richValueStruct != nil {
+		f.richValueStruct.Count = len(f.richValueStruct.S)
+		f.richValuePartWriter(SourceRelationshipRichValueStructure, "xl/richData/rdrichvaluestructure.xml", f.richValueStruct, new(xlsxRichValueStructures), len(f.richValueStruct.S))
+	}
+	if f.richValueRels != nil {
+		f.richValuePartWriter(SourceRelationshipRichValueRel, "xl/richData/richValueRel.xml", f.richValueRels, new(xlsxRichValueRels), len(f.richValueRels.Rel))
+	}
+}
+
+// richValuePartWriter provides a function to save the rich value part by
+// given relationship type, default path, the structure of the part, the
+// pointer to a new structure of the part and the number of the items in the
+// part.
+func (f *File) richValuePartWriter(relType, defaultPath string, part, v interface{}, items int) {
+	partPath := f.getWorkbookPartPath(relType, defaultPath)
+	if _, ok := f.XLSX[partPath]; !ok && items == 0 {
+		return
+	}
+	output, _ := xml.Marshal(part)
+	if f.isPartChanged(partPath, output, v) {
+		f.saveFileList(partPath, output)
+	}
+}
+
+// getRichValueRelsPath provides a function to get the path of the
+// relationships of the rich value relationships part.
+func (f *File) getRichValueRelsPath() string {
+	relPath := f.getWorkbookPartPath(SourceRelationshipRichValueRel, "xl/richData/richValueRel.xml")
+	return path.Dir(relPath) + "/_rels/" + path.Base(relPath) + ".rels"
+}
+
+// addRichValueImage provides a function to add the local image rich value by
+// given media path, and returns the one-based index of the value metadata
+// which references the rich value.
+func (f *File) addRichValueImage(media string) int {
+	rv, rvStruct, rvRels := f.richValueReader()
+	relIdx := -1
+	for idx, rel := range rvRels.Rel {
+		if f.getRichValueRelTarget(rel.ID) == media {
+			relIdx = idx
+			break
+		}
+	}
+	if relIdx == -1 {
+		rID := f.addRels(f.getRichValueRelsPath(), SourceRelationshipImage, ".."+strings.TrimPrefix(media, "xl"), "")
+		rvRels.Rel = append(rvRels.Rel, xlsxRichValueRel{ID: "rId" + strconv.Itoa(rID)})
+		relIdx = len(rvRels.Rel) - 1
+	}
+	structID := -1
+	for idx, s := range rvStruct.S {
+		if s.T == "_localImage" && len(s.K) == 2 && s.K[0].N == "_rvRel:LocalImageIdentifier" && s.K[1].N == "CalcOrigin" {
+			structID = idx
+			break
+		}
+	}
+	if structID == -1 {
+		rvStruct.S = append(rvStruct.S, xlsxRichValueStructure{
+			T: "_localImage",
+			K: []xlsxRichValueKey{{N: "_rvRel:LocalImageIdentifier", T: "i"}, {N: "CalcOrigin", T: "i"}},
+		})
+		structID = len(rvStruct.S) - 1
+	}
+	rv.Rv = append(rv.Rv, xlsxRichValue{
+		S: structID,
+		V: []xlsxRichValueV{{Val: strconv.Itoa(relIdx)}, {Val: "5"}},
+	})
+	f.addWorkbookPart(SourceRelationshipRichValue, "xl/richData/rdrichvalue.xml", ContentTypeSpreadSheetMLRichValue)
+	f.addWorkbookPart(SourceRelationshipRichValueStructure, "xl/richData/rdrichvaluestructure.xml", ContentTypeSpreadSheetMLRichValueStructure)
+	f.addWorkbookPart(SourceRelationshipRichValueRel, "xl/richData/richValueRel.xml", ContentTypeSpreadSheetMLRichValueRel)
+	return f.addRichValueMetadata(len(rv.Rv) - 1)
+}
+
+// addRichValueMetadata provides a function to add the value metadata which
+// references the rich value by given zero-based rich value index, and
+// returns the one-based index of the value metadata.
+func (f *File) addRichValueMetadata(rvIdx int) int {
+	md := f.metadataReader()
+	if md.MetadataTypes == nil {
+		md.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	typeID := -1
+	for idx, t := range md.MetadataTypes.MetadataType {
+		if t.Name == "XLRICHVALUE" {
+			typeID = idx + 1
+			break
+		}
+	}
+	if typeID == -1 {
+		md.MetadataTypes.MetadataType = append(md.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLRICHVALUE", MinSupportedVersion: 120000, Copy: true, PasteAll: true, PasteValues: true,
+			Merge: true, SplitFirst: true, RowColShift: true, ClearFormats: true, ClearComments: true,
+			Assign: true, Coerce: true,
+		})
+		typeID = len(md.MetadataTypes.MetadataType)
+	}
+	md.MetadataTypes.Count = len(md.MetadataTypes.MetadataType)
+	fmIdx := -1
+	for idx := range md.FutureMetadata {
+		if md.FutureMetadata[idx].Name == "XLRICHVALUE" {
+			fmIdx = idx
+			break
+		}
+	}
+	if fmIdx == -1 {
+		md.FutureMetadata = append(md.FutureMetadata, xlsxFutureMetadata{Name: "XLRICHVALUE"})
+		fmIdx = len(md.FutureMetadata) - 1
+	}
+	fm := &md.FutureMetadata[fmIdx]
+	fm.Bk = append(fm.Bk, xlsxFutureMetadataBlock{ExtLst: &xlsxFutureMetadataExtLst{
+		Ext: []xlsxFutureMetadataExt{{URI: ExtURIRichValueBlock, Content: fmt.Sprintf(`<xlrd:rvb i="%d"/>`, rvIdx)}},
+	}})
+	fm.Count = len(fm.Bk)
+	if md.ValueMetadata == nil {
+		md.ValueMetadata = &xlsxMetadataBlocks{}
+	}
+	md.ValueMetadata.Bk = append(md.ValueMetadata.Bk, xlsxMetadataBlock{
+		Rc: []xlsxMetadataRecord{{T: typeID, V: len(fm.Bk) - 1}},
+	})
+	md.ValueMetadata.Count = len(md.ValueMetadata.Bk)
+	f.addWorkbookPart(SourceRelationshipSheetMetadata, f.getMetadataPath(), ContentTypeSpreadSheetMLSheetMetadata)
+	return len(md.ValueMetadata.Bk)
+}
+
+// getRichValueImage provides a function to get the media path of the local
+// image rich value by given zero-based rich value index, an empty string will
+// be returned if the rich value is not a local image.
+func (f *File) getRichValueImage(rvIdx int) string {
+	_, _, rvRels := f.richValueReader()
+	if relIdx := f.getRichValueImageRel(rvIdx); relIdx != -1 {
+		return f.getRichValueRelTarget(rvRels.Rel[relIdx].ID)
+	}
+	return ""
+}
+
+// getRichValueImageRel provides a function to get the zero-based index of
+// the rich value relationship of the local image rich value by given
+// zero-based rich value index, the value -1 will be returned if the rich
+// value is not a local image.
+func (f *File) getRichValueImageRel(rvIdx int) int {
+	rv, rvStruct, rvRels := f.richValueReader()
+	if idx := localImageKeyIndex(rv, rvStruct, rvIdx); idx != -1 {
+		relIdx, err := strconv.Atoi(rv.Rv[rvIdx].V[idx].Val)
+		if err == nil && relIdx >= 0 && relIdx < len(rvRels.Rel) {
+			return relIdx
+		}
+	}
+	return -1
+}
+
+// localImageKeyIndex provides a function to get the index of the value of
+// the local image identifier key in the local image rich value by given rich
+// values, rich value structures and zero-based rich value index, the value
+// -1 will be returned if the rich value is not a local image.
+func localImageKeyIndex(rv *xlsxRichValueData, rvStruct *xlsxRichValueStructures, rvIdx int) int {
+	if rvIdx < 0 || rvIdx >= len(rv.Rv) || rv.Rv[rvIdx].S < 0 || rv.Rv[rvIdx].S >= len(rvStruct.S) {
+		return -1
+	}
+	s := rvStruct.S[rv.Rv[rvIdx].S]
+	if s.T != "_localImage" {
+		return -1
+	}
+	for idx, k := range s.K {
+		if k.N == "_rvRel:LocalImageIdentifier" && idx < len(rv.Rv[rvIdx].V) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// deleteValueMetadata provides a function to delete the value metadata by
+// given one-based index of the value metadata, if it is no longer referenced
+// by any cells. The rich value referenced by the value metadata, the
+// relationship of the image in the rich value and the image file will be
+// deleted if they are no longer referenced either, and the indexes of the
+// remaining ones will be updated.
+func (f *File) deleteValueMetadata(vm uint) error {
+	md := f.metadataReader()
+	if vm == 0 || md.ValueMetadata == nil || int(vm) > len(md.ValueMetadata.Bk) {
+		return nil
+	}
+	var sheets []*xlsxWorksheet
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if errors.As(err, &ErrChartSheet{}) {
+				continue
+			}
+			return err
+		}
+		sheets = append(sheets, ws)
+	}
+	for _, ws := range sheets {
+		if ws.hasValueMetadata(vm) {
+			return nil
+		}
+	}
+	for _, ws := range sheets {
+		ws.Lock()
+		for row := range ws.SheetData.Row {
+			for col := range ws.SheetData.Row[row].C {
+				if c := &ws.SheetData.Row[row].C[col]; c.VM > vm {
+					c.VM--
+				}
+			}
+		}
+		ws.Unlock()
+	}
+	block := md.ValueMetadata.Bk[vm-1]
+	md.ValueMetadata.Bk = append(md.ValueMetadata.Bk[:vm-1], md.ValueMetadata.Bk[vm:]...)
+	md.ValueMetadata.Count = len(md.ValueMetadata.Bk)
+	for _, rc := range block.Rc {
+		if md.MetadataTypes != nil && rc.T > 0 && rc.T <= len(md.MetadataTypes.MetadataType) &&
+			md.MetadataTypes.MetadataType[rc.T-1].Name == "XLRICHVALUE" {
+			f.deleteRichValueBlock(rc.T, rc.V)
+		}
+	}
+	return nil
+}
+
+// hasValueMetadata provides a function to check if any cells in the
+// worksheet reference the value metadata by given one-based index of the
+// value metadata.
+func (ws *xlsxWorksheet) hasValueMetadata(vm uint) bool {
+	ws.Lock()
+	defer ws.Unlock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.VM == vm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deleteRichValueBlock provides a function to delete the rich value block of
+// the future metadata by given one-based index of the metadata type and
+// zero-based index of the block, if it is no longer referenced by any value
+// metadata.
+func (f *File) deleteRichValueBlock(typeID, bkIdx int) {
+	md := f.metadataReader()
+	for _, bk := range md.ValueMetadata.Bk {
+		for _, rc := range bk.Rc {
+			if rc.T == typeID && rc.V == bkIdx {
+				return
+			}
+		}
+	}
+	for idx := range md.FutureMetadata {
+		fm := &md.FutureMetadata[idx]
+		if fm.Name != "XLRICHVALUE" || bkIdx < 0 || bkIdx >= len(fm.Bk) {
+			continue
+		}
+		rvIdx := -1
+		for _, ext := range md.futureMetadataExt(fm.Name, bkIdx) {
+			if ext.RichValueBlock != nil {
+				rvIdx = ext.RichValueBlock.I
+			}
+		}
+		fm.Bk = append(fm.Bk[:bkIdx], fm.Bk[bkIdx+1:]...)
+		fm.Count = len(fm.Bk)
+		for i := range md.ValueMetadata.Bk {
+			for j := range md.ValueMetadata.Bk[i].Rc {
+				if rc := &md.ValueMetadata.Bk[i].Rc[j]; rc.T == typeID && rc.V > bkIdx {
+					rc.V--
+				}
+			}
+		}
+		if rvIdx != -1 {
+			f.deleteRichValue(fm, rvIdx)
+		}
+		return
+	}
+}
+
+// deleteRichValue provides a function to delete the rich value by given
+// rich value blocks of the future metadata and zero-based rich value index,
+// if it is no longer referenced by any blocks. The rich value indexes in the
+// blocks will be updated.
+func (f *File) deleteRichValue(fm *xlsxFutureMetadata, rvIdx int) {
+	for idx := range fm.Bk {
+		if fm.Bk[idx].ExtLst == nil {
+			continue
+		}
+		for _, ext := range fm.Bk[idx].ExtLst.Ext {
+			if rvb := ext.decode().RichValueBlock; rvb != nil && rvb.I == rvIdx {
+				return
+			}
+		}
+	}
+	rv, _, _ := f.richValueReader()
+	if rvIdx < 0 || rvIdx >= len(rv.Rv) {
+		return
+	}
+	relIdx := f.getRichValueImageRel(rvIdx)
+	rv.Rv = append(rv.Rv[:rvIdx], rv.Rv[rvIdx+1:]...)
+	for idx := range fm.Bk {
+		if fm.Bk[idx].ExtLst == nil {
+			continue
+		}
+		for i, ext := range fm.Bk[idx].ExtLst.Ext {
+			if rvb := ext.decode().RichValueBlock; rvb != nil && rvb.I > rvIdx {
+				fm.Bk[idx].ExtLst.Ext[i].Content = fmt.Sprintf(`<xlrd:rvb i="%d"/>`, rvb.I-1)
+			}
+		}
+	}
+	if relIdx != -1 {
+		f.deleteRichValueRel(relIdx)
+	}
+}
+
+// deleteRichValueRel provides a function to delete the rich value
+// relationship by given zero-based index of the relationship, if it is no
+// longer referenced by any local image rich values. The relationship
+// indexes in the rich values will be updated, and the image file will be
+// deleted if it is no longer referenced by any parts.
+func (f *File) deleteRichValueRel(relIdx int) {
+	rv, rvStruct, rvRels := f.richValueReader()
+	for idx := range rv.Rv {
+		if f.getRichValueImageRel(idx) == relIdx {
+			return
+		}
+	}
+	rID, relsPath := rvRels.Rel[relIdx].ID, f.getRichValueRelsPath()
+	media := f.getRichValueRelTarget(rID)
+	rvRels.Rel = append(rvRels.Rel[:relIdx], rvRels.Rel[relIdx+1:]...)
+	for idx := range rv.Rv {
+		if key := localImageKeyIndex(rv, rvStruct, idx); key != -1 {
+			if n, err := strconv.Atoi(rv.Rv[idx].V[key].Val); err == nil && n > relIdx {
+				rv.Rv[idx].V[key].Val = strconv.Itoa(n - 1)
+			}
+		}
+	}
+	if rels := f.relsReader(relsPath); rels != nil {
+		for idx, v := range rels.Relationships {
+			if v.ID == rID {
+				rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+				break
+			}
+		}
+	}
+	if media != "" && !f.isPartReferenced(media) {
+		delete(f.XLSX, media)
+	}
+}
+
+// getRichValueRelTarget provides a function to get the path of the part
+// referenced by the rich value relationships by given relationship ID.
+func (f *File) getRichValueRelTarget(rID string) string {
+	relsPath := f.getRichValueRelsPath()
+	if rel := f.getDrawingRelationships(relsPath, rID); rel != nil {
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join(path.Dir(path.Dir(relsPath)), rel.Target)
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCellMetadata(t *testing.T) {
+	f := NewFile()
+	f.XLSX["xl/metadata.xml"] = []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><metadataTypes count="2"><metadataType name="XLDAPR" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1" cellMeta="1"/><metadataType name="XLRICHVALUE" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1"/></metadataTypes><futureMetadata name="XLDAPR" count="1"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext></extLst></bk></futureMetadata><futureMetadata name="XLRICHVALUE" count="2"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></ext></extLst></bk><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="1"/></ext></extLst></bk></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata><valueMetadata count="2"><bk><rc t="2" v="0"/></bk><bk><rc t="2" v="1"/></bk></valueMetadata></metadata>`)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SEQUENCE(3)"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "B1", "Microsoft Corporation (XNAS:MSFT)"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "B2", "Seattle"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[0].CM = 1
+	ws.SheetData.Row[0].C[1].VM = 1
+	ws.SheetData.Row[1].C[1].VM = 2
+
+	// Test the cell metadata will be preserved on save.
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCellMetadata.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestGetCellMetadata.xlsx"))
+	assert.NoError(t, err)
+	metadata, err := f.GetCellMetadata("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{CellMetadataIndex: 1, Types: []string{"XLDAPR"}, DynamicArray: true, RichValueIndex: -1}, metadata)
+	metadata, err = f.GetCellMetadata("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{ValueMetadataIndex: 2, Types: []string{"XLRICHVALUE"}, RichValueIndex: 1}, metadata)
+	metadata, err = f.GetCellMetadata("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{RichValueIndex: -1}, metadata)
+	// Test the unchanged metadata part will be kept as is on save.
+	original := f.readXML("xl/metadata.xml")
+	f.metadataWriter()
+	assert.Equal(t, original, f.readXML("xl/metadata.xml"))
+
+	// Test the value metadata will be removed on set the cell value.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "MSFT"))
+	metadata, err = f.GetCellMetadata("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{RichValueIndex: -1}, metadata)
+
+	// Test get the cell metadata with not exist metadata.
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[1].VM = 3
+	_, err = f.GetCellMetadata("Sheet1", "B1")
+	assert.EqualError(t, err, "metadata 3 of cell B1 is not exist")
+	f.metadata.MetadataTypes = nil
+	_, err = f.GetCellMetadata("Sheet1", "A1")
+	assert.EqualError(t, err, "metadata type 1 is not exist")
+
+	// Test get the cell metadata with not exist worksheet.
+	_, err = f.GetCellMetadata("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get the cell metadata with invalid cell coordinates.
+	_, err = f.GetCellMetadata("Sheet1", "A")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestGetMetadataPath(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, "xl/metadata.xml", f.getMetadataPath())
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "meta/metadata1.xml", "")
+	assert.Equal(t, "xl/meta/metadata1.xml", f.getMetadataPath())
+	f = NewFile()
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "/xl/metadata2.xml", "")
+	assert.Equal(t, "xl/metadata2.xml", f.getMetadataPath())
+}
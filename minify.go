@@ -0,0 +1,343 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// minifyWorkbook provides a function to minimize the worksheets, the
+// calculation chain and the style sheet before saving the spreadsheet. The
+// empty cells and rows, the redundant column definitions, the entries of the
+// calculation chain without formulas and the unused styles will be removed.
+func (f *File) minifyWorkbook() error {
+	used := map[int]bool{0: true}
+	for sheet, name := range f.sheetMap {
+		if strings.HasPrefix(name, "xl/chartsheets") {
+			continue
+		}
+		if _, ok := f.streams[name]; ok {
+			continue
+		}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		minifyCols(ws)
+		minifySheetData(ws)
+		for _, row := range ws.SheetData.Row {
+			used[row.S] = true
+			for _, c := range row.C {
+				used[c.S] = true
+			}
+		}
+		if ws.Cols != nil {
+			for _, col := range ws.Cols.Col {
+				used[col.Style] = true
+			}
+		}
+	}
+	f.minifyCalcChain()
+	// The cell styles used by the worksheets written by the StreamWriter can't
+	// be renumbered.
+	if len(f.streams) == 0 {
+		f.minifyStyles(used)
+	}
+	return nil
+}
+
+// minifyCols provides a function to remove the column definitions without
+// any formatting and merge the adjacent column definitions with the same
+// formatting by given worksheet.
+func minifyCols(ws *xlsxWorksheet) {
+	if ws.Cols == nil {
+		return
+	}
+	var cols []xlsxCol
+	for _, col := range ws.Cols.Col {
+		if (xlsxCol{Min: col.Min, Max: col.Max}) == col {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Min < cols[j].Min })
+	var merged []xlsxCol
+	for _, col := range cols {
+		if l := len(merged); l > 0 && merged[l-1].Max+1 == col.Min {
+			prev := merged[l-1]
+			prev.Min, prev.Max = col.Min, col.Max
+			if prev == col {
+				merged[l-1].Max = col.Max
+				continue
+			}
+		}
+		merged = append(merged, col)
+	}
+	if len(merged) == 0 {
+		ws.Cols = nil
+		return
+	}
+	ws.Cols.Col = merged
+}
+
+// minifySheetData provides a function to remove the cells without data which
+// have the same style as the row or the column, and the empty rows without
+// any formatting by given worksheet.
+func minifySheetData(ws *xlsxWorksheet) {
+	colStyle := func(col int) int {
+		var style int
+		if ws.Cols != nil {
+			for _, c := range ws.Cols.Col {
+				if c.Min <= col && col <= c.Max {
+					style = c.Style
+				}
+			}
+		}
+		return style
+	}
+	rows := ws.SheetData.Row[:0]
+	for _, row := range ws.SheetData.Row {
+		cells := row.C[:0]
+		for _, c := range row.C {
+			if !c.hasData() && c.CM == 0 && c.VM == 0 {
+				col, _, err := CellNameToCoordinates(c.R)
+				style := row.S
+				if !row.CustomFormat {
+					style = colStyle(col)
+				}
+				if err == nil && c.S == style {
+					continue
+				}
+			}
+			cells = append(cells, c)
+		}
+		if row.C = cells; len(row.C) == 0 && row.S == 0 && !row.CustomFormat && row.Ht == 0 &&
+			!row.Hidden && !row.CustomHeight && row.OutlineLevel == 0 && !row.Collapsed &&
+			!row.ThickTop && !row.ThickBot && !row.Ph {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	ws.SheetData.Row = rows
+}
+
+// minifyCalcChain provides a function to remove the entries of the
+// calculation chain which reference to the cells without formulas or the
+// worksheets which are not exist. The sheet ID of each entry will be
+// specified explicitly, and the calculation chain part will be removed if
+// there are no entries left.
+func (f *File) minifyCalcChain() {
+	calc := f.calcChainReader()
+	if calc == nil || len(calc.C) == 0 {
+		return
+	}
+	formulas := map[int]map[string]bool{}
+	for _, sheet := range f.workbookReader().Sheets.Sheet {
+		name, ok := f.sheetMap[sheet.Name]
+		if !ok {
+			continue
+		}
+		if _, ok = f.streams[name]; ok {
+			formulas[sheet.SheetID] = nil
+			continue
+		}
+		cells := map[string]bool{}
+		if ws := f.Sheet[name]; ws != nil {
+			for _, row := range ws.SheetData.Row {
+				for _, c := range row.C {
+					if c.F != nil {
+						cells[c.R] = true
+					}
+				}
+			}
+		}
+		formulas[sheet.SheetID] = cells
+	}
+	var (
+		chain []xlsxCalcChainC
+		id    int
+	)
+	for _, c := range calc.C {
+		if c.I != 0 {
+			id = c.I
+		}
+		cells, ok := formulas[id]
+		if !ok || (cells != nil && !cells[c.R]) {
+			continue
+		}
+		c.I = id
+		chain = append(chain, c)
+	}
+	if calc.C = chain; len(chain) == 0 {
+		f.deleteCalcChainPart()
+	}
+}
+
+// minifyStyles provides a function to remove the unused cell styles by given
+// used cell style indexes, and the fonts, fills, borders and number formats
+// which are not used by any cell styles. The cell styles used by the
+// worksheets will be renumbered.
+func (f *File) minifyStyles(used map[int]bool) {
+	s := f.stylesReader()
+	if s.CellXfs == nil {
+		return
+	}
+	styles, xfs := map[int]int{}, []xlsxXf{}
+	for idx, xf := range s.CellXfs.Xf {
+		if used[idx] {
+			styles[idx] = len(xfs)
+			xfs = append(xfs, xf)
+		}
+	}
+	s.CellXfs.Xf, s.CellXfs.Count = xfs, len(xfs)
+	for _, ws := range f.Sheet {
+		if ws == nil {
+			continue
+		}
+		for r := range ws.SheetData.Row {
+			row := &ws.SheetData.Row[r]
+			row.S = styles[row.S]
+			for c := range row.C {
+				row.C[c].S = styles[row.C[c].S]
+			}
+		}
+		if ws.Cols != nil {
+			for c := range ws.Cols.Col {
+				ws.Cols.Col[c].Style = styles[ws.Cols.Col[c].Style]
+			}
+		}
+	}
+	allXfs := [][]xlsxXf{s.CellXfs.Xf}
+	if s.CellStyleXfs != nil {
+		allXfs = append(allXfs, s.CellStyleXfs.Xf)
+	}
+	fonts, fills, borders := map[int]bool{0: true}, map[int]bool{0: true, 1: true}, map[int]bool{0: true}
+	numFmts := map[int]bool{}
+	for _, xfs := range allXfs {
+		for _, xf := range xfs {
+			if xf.FontID != nil {
+				fonts[*xf.FontID] = true
+			}
+			if xf.FillID != nil {
+				fills[*xf.FillID] = true
+			}
+			if xf.BorderID != nil {
+				borders[*xf.BorderID] = true
+			}
+			if xf.NumFmtID != nil {
+				numFmts[*xf.NumFmtID] = true
+			}
+		}
+	}
+	var fontIDs, fillIDs, borderIDs map[int]int
+	if s.Fonts != nil {
+		var items []*xlsxFont
+		fontIDs = map[int]int{}
+		for idx, font := range s.Fonts.Font {
+			if fonts[idx] {
+				fontIDs[idx] = len(items)
+				items = append(items, font)
+			}
+		}
+		s.Fonts.Font, s.Fonts.Count = items, len(items)
+	}
+	if s.Fills != nil {
+		var items []*xlsxFill
+		fillIDs = map[int]int{}
+		for idx, fill := range s.Fills.Fill {
+			if fills[idx] {
+				fillIDs[idx] = len(items)
+				items = append(items, fill)
+			}
+		}
+		s.Fills.Fill, s.Fills.Count = items, len(items)
+	}
+	if s.Borders != nil {
+		var items []*xlsxBorder
+		borderIDs = map[int]int{}
+		for idx, border := range s.Borders.Border {
+			if borders[idx] {
+				borderIDs[idx] = len(items)
+				items = append(items, border)
+			}
+		}
+		s.Borders.Border, s.Borders.Count = items, len(items)
+	}
+	if s.NumFmts != nil {
+		var items []*xlsxNumFmt
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmts[numFmt.NumFmtID] {
+				items = append(items, numFmt)
+			}
+		}
+		s.NumFmts.NumFmt, s.NumFmts.Count = items, len(items)
+		if len(items) == 0 {
+			s.NumFmts = nil
+		}
+	}
+	renumber := func(id *int, ids map[int]int) *int {
+		if id != nil {
+			if newID, ok := ids[*id]; ok {
+				return intPtr(newID)
+			}
+		}
+		return id
+	}
+	for _, xfs := range allXfs {
+		for idx := range xfs {
+			xfs[idx].FontID = renumber(xfs[idx].FontID, fontIDs)
+			xfs[idx].FillID = renumber(xfs[idx].FillID, fillIDs)
+			xfs[idx].BorderID = renumber(xfs[idx].BorderID, borderIDs)
+		}
+	}
+}
+
+// deleteOrphanedParts provides a function to remove the internal
+// relationships which reference to the parts which are not exist, and the
+// media which are not referenced by any relationships after the parts of the
+// spreadsheet have been serialized.
+func (f *File) deleteOrphanedParts() {
+	relsPaths := map[string]bool{}
+	for p := range f.XLSX {
+		if strings.HasSuffix(p, ".rels") {
+			relsPaths[p] = true
+		}
+	}
+	for p := range f.Relationships {
+		relsPaths[p] = true
+	}
+	for p := range relsPaths {
+		rels := f.relsReader(p)
+		if rels == nil {
+			continue
+		}
+		var relationships []xlsxRelationship
+		for _, rel := range rels.Relationships {
+			target := path.Join(path.Dir(path.Dir(p)), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			if rel.TargetMode == "External" || f.hasPart(target) {
+				relationships = append(relationships, rel)
+			}
+		}
+		rels.Relationships = relationships
+	}
+	for p := range f.XLSX {
+		if strings.HasPrefix(p, "xl/media/") && !f.isPartReferenced(p) {
+			delete(f.XLSX, p)
+			f.removeTempFile(p)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinify(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Border: []Border{{Type: "left", Color: "0000FF", Style: 3}}})
+	assert.NoError(t, err)
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"#E0EBF5"}, Pattern: 1}, NumFmt: 200, CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	_, err = f.NewStyle(&Style{CustomNumFmt: stringPtr("0.0000")})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "=A1+1"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", style))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C3", "C3", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", "text"))
+	assert.NoError(t, f.AddPicture("Sheet1", "E1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A6", "https://github.com/xuri/excelize", "External"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Cols.Col = append(ws.Cols.Col, xlsxCol{Min: 4, Max: 4})
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "A2", I: 1}, {R: "B2"}, {R: "A1", I: 5}}}
+	rels := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	rels.Relationships = append(rels.Relationships, xlsxRelationship{ID: "rId9", Target: "../drawings/drawing9.xml", Type: SourceRelationshipDrawingML})
+	f.XLSX["xl/media/image9.png"] = []byte{}
+
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMinify.xlsx"), Options{Minify: true}))
+	f, err = OpenFile(filepath.Join("test", "TestMinify.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {""}, nil, nil, nil, {"text"}}, rows)
+	// Test remove the empty cells and rows.
+	content := string(f.readXML("xl/worksheets/sheet1.xml"))
+	assert.Contains(t, content, `<sheetData><row r="1"><c r="A1" s="1"><v>1</v></c></row><row r="2"><c r="A2"><f>=A1+1</f></c></row><row r="6">`)
+	// Test remove and merge the column definitions.
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{{Min: 1, Max: 2, Width: 20, CustomWidth: true}, {Min: 3, Max: 3, Width: 9.140625, Style: 1}}, ws.Cols.Col)
+	// Test remove the unused styles.
+	s := f.stylesReader()
+	assert.Equal(t, 2, s.CellXfs.Count)
+	assert.Equal(t, 1, s.Fonts.Count)
+	assert.Equal(t, 3, s.Fills.Count)
+	assert.Equal(t, 1, s.Borders.Count)
+	assert.Equal(t, []*xlsxNumFmt{{NumFmtID: 164, FormatCode: "0.000"}}, s.NumFmts.NumFmt)
+	assert.Equal(t, 2, *s.CellXfs.Xf[1].FillID)
+	// Test remove the stale entries of the calculation chain.
+	assert.Equal(t, []xlsxCalcChainC{{R: "A2", I: 1}}, f.calcChainReader().C)
+	// Test remove the orphaned relationships and media.
+	rels = f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.Len(t, rels.Relationships, 2)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, "rId9", rel.ID)
+	}
+	_, ok := f.XLSX["xl/media/image9.png"]
+	assert.False(t, ok)
+	_, ok = f.XLSX["xl/media/image1.png"]
+	assert.True(t, ok)
+
+	// Test remove the calculation chain without entries.
+	f = NewFile()
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "A1", I: 1}}}
+	f.XLSX["xl/calcChain.xml"] = []byte(`<calcChain xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><c r="A1" i="1"/></calcChain>`)
+	f.ContentTypes.Overrides = append(f.ContentTypes.Overrides, xlsxOverride{PartName: "/xl/calcChain.xml", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.calcChain+xml"})
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	f.options = &Options{Minify: true}
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	_, ok = f.XLSX["xl/calcChain.xml"]
+	assert.False(t, ok)
+	assert.Nil(t, f.CalcChain)
+
+	// Test minify the workbook with the unsupported charset worksheet.
+	f = NewFile()
+	f.Sheet = map[string]*xlsxWorksheet{}
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	assert.EqualError(t, f.SaveAs(filepath.Join("test", "TestMinify.xlsx"), Options{Minify: true}), "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
@@ -0,0 +1,435 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Content type and namespaces of the OpenDocument Spreadsheet.
+const (
+	ContentTypeODS      = "application/vnd.oasis.opendocument.spreadsheet"
+	NameSpaceODSOffice  = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	NameSpaceODSTable   = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	NameSpaceODSText    = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+var (
+	// odsDurationExp defined the regular expression to parse the time value
+	// of the OpenDocument Spreadsheet, such as PT08H30M00S.
+	odsDurationExp = regexp.MustCompile(`^(-)?P(?:(\d+)D)?T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?$`)
+	// odsReferenceExp defined the regular expression to match the cell
+	// references in the OpenDocument formula, such as [.A1:.B2].
+	odsReferenceExp = regexp.MustCompile(`\[([^\]]*)\]`)
+)
+
+// odsCell directly maps the table:table-cell element of the OpenDocument
+// Spreadsheet content.
+type odsCell struct {
+	valueType, value, formula string
+	text                      strings.Builder
+	paragraphs, inParagraph   int
+	colsRepeated, colsSpanned int
+	rowsSpanned               int
+}
+
+// odsReader directly maps the state of the OpenDocument Spreadsheet content
+// reader.
+type odsReader struct {
+	f             *File
+	sheet         string
+	sheets        int
+	row, col      int
+	rowsRepeated  int
+	rowHidden     bool
+	rowCells      []*odsCell
+	rowCols       []int
+	cell          *odsCell
+	skip          int
+	columns       int
+	hiddenColumns []int
+}
+
+// isODS provides a function to check if the files of the package is an
+// OpenDocument Spreadsheet by the mimetype.
+func isODS(files map[string][]byte) bool {
+	mimetype, ok := files["mimetype"]
+	return ok && strings.TrimSpace(string(mimetype)) == ContentTypeODS
+}
+
+// openODS provides a function to create the spreadsheet by given the files
+// of the OpenDocument Spreadsheet package and the options. The values,
+// formulas, merged cells and hidden rows and columns of the tables will be
+// mapped into the worksheets, the other features, such as styles, charts and
+// pictures are not supported currently.
+func openODS(files map[string][]byte, opt ...Options) (*File, error) {
+	content, ok := files["content.xml"]
+	if !ok {
+		return nil, errors.New("invalid OpenDocument Spreadsheet: content.xml is not exist")
+	}
+	r := &odsReader{f: NewFile(opt...)}
+	decoder := r.f.xmlNewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			err = r.startElement(element)
+		case xml.EndElement:
+			err = r.endElement(element)
+		case xml.CharData:
+			if r.cell != nil && r.skip == 0 && r.cell.inParagraph > 0 {
+				r.cell.text.Write(element)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.sheets == 0 {
+		return nil, errors.New("invalid OpenDocument Spreadsheet: no table found")
+	}
+	return r.f, nil
+}
+
+// getODSAttr provides a function to get the attribute value of the element
+// by given element, namespace and local name of the attribute.
+func getODSAttr(element xml.StartElement, space, local string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// getODSAttrInt provides a function to get the positive integer attribute
+// value of the element with default value 1.
+func getODSAttrInt(element xml.StartElement, space, local string) int {
+	if val, err := strconv.Atoi(getODSAttr(element, space, local)); err == nil && val > 0 {
+		return val
+	}
+	return 1
+}
+
+// startElement provides a function to handle the start element of the
+// OpenDocument Spreadsheet content.
+func (r *odsReader) startElement(element xml.StartElement) error {
+	if r.skip > 0 {
+		r.skip++
+		return nil
+	}
+	switch element.Name.Space {
+	case NameSpaceODSTable:
+		switch element.Name.Local {
+		case "table":
+			r.sheets++
+			r.sheet, r.row, r.columns, r.hiddenColumns = trimSheetName(getODSAttr(element, NameSpaceODSTable, "name")), 1, 0, nil
+			if r.sheet == "" {
+				r.sheet = "Sheet" + strconv.Itoa(r.sheets)
+			}
+			if r.sheets == 1 {
+				r.f.SetSheetName(r.f.GetSheetName(0), r.sheet)
+				return nil
+			}
+			if r.f.GetSheetIndex(r.sheet) != -1 {
+				return errors.New("invalid OpenDocument Spreadsheet: duplicate table name " + r.sheet)
+			}
+			r.f.NewSheet(r.sheet)
+		case "table-column":
+			repeated := getODSAttrInt(element, NameSpaceODSTable, "number-columns-repeated")
+			if getODSAttr(element, NameSpaceODSTable, "visibility") == "collapse" {
+				for col := r.columns + 1; col <= r.columns+repeated && col <= TotalColumns; col++ {
+					r.hiddenColumns = append(r.hiddenColumns, col)
+				}
+			}
+			r.columns += repeated
+		case "table-row":
+			r.col, r.rowCells, r.rowCols = 1, nil, nil
+			r.rowsRepeated = getODSAttrInt(element, NameSpaceODSTable, "number-rows-repeated")
+			r.rowHidden = getODSAttr(element, NameSpaceODSTable, "visibility") == "collapse"
+		case "table-cell", "covered-table-cell":
+			r.cell = &odsCell{
+				valueType:    getODSAttr(element, NameSpaceODSOffice, "value-type"),
+				formula:      getODSAttr(element, NameSpaceODSTable, "formula"),
+				colsRepeated: getODSAttrInt(element, NameSpaceODSTable, "number-columns-repeated"),
+				colsSpanned:  getODSAttrInt(element, NameSpaceODSTable, "number-columns-spanned"),
+				rowsSpanned:  getODSAttrInt(element, NameSpaceODSTable, "number-rows-spanned"),
+			}
+			switch r.cell.valueType {
+			case "float", "percentage", "currency":
+				r.cell.value = getODSAttr(element, NameSpaceODSOffice, "value")
+			case "date":
+				r.cell.value = getODSAttr(element, NameSpaceODSOffice, "date-value")
+			case "time":
+				r.cell.value = getODSAttr(element, NameSpaceODSOffice, "time-value")
+			case "boolean":
+				r.cell.value = getODSAttr(element, NameSpaceODSOffice, "boolean-value")
+			case "string":
+				r.cell.value = getODSAttr(element, NameSpaceODSOffice, "string-value")
+			}
+		}
+	case NameSpaceODSText:
+		if r.cell == nil {
+			return nil
+		}
+		switch element.Name.Local {
+		case "p", "h":
+			if r.cell.paragraphs > 0 {
+				r.cell.text.WriteByte('\n')
+			}
+			r.cell.paragraphs++
+			r.cell.inParagraph++
+		case "s":
+			r.cell.text.WriteString(strings.Repeat(" ", getODSAttrInt(element, NameSpaceODSText, "c")))
+		case "tab":
+			r.cell.text.WriteByte('\t')
+		case "line-break":
+			r.cell.text.WriteByte('\n')
+		}
+	case NameSpaceODSOffice:
+		if r.cell != nil && element.Name.Local == "annotation" {
+			r.skip = 1
+		}
+	default:
+		if r.cell != nil {
+			r.skip = 1
+		}
+	}
+	return nil
+}
+
+// endElement provides a function to handle the end element of the
+// OpenDocument Spreadsheet content.
+func (r *odsReader) endElement(element xml.EndElement) error {
+	if r.skip > 0 {
+		r.skip--
+		return nil
+	}
+	if element.Name.Space == NameSpaceODSText && r.cell != nil && (element.Name.Local == "p" || element.Name.Local == "h") {
+		r.cell.inParagraph--
+		return nil
+	}
+	if element.Name.Space != NameSpaceODSTable {
+		return nil
+	}
+	switch element.Name.Local {
+	case "table":
+		for _, col := range r.hiddenColumns {
+			name, _ := ColumnNumberToName(col)
+			if err := r.f.SetColVisible(r.sheet, name, false); err != nil {
+				return err
+			}
+		}
+	case "table-cell", "covered-table-cell":
+		if r.cell.valueType != "" || r.cell.formula != "" || r.cell.text.Len() > 0 || r.cell.colsSpanned > 1 || r.cell.rowsSpanned > 1 {
+			for i := 0; i < r.cell.colsRepeated && r.col+i <= TotalColumns; i++ {
+				r.rowCells, r.rowCols = append(r.rowCells, r.cell), append(r.rowCols, r.col+i)
+			}
+		}
+		r.col += r.cell.colsRepeated
+		r.cell = nil
+	case "table-row":
+		if len(r.rowCells) == 0 && !r.rowHidden {
+			r.row += r.rowsRepeated
+			return nil
+		}
+		for i := 0; i < r.rowsRepeated && r.row <= TotalRows; i++ {
+			for idx, cell := range r.rowCells {
+				if err := r.setCell(cell, r.rowCols[idx], r.row); err != nil {
+					return err
+				}
+			}
+			if r.rowHidden {
+				if err := r.f.SetRowVisible(r.sheet, r.row, false); err != nil {
+					return err
+				}
+			}
+			r.row++
+		}
+	}
+	return nil
+}
+
+// setCell provides a function to set the value, formula and merged cell
+// range of the cell by given cell and the coordinates.
+func (r *odsReader) setCell(cell *odsCell, col, row int) error {
+	axis, err := CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
+	if cell.colsSpanned > 1 || cell.rowsSpanned > 1 {
+		vcell, err := CoordinatesToCellName(col+cell.colsSpanned-1, row+cell.rowsSpanned-1)
+		if err != nil {
+			return err
+		}
+		if err = r.f.MergeCell(r.sheet, axis, vcell); err != nil {
+			return err
+		}
+	}
+	if err = r.setCellValue(cell, axis); err != nil {
+		return err
+	}
+	if cell.formula != "" {
+		return r.f.SetCellFormula(r.sheet, axis, convertODSFormula(cell.formula))
+	}
+	return err
+}
+
+// setCellValue provides a function to set the value of the cell by given
+// cell and the cell coordinates.
+func (r *odsReader) setCellValue(cell *odsCell, axis string) error {
+	text := cell.text.String()
+	switch cell.valueType {
+	case "float", "percentage", "currency":
+		num, err := strconv.ParseFloat(cell.value, 64)
+		if err != nil {
+			return r.f.SetCellStr(r.sheet, axis, text)
+		}
+		if err = r.f.SetCellFloat(r.sheet, axis, num, -1, 64); err != nil || cell.valueType != "percentage" {
+			return err
+		}
+		return r.setCellNumFmt(axis, 10)
+	case "date":
+		loc := time.UTC
+		if r.f.timeLocation != nil {
+			loc = r.f.timeLocation
+		}
+		for _, layout := range []string{"2006-01-02T15:04:05.999999999", "2006-01-02"} {
+			if tm, err := time.ParseInLocation(layout, cell.value, loc); err == nil {
+				if err = r.f.SetCellValue(r.sheet, axis, tm); err != nil || len(cell.value) > 10 {
+					return err
+				}
+				return r.setCellNumFmt(axis, 14)
+			}
+		}
+		return r.f.SetCellStr(r.sheet, axis, text)
+	case "time":
+		matches := odsDurationExp.FindStringSubmatch(cell.value)
+		if matches == nil {
+			return r.f.SetCellStr(r.sheet, axis, text)
+		}
+		var duration time.Duration
+		for idx, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+			val, _ := strconv.ParseFloat(matches[idx+2], 64)
+			duration += time.Duration(val * float64(unit))
+		}
+		if matches[1] == "-" {
+			duration = -duration
+		}
+		if err := r.f.SetCellValue(r.sheet, axis, duration); err != nil {
+			return err
+		}
+		return r.setCellNumFmt(axis, 21)
+	case "boolean":
+		return r.f.SetCellBool(r.sheet, axis, cell.value == "true")
+	case "string":
+		if cell.value != "" {
+			text = cell.value
+		}
+	}
+	if text == "" {
+		return nil
+	}
+	return r.f.SetCellStr(r.sheet, axis, text)
+}
+
+// setCellNumFmt provides a function to set the built-in number format of
+// the cell by given cell coordinates and number format ID.
+func (r *odsReader) setCellNumFmt(axis string, numFmt int) error {
+	style, err := r.f.NewStyle(&Style{NumFmt: numFmt})
+	if err != nil {
+		return err
+	}
+	return r.f.SetCellStyle(r.sheet, axis, axis, style)
+}
+
+// convertODSFormula provides a function to convert the OpenFormula of the
+// OpenDocument Spreadsheet to the formula of the spreadsheet, such as
+// "of:=SUM([.A1:.A2];[$Sheet2.B1])" will be converted to
+// "SUM(A1:A2,Sheet2!B1)".
+func convertODSFormula(formula string) string {
+	if idx := strings.Index(formula, ":="); idx != -1 && idx < 8 {
+		formula = formula[idx+2:]
+	}
+	formula = strings.TrimPrefix(formula, "=")
+	var (
+		buf      strings.Builder
+		inString bool
+		start    int
+	)
+	flush := func(end int) {
+		buf.WriteString(odsReferenceExp.ReplaceAllStringFunc(formula[start:end], convertODSReference))
+	}
+	for idx := 0; idx < len(formula); idx++ {
+		switch formula[idx] {
+		case '"':
+			if !inString {
+				flush(idx)
+				start = idx
+			} else {
+				buf.WriteString(formula[start : idx+1])
+				start = idx + 1
+			}
+			inString = !inString
+		case ';':
+			if !inString {
+				flush(idx)
+				buf.WriteByte(',')
+				start = idx + 1
+			}
+		}
+	}
+	if inString {
+		buf.WriteString(formula[start:])
+		return buf.String()
+	}
+	flush(len(formula))
+	return buf.String()
+}
+
+// convertODSReference provides a function to convert the cell reference of
+// the OpenFormula, such as "[$'Sheet 2'.A1:.B2]" will be converted to
+// "'Sheet 2'!A1:B2".
+func convertODSReference(reference string) string {
+	var (
+		sheet string
+		cells []string
+	)
+	for idx, part := range strings.Split(strings.Trim(reference, "[]"), ":") {
+		dot := strings.LastIndex(part, ".")
+		if dot == -1 {
+			cells = append(cells, part)
+			continue
+		}
+		if name := strings.TrimPrefix(part[:dot], "$"); idx == 0 && name != "" {
+			sheet = name
+		}
+		cells = append(cells, part[dot+1:])
+	}
+	if sheet == "" {
+		return strings.Join(cells, ":")
+	}
+	return sheet + "!" + strings.Join(cells, ":")
+}
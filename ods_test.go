@@ -0,0 +1,145 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newODS provides a function to create the OpenDocument Spreadsheet package
+// by given the tables of the content.
+func newODS(t *testing.T, tables string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range []struct{ name, content string }{
+		{"mimetype", ContentTypeODS},
+		{"content.xml", `<?xml version="1.0" encoding="UTF-8"?><office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2"><office:body><office:spreadsheet>` + tables + `</office:spreadsheet></office:body></office:document-content>`},
+	} {
+		fw, err := zw.Create(part.name)
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(part.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestOpenODS(t *testing.T) {
+	ods := newODS(t, `<table:table table:name="Data">
+		<table:table-column table:number-columns-repeated="2"/>
+		<table:table-column table:visibility="collapse"/>
+		<table:table-column table:number-columns-repeated="1021"/>
+		<table:table-row>
+			<table:table-cell office:value-type="string"><text:p>Name</text:p></table:table-cell>
+			<table:table-cell office:value-type="string"><text:p>Value</text:p></table:table-cell>
+			<table:table-cell office:value-type="string"><text:p>Hidden</text:p></table:table-cell>
+			<table:table-cell table:number-columns-repeated="1021"/>
+		</table:table-row>
+		<table:table-row>
+			<table:table-cell office:value-type="string"><text:p>Multi<text:s text:c="2"/>line</text:p><text:p><text:span>second<text:tab/>tab<text:line-break/>end</text:span></text:p><office:annotation><dc:creator>A</dc:creator><text:p>Note</text:p></office:annotation></table:table-cell>
+			<table:table-cell office:value-type="float" office:value="1.5"><text:p>1.50</text:p></table:table-cell>
+			<table:table-cell office:value-type="percentage" office:value="0.25"><text:p>25.00%</text:p></table:table-cell>
+		</table:table-row>
+		<table:table-row table:number-rows-repeated="2">
+			<table:table-cell office:value-type="boolean" office:boolean-value="true" table:number-columns-repeated="2"><text:p>TRUE</text:p></table:table-cell>
+		</table:table-row>
+		<table:table-row table:number-rows-repeated="3"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+		<table:table-row table:visibility="collapse">
+			<table:table-cell office:value-type="date" office:date-value="2021-03-04"><text:p>03/04/21</text:p></table:table-cell>
+			<table:table-cell office:value-type="date" office:date-value="2021-03-04T08:30:00"><text:p>03/04/21 08:30</text:p></table:table-cell>
+			<table:table-cell office:value-type="time" office:time-value="PT08H30M00S"><text:p>08:30:00</text:p></table:table-cell>
+			<table:table-cell office:value-type="currency" office:value="12" office:currency="USD"><text:p>$12.00</text:p><draw:frame><text:p>ignored</text:p></draw:frame></table:table-cell>
+		</table:table-row>
+		<table:table-row>
+			<table:table-cell table:formula="of:=SUM([.B2:.B4];[$'Other sheet'.A1])" office:value-type="float" office:value="4.5"><text:p>4.5</text:p></table:table-cell>
+			<table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="2" office:value-type="string"><text:p>Merged</text:p></table:table-cell>
+			<table:covered-table-cell/>
+		</table:table-row>
+		<table:table-row><table:covered-table-cell table:number-columns-repeated="3"/></table:table-row>
+		<table:table-row table:number-rows-repeated="1048560"><table:table-cell/></table:table-row>
+	</table:table>
+	<table:table table:name="Other sheet">
+		<table:table-row><table:table-cell office:value-type="float" office:value="3"><text:p>3</text:p></table:table-cell></table:table-row>
+	</table:table>`)
+	f, err := OpenReader(bytes.NewReader(ods))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Other sheet"}, f.GetSheetList())
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Value", "Hidden"},
+		{"Multi  line\nsecond\ttab\nend", "1.5", "25.00%"},
+		{"1", "1"},
+		{"1", "1"},
+		nil, nil, nil,
+		{"03-04-21", "3/4/21 8:29", "8:29:59", "12"},
+		{"4.5", "Merged"},
+	}, rows)
+	formula, err := f.GetCellFormula("Data", "A9")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2:B4,'Other sheet'!A1)", formula)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"B9:C10", "Merged"}}, mergeCells)
+	visible, err := f.GetColVisible("Data", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetRowVisible("Data", 8)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	cellType, err := f.GetCellType("Data", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+	tm, err := f.GetCellTime("Data", "B8")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 3, 4, 8, 30, 0, 0, time.UTC), tm.Round(time.Second))
+	val, err := f.GetCellValue("Other sheet", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenODS.xlsx")))
+
+	// Test open OpenDocument Spreadsheet with unnamed and duplicate tables.
+	f, err = OpenReader(bytes.NewReader(newODS(t, `<table:table/><table:table/>`)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	_, err = OpenReader(bytes.NewReader(newODS(t, `<table:table table:name="A"/><table:table table:name="A"/>`)))
+	assert.EqualError(t, err, "invalid OpenDocument Spreadsheet: duplicate table name A")
+	// Test open OpenDocument Spreadsheet with invalid values.
+	f, err = OpenReader(bytes.NewReader(newODS(t, `<table:table><table:table-row>
+		<table:table-cell office:value-type="float" office:value="x"><text:p>x</text:p></table:table-cell>
+		<table:table-cell office:value-type="date" office:date-value="x"><text:p>y</text:p></table:table-cell>
+		<table:table-cell office:value-type="time" office:time-value="x"><text:p>z</text:p></table:table-cell>
+		<table:table-cell office:value-type="string" office:string-value="value"><text:p>text</text:p></table:table-cell>
+		<table:table-cell office:value-type="time" office:time-value="-P1DT1H"><text:p>-25:00:00</text:p></table:table-cell>
+	</table:table-row></table:table>`)))
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"x", "y", "z", "value", "11:00:00"}}, rows)
+	// Test open OpenDocument Spreadsheet without tables and content.
+	_, err = OpenReader(bytes.NewReader(newODS(t, "")))
+	assert.EqualError(t, err, "invalid OpenDocument Spreadsheet: no table found")
+	_, err = openODS(map[string][]byte{"mimetype": []byte(ContentTypeODS)})
+	assert.EqualError(t, err, "invalid OpenDocument Spreadsheet: content.xml is not exist")
+	_, err = openODS(map[string][]byte{"content.xml": []byte("<table:table")})
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	// Test open OpenDocument Spreadsheet with the merged cell exceeds limit.
+	_, err = OpenReader(bytes.NewReader(newODS(t, `<table:table><table:table-row><table:table-cell table:number-columns-repeated="16383"/><table:table-cell table:number-columns-spanned="2"/></table:table-row></table:table>`)))
+	assert.EqualError(t, err, "column number exceeds maximum limit")
+}
+
+func TestConvertODSFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"of:=SUM([.A1:.A2];[$Sheet2.B1])":    "SUM(A1:A2,Sheet2!B1)",
+		`of:=IF([.A1]="a;[.B1]";[.C1];"x")`: `IF(A1="a;[.B1]",C1,"x")`,
+		`=CONCATENATE("a";"b`:                `CONCATENATE("a","b`,
+		"of:=[$Sheet1.A1:$Sheet2.B2]":        "Sheet1!A1:B2",
+		"of:=[A1]":                           "A1",
+	} {
+		assert.Equal(t, expected, convertODSFormula(formula), formula)
+	}
+}
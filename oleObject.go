@@ -0,0 +1,608 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// oleObjectPackageTypes defined the program ID, the part name prefix and the
+// content type of the Office Open XML documents which embedded as package.
+var oleObjectPackageTypes = map[string]struct {
+	progID, name, contentType string
+}{
+	".docx": {"Word.Document.12", "Microsoft_Word_Document", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	".docm": {"Word.DocumentMacroEnabled.12", "Microsoft_Word_Macro-Enabled_Document", "application/vnd.ms-word.document.macroEnabled.12"},
+	".xlsx": {"Excel.Sheet.12", "Microsoft_Excel_Worksheet", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	".xlsm": {"Excel.SheetMacroEnabled.12", "Microsoft_Excel_Macro-Enabled_Worksheet", "application/vnd.ms-excel.sheet.macroEnabled.12"},
+	".pptx": {"PowerPoint.Show.12", "Microsoft_PowerPoint_Presentation", "application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+	".pptm": {"PowerPoint.ShowMacroEnabled.12", "Microsoft_PowerPoint_Macro-Enabled_Presentation", "application/vnd.ms-powerpoint.presentation.macroEnabled.12"},
+}
+
+// oleObjectPackageCLSID defined the class ID of the OLE package object
+// {0003000C-0000-0000-C000-000000000046} in little-endian byte order.
+var oleObjectPackageCLSID = []byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+// AddOLEObject provides the method to embed a file as OLE object displayed as
+// icon in a worksheet by given worksheet name and OLE object options. The
+// Office Open XML documents (.docx, .docm, .xlsx, .xlsm, .pptx and .pptm)
+// will be embedded as package, and the other files (such as PDF) will be
+// embedded as OLE package object in the compound file. The Caption is the
+// label of the object, the file name will be used if the caption is empty.
+// The Icon specifies the PNG format image displayed for the object, a
+// default document icon will be used if it is empty. The Width and Height
+// are the size of the icon in pixels, the default size is 64 x 64. For
+// example, embed the PDF file report.pdf in the cell B2 of Sheet1:
+//
+//    file, err := ioutil.ReadFile("report.pdf")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    err = f.AddOLEObject("Sheet1", excelize.OLEObject{
+//        Cell:     "B2",
+//        FileName: "report.pdf",
+//        Caption:  "Audit Report",
+//        File:     file,
+//    })
+//
+func (f *File) AddOLEObject(sheet string, opts OLEObject) error {
+	if opts.FileName == "" || len(opts.File) == 0 {
+		return errors.New("the file name and the content of the OLE object are required")
+	}
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if opts.Caption == "" {
+		opts.Caption = opts.FileName
+	}
+	if opts.Width <= 0 {
+		opts.Width = 64
+	}
+	if opts.Height <= 0 {
+		opts.Height = 64
+	}
+	if len(opts.Icon) == 0 {
+		if opts.Icon, err = newOLEObjectIcon(); err != nil {
+			return err
+		}
+	}
+	if !bytes.HasPrefix(opts.Icon, []byte("\x89PNG\r\n\x1a\n")) {
+		return errors.New("unsupported icon image extension")
+	}
+	// Add the embedded object part.
+	progID, relType, contentType := "Package", SourceRelationshipOLEObject, ContentTypeOLEObject
+	embeddingID := f.countEmbeddings() + 1
+	embedding := "xl/embeddings/oleObject" + strconv.Itoa(embeddingID) + ".bin"
+	if pkg, ok := oleObjectPackageTypes[strings.ToLower(path.Ext(opts.FileName))]; ok {
+		progID, relType, contentType = pkg.progID, SourceRelationshipPackage, pkg.contentType
+		embedding = "xl/embeddings/" + pkg.name + strconv.Itoa(embeddingID) + strings.ToLower(path.Ext(opts.FileName))
+		f.XLSX[embedding] = opts.File
+	} else {
+		f.XLSX[embedding] = newCompoundFile(oleObjectPackageCLSID, []cfbStream{
+			{name: "\x01CompObj", data: newOLECompObj()},
+			{name: "\x01Ole10Native", data: newOle10Native(opts.Caption, opts.FileName, opts.File)},
+		})
+	}
+	content := f.contentTypesReader()
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/" + embedding, ContentType: contentType})
+	media := f.addMedia(opts.Icon, ".png")
+	f.setContentTypePartImageExtensions()
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, relType, strings.Replace(embedding, "xl", "..", 1), "")
+	imageRID := f.addRels(sheetRels, SourceRelationshipImage, strings.Replace(media, "xl", "..", 1), "")
+	// Add the VML shape of the OLE object.
+	vmlID, drawingVML := f.prepareVMLDrawing(sheet, ws)
+	vml := f.commentsVMLReader(vmlID, drawingVML)
+	addPictureFrameShapetype(vml)
+	shapeID := getVMLShapeID(vml, vmlID)
+	vmlRels := strings.Replace(drawingVML, "xl/drawings/", "xl/drawings/_rels/", 1) + ".rels"
+	vmlImageRID := f.addRels(vmlRels, SourceRelationshipImage, strings.Replace(media, "xl", "..", 1), "")
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col-1, row-1, 0, 0, opts.Width, opts.Height)
+	sp, _ := xml.Marshal(encodeOLEObjectShape{
+		ImageData: &vImageData{RelID: "rId" + strconv.Itoa(vmlImageRID)},
+		ClientData: &xOLEObjectClientData{
+			ObjectType:    "Pict",
+			SizeWithCells: &struct{}{},
+			Anchor:        fmt.Sprintf("%d, 0, %d, 0, %d, %d, %d, %d", colStart, rowStart, colEnd, x2, rowEnd, y2),
+			CF:            "Pict",
+			AutoPict:      &struct{}{},
+		},
+	})
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:          "_x0000_s" + strconv.Itoa(shapeID),
+		Type:        "#_x0000_t75",
+		Style:       fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%dpt;height:%dpt;z-index:%d", opts.Width*3/4, opts.Height*3/4, len(vml.Shape)+1),
+		Filled:      "t",
+		Fillcolor:   "window [65]",
+		Stroked:     "t",
+		Strokecolor: "windowText [64]",
+		Val:         strings.TrimSuffix(strings.TrimPrefix(string(sp), "<encodeOLEObjectShape>"), "</encodeOLEObjectShape>"),
+	})
+	// Add the OLE object of the worksheet.
+	oleObject := xlsxOleObject{
+		ProgID:   progID,
+		DvAspect: "DVASPECT_ICON",
+		ShapeID:  shapeID,
+		RID:      "rId" + strconv.Itoa(rID),
+	}
+	fallback := oleObject
+	oleObject.ObjectPr = &xlsxObjectPr{
+		AltText: opts.Caption,
+		RID:     "rId" + strconv.Itoa(imageRID),
+		Anchor: &xlsxControlAnchor{
+			MoveWithCells: true,
+			From:          xlsxFrom{Col: colStart, Row: rowStart},
+			To:            xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+		},
+	}
+	output, _ := xml.Marshal(xlsxOleObjectAlternateContent{
+		XMLNSMC:  SourceRelationshipCompatibility.Value,
+		XMLNSX14: NameSpaceSpreadSheetX14.Value,
+		XMLNSXdr: NameSpaceDrawingMLSpreadSheet.Value,
+		Choice:   xlsxOleObjectChoice{Requires: "x14", OleObject: oleObject},
+		Fallback: xlsxOleObjectFallback{OleObject: fallback},
+	})
+	if ws.OleObjects == nil {
+		ws.OleObjects = &xlsxInnerXML{}
+	}
+	ws.OleObjects.Content += string(output)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// addPictureFrameShapetype provides a function to add the VML shape type of
+// the picture frame if it doesn't exist in the VML drawing.
+func addPictureFrameShapetype(vml *vmlDrawing) {
+	for _, shapetype := range vml.Shapetype {
+		if shapetype.ID == "_x0000_t75" {
+			return
+		}
+	}
+	vml.Shapetype = append(vml.Shapetype, xlsxShapetype{
+		ID:        "_x0000_t75",
+		Coordsize: "21600,21600",
+		Spt:       75,
+		Path:      "m,l,21600r21600,l21600,xe",
+		Stroke:    &xlsxStroke{Joinstyle: "miter"},
+		VPath:     &vPath{Gradientshapeok: "t", Connecttype: "rect"},
+	})
+}
+
+// countEmbeddings provides a function to get embedded object files count
+// storage in the folder xl/embeddings.
+func (f *File) countEmbeddings() int {
+	count := 0
+	for k := range f.XLSX {
+		if strings.HasPrefix(k, "xl/embeddings/") {
+			count++
+		}
+	}
+	return count
+}
+
+// newOLEObjectIcon provides a function to create the default document icon
+// of the OLE object in PNG format.
+func newOLEObjectIcon() ([]byte, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	border, fill := color.NRGBA{R: 128, G: 128, B: 128, A: 255}, color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	for y := 6; y <= 58; y++ {
+		for x := 12; x <= 52; x++ {
+			// Cut the folded corner at the top-right of the page.
+			if x-40 > y-6 {
+				continue
+			}
+			c := fill
+			if x == 12 || x == 52 || y == 6 || y == 58 || x-40 == y-6 || (x == 40 && y <= 18) || (y == 18 && x >= 40) ||
+				(y%6 == 0 && y >= 24 && y <= 50 && x >= 18 && x <= 46) {
+				c = border
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), err
+}
+
+// newOLECompObj provides a function to create the CompObj stream of the OLE
+// package object.
+func newOLECompObj() []byte {
+	var buf bytes.Buffer
+	writeString := func(s string) {
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(s)+1))
+		buf.WriteString(s)
+		buf.WriteByte(0)
+	}
+	buf.Write([]byte{0x01, 0x00, 0xFE, 0xFF, 0x03, 0x0A, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF})
+	buf.Write(oleObjectPackageCLSID)
+	writeString("OLE Package")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(0))
+	writeString("Package")
+	_ = binary.Write(&buf, binary.LittleEndian, []uint32{0x71B239F4, 0, 0, 0})
+	return buf.Bytes()
+}
+
+// newOle10Native provides a function to create the Ole10Native stream of the
+// OLE package object by given label, file name and the content of the file.
+func newOle10Native(label, fileName string, file []byte) []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(2))
+	buf.WriteString(label)
+	buf.WriteByte(0)
+	buf.WriteString(fileName)
+	buf.WriteByte(0)
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{0, 3})
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(fileName)+1))
+	buf.WriteString(fileName)
+	buf.WriteByte(0)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(file)))
+	buf.Write(file)
+	stream := make([]byte, 4, buf.Len()+4)
+	binary.LittleEndian.PutUint32(stream, uint32(buf.Len()))
+	return append(stream, buf.Bytes()...)
+}
+
+// parseOle10Native provides a function to get the label, file name and the
+// content of the file by given Ole10Native stream of the OLE package object.
+func parseOle10Native(stream []byte) (label, fileName string, file []byte, err error) {
+	err = errors.New("invalid OLE package object")
+	readString := func() (string, bool) {
+		idx := bytes.IndexByte(stream, 0)
+		if idx == -1 {
+			return "", false
+		}
+		s := string(stream[:idx])
+		stream = stream[idx+1:]
+		return s, true
+	}
+	readUint32 := func() (int, bool) {
+		if len(stream) < 4 {
+			return 0, false
+		}
+		n := int(binary.LittleEndian.Uint32(stream))
+		stream = stream[4:]
+		return n, true
+	}
+	if len(stream) < 6 {
+		return
+	}
+	stream = stream[6:]
+	var ok bool
+	if label, ok = readString(); !ok {
+		return
+	}
+	if fileName, ok = readString(); !ok || len(stream) < 4 {
+		return
+	}
+	stream = stream[4:]
+	size, ok := readUint32()
+	if !ok || len(stream) < size {
+		return
+	}
+	stream = stream[size:]
+	if size, ok = readUint32(); !ok || len(stream) < size {
+		return
+	}
+	return label, fileName, stream[:size], nil
+}
+
+// GetOLEObjects retrieves all the embedded OLE objects in a worksheet by
+// given worksheet name. The Width and Height of the objects are calculated
+// by the anchor of the objects. For example, save the embedded files in
+// Sheet1:
+//
+//    objects, err := f.GetOLEObjects("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    for _, object := range objects {
+//        if err := ioutil.WriteFile(object.FileName, object.File, 0644); err != nil {
+//            fmt.Println(err)
+//        }
+//    }
+//
+func (f *File) GetOLEObjects(sheet string) ([]OLEObject, error) {
+	var objects []OLEObject
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.OleObjects == nil {
+		return objects, err
+	}
+	var decoded decodeOleObjects
+	if err = f.xmlNewDecoder(strings.NewReader("<oleObjects>" + ws.OleObjects.Content + "</oleObjects>")).
+		Decode(&decoded); err != nil && err != io.EOF {
+		return objects, fmt.Errorf("xml decode error: %s", err)
+	}
+	oleObjects := decoded.OleObject
+	for _, alternateContent := range decoded.AlternateContent {
+		oleObjects = append(oleObjects, alternateContent.Choice.OleObject)
+	}
+	for _, oleObject := range oleObjects {
+		object, err := f.newOLEObject(sheet, &oleObject)
+		if err != nil {
+			return objects, err
+		}
+		if object.File != nil {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// newOLEObject provides a function to get the OLE object options by given
+// worksheet name and the decoded OLE object.
+func (f *File) newOLEObject(sheet string, oleObject *decodeOleObject) (OLEObject, error) {
+	var object OLEObject
+	target := f.getSheetRelationshipsTargetByID(sheet, oleObject.RID)
+	if target == "" {
+		return object, nil
+	}
+	embedding := strings.Replace(target, "..", "xl", 1)
+	if _, ok := f.XLSX[embedding]; !ok {
+		return object, nil
+	}
+	file, err := f.readPart(embedding)
+	if err != nil {
+		return object, err
+	}
+	object.FileName, object.File = path.Base(target), file
+	if oleObject.ProgID == "Package" {
+		doc, err := mscfb.New(bytes.NewReader(file))
+		if err != nil {
+			return object, err
+		}
+		for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+			if entry.Name != "Ole10Native" {
+				continue
+			}
+			stream, err := ioutil.ReadAll(entry)
+			if err != nil {
+				return object, err
+			}
+			if object.Caption, object.FileName, object.File, err = parseOle10Native(stream); err != nil {
+				return object, err
+			}
+		}
+	}
+	if objectPr := oleObject.ObjectPr; objectPr != nil {
+		if objectPr.AltText != "" {
+			object.Caption = objectPr.AltText
+		}
+		icon := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, objectPr.RID), "..", "xl", 1)
+		if _, ok := f.XLSX[icon]; ok {
+			if object.Icon, err = f.readPart(icon); err != nil {
+				return object, err
+			}
+		}
+		if anchor := objectPr.Anchor; anchor != nil {
+			object.Cell, _ = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+			x1, y1 := f.cellAnchorToPixels(sheet, anchor.From.Col, anchor.From.ColOff, anchor.From.Row, anchor.From.RowOff)
+			x2, y2 := f.cellAnchorToPixels(sheet, anchor.To.Col, anchor.To.ColOff, anchor.To.Row, anchor.To.RowOff)
+			object.Width, object.Height = x2-x1, y2-y1
+		}
+	}
+	return object, nil
+}
+
+// cfbStream defined the stream in the root storage of the compound file.
+type cfbStream struct {
+	name string
+	data []byte
+}
+
+// newCompoundFile provides a function to create the compound file binary
+// (version 3 with 512 bytes sector) by given class ID of the root storage and
+// the streams in the root storage. The streams smaller than 4096 bytes will
+// be stored in the mini stream.
+func newCompoundFile(clsid []byte, streams []cfbStream) []byte {
+	const (
+		sectorSize, miniSectorSize, miniStreamCutoff = 512, 64, 4096
+		freeSect, endOfChain, fatSect, difatSect     = 0xFFFFFFFF, 0xFFFFFFFE, 0xFFFFFFFD, 0xFFFFFFFC
+	)
+	sectors := func(size, unit int) int { return (size + unit - 1) / unit }
+	// Build the mini stream and the mini FAT.
+	var (
+		miniStream []byte
+		miniFAT    []uint32
+		starts     = make([]uint32, len(streams))
+		nData      int
+	)
+	for i, stream := range streams {
+		if size := len(stream.data); size >= miniStreamCutoff {
+			nData += sectors(size, sectorSize)
+			continue
+		}
+		n := sectors(len(stream.data), miniSectorSize)
+		starts[i] = endOfChain
+		if n > 0 {
+			starts[i] = uint32(len(miniFAT))
+		}
+		for j := 0; j < n; j++ {
+			next := uint32(len(miniFAT) + 1)
+			if j == n-1 {
+				next = endOfChain
+			}
+			miniFAT = append(miniFAT, next)
+		}
+		miniStream = append(miniStream, stream.data...)
+		miniStream = append(miniStream, make([]byte, n*miniSectorSize-len(stream.data))...)
+	}
+	nMiniFAT, nDir, nMiniStream := sectors(len(miniFAT)*4, sectorSize), sectors((len(streams)+1)*128, sectorSize), sectors(len(miniStream), sectorSize)
+	// Calculate the number of the FAT and DIFAT sectors.
+	var nFAT, nDIFAT int
+	for {
+		fat := sectors(nFAT+nDIFAT+nMiniFAT+nDir+nMiniStream+nData, sectorSize/4)
+		difat := 0
+		if fat > 109 {
+			difat = sectors(fat-109, sectorSize/4-1)
+		}
+		if fat == nFAT && difat == nDIFAT {
+			break
+		}
+		nFAT, nDIFAT = fat, difat
+	}
+	// Build the FAT with the sector chains.
+	fat := make([]uint32, nFAT*sectorSize/4)
+	for i := range fat {
+		fat[i] = freeSect
+	}
+	next := 0
+	chain := func(n int) uint32 {
+		if n == 0 {
+			return endOfChain
+		}
+		start := next
+		for j := 0; j < n; j++ {
+			fat[next] = uint32(next + 1)
+			next++
+		}
+		fat[next-1] = endOfChain
+		return uint32(start)
+	}
+	for ; next < nFAT; next++ {
+		fat[next] = fatSect
+	}
+	difatStart := uint32(endOfChain)
+	if nDIFAT > 0 {
+		difatStart = uint32(next)
+	}
+	for ; next < nFAT+nDIFAT; next++ {
+		fat[next] = difatSect
+	}
+	miniFATStart, dirStart, miniStreamStart := chain(nMiniFAT), chain(nDir), chain(nMiniStream)
+	for i, stream := range streams {
+		if len(stream.data) >= miniStreamCutoff {
+			starts[i] = chain(sectors(len(stream.data), sectorSize))
+		}
+	}
+	// Build the directory entries, the streams are organized as a balanced
+	// binary search tree in the root storage.
+	dir := make([]byte, nDir*sectorSize)
+	writeEntry := func(idx int, name string, entryType byte, left, right, child uint32, clsid []byte, start uint32, size int) {
+		entry := dir[idx*128 : idx*128+128]
+		u := utf16.Encode([]rune(name))
+		for k, c := range u {
+			binary.LittleEndian.PutUint16(entry[k*2:], c)
+		}
+		binary.LittleEndian.PutUint16(entry[64:], uint16((len(u)+1)*2))
+		entry[66], entry[67] = entryType, 1
+		binary.LittleEndian.PutUint32(entry[68:], left)
+		binary.LittleEndian.PutUint32(entry[72:], right)
+		binary.LittleEndian.PutUint32(entry[76:], child)
+		copy(entry[80:96], clsid)
+		binary.LittleEndian.PutUint32(entry[116:], start)
+		binary.LittleEndian.PutUint64(entry[120:], uint64(size))
+	}
+	for idx := len(streams) + 1; idx < nDir*sectorSize/128; idx++ {
+		writeEntry(idx, "", 0, freeSect, freeSect, freeSect, nil, 0, 0)
+	}
+	order := make([]int, len(streams))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := utf16.Encode([]rune(streams[order[i]].name)), utf16.Encode([]rune(streams[order[j]].name))
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return strings.ToUpper(streams[order[i]].name) < strings.ToUpper(streams[order[j]].name)
+	})
+	var tree func(lo, hi int) uint32
+	tree = func(lo, hi int) uint32 {
+		if lo >= hi {
+			return freeSect
+		}
+		mid := (lo + hi) / 2
+		i := order[mid]
+		writeEntry(i+1, streams[i].name, 2, tree(lo, mid), tree(mid+1, hi), freeSect, nil, starts[i], len(streams[i].data))
+		return uint32(i + 1)
+	}
+	rootStart := miniStreamStart
+	writeEntry(0, "Root Entry", 5, freeSect, freeSect, tree(0, len(order)), clsid, rootStart, len(miniStream))
+	// Write the header and the sectors.
+	var buf bytes.Buffer
+	header := make([]byte, sectorSize)
+	copy(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1})
+	for offset, v := range map[int]uint16{24: 0x003E, 26: 0x0003, 28: 0xFFFE, 30: 9, 32: 6} {
+		binary.LittleEndian.PutUint16(header[offset:], v)
+	}
+	for offset, v := range map[int]uint32{44: uint32(nFAT), 48: dirStart, 56: miniStreamCutoff,
+		60: miniFATStart, 64: uint32(nMiniFAT), 68: difatStart, 72: uint32(nDIFAT)} {
+		binary.LittleEndian.PutUint32(header[offset:], v)
+	}
+	difat := make([]uint32, 109+nDIFAT*(sectorSize/4))
+	for i := range difat {
+		difat[i] = freeSect
+	}
+	for i := 0; i < nFAT; i++ {
+		idx := i
+		if i >= 109 {
+			idx = 109 + (i-109)/(sectorSize/4-1)*(sectorSize/4) + (i-109)%(sectorSize/4-1)
+		}
+		difat[idx] = uint32(i)
+	}
+	for i := 0; i < nDIFAT; i++ {
+		next := uint32(endOfChain)
+		if i < nDIFAT-1 {
+			next = difatStart + uint32(i) + 1
+		}
+		difat[109+(i+1)*(sectorSize/4)-1] = next
+	}
+	for i := 0; i < 109; i++ {
+		binary.LittleEndian.PutUint32(header[76+i*4:], difat[i])
+	}
+	buf.Write(header)
+	_ = binary.Write(&buf, binary.LittleEndian, fat)
+	_ = binary.Write(&buf, binary.LittleEndian, difat[109:])
+	padding := func() {
+		if n := buf.Len() % sectorSize; n != 0 {
+			buf.Write(make([]byte, sectorSize-n))
+		}
+	}
+	for i := 0; i < nMiniFAT*sectorSize/4; i++ {
+		v := uint32(freeSect)
+		if i < len(miniFAT) {
+			v = miniFAT[i]
+		}
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	buf.Write(dir)
+	buf.Write(miniStream)
+	padding()
+	for _, stream := range streams {
+		if len(stream.data) >= miniStreamCutoff {
+			buf.Write(stream.data)
+			padding()
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,132 @@
+package excelize
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddOLEObject(t *testing.T) {
+	f := NewFile()
+	pdf := []byte("%PDF-1.4\n%%EOF\n")
+	book, err := ioutil.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	icon, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "B2", FileName: "report.pdf", Caption: "Audit Report", File: pdf}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "B8", FileName: "Book1.xlsx", File: book, Icon: icon, Width: 96, Height: 48}))
+	// Test add comment on the worksheet which contains the OLE objects.
+	assert.NoError(t, f.AddComment("Sheet1", "H1", `{"author":"Excelize: ","text":"This is a comment."}`))
+
+	check := func(f *File) {
+		objects, err := f.GetOLEObjects("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, objects, 2) {
+			return
+		}
+		assert.Equal(t, "B2", objects[0].Cell)
+		assert.Equal(t, "report.pdf", objects[0].FileName)
+		assert.Equal(t, "Audit Report", objects[0].Caption)
+		assert.Equal(t, pdf, objects[0].File)
+		assert.Equal(t, 64, objects[0].Width)
+		assert.Equal(t, 64, objects[0].Height)
+		assert.True(t, bytes.HasPrefix(objects[0].Icon, []byte("\x89PNG")))
+		assert.Equal(t, OLEObject{Cell: "B8", FileName: "Microsoft_Excel_Worksheet2.xlsx", Caption: "Book1.xlsx", File: book, Icon: icon, Width: 96, Height: 48}, objects[1])
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddOLEObject.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAddOLEObject.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(ws.OleObjects.Content, "<mc:AlternateContent "))
+	assert.Contains(t, string(f.readXML("[Content_Types].xml")), `<Override PartName="/xl/embeddings/oleObject1.bin" ContentType="application/vnd.openxmlformats-officedocument.oleObject">`)
+	assert.Contains(t, string(f.readXML("[Content_Types].xml")), `<Override PartName="/xl/embeddings/Microsoft_Excel_Worksheet2.xlsx" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet">`)
+	assert.Contains(t, string(f.XLSX["xl/drawings/vmlDrawing1.vml"]), `id="_x0000_t75"`)
+
+	// Test add OLE object on the worksheet loaded with the OLE objects and
+	// comments.
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "F8", FileName: "notes.txt", File: []byte("notes")}))
+	objects, err := f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, "notes.txt", objects[2].Caption)
+	assert.Len(t, f.GetComments()["Sheet1"], 1)
+
+	// Test add OLE object with invalid options.
+	assert.EqualError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1"}), "the file name and the content of the OLE object are required")
+	assert.EqualError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "A", FileName: "a.pdf", File: pdf}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.AddOLEObject("SheetN", OLEObject{Cell: "A1", FileName: "a.pdf", File: pdf}), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", FileName: "a.pdf", File: pdf, Icon: []byte("icon")}), "unsupported icon image extension")
+}
+
+func TestGetOLEObjects(t *testing.T) {
+	f := NewFile()
+	objects, err := f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 0)
+	// Test get OLE objects on not exists worksheet.
+	_, err = f.GetOLEObjects("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get OLE objects with invalid embedded object.
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", FileName: "a.pdf", File: []byte("pdf")}))
+	f.XLSX["xl/embeddings/oleObject1.bin"] = []byte("bin")
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.Error(t, err)
+	f.XLSX["xl/embeddings/oleObject1.bin"] = newCompoundFile(oleObjectPackageCLSID, []cfbStream{{name: "\x01Ole10Native", data: []byte("invalid")}})
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.EqualError(t, err, "invalid OLE package object")
+	// Test get OLE objects with the embedded object doesn't exist.
+	delete(f.XLSX, "xl/embeddings/oleObject1.bin")
+	objects, err = f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 0)
+	// Test get OLE objects with invalid OLE objects XML.
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.OleObjects.Content = "<oleObject"
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: expected attribute name in element")
+}
+
+func TestNewCompoundFile(t *testing.T) {
+	large := bytes.Repeat([]byte("excelize"), 1<<20)
+	streams := []cfbStream{
+		{name: "\x01Ole10Native", data: newOle10Native("label", "a.pdf", []byte("pdf"))},
+		{name: "\x01CompObj", data: newOLECompObj()},
+		{name: "Empty"},
+		{name: "Medium", data: bytes.Repeat([]byte{1}, 5000)},
+		{name: "Large", data: large},
+	}
+	doc, err := mscfb.New(bytes.NewReader(newCompoundFile(oleObjectPackageCLSID, streams)))
+	assert.NoError(t, err)
+	entries := map[string][]byte{}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		data, err := ioutil.ReadAll(entry)
+		assert.NoError(t, err)
+		entries[entry.Name] = data
+	}
+	assert.Len(t, entries, len(streams))
+	for _, stream := range streams {
+		assert.Equal(t, len(stream.data), len(entries[strings.TrimPrefix(stream.name, "\x01")]), stream.name)
+		assert.True(t, bytes.Equal(stream.data, entries[strings.TrimPrefix(stream.name, "\x01")]), stream.name)
+	}
+	label, fileName, file, err := parseOle10Native(entries["Ole10Native"])
+	assert.NoError(t, err)
+	assert.Equal(t, "label", label)
+	assert.Equal(t, "a.pdf", fileName)
+	assert.Equal(t, []byte("pdf"), file)
+	// Test parse the Ole10Native stream with invalid data.
+	for _, stream := range []string{"", "\x00\x00\x00\x00\x02\x00label", "\x00\x00\x00\x00\x02\x00label\x00a", "\x00\x00\x00\x00\x02\x00label\x00a\x00\x00\x00",
+		"\x00\x00\x00\x00\x02\x00label\x00a\x00\x00\x00\x03\x00\xff", "\x00\x00\x00\x00\x02\x00label\x00a\x00\x00\x00\x03\x00\x00\x00\x00\x00\xff"} {
+		_, _, _, err = parseOle10Native([]byte(stream))
+		assert.EqualError(t, err, "invalid OLE package object")
+	}
+}
@@ -13,13 +13,16 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"image"
+	"image/png"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -81,10 +84,44 @@ func parseFormatPictureSet(formatSet string) (*formatPicture, error) {
 // "Location" for moving to one of cell in this workbook. When the
 // "hyperlink_type" is "Location", coordinates need to start with "#".
 //
-// Positioning defines two types of the position of a picture in an Excel
-// spreadsheet, "oneCell" (Move but don't size with cells) or "absolute"
-// (Don't move or size with cells). If you don't set this parameter, default
-// positioning is move and size with cells.
+// Positioning defines three types of the position of a picture in an Excel
+// spreadsheet, "twoCell" (Move and size with cells), "oneCell" (Move but
+// don't size with cells) or "absolute" (Don't move or size with cells). If
+// you don't set this parameter, default positioning is move and size with
+// cells.
+//
+// The "absolute_x" and "absolute_y" specify the absolute position of the
+// top-left corner of the picture on the worksheet in EMUs (English Metric
+// Units, 9525 EMUs per pixel), the picture will be placed regardless of the
+// cell and the offsets when any of them is set. For example, place a picture
+// at 1 inch from the left and the top of the worksheet:
+//
+//    err := f.AddPicture("Sheet1", "A1", "image.png", `{"absolute_x": 914400, "absolute_y": 914400}`)
+//
+// The "fit_cell" scales the picture to fit the cell (or the merged cell
+// range which contains the cell) keeping the aspect ratio, the scale of the
+// picture will be calculated by the size of the cell, and the "x_scale" and
+// "y_scale" will be ignored. For example, fit a picture into Sheet1!B2:
+//
+//    err := f.AddPicture("Sheet1", "B2", "image.png", `{"fit_cell": true}`)
+//
+// The vector images in SVG, EMF and WMF formats are supported. The SVG image
+// will be stored with a PNG fallback image for the spreadsheet applications
+// which don't support the SVG image, the "fallback" specifies the path of the
+// fallback image, the "fallback_data" specifies the base64 encoded content of
+// the fallback image, and a transparent image will be used if neither of them
+// is set. For example, insert the SVG logo with the fallback image:
+//
+//    err := f.AddPicture("Sheet1", "A2", "logo.svg", `{"fallback": "logo.png"}`)
+//
+// The "in_cell" places the picture in the cell instead of floating over the
+// cells, the picture will be moved, sorted and filtered with the cell data,
+// and the other format settings will be ignored. This requires the
+// spreadsheet application which supports the pictures in cells, such as
+// Excel for Microsoft 365. For example, place a picture in Sheet1!A2:
+//
+//    err := f.AddPicture("Sheet1", "A2", "image.png", `{"in_cell": true}`)
+//
 func (f *File) AddPicture(sheet, cell, picture, format string) error {
 	var err error
 	// Check picture exists first.
@@ -95,14 +132,29 @@ func (f *File) AddPicture(sheet, cell, picture, format string) error {
 	if !ok {
 		return errors.New("unsupported image extension")
 	}
+	formatSet, err := parseFormatPictureSet(format)
+	if err != nil {
+		return err
+	}
+	if ext == ".svg" && formatSet.Fallback != "" && formatSet.FallbackData == nil {
+		if filepath.Ext(formatSet.Fallback) != ".png" {
+			return errors.New("unsupported fallback image extension")
+		}
+		if formatSet.FallbackData, err = ioutil.ReadFile(formatSet.Fallback); err != nil {
+			return err
+		}
+	}
 	file, _ := ioutil.ReadFile(picture)
 	_, name := filepath.Split(picture)
-	return f.AddPictureFromBytes(sheet, cell, format, name, ext, file)
+	return f.addPicture(sheet, cell, name, ext, file, formatSet)
 }
 
 // AddPictureFromBytes provides the method to add picture in a sheet by given
 // picture format set (such as offset, scale, aspect ratio setting and print
-// settings), file base name, extension name and file bytes. For example:
+// settings), file base name, extension name and file bytes. The fallback
+// image of the SVG picture should be given by the "fallback_data" instead of
+// the "fallback" path, so that the picture could be added without accessing
+// the file system. For example:
 //
 //    package main
 //
@@ -130,8 +182,6 @@ func (f *File) AddPicture(sheet, cell, picture, format string) error {
 //    }
 //
 func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string, file []byte) error {
-	var drawingHyperlinkRID int
-	var hyperlinkType string
 	ext, ok := supportImageTypes[extension]
 	if !ok {
 		return errors.New("unsupported image extension")
@@ -140,10 +190,36 @@ func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string,
 	if err != nil {
 		return err
 	}
-	img, _, err := image.DecodeConfig(bytes.NewReader(file))
+	if ext == ".svg" && formatSet.Fallback != "" && formatSet.FallbackData == nil {
+		return errors.New("unsupported fallback image path, use fallback_data instead")
+	}
+	return f.addPicture(sheet, cell, name, ext, file, formatSet)
+}
+
+// addPicture provides a function to add picture in a sheet by given
+// worksheet name, cell name, file base name, extension name, file bytes and
+// the parsed picture format set.
+func (f *File) addPicture(sheet, cell, name, ext string, file []byte, formatSet *formatPicture) error {
+	var drawingHyperlinkRID int
+	var hyperlinkType string
+	width, height, err := getImageSize(file, ext)
 	if err != nil {
 		return err
 	}
+	if formatSet.InCell {
+		return f.addCellPicture(sheet, cell, file, ext)
+	}
+	switch formatSet.Positioning {
+	case "", "twoCell", "oneCell", "absolute":
+	default:
+		return errors.New("unsupported positioning type")
+	}
+	var fallback []byte
+	if ext == ".svg" {
+		if fallback, err = getSVGFallback(formatSet.FallbackData); err != nil {
+			return err
+		}
+	}
 	// Read sheet data.
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -154,8 +230,13 @@ func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string,
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
 	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
-	mediaStr := ".." + strings.TrimPrefix(f.addMedia(file, ext), "xl")
-	drawingRID := f.addRels(drawingRels, SourceRelationshipImage, mediaStr, hyperlinkType)
+	var drawingRID, drawingSVGRID int
+	if fallback != nil {
+		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, ".."+strings.TrimPrefix(f.addMedia(fallback, ".png"), "xl"), hyperlinkType)
+		drawingSVGRID = f.addRels(drawingRels, SourceRelationshipImage, ".."+strings.TrimPrefix(f.addMedia(file, ext), "xl"), hyperlinkType)
+	} else {
+		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, ".."+strings.TrimPrefix(f.addMedia(file, ext), "xl"), hyperlinkType)
+	}
 	// Add picture with hyperlink.
 	if formatSet.Hyperlink != "" && formatSet.HyperlinkType != "" {
 		if formatSet.HyperlinkType == "External" {
@@ -163,7 +244,7 @@ func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string,
 		}
 		drawingHyperlinkRID = f.addRels(drawingRels, SourceRelationshipHyperLink, formatSet.Hyperlink, hyperlinkType)
 	}
-	err = f.addDrawingPicture(sheet, drawingXML, cell, name, img.Width, img.Height, drawingRID, drawingHyperlinkRID, formatSet)
+	err = f.addDrawingPicture(sheet, drawingXML, cell, name, width, height, drawingRID, drawingSVGRID, drawingHyperlinkRID, formatSet)
 	if err != nil {
 		return err
 	}
@@ -172,6 +253,43 @@ func (f *File) AddPictureFromBytes(sheet, cell, format, name, extension string,
 	return err
 }
 
+// addCellPicture provides a function to place the picture in the cell by
+// given worksheet name, cell name, the content and the extension of the
+// image. The picture will be stored as a local image rich value, and the
+// cell references the rich value by the value metadata.
+func (f *File) addCellPicture(sheet, cell string, file []byte, ext string) error {
+	f.clearCalcCache()
+	switch ext {
+	case ".emf", ".svg", ".wmf":
+		return errors.New("unsupported image extension for picture in cell")
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cellData, col, _, err := f.prepareCell(ws, sheet, cell)
+	if err != nil {
+		return err
+	}
+	f.setContentTypePartImageExtensions()
+	cellData.S = f.prepareCellStyle(ws, col, cellData.S)
+	cellData.F, cellData.IS = nil, nil
+	cellData.T, cellData.V = "e", "#VALUE!"
+	cellData.VM = uint(f.addRichValueImage(f.addMedia(file, ext)))
+	return err
+}
+
+// getCellPicture provides a function to get the path of the image placed in
+// the cell by given worksheet name and cell name, an empty string will be
+// returned if the cell doesn't contain a picture.
+func (f *File) getCellPicture(sheet, cell string) (string, error) {
+	metadata, err := f.GetCellMetadata(sheet, cell)
+	if err != nil || metadata.RichValueIndex == -1 {
+		return "", err
+	}
+	return f.getRichValueImage(metadata.RichValueIndex), err
+}
+
 // deleteSheetRelationships provides a function to delete relationships in
 // xl/worksheets/_rels/sheet%d.xml.rels by given worksheet name and
 // relationship index.
@@ -242,13 +360,13 @@ func (f *File) countDrawings() int {
 
 // addDrawingPicture provides a function to add picture by given sheet,
 // drawingXML, cell, file name, width, height relationship index and format
-// sets.
-func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, height, rID, hyperlinkRID int, formatSet *formatPicture) error {
+// sets. The SVG image relationship index will be ignored if it is zero.
+func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, height, rID, svgRID, hyperlinkRID int, formatSet *formatPicture) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	if formatSet.Autofit {
+	if formatSet.Autofit || formatSet.FitCell {
 		width, height, col, row, err = f.drawingResize(sheet, cell, float64(width), float64(height), formatSet)
 		if err != nil {
 			return err
@@ -259,23 +377,34 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, he
 	}
 	col--
 	row--
-	colStart, rowStart, colEnd, rowEnd, x2, y2 :=
-		f.positionObjectPixels(sheet, col, row, formatSet.OffsetX, formatSet.OffsetY, width, height)
 	content, cNvPrID := f.drawingParser(drawingXML)
 	twoCellAnchor := xdrCellAnchor{}
-	twoCellAnchor.EditAs = formatSet.Positioning
-	from := xlsxFrom{}
-	from.Col = colStart
-	from.ColOff = formatSet.OffsetX * EMU
-	from.Row = rowStart
-	from.RowOff = formatSet.OffsetY * EMU
-	to := xlsxTo{}
-	to.Col = colEnd
-	to.ColOff = x2 * EMU
-	to.Row = rowEnd
-	to.RowOff = y2 * EMU
-	twoCellAnchor.From = &from
-	twoCellAnchor.To = &to
+	if formatSet.AbsoluteX != nil || formatSet.AbsoluteY != nil {
+		twoCellAnchor.Pos = &xlsxPoint2D{}
+		if formatSet.AbsoluteX != nil {
+			twoCellAnchor.Pos.X = *formatSet.AbsoluteX
+		}
+		if formatSet.AbsoluteY != nil {
+			twoCellAnchor.Pos.Y = *formatSet.AbsoluteY
+		}
+		twoCellAnchor.Ext = &xlsxExt{Cx: width * EMU, Cy: height * EMU}
+	} else {
+		colStart, rowStart, colEnd, rowEnd, x2, y2 :=
+			f.positionObjectPixels(sheet, col, row, formatSet.OffsetX, formatSet.OffsetY, width, height)
+		twoCellAnchor.EditAs = formatSet.Positioning
+		from := xlsxFrom{}
+		from.Col = colStart
+		from.ColOff = formatSet.OffsetX * EMU
+		from.Row = rowStart
+		from.RowOff = formatSet.OffsetY * EMU
+		to := xlsxTo{}
+		to.Col = colEnd
+		to.ColOff = x2 * EMU
+		to.Row = rowEnd
+		to.RowOff = y2 * EMU
+		twoCellAnchor.From = &from
+		twoCellAnchor.To = &to
+	}
 	pic := xlsxPic{}
 	pic.NvPicPr.CNvPicPr.PicLocks.NoChangeAspect = formatSet.NoChangeAspect
 	pic.NvPicPr.CNvPr.ID = cNvPrID
@@ -289,6 +418,17 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, he
 	}
 	pic.BlipFill.Blip.R = SourceRelationship.Value
 	pic.BlipFill.Blip.Embed = "rId" + strconv.Itoa(rID)
+	if svgRID != 0 {
+		pic.BlipFill.Blip.ExtLst = &xlsxEGOfficeArtExtensionList{
+			Ext: []xlsxCTOfficeArtExtension{{
+				URI: ExtURISVG,
+				SVGBlip: &xlsxCTSVGBlip{
+					XMLNSASVG: NameSpaceDrawing2016SVG,
+					Embed:     "rId" + strconv.Itoa(svgRID),
+				},
+			}},
+		}
+	}
 	pic.SpPr.PrstGeom.Prst = "rect"
 
 	twoCellAnchor.Pic = &pic
@@ -296,11 +436,136 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, file string, width, he
 		FLocksWithSheet:  formatSet.FLocksWithSheet,
 		FPrintsWithSheet: formatSet.FPrintsWithSheet,
 	}
+	if twoCellAnchor.Pos != nil {
+		content.AbsoluteAnchor = append(content.AbsoluteAnchor, &twoCellAnchor)
+		f.Drawings[drawingXML] = content
+		return err
+	}
 	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
 	f.Drawings[drawingXML] = content
 	return err
 }
 
+// getImageSize provides a function to get the width and height of the image
+// in pixels by given image file and extension name.
+func getImageSize(file []byte, ext string) (int, int, error) {
+	switch ext {
+	case ".emf":
+		return getEMFSize(file)
+	case ".wmf":
+		return getWMFSize(file)
+	case ".svg":
+		return getSVGSize(file)
+	}
+	img, _, err := image.DecodeConfig(bytes.NewReader(file))
+	return img.Width, img.Height, err
+}
+
+// getEMFSize provides a function to get the size of the EMF image in pixels
+// by the frame of the header record, the frame is in 0.01 millimeter units.
+func getEMFSize(file []byte) (int, int, error) {
+	if len(file) < 44 || binary.LittleEndian.Uint32(file) != 1 || string(file[40:44]) != " EMF" {
+		return 0, 0, errors.New("invalid EMF image")
+	}
+	frame := make([]int32, 4)
+	for i := range frame {
+		frame[i] = int32(binary.LittleEndian.Uint32(file[24+i*4:]))
+	}
+	return int(math.Round(float64(frame[2]-frame[0]) * 96 / 2540)),
+		int(math.Round(float64(frame[3]-frame[1]) * 96 / 2540)), nil
+}
+
+// getWMFSize provides a function to get the size of the WMF image in pixels
+// by the bounding box and the units per inch of the placeable header.
+func getWMFSize(file []byte) (int, int, error) {
+	if len(file) < 22 || binary.LittleEndian.Uint32(file) != 0x9AC6CDD7 {
+		return 0, 0, errors.New("invalid WMF image, the placeable header is required")
+	}
+	box := make([]int16, 4)
+	for i := range box {
+		box[i] = int16(binary.LittleEndian.Uint16(file[6+i*2:]))
+	}
+	inch := int(binary.LittleEndian.Uint16(file[14:]))
+	if inch == 0 {
+		return 0, 0, errors.New("invalid WMF image, the placeable header is required")
+	}
+	return (int(box[2]) - int(box[0])) * 96 / inch, (int(box[3]) - int(box[1])) * 96 / inch, nil
+}
+
+// getSVGSize provides a function to get the size of the SVG image in pixels
+// by the width and height attributes of the root element, the view box will
+// be used if the size is not specified or relative, and the default size
+// 300 x 150 will be used if the view box is not specified.
+func getSVGSize(file []byte) (int, int, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(file))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, 0, errors.New("invalid SVG image")
+		}
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root.Name.Local != "svg" {
+			return 0, 0, errors.New("invalid SVG image")
+		}
+		attrs := map[string]string{}
+		for _, attr := range root.Attr {
+			attrs[attr.Name.Local] = attr.Value
+		}
+		width, height := 300.0, 150.0
+		if box := strings.Fields(strings.Replace(attrs["viewBox"], ",", " ", -1)); len(box) == 4 {
+			w, errW := strconv.ParseFloat(box[2], 64)
+			h, errH := strconv.ParseFloat(box[3], 64)
+			if errW == nil && errH == nil && w > 0 && h > 0 {
+				width, height = w, h
+			}
+		}
+		if w, ok := parseSVGLength(attrs["width"]); ok {
+			width = w
+		}
+		if h, ok := parseSVGLength(attrs["height"]); ok {
+			height = h
+		}
+		return int(math.Round(width)), int(math.Round(height)), nil
+	}
+}
+
+// parseSVGLength provides a function to convert the SVG length in absolute
+// units to pixels. Boolean type value ok will be false if the length is
+// empty, relative or invalid.
+func parseSVGLength(length string) (float64, bool) {
+	units := map[string]float64{"px": 1, "pt": 96.0 / 72, "pc": 16, "mm": 96 / 25.4, "cm": 96 / 2.54, "in": 96}
+	length, unit := strings.TrimSpace(length), 1.0
+	for k, v := range units {
+		if strings.HasSuffix(length, k) {
+			length, unit = strings.TrimSuffix(length, k), v
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(length), 64)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value * unit, true
+}
+
+// getSVGFallback provides a function to get the PNG fallback image of the SVG
+// image by given content of the fallback image, a transparent PNG image will
+// be returned if the content is empty.
+func getSVGFallback(fallback []byte) ([]byte, error) {
+	if len(fallback) > 0 {
+		if _, err := png.DecodeConfig(bytes.NewReader(fallback)); err != nil {
+			return nil, errors.New("invalid fallback PNG image")
+		}
+		return fallback, nil
+	}
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 1, 1)))
+	return buf.Bytes(), err
+}
+
 // countMedia provides a function to get media files count storage in the
 // folder xl/media/image.
 func (f *File) countMedia() int {
@@ -334,19 +599,24 @@ func (f *File) addMedia(file []byte, ext string) string {
 // setContentTypePartImageExtensions provides a function to set the content
 // type for relationship parts and the Main Document part.
 func (f *File) setContentTypePartImageExtensions() {
-	var imageTypes = map[string]bool{"jpeg": false, "png": false, "gif": false, "tiff": false}
+	var imageTypes = map[string]string{
+		"emf":  "image/x-emf",
+		"gif":  "image/gif",
+		"jpeg": "image/jpeg",
+		"png":  "image/png",
+		"svg":  "image/svg+xml",
+		"tiff": "image/tiff",
+		"wmf":  "image/x-wmf",
+	}
 	content := f.contentTypesReader()
 	for _, v := range content.Defaults {
-		_, ok := imageTypes[v.Extension]
-		if ok {
-			imageTypes[v.Extension] = true
-		}
+		delete(imageTypes, v.Extension)
 	}
-	for k, v := range imageTypes {
-		if !v {
+	for _, k := range []string{"emf", "gif", "jpeg", "png", "svg", "tiff", "wmf"} {
+		if contentType, ok := imageTypes[k]; ok {
 			content.Defaults = append(content.Defaults, xlsxDefault{
 				Extension:   k,
-				ContentType: "image/" + k,
+				ContentType: contentType,
 			})
 		}
 	}
@@ -378,24 +648,30 @@ func (f *File) addContentTypePart(index int, contentType string) {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
+		"chart":            "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":       "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":         "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":         "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"table":            "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":       "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":       "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":    "/xl/sharedStrings.xml",
+		"threadedComments": "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
+		"persons":          "/xl/persons/person.xml",
+		"ctrlProps":        "/xl/ctrlProps/ctrlProp" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
+		"chart":            ContentTypeDrawingML,
+		"chartsheet":       ContentTypeSpreadSheetMLChartsheet,
+		"comments":         ContentTypeSpreadSheetMLComments,
+		"drawings":         ContentTypeDrawing,
+		"table":            ContentTypeSpreadSheetMLTable,
+		"pivotTable":       ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":       ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"sharedStrings":    ContentTypeSpreadSheetMLSharedStrings,
+		"threadedComments": ContentTypeSpreadSheetMLThreadedComments,
+		"persons":          ContentTypeSpreadSheetMLPerson,
+		"ctrlProps":        ContentTypeSpreadSheetMLCtrlProp,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -478,10 +754,153 @@ func (f *File) GetPicture(sheet, cell string) (string, []byte, error) {
 	return f.getPicture(row, col, drawingXML, drawingRelationships)
 }
 
-// DeletePicture provides a function to delete charts in spreadsheet by given
-// worksheet and cell name. Note that the image file won't be deleted from the
-// document currently.
+// GetPictures provides a function to get all pictures of the worksheet by
+// given worksheet name. This function returns the anchor cells, offsets,
+// sizes, names, file extensions and raw contents of the pictures, the SVG
+// image instead of the fallback image will be returned for the SVG picture.
+// For example, save all pictures of Sheet1:
+//
+//    pics, err := f.GetPictures("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for idx, pic := range pics {
+//        name := fmt.Sprintf("image%d%s", idx+1, pic.Extension)
+//        if err := ioutil.WriteFile(name, pic.File, 0644); err != nil {
+//            fmt.Println(err)
+//        }
+//    }
+//
+func (f *File) GetPictures(sheet string) ([]Picture, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var pics []Picture
+	if ws.Drawing != nil {
+		if pics, err = f.getDrawingPictures(sheet, ws.Drawing.RID); err != nil {
+			return pics, err
+		}
+	}
+	var cells []string
+	ws.Lock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.VM != 0 {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	ws.Unlock()
+	for _, cell := range cells {
+		media, err := f.getCellPicture(sheet, cell)
+		if err != nil {
+			return pics, err
+		}
+		if media == "" {
+			continue
+		}
+		pic := Picture{
+			InCell:    true,
+			Cell:      cell,
+			Extension: filepath.Ext(media),
+		}
+		if pic.File, err = f.readPart(media); err != nil {
+			return pics, err
+		}
+		pic.Width, pic.Height, _ = getImageSize(pic.File, pic.Extension)
+		pics = append(pics, pic)
+	}
+	return pics, err
+}
+
+// getDrawingPictures provides a function to get the pictures floating over
+// the cells in the drawing part by given worksheet name and the relationship
+// ID of the drawing.
+func (f *File) getDrawingPictures(sheet, rID string) ([]Picture, error) {
+	var pics []Picture
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	if _, ok := f.XLSX[drawingXML]; !ok && f.Drawings[drawingXML] == nil {
+		return pics, nil
+	}
+	drawingRelationships := strings.Replace(
+		strings.Replace(target, "../drawings", "xl/drawings/_rels", -1), ".xml", ".xml.rels", -1)
+	wsDr, _ := f.drawingParser(drawingXML)
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.AbsoluteAnchor, wsDr.OneCellAnchor, wsDr.TwoCellAnchor} {
+		for _, anchor := range anchors {
+			deAnchor, err := f.decodeDrawingAnchor(anchor)
+			if err != nil {
+				return pics, err
+			}
+			if deAnchor.From == nil && deAnchor.Pos == nil || deAnchor.Pic == nil {
+				continue
+			}
+			rID := deAnchor.Pic.BlipFill.Blip.Embed
+			if svgRID := deAnchor.Pic.BlipFill.Blip.svgEmbed(); svgRID != "" {
+				rID = svgRID
+			}
+			drawRel := f.getDrawingRelationships(drawingRelationships, rID)
+			if drawRel == nil {
+				continue
+			}
+			ext := filepath.Ext(drawRel.Target)
+			if _, ok := supportImageTypes[ext]; !ok {
+				continue
+			}
+			pic := Picture{
+				Name:        deAnchor.Pic.NvPicPr.CNvPr.Name,
+				Description: deAnchor.Pic.NvPicPr.CNvPr.Descr,
+				Extension:   ext,
+			}
+			if pic.File, err = f.readPart(strings.Replace(drawRel.Target, "..", "xl", -1)); err != nil {
+				return pics, err
+			}
+			if deAnchor.From != nil {
+				pic.OffsetX, pic.OffsetY = deAnchor.From.ColOff/EMU, deAnchor.From.RowOff/EMU
+				pic.Cell, _ = CoordinatesToCellName(deAnchor.From.Col+1, deAnchor.From.Row+1)
+			} else {
+				pic.OffsetX, pic.OffsetY = deAnchor.Pos.X/EMU, deAnchor.Pos.Y/EMU
+			}
+			pic.Width, pic.Height = f.drawingAnchorSize(sheet, deAnchor)
+			pics = append(pics, pic)
+		}
+	}
+	return pics, nil
+}
+
+// drawingAnchorSize provides a function to get the size of the drawing
+// object in pixels by given worksheet name and decoded cell anchor. The size
+// will be calculated by the anchor cells if the extents of the object are
+// not specified.
+func (f *File) drawingAnchorSize(sheet string, anchor *decodeTwoCellAnchor) (int, int) {
+	if anchor.Pic != nil && anchor.Pic.SpPr.Xfrm.Ext.Cx > 0 && anchor.Pic.SpPr.Xfrm.Ext.Cy > 0 {
+		return anchor.Pic.SpPr.Xfrm.Ext.Cx / EMU, anchor.Pic.SpPr.Xfrm.Ext.Cy / EMU
+	}
+	if anchor.Ext != nil {
+		return anchor.Ext.Cx / EMU, anchor.Ext.Cy / EMU
+	}
+	if anchor.From == nil || anchor.To == nil {
+		return 0, 0
+	}
+	width, height := anchor.To.ColOff/EMU-anchor.From.ColOff/EMU, anchor.To.RowOff/EMU-anchor.From.RowOff/EMU
+	for col := anchor.From.Col; col < anchor.To.Col; col++ {
+		width += f.getColWidth(sheet, col)
+	}
+	for row := anchor.From.Row; row < anchor.To.Row; row++ {
+		height += f.getRowHeight(sheet, row)
+	}
+	return width, height
+}
+
+// DeletePicture provides a function to delete pictures in spreadsheet by
+// given worksheet and cell name. The relationships and the image files which
+// are no longer referenced by any other drawings will be deleted from the
+// spreadsheet. For the picture in cell, the value metadata, the rich value
+// and its relationship will be deleted as well if no other cells use them.
 func (f *File) DeletePicture(sheet, cell string) (err error) {
+	f.clearCalcCache()
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return
@@ -492,11 +911,116 @@ func (f *File) DeletePicture(sheet, cell string) (err error) {
 	if err != nil {
 		return
 	}
+	media, err := f.getCellPicture(sheet, cell)
+	if err != nil {
+		return
+	}
+	if media != "" {
+		var c *xlsxC
+		if c, _, _, err = f.prepareCell(ws, sheet, cell); err != nil {
+			return
+		}
+		vm := c.VM
+		c.T, c.V, c.VM = "", "", 0
+		return f.deleteValueMetadata(vm)
+	}
 	if ws.Drawing == nil {
 		return
 	}
-	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
-	return f.deleteDrawing(col, row, drawingXML, "Pic")
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.Replace(target, "..", "xl", -1)
+	drawingRelationships := strings.Replace(
+		strings.Replace(target, "../drawings", "xl/drawings/_rels", -1), ".xml", ".xml.rels", -1)
+	deleted, err := f.getDrawingPictureRIDs(drawingXML, func(anchor *decodeTwoCellAnchor) bool {
+		return anchor.From != nil && anchor.From.Col == col && anchor.From.Row == row
+	})
+	if err != nil {
+		return
+	}
+	if err = f.deleteDrawing(col, row, drawingXML, "Pic"); err != nil {
+		return
+	}
+	referenced, err := f.getDrawingPictureRIDs(drawingXML, func(anchor *decodeTwoCellAnchor) bool { return true })
+	if err != nil {
+		return
+	}
+	for rID := range deleted {
+		if referenced[rID] {
+			continue
+		}
+		drawRel := f.getDrawingRelationships(drawingRelationships, rID)
+		if drawRel == nil {
+			continue
+		}
+		rels := f.relsReader(drawingRelationships)
+		for idx, v := range rels.Relationships {
+			if v.ID == rID {
+				rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+				break
+			}
+		}
+		if media := strings.Replace(drawRel.Target, "..", "xl", -1); !f.isPartReferenced(media) {
+			delete(f.XLSX, media)
+		}
+	}
+	return
+}
+
+// getDrawingPictureRIDs provides a function to get the relationship IDs of
+// the images used by the pictures in the drawing part which satisfy the given
+// filter function.
+func (f *File) getDrawingPictureRIDs(drawingXML string, fn func(anchor *decodeTwoCellAnchor) bool) (map[string]bool, error) {
+	rIDs := map[string]bool{}
+	wsDr, _ := f.drawingParser(drawingXML)
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.AbsoluteAnchor, wsDr.OneCellAnchor, wsDr.TwoCellAnchor} {
+		for _, anchor := range anchors {
+			deAnchor, err := f.decodeDrawingAnchor(anchor)
+			if err != nil {
+				return rIDs, err
+			}
+			if deAnchor.Pic != nil && fn(deAnchor) {
+				rIDs[deAnchor.Pic.BlipFill.Blip.Embed] = true
+				if svgRID := deAnchor.Pic.BlipFill.Blip.svgEmbed(); svgRID != "" {
+					rIDs[svgRID] = true
+				}
+			}
+		}
+	}
+	return rIDs, nil
+}
+
+// isPartReferenced provides a function to check if the part is referenced by
+// any relationships in the spreadsheet by given part path.
+func (f *File) isPartReferenced(part string) bool {
+	relsPaths := map[string]bool{}
+	for p := range f.XLSX {
+		relsPaths[p] = strings.HasSuffix(p, ".rels")
+	}
+	for p := range f.Relationships {
+		relsPaths[p] = true
+	}
+	for p, ok := range relsPaths {
+		if !ok {
+			continue
+		}
+		rels := f.relsReader(p)
+		if rels == nil {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
+			}
+			target := path.Join(path.Dir(path.Dir(p)), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			if target == part {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // getPicture provides a function to get picture base name and raw content
@@ -532,7 +1056,8 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 			if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
 				drawRel = f.getDrawingRelationships(drawingRelationships, deTwoCellAnchor.Pic.BlipFill.Blip.Embed)
 				if _, ok = supportImageTypes[filepath.Ext(drawRel.Target)]; ok {
-					ret, buf = filepath.Base(drawRel.Target), f.XLSX[strings.Replace(drawRel.Target, "..", "xl", -1)]
+					ret = filepath.Base(drawRel.Target)
+					buf, err = f.readPart(strings.Replace(drawRel.Target, "..", "xl", -1))
 					return
 				}
 			}
@@ -556,7 +1081,7 @@ func (f *File) getPictureFromWsDr(row, col int, drawingRelationships string, wsD
 				if drawRel = f.getDrawingRelationships(drawingRelationships,
 					anchor.Pic.BlipFill.Blip.Embed); drawRel != nil {
 					if _, ok = supportImageTypes[filepath.Ext(drawRel.Target)]; ok {
-						ret, buf = filepath.Base(drawRel.Target), f.XLSX[strings.Replace(drawRel.Target, "..", "xl", -1)]
+						ret, buf = filepath.Base(drawRel.Target), f.readXML(strings.Replace(drawRel.Target, "..", "xl", -1))
 						return
 					}
 				}
@@ -626,6 +1151,16 @@ func (f *File) drawingResize(sheet string, cell string, width, height float64, f
 			cellHeight += f.getRowHeight(sheet, row)
 		}
 	}
+	if formatSet.FitCell {
+		cellWidth, cellHeight = cellWidth-formatSet.OffsetX, cellHeight-formatSet.OffsetY
+		if cellWidth <= 0 || cellHeight <= 0 || width <= 0 || height <= 0 {
+			w, h = int(width), int(height)
+			return
+		}
+		asp := math.Min(float64(cellWidth)/width, float64(cellHeight)/height)
+		w, h = int(width*asp), int(height*asp)
+		return
+	}
 	if float64(cellWidth) < width {
 		asp := float64(cellWidth) / width
 		width, height = float64(cellWidth), height*asp
@@ -1,6 +1,10 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
@@ -163,7 +167,7 @@ func TestGetPicture(t *testing.T) {
 func TestAddDrawingPicture(t *testing.T) {
 	// testing addDrawingPicture with illegal cell coordinates.
 	f := NewFile()
-	assert.EqualError(t, f.addDrawingPicture("sheet1", "", "A", "", 0, 0, 0, 0, nil), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.addDrawingPicture("sheet1", "", "A", "", 0, 0, 0, 0, 0, nil), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
 
 func TestAddPictureFromBytes(t *testing.T) {
@@ -197,6 +201,190 @@ func TestDeletePicture(t *testing.T) {
 	assert.NoError(t, NewFile().DeletePicture("Sheet1", "A1"))
 }
 
+func TestAddVectorPicture(t *testing.T) {
+	// Prepare the EMF image with 200 x 100 pixels frame.
+	emf := make([]byte, 88)
+	binary.LittleEndian.PutUint32(emf, 1)
+	binary.LittleEndian.PutUint32(emf[32:], 5292)
+	binary.LittleEndian.PutUint32(emf[36:], 2646)
+	copy(emf[40:], " EMF")
+	// Prepare the WMF image with 144 x 48 pixels bounding box.
+	wmf := make([]byte, 22)
+	binary.LittleEndian.PutUint32(wmf, 0x9AC6CDD7)
+	binary.LittleEndian.PutUint16(wmf[10:], 2160)
+	binary.LittleEndian.PutUint16(wmf[12:], 720)
+	binary.LittleEndian.PutUint16(wmf[14:], 1440)
+	svg := []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="1in" viewBox="0 0 48 24"><rect width="48" height="24"/></svg>`)
+
+	png, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	svgPath := filepath.Join("test", "TestAddVectorPicture.svg")
+	assert.NoError(t, ioutil.WriteFile(svgPath, svg, 0644))
+
+	f := NewFile()
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".emf", emf))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "E1", "", "Logo", ".wmf", wmf))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A10", "", "Logo", ".svg", svg))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "E10", fmt.Sprintf(`{"fallback_data":"%s"}`, base64.StdEncoding.EncodeToString(png)), "Logo", ".svg", svg))
+	assert.NoError(t, f.AddPicture("Sheet1", "A20", svgPath, fmt.Sprintf(`{"fallback":"%s"}`, filepath.ToSlash(filepath.Join("test", "images", "excel.png")))))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddVectorPicture.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAddVectorPicture.xlsx"))
+	assert.NoError(t, err)
+	pics, err := f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, pics, 5) {
+		for idx, expected := range []Picture{
+			{Cell: "A1", Width: 200, Height: 100, Extension: ".emf", File: emf},
+			{Cell: "E1", Width: 144, Height: 48, Extension: ".wmf", File: wmf},
+			{Cell: "A10", Width: 96, Height: 24, Extension: ".svg", File: svg},
+			{Cell: "E10", Width: 96, Height: 24, Extension: ".svg", File: svg},
+			{Cell: "A20", Width: 96, Height: 24, Extension: ".svg", File: svg},
+		} {
+			assert.Equal(t, expected.Cell, pics[idx].Cell)
+			assert.Equal(t, expected.Width, pics[idx].Width)
+			assert.Equal(t, expected.Height, pics[idx].Height)
+			assert.Equal(t, expected.Extension, pics[idx].Extension)
+			assert.Equal(t, expected.File, pics[idx].File)
+		}
+	}
+	for _, cell := range []string{"E10", "A20"} {
+		_, raw, err := f.GetPicture("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, png, raw)
+	}
+	contentTypes := map[string]string{}
+	for _, v := range f.contentTypesReader().Defaults {
+		contentTypes[v.Extension] = v.ContentType
+	}
+	assert.Equal(t, "image/x-emf", contentTypes["emf"])
+	assert.Equal(t, "image/x-wmf", contentTypes["wmf"])
+	assert.Equal(t, "image/svg+xml", contentTypes["svg"])
+
+	// Test delete SVG picture will delete the SVG image and fallback image.
+	assert.NoError(t, f.DeletePicture("Sheet1", "E10"))
+	assert.NoError(t, f.DeletePicture("Sheet1", "A20"))
+	assert.Nil(t, f.XLSX["xl/media/image5.png"])
+	assert.Equal(t, svg, f.XLSX["xl/media/image4.svg"])
+	assert.NoError(t, f.DeletePicture("Sheet1", "A10"))
+	assert.Nil(t, f.XLSX["xl/media/image4.svg"])
+
+	// Test add vector picture with invalid image.
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".emf", wmf), "invalid EMF image")
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".wmf", emf), "invalid WMF image, the placeable header is required")
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".wmf", make([]byte, 22)), "invalid WMF image, the placeable header is required")
+	wmf[14], wmf[15] = 0, 0
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".wmf", wmf), "invalid WMF image, the placeable header is required")
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".svg", []byte(`<html/>`)), "invalid SVG image")
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", "", "Logo", ".svg", []byte(`<svg`)), "invalid SVG image")
+	// Test add SVG picture with invalid fallback image.
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", `{"fallback":"logo.png"}`, "Logo", ".svg", svg), "unsupported fallback image path, use fallback_data instead")
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", fmt.Sprintf(`{"fallback_data":"%s"}`, base64.StdEncoding.EncodeToString(svg)), "Logo", ".svg", svg), "invalid fallback PNG image")
+	assert.EqualError(t, f.AddPicture("Sheet1", "A1", svgPath, `{"fallback":"logo.jpg"}`), "unsupported fallback image extension")
+	assert.Error(t, f.AddPicture("Sheet1", "A1", svgPath, `{"fallback":"not_exist.png"}`))
+	assert.Error(t, f.AddPicture("Sheet1", "A1", svgPath, `{"fallback":}`))
+	assert.NoError(t, os.Remove(svgPath))
+}
+
+func TestGetSVGSize(t *testing.T) {
+	for svg, expected := range map[string][]int{
+		`<svg/>`: {300, 150},
+		`<svg width="100%" viewBox="0,0,30,20"/>`:    {30, 20},
+		`<svg width="72pt" height="2cm"/>`:           {96, 76},
+		`<svg width="10mm" height="1pc"/>`:           {38, 16},
+		`<svg width="12px" height="-1" viewBox=""/>`: {12, 150},
+	} {
+		w, h, err := getSVGSize([]byte(svg))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, []int{w, h}, svg)
+	}
+}
+
+func TestGetPictures(t *testing.T) {
+	f := NewFile()
+	png, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	jpg, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), `{"x_offset": 10, "y_offset": 5}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "F10", filepath.Join("test", "images", "excel.jpg"), `{"x_scale": 0.5, "y_scale": 0.5}`))
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.AddPicture("Sheet2", "B2", filepath.Join("test", "images", "excel.png"), ""))
+
+	check := func(pics []Picture) {
+		if !assert.Len(t, pics, 2) {
+			return
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(png))
+		assert.NoError(t, err)
+		assert.Equal(t, Picture{
+			Cell: "A1", OffsetX: 10, OffsetY: 5, Width: cfg.Width, Height: cfg.Height, Name: "Picture 2",
+			Description: "excel.png", Extension: ".png", File: png,
+		}, pics[0])
+		cfg, _, err = image.DecodeConfig(bytes.NewReader(jpg))
+		assert.NoError(t, err)
+		assert.Equal(t, "F10", pics[1].Cell)
+		assert.Equal(t, cfg.Width/2, pics[1].Width)
+		assert.Equal(t, cfg.Height/2, pics[1].Height)
+		assert.Equal(t, ".jpeg", pics[1].Extension)
+		assert.Equal(t, jpg, pics[1].File)
+	}
+	pics, err := f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	check(pics)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictures.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestGetPictures.xlsx"))
+	assert.NoError(t, err)
+	pics, err = f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	check(pics)
+
+	// Test delete picture which image is referenced by the other worksheet.
+	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	pics, err = f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Len(t, f.Relationships["xl/drawings/_rels/drawing1.xml.rels"].Relationships, 1)
+	assert.Equal(t, png, f.XLSX["xl/media/image1.png"])
+	// Test delete picture which image is not referenced.
+	assert.NoError(t, f.DeletePicture("Sheet1", "F10"))
+	_, ok := f.XLSX["xl/media/image2.jpeg"]
+	assert.False(t, ok)
+	assert.NoError(t, f.DeletePicture("Sheet2", "B2"))
+	_, ok = f.XLSX["xl/media/image1.png"]
+	assert.False(t, ok)
+	pics, err = f.GetPictures("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 0)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictures.xlsx")))
+
+	// Test get pictures on not exists worksheet.
+	_, err = f.GetPictures("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get pictures on the worksheet without drawing.
+	pics, err = NewFile().GetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 0)
+	// Test get pictures with invalid drawing part.
+	f = NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), ""))
+	f.Drawings["xl/drawings/drawing1.xml"].TwoCellAnchor[0] = &xdrCellAnchor{GraphicFrame: "<from><col>A</col></from>"}
+	_, err = f.GetPictures("Sheet1")
+	assert.EqualError(t, err, `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+	assert.EqualError(t, f.DeletePicture("Sheet1", "A1"), `xml decode error: strconv.ParseInt: parsing "A": invalid syntax`)
+}
+
+func TestDrawingAnchorSize(t *testing.T) {
+	f := NewFile()
+	w, h := f.drawingAnchorSize("Sheet1", &decodeTwoCellAnchor{Ext: &decodeExt{Cx: 10 * EMU, Cy: 20 * EMU}})
+	assert.Equal(t, []int{10, 20}, []int{w, h})
+	w, h = f.drawingAnchorSize("Sheet1", &decodeTwoCellAnchor{From: &decodeFrom{}})
+	assert.Equal(t, []int{0, 0}, []int{w, h})
+	w, h = f.drawingAnchorSize("Sheet1", &decodeTwoCellAnchor{Pic: &decodePic{SpPr: decodeSpPr{Xfrm: decodeXfrm{Ext: decodeExt{Cx: 30 * EMU, Cy: 40 * EMU}}}}})
+	assert.Equal(t, []int{30, 40}, []int{w, h})
+}
+
 func TestDrawingResize(t *testing.T) {
 	f := NewFile()
 	// Test calculate drawing resize on not exists worksheet.
@@ -208,3 +396,171 @@ func TestDrawingResize(t *testing.T) {
 	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
 	assert.EqualError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.jpg"), `{"autofit": true}`), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 }
+
+func TestAddCellPicture(t *testing.T) {
+	f := NewFile()
+	png, err := ioutil.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(png))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), `{"in_cell": true}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), `{"in_cell": true}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "C3", filepath.Join("test", "images", "excel.jpg"), `{"in_cell": true}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "D4", filepath.Join("test", "images", "excel.png"), ""))
+
+	check := func(f *File) {
+		pics, err := f.GetPictures("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, pics, 4) {
+			return
+		}
+		assert.False(t, pics[0].InCell)
+		assert.Equal(t, "D4", pics[0].Cell)
+		assert.Equal(t, Picture{InCell: true, Cell: "A1", Width: cfg.Width, Height: cfg.Height, Extension: ".png", File: png}, pics[1])
+		assert.Equal(t, Picture{InCell: true, Cell: "B2", Width: cfg.Width, Height: cfg.Height, Extension: ".png", File: png}, pics[2])
+		assert.Equal(t, "C3", pics[3].Cell)
+		assert.Equal(t, ".jpeg", pics[3].Extension)
+		metadata, err := f.GetCellMetadata("Sheet1", "B2")
+		assert.NoError(t, err)
+		assert.Equal(t, CellMetadata{ValueMetadataIndex: 2, Types: []string{"XLRICHVALUE"}, RichValueIndex: 1}, metadata)
+		cellType, err := f.GetCellType("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeError, cellType)
+	}
+	check(f)
+	_, rvStruct, rvRels := f.richValueReader()
+	assert.Len(t, rvStruct.S, 1)
+	assert.Len(t, rvRels.Rel, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCellPicture.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAddCellPicture.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test add picture in cell on the workbook which contains the rich values.
+	assert.NoError(t, f.AddPicture("Sheet1", "E5", filepath.Join("test", "images", "excel.png"), `{"in_cell": true}`))
+	rv, rvStruct, _ := f.richValueReader()
+	assert.Len(t, rv.Rv, 4)
+	assert.Len(t, rvStruct.S, 1)
+	metadata, err := f.GetCellMetadata("Sheet1", "E5")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{ValueMetadataIndex: 4, Types: []string{"XLRICHVALUE"}, RichValueIndex: 3}, metadata)
+
+	// Test delete picture in cell.
+	jpg, err := f.getCellPicture("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	metadata, err = f.GetCellMetadata("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{RichValueIndex: -1}, metadata)
+	pics, err := f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 4)
+	rv, _, rvRels = f.richValueReader()
+	assert.Len(t, rv.Rv, 3)
+	assert.Len(t, rvRels.Rel, 2)
+	metadata, err = f.GetCellMetadata("Sheet1", "E5")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{ValueMetadataIndex: 3, Types: []string{"XLRICHVALUE"}, RichValueIndex: 2}, metadata)
+	// Test delete the picture in cell with the image which is no longer
+	// referenced.
+	assert.NoError(t, f.DeletePicture("Sheet1", "C3"))
+	assert.Len(t, rv.Rv, 2)
+	assert.Len(t, rvRels.Rel, 1)
+	_, ok := f.XLSX[jpg]
+	assert.False(t, ok)
+	metadata, err = f.GetCellMetadata("Sheet1", "E5")
+	assert.NoError(t, err)
+	assert.Equal(t, CellMetadata{ValueMetadataIndex: 2, Types: []string{"XLRICHVALUE"}, RichValueIndex: 1}, metadata)
+	// Test delete the picture in cell with the value metadata which is
+	// referenced by the other cells.
+	assert.NoError(t, f.SetCellStr("Sheet1", "F6", "#VALUE!"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[5].C[5].T, ws.SheetData.Row[5].C[5].VM = "e", 2
+	assert.NoError(t, f.DeletePicture("Sheet1", "E5"))
+	assert.Len(t, rv.Rv, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteCellPicture.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestDeleteCellPicture.xlsx"))
+	assert.NoError(t, err)
+	pics, err = f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, pics, 3) {
+		assert.Equal(t, Picture{InCell: true, Cell: "B2", Width: cfg.Width, Height: cfg.Height, Extension: ".png", File: png}, pics[1])
+		assert.Equal(t, Picture{InCell: true, Cell: "F6", Width: cfg.Width, Height: cfg.Height, Extension: ".png", File: png}, pics[2])
+	}
+
+	// Test add picture in cell with unsupported image extension.
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="48" height="24"/>`)
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", `{"in_cell": true}`, "Logo", ".svg", svg), "unsupported image extension for picture in cell")
+	// Test add picture in cell with invalid cell coordinates.
+	assert.EqualError(t, f.AddPicture("Sheet1", "A", filepath.Join("test", "images", "excel.png"), `{"in_cell": true}`), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test add picture in cell on not exist worksheet.
+	assert.EqualError(t, f.AddPicture("SheetN", "A1", filepath.Join("test", "images", "excel.png"), `{"in_cell": true}`), "sheet SheetN is not exist")
+}
+
+func TestGetRichValueImage(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, "", f.getRichValueImage(0))
+	rv, rvStruct, rvRels := f.richValueReader()
+	rvStruct.S = append(rvStruct.S, xlsxRichValueStructure{T: "_linkedEntity"}, xlsxRichValueStructure{
+		T: "_localImage", K: []xlsxRichValueKey{{N: "_rvRel:LocalImageIdentifier", T: "i"}},
+	})
+	rv.Rv = append(rv.Rv, xlsxRichValue{S: 0}, xlsxRichValue{S: 1, V: []xlsxRichValueV{{Val: "x"}}},
+		xlsxRichValue{S: 1, V: []xlsxRichValueV{{Val: "0"}}})
+	assert.Equal(t, "", f.getRichValueImage(0))
+	assert.Equal(t, "", f.getRichValueImage(1))
+	assert.Equal(t, "", f.getRichValueImage(2))
+	rvRels.Rel = append(rvRels.Rel, xlsxRichValueRel{ID: "rId1"})
+	f.addRels(f.getRichValueRelsPath(), SourceRelationshipImage, "/xl/media/image1.png", "")
+	assert.Equal(t, "xl/media/image1.png", f.getRichValueImage(2))
+}
+
+func TestAddPictureAnchoring(t *testing.T) {
+	f := NewFile()
+	img := filepath.Join("test", "images", "excel.png")
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "E7"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C10", "J30"))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", img, `{"positioning": "twoCell"}`))
+	// Test add picture with fit to cell mode, the picture will be shrunk or
+	// enlarged by the size of the merged cell range.
+	assert.NoError(t, f.AddPicture("Sheet1", "C3", img, `{"fit_cell": true}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "D20", img, `{"fit_cell": true, "x_scale": 2}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "L1", img, `{"fit_cell": true, "x_offset": 4, "y_offset": 4}`))
+	// Test add picture with absolute position in EMUs.
+	assert.NoError(t, f.AddPicture("Sheet1", "Z100", img, `{"absolute_x": 914400, "absolute_y": 457200}`))
+
+	check := func(f *File) {
+		pics, err := f.GetPictures("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, pics, 5) {
+			return
+		}
+		assert.Equal(t, "", pics[0].Cell)
+		assert.Equal(t, [4]int{96, 48, 200, 128}, [4]int{pics[0].OffsetX, pics[0].OffsetY, pics[0].Width, pics[0].Height})
+		assert.Equal(t, "A1", pics[1].Cell)
+		assert.Equal(t, [2]int{200, 128}, [2]int{pics[1].Width, pics[1].Height})
+		assert.Equal(t, "B2", pics[2].Cell)
+		assert.Equal(t, [2]int{187, 120}, [2]int{pics[2].Width, pics[2].Height})
+		assert.Equal(t, "C10", pics[3].Cell)
+		assert.Equal(t, [2]int{512, 327}, [2]int{pics[3].Width, pics[3].Height})
+		assert.Equal(t, "L1", pics[4].Cell)
+		assert.Equal(t, [2]int{25, 16}, [2]int{pics[4].Width, pics[4].Height})
+	}
+	check(f)
+	wsDr, _ := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.Equal(t, "twoCell", wsDr.TwoCellAnchor[0].EditAs)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureAnchoring.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureAnchoring.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test delete picture keeps the absolute anchored picture.
+	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	pics, err := f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 4)
+	assert.Equal(t, "", pics[0].Cell)
+
+	// Test add picture with unsupported positioning type.
+	assert.EqualError(t, f.AddPicture("Sheet1", "A1", img, `{"positioning": "none"}`), "unsupported positioning type")
+}
@@ -15,11 +15,16 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
 // PivotTableOption directly maps the format settings of the pivot table.
+// GrandTotalCaption specifies the caption of the grand total, ErrorCaption
+// specifies the text displayed in the cells with errors when ShowError is
+// true, and MissingCaption specifies the text displayed in the empty cells.
 type PivotTableOption struct {
 	DataRange           string
 	PivotTableRange     string
@@ -35,6 +40,9 @@ type PivotTableOption struct {
 	MergeItem           bool
 	CompactData         bool
 	ShowError           bool
+	GrandTotalCaption   string
+	ErrorCaption        string
+	MissingCaption      string
 	ShowRowHeaders      bool
 	ShowColHeaders      bool
 	ShowRowStripes      bool
@@ -62,11 +70,22 @@ type PivotTableOption struct {
 //
 // Name specifies the name of the data field. Maximum 255 characters
 // are allowed in data field name, excess characters will be truncated.
+//
+// InsertBlankRow specifies inserting a blank row after each item of the row
+// field, and InsertPageBreak specifies inserting a page break after each
+// item of the row field when printing.
+//
+// HiddenItems specifies the items of the row, column or filter field which
+// will be hidden in the pivot table, such as []string{"East", "West"}, each
+// item should be the formatted value of the cell in the data range.
 type PivotTableField struct {
 	Data            string
 	Name            string
 	Subtotal        string
 	DefaultSubtotal bool
+	InsertBlankRow  bool
+	InsertPageBreak bool
+	HiddenItems     []string
 }
 
 // AddPivotTable provides the method to add pivot table by given pivot table
@@ -177,7 +196,7 @@ func (f *File) parseFormatPivotTableSet(opt *PivotTableOption) (*xlsxWorksheet,
 	}
 	pivotTableSheetPath, ok := f.sheetMap[trimSheetName(pivotTableSheetName)]
 	if !ok {
-		return dataSheet, pivotTableSheetPath, fmt.Errorf("sheet %s is not exist", pivotTableSheetName)
+		return dataSheet, pivotTableSheetPath, ErrSheetNotExist{pivotTableSheetName}
 	}
 	return dataSheet, pivotTableSheetPath, err
 }
@@ -261,13 +280,21 @@ func (f *File) addPivotCache(pivotCacheID int, pivotCacheXML string, opt *PivotT
 		sharedItems := xlsxSharedItems{
 			Count: 0,
 		}
-		s := xlsxString{}
 		if (rowOk && !defaultRowsSubtotal) || (colOk && !defaultColumnsSubtotal) {
-			s = xlsxString{
-				V: "",
-			}
 			sharedItems.Count++
-			sharedItems.S = &s
+			sharedItems.S = []xlsxString{{V: ""}}
+		}
+		if field, ok := getPivotTableFieldWithHiddenItems(name, opt); ok {
+			values, numbers, err := f.getPivotFieldItems(opt.DataRange, name)
+			if err != nil {
+				return err
+			}
+			for _, item := range field.HiddenItems {
+				if inStrSlice(values, item) == -1 {
+					return fmt.Errorf("pivot item %s is not exist in field %s", item, name)
+				}
+			}
+			sharedItems = newPivotCacheSharedItems(values, numbers)
 		}
 
 		pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
@@ -281,6 +308,95 @@ func (f *File) addPivotCache(pivotCacheID int, pivotCacheXML string, opt *PivotT
 	return err
 }
 
+// getPivotTableFieldWithHiddenItems provides a function to get the row,
+// column or filter field which has the hidden items by given field name.
+func getPivotTableFieldWithHiddenItems(name string, opt *PivotTableOption) (PivotTableField, bool) {
+	for _, fields := range [][]PivotTableField{opt.Rows, opt.Columns, opt.Filter} {
+		if idx := inPivotTableField(fields, name); idx != -1 && len(fields[idx].HiddenItems) > 0 {
+			return fields[idx], true
+		}
+	}
+	return PivotTableField{}, false
+}
+
+// getPivotFieldItems provides a function to get the unique items of the
+// pivot field in the data range by given field name, the blank cells will be
+// skipped. The formatted values of the items will be returned, and the
+// numbers of the items will be also returned if all the items are numbers.
+func (f *File) getPivotFieldItems(dataRange, name string) ([]string, []float64, error) {
+	order, err := f.getPivotFieldsOrder(dataRange)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataSheet, coordinates, _ := f.adjustRange(dataRange)
+	col := coordinates[0] + inStrSlice(order, name)
+	var (
+		values  []string
+		numbers []float64
+		numeric = true
+	)
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		cell, _ := CoordinatesToCellName(col, row)
+		value, err := f.GetCellValue(dataSheet, cell)
+		if err != nil {
+			return nil, nil, err
+		}
+		if value == "" || inStrSlice(values, value) != -1 {
+			continue
+		}
+		values = append(values, value)
+		if numeric {
+			cellType, _ := f.GetCellType(dataSheet, cell)
+			number, _ := f.GetCellFloat(dataSheet, cell)
+			numbers, numeric = append(numbers, number), cellType == CellTypeNumber
+		}
+	}
+	if !numeric {
+		numbers = nil
+	}
+	return values, numbers, nil
+}
+
+// newPivotCacheSharedItems provides a function to create the shared items of
+// the pivot cache field by given formatted values and the numbers of the
+// items, the items will be stored as the numbers if the numbers are given.
+func newPivotCacheSharedItems(values []string, numbers []float64) xlsxSharedItems {
+	sharedItems := xlsxSharedItems{Count: len(values)}
+	if numbers == nil {
+		for _, value := range values {
+			sharedItems.S = append(sharedItems.S, xlsxString{V: value})
+		}
+		return sharedItems
+	}
+	sharedItems.ContainsSemiMixedTypes, sharedItems.ContainsString = boolPtr(false), boolPtr(false)
+	sharedItems.ContainsNumber, sharedItems.ContainsInteger = true, true
+	for i, number := range numbers {
+		if i == 0 || number < sharedItems.MinValue {
+			sharedItems.MinValue = number
+		}
+		if i == 0 || number > sharedItems.MaxValue {
+			sharedItems.MaxValue = number
+		}
+		if number != math.Trunc(number) {
+			sharedItems.ContainsInteger = false
+		}
+		sharedItems.N = append(sharedItems.N, xlsxNumber{V: number})
+	}
+	return sharedItems
+}
+
+// getPivotItems provides a function to create the items of the pivot field
+// by given pivot table field, the items in the hidden items of the field
+// will be hidden.
+func (f *File) getPivotItems(opt *PivotTableOption, field PivotTableField) []*xlsxItem {
+	values, _, _ := f.getPivotFieldItems(opt.DataRange, field.Data)
+	var items []*xlsxItem
+	for i, value := range values {
+		items = append(items, &xlsxItem{X: intPtr(i), H: inStrSlice(field.HiddenItems, value) != -1})
+	}
+	return items
+}
+
 // addPivotTable provides a function to create a pivot table by given pivot
 // table ID and properties.
 func (f *File) addPivotTable(cacheID, pivotTableID int, pivotTableXML string, opt *PivotTableOption) error {
@@ -311,6 +427,9 @@ func (f *File) addPivotTable(cacheID, pivotTableID int, pivotTableXML string, op
 		CompactData:       &opt.CompactData,
 		ShowError:         &opt.ShowError,
 		DataCaption:       "Values",
+		GrandTotalCaption: opt.GrandTotalCaption,
+		ErrorCaption:      opt.ErrorCaption,
+		MissingCaption:    opt.MissingCaption,
 		Location: &xlsxLocation{
 			Ref:            hcell + ":" + vcell,
 			FirstDataCol:   1,
@@ -508,10 +627,14 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opt *PivotTableOptio
 	}
 	x := 0
 	for _, name := range order {
-		if inPivotTableField(opt.Rows, name) != -1 {
+		if idx := inPivotTableField(opt.Rows, name); idx != -1 {
 			defaultSubtotal, ok := f.getPivotTableFieldNameDefaultSubtotal(name, opt.Rows)
 			var items []*xlsxItem
-			if !ok || !defaultSubtotal {
+			if len(opt.Rows[idx].HiddenItems) > 0 {
+				if items = f.getPivotItems(opt, opt.Rows[idx]); defaultSubtotal {
+					items = append(items, &xlsxItem{T: "default"})
+				}
+			} else if !ok || !defaultSubtotal {
 				items = append(items, &xlsxItem{X: &x})
 			} else {
 				items = append(items, &xlsxItem{T: "default"})
@@ -525,26 +648,36 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opt *PivotTableOptio
 					Item:  items,
 				},
 				DefaultSubtotal: &defaultSubtotal,
+				InsertBlankRow:  opt.Rows[idx].InsertBlankRow,
+				InsertPageBreak: opt.Rows[idx].InsertPageBreak,
 			})
 			continue
 		}
-		if inPivotTableField(opt.Filter, name) != -1 {
+		if idx := inPivotTableField(opt.Filter, name); idx != -1 {
+			var items []*xlsxItem
+			if len(opt.Filter[idx].HiddenItems) > 0 {
+				items = f.getPivotItems(opt, opt.Filter[idx])
+			}
+			items = append(items, &xlsxItem{T: "default"})
 			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, &xlsxPivotField{
 				Axis: "axisPage",
 				Name: f.getPivotTableFieldName(name, opt.Columns),
 				Items: &xlsxItems{
-					Count: 1,
-					Item: []*xlsxItem{
-						{T: "default"},
-					},
+					Count: len(items),
+					Item:  items,
 				},
+				MultipleItemSelectionAllowed: len(opt.Filter[idx].HiddenItems) > 0,
 			})
 			continue
 		}
-		if inPivotTableField(opt.Columns, name) != -1 {
+		if idx := inPivotTableField(opt.Columns, name); idx != -1 {
 			defaultSubtotal, ok := f.getPivotTableFieldNameDefaultSubtotal(name, opt.Columns)
 			var items []*xlsxItem
-			if !ok || !defaultSubtotal {
+			if len(opt.Columns[idx].HiddenItems) > 0 {
+				if items = f.getPivotItems(opt, opt.Columns[idx]); defaultSubtotal {
+					items = append(items, &xlsxItem{T: "default"})
+				}
+			} else if !ok || !defaultSubtotal {
 				items = append(items, &xlsxItem{X: &x})
 			} else {
 				items = append(items, &xlsxItem{T: "default"})
@@ -682,3 +815,155 @@ func (f *File) addWorkbookPivotCache(RID int) int {
 	})
 	return cacheID
 }
+
+// PivotTableStyle directly maps the settings of the custom pivot table
+// style. Name specifies the name of the style, which could be used as the
+// PivotTableStyleName of the pivot table options. Each of the other fields
+// specifies the ID of the differential style created by NewConditionalStyle
+// for the area of the pivot table, the area will use the default format if
+// the field is nil. The stripe size of the row and column stripes is 1 if
+// not specified.
+type PivotTableStyle struct {
+	Name                   string
+	WholeTable             *int
+	HeaderRow              *int
+	GrandTotalRow          *int
+	FirstColumn            *int
+	GrandTotalColumn       *int
+	FirstRowStripe         *int
+	FirstRowStripeSize     int
+	SecondRowStripe        *int
+	SecondRowStripeSize    int
+	FirstColumnStripe      *int
+	FirstColumnStripeSize  int
+	SecondColumnStripe     *int
+	SecondColumnStripeSize int
+	FirstHeaderCell        *int
+	FirstSubtotalColumn    *int
+	SecondSubtotalColumn   *int
+	ThirdSubtotalColumn    *int
+	FirstSubtotalRow       *int
+	SecondSubtotalRow      *int
+	ThirdSubtotalRow       *int
+	BlankRow               *int
+	FirstColumnSubheading  *int
+	SecondColumnSubheading *int
+	ThirdColumnSubheading  *int
+	FirstRowSubheading     *int
+	SecondRowSubheading    *int
+	ThirdRowSubheading     *int
+	PageFieldLabels        *int
+	PageFieldValues        *int
+}
+
+// AddPivotTableStyle provides a function to register a custom pivot table
+// style in the workbook by given style settings, the name of the style
+// should be different from the built-in pivot table styles and the other
+// custom table styles. For example, create a pivot table style with the
+// blue bold header and the grand total row with the top border:
+//
+//    header, err := f.NewConditionalStyle(`{"font":{"bold":true,"color":"#FFFFFF"},"fill":{"type":"pattern","color":["#1F4E78"],"pattern":1}}`)
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    total, err := f.NewConditionalStyle(`{"font":{"bold":true},"border":[{"type":"top","color":"#1F4E78","style":2}]}`)
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    if err := f.AddPivotTableStyle(&excelize.PivotTableStyle{
+//        Name:          "Brand",
+//        HeaderRow:     &header,
+//        GrandTotalRow: &total,
+//    }); err != nil {
+//        fmt.Println(err)
+//    }
+//
+// Then create the pivot table with the PivotTableStyleName "Brand".
+func (f *File) AddPivotTableStyle(style *PivotTableStyle) error {
+	if style == nil {
+		return errors.New("parameter is required")
+	}
+	if style.Name == "" {
+		return errors.New("parameter 'Name' is required")
+	}
+	s := f.stylesReader()
+	if s.TableStyles == nil {
+		s.TableStyles = &xlsxTableStyles{DefaultTableStyle: "TableStyleMedium2", DefaultPivotStyle: "PivotStyleLight16"}
+	}
+	if isBuiltInPivotTableStyle(style.Name) || isBuiltInTableStyle(style.Name) {
+		return errors.New("the same name table style already exists")
+	}
+	for _, ts := range s.TableStyles.TableStyles {
+		if strings.EqualFold(ts.Name, style.Name) {
+			return errors.New("the same name table style already exists")
+		}
+	}
+	var dxfs int
+	if s.Dxfs != nil {
+		dxfs = len(s.Dxfs.Dxfs)
+	}
+	tableStyle := &xlsxTableStyle{Name: style.Name, Table: boolPtr(false)}
+	for _, element := range []struct {
+		typ   string
+		dxfID *int
+		size  int
+	}{
+		{"wholeTable", style.WholeTable, 0},
+		{"headerRow", style.HeaderRow, 0},
+		{"totalRow", style.GrandTotalRow, 0},
+		{"firstColumn", style.FirstColumn, 0},
+		{"lastColumn", style.GrandTotalColumn, 0},
+		{"firstRowStripe", style.FirstRowStripe, style.FirstRowStripeSize},
+		{"secondRowStripe", style.SecondRowStripe, style.SecondRowStripeSize},
+		{"firstColumnStripe", style.FirstColumnStripe, style.FirstColumnStripeSize},
+		{"secondColumnStripe", style.SecondColumnStripe, style.SecondColumnStripeSize},
+		{"firstHeaderCell", style.FirstHeaderCell, 0},
+		{"firstSubtotalColumn", style.FirstSubtotalColumn, 0},
+		{"secondSubtotalColumn", style.SecondSubtotalColumn, 0},
+		{"thirdSubtotalColumn", style.ThirdSubtotalColumn, 0},
+		{"firstSubtotalRow", style.FirstSubtotalRow, 0},
+		{"secondSubtotalRow", style.SecondSubtotalRow, 0},
+		{"thirdSubtotalRow", style.ThirdSubtotalRow, 0},
+		{"blankRow", style.BlankRow, 0},
+		{"firstColumnSubheading", style.FirstColumnSubheading, 0},
+		{"secondColumnSubheading", style.SecondColumnSubheading, 0},
+		{"thirdColumnSubheading", style.ThirdColumnSubheading, 0},
+		{"firstRowSubheading", style.FirstRowSubheading, 0},
+		{"secondRowSubheading", style.SecondRowSubheading, 0},
+		{"thirdRowSubheading", style.ThirdRowSubheading, 0},
+		{"pageFieldLabels", style.PageFieldLabels, 0},
+		{"pageFieldValues", style.PageFieldValues, 0},
+	} {
+		if element.dxfID == nil {
+			continue
+		}
+		if *element.dxfID < 0 || *element.dxfID >= dxfs {
+			return fmt.Errorf("invalid differential style ID %d", *element.dxfID)
+		}
+		if element.size < 0 || element.size > 9 {
+			return fmt.Errorf("invalid stripe size %d", element.size)
+		}
+		if element.size == 1 {
+			element.size = 0
+		}
+		tableStyle.TableStyleElement = append(tableStyle.TableStyleElement, &xlsxTableStyleElement{
+			Type: element.typ, Size: element.size, DxfID: intPtr(*element.dxfID),
+		})
+	}
+	tableStyle.Count = len(tableStyle.TableStyleElement)
+	s.TableStyles.TableStyles = append(s.TableStyles.TableStyles, tableStyle)
+	s.TableStyles.Count = len(s.TableStyles.TableStyles)
+	return nil
+}
+
+// isBuiltInPivotTableStyle provides a function to check if the given name is
+// one of the built-in pivot table styles, such as PivotStyleLight16.
+func isBuiltInPivotTableStyle(name string) bool {
+	return regexp.MustCompile(`^(?i)PivotStyle(Light|Medium|Dark)([1-9]|1\d|2[0-8])$`).MatchString(name)
+}
+
+// isBuiltInTableStyle provides a function to check if the given name is one
+// of the built-in table styles, such as TableStyleMedium2.
+func isBuiltInTableStyle(name string) bool {
+	return regexp.MustCompile(`^(?i)TableStyle(Light([1-9]|1\d|2[01])|Medium([1-9]|1\d|2[0-8])|Dark([1-9]|1[01]))$`).MatchString(name)
+}
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math/rand"
 	"path/filepath"
@@ -252,6 +253,159 @@ func TestAddPivotTable(t *testing.T) {
 	assert.EqualError(t, err, `parameter 'DataRange' parsing error: parameter is required`)
 }
 
+func TestAddPivotTableStyle(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Sales"}))
+	for row, region := range []string{"East", "West", "East"} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &[]interface{}{region, row * 100}))
+	}
+	header, err := f.NewConditionalStyle(`{"font":{"bold":true,"color":"#FFFFFF"},"fill":{"type":"pattern","color":["#1F4E78"],"pattern":1}}`)
+	assert.NoError(t, err)
+	total, err := f.NewConditionalStyle(`{"font":{"bold":true},"border":[{"type":"top","color":"#1F4E78","style":2}]}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPivotTableStyle(&PivotTableStyle{
+		Name:               "Brand",
+		HeaderRow:          &header,
+		GrandTotalRow:      &total,
+		FirstSubtotalRow:   &total,
+		FirstRowStripe:     &header,
+		FirstRowStripeSize: 2,
+		SecondRowStripe:    &total,
+	}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:           "Sheet1!$A$1:$B$4",
+		PivotTableRange:     "Sheet1!$D$1:$E$4",
+		Rows:                []PivotTableField{{Data: "Region"}},
+		Data:                []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+		RowGrandTotals:      true,
+		ColGrandTotals:      true,
+		ShowRowHeaders:      true,
+		ShowColHeaders:      true,
+		PivotTableStyleName: "Brand",
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotTableStyle.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAddPivotTableStyle.xlsx"))
+	assert.NoError(t, err)
+	s := f.stylesReader()
+	assert.Equal(t, 1, s.TableStyles.Count)
+	assert.Equal(t, &xlsxTableStyle{Name: "Brand", Table: boolPtr(false), Count: 5, TableStyleElement: []*xlsxTableStyleElement{
+		{Type: "headerRow", DxfID: intPtr(header)},
+		{Type: "totalRow", DxfID: intPtr(total)},
+		{Type: "firstRowStripe", Size: 2, DxfID: intPtr(header)},
+		{Type: "secondRowStripe", DxfID: intPtr(total)},
+		{Type: "firstSubtotalRow", DxfID: intPtr(total)},
+	}}, s.TableStyles.TableStyles[0])
+	// Test add pivot table style with the same name.
+	for _, name := range []string{"brand", "PivotStyleLight16", "pivotstyledark28", "TableStyleMedium2"} {
+		assert.EqualError(t, f.AddPivotTableStyle(&PivotTableStyle{Name: name}), "the same name table style already exists", name)
+	}
+	assert.NoError(t, f.AddPivotTableStyle(&PivotTableStyle{Name: "PivotStyleLight29"}))
+	assert.NoError(t, f.Close())
+
+	// Test add pivot table style with the invalid settings.
+	f = NewFile()
+	assert.EqualError(t, f.AddPivotTableStyle(nil), "parameter is required")
+	assert.EqualError(t, f.AddPivotTableStyle(&PivotTableStyle{}), "parameter 'Name' is required")
+	assert.EqualError(t, f.AddPivotTableStyle(&PivotTableStyle{Name: "Brand", WholeTable: intPtr(0)}), "invalid differential style ID 0")
+	f.Styles.TableStyles = nil
+	header, err = f.NewConditionalStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.EqualError(t, f.AddPivotTableStyle(&PivotTableStyle{Name: "Brand", FirstColumnStripe: &header, FirstColumnStripeSize: 10}), "invalid stripe size 10")
+	assert.NoError(t, f.AddPivotTableStyle(&PivotTableStyle{Name: "Brand", FirstColumnStripe: &header, FirstColumnStripeSize: 1}))
+	assert.Equal(t, &xlsxTableStyles{Count: 1, DefaultTableStyle: "TableStyleMedium2", DefaultPivotStyle: "PivotStyleLight16", TableStyles: []*xlsxTableStyle{
+		{Name: "Brand", Table: boolPtr(false), Count: 1, TableStyleElement: []*xlsxTableStyleElement{{Type: "firstColumnStripe", DxfID: intPtr(header)}}},
+	}}, f.Styles.TableStyles)
+}
+
+func TestAddPivotTableCaptions(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Type", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"East", "Meat", 100}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:         "Sheet1!$A$1:$C$2",
+		PivotTableRange:   "Sheet1!$E$1:$G$4",
+		Rows:              []PivotTableField{{Data: "Region", InsertBlankRow: true, InsertPageBreak: true}, {Data: "Type"}},
+		Data:              []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+		RowGrandTotals:    true,
+		ShowError:         true,
+		GrandTotalCaption: "Overall",
+		ErrorCaption:      "N/A",
+		MissingCaption:    "-",
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotTableCaptions.xlsx")))
+	pt := xlsxPivotTableDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotTables/pivotTable1.xml"), &pt))
+	assert.Equal(t, [3]string{"Overall", "N/A", "-"}, [3]string{pt.GrandTotalCaption, pt.ErrorCaption, pt.MissingCaption})
+	assert.True(t, pt.PivotFields.PivotField[0].InsertBlankRow)
+	assert.True(t, pt.PivotFields.PivotField[0].InsertPageBreak)
+	assert.False(t, pt.PivotFields.PivotField[1].InsertBlankRow)
+	assert.False(t, pt.PivotFields.PivotField[1].InsertPageBreak)
+}
+
+func TestAddPivotTableHiddenItems(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Region", "Year", "Type", "Sales"}))
+	for row, values := range [][]interface{}{
+		{"East", 2019, "Meat", 100}, {"West", 2020, "Dairy", 200}, {"North", 2019, "Meat", 300}, {nil, 2021, "Produce", 400}, {"East", 2020.5, "Dairy", 500},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &values))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$D$6",
+		PivotTableRange: "Sheet1!$F$1:$J$10",
+		Rows:            []PivotTableField{{Data: "Region", DefaultSubtotal: true, HiddenItems: []string{"West", "North"}}},
+		Columns:         []PivotTableField{{Data: "Year", HiddenItems: []string{"2021"}}},
+		Filter:          []PivotTableField{{Data: "Type", HiddenItems: []string{"Produce"}}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+		RowGrandTotals:  true,
+		ColGrandTotals:  true,
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotTableHiddenItems.xlsx")))
+
+	pc := xlsxPivotCacheDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotCache/pivotCacheDefinition1.xml"), &pc))
+	assert.Equal(t, []xlsxString{{V: "East"}, {V: "West"}, {V: "North"}}, pc.CacheFields.CacheField[0].SharedItems.S)
+	assert.Equal(t, &xlsxSharedItems{
+		ContainsSemiMixedTypes: boolPtr(false), ContainsString: boolPtr(false), ContainsNumber: true,
+		MinValue: 2019, MaxValue: 2021, Count: 4, N: []xlsxNumber{{V: 2019}, {V: 2020}, {V: 2021}, {V: 2020.5}},
+	}, pc.CacheFields.CacheField[1].SharedItems)
+	assert.Len(t, pc.CacheFields.CacheField[2].SharedItems.S, 3)
+	assert.Nil(t, pc.CacheFields.CacheField[3].SharedItems.S)
+
+	pt := xlsxPivotTableDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotTables/pivotTable1.xml"), &pt))
+	hidden := func(items []*xlsxItem) (result []bool) {
+		for _, item := range items {
+			result = append(result, item.H)
+		}
+		return
+	}
+	fields := pt.PivotFields.PivotField
+	assert.Equal(t, []bool{false, true, true, false}, hidden(fields[0].Items.Item))
+	assert.Equal(t, "default", fields[0].Items.Item[3].T)
+	assert.Equal(t, []bool{false, false, true, false}, hidden(fields[1].Items.Item))
+	assert.Equal(t, []bool{false, false, true, false}, hidden(fields[2].Items.Item))
+	assert.True(t, fields[2].MultipleItemSelectionAllowed)
+
+	// Test add pivot table with the hidden item which is not exist.
+	assert.EqualError(t, f.AddPivotTable(&PivotTableOption{
+		DataRange:       "Sheet1!$A$1:$D$6",
+		PivotTableRange: "Sheet1!$F$20:$J$30",
+		Rows:            []PivotTableField{{Data: "Region", HiddenItems: []string{"South"}}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+	}), "pivot item South is not exist in field Region")
+	// Test get the pivot field items with the invalid data range.
+	_, _, err := f.getPivotFieldItems("Sheet1!$A$1:$D", "Region")
+	assert.EqualError(t, err, `parameter 'DataRange' parsing error: cannot convert cell "D" to coordinates: invalid cell name "D"`)
+	// Test get the pivot field items with the unsupported charset worksheet.
+	f.Sheet["xl/worksheets/sheet1.xml"] = nil
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	_, _, err = f.getPivotFieldItems("Sheet1!$A$1:$D$6", "Region")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestAddPivotRowFields(t *testing.T) {
 	f := NewFile()
 	// Test invalid data range
@@ -0,0 +1,297 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// charRefRegexp matches the numeric character reference at the beginning
+	// of the content, such as &#10; or &#xA;.
+	charRefRegexp = regexp.MustCompile(`^&#(x[0-9A-Fa-f]+|[0-9]+);`)
+	// dimensionRegexp matches the dimension element of the worksheet.
+	dimensionRegexp = regexp.MustCompile(`<(\w+:)?dimension\b[^>]*?\bref="([^"]*)"[^>]*?(/>|>\s*</(\w+:)?dimension>)`)
+	// relationshipContentTypes defined the content types of the parts by the
+	// type of the relationships which refer to them.
+	relationshipContentTypes = map[string]string{
+		"calcChain":            "application/vnd.openxmlformats-officedocument.spreadsheetml.calcChain+xml",
+		"chart":                ContentTypeDrawingML,
+		"chartsheet":           ContentTypeSpreadSheetMLChartsheet,
+		"comments":             ContentTypeSpreadSheetMLComments,
+		"core-properties":      "application/vnd.openxmlformats-package.core-properties+xml",
+		"ctrlProp":             ContentTypeSpreadSheetMLCtrlProp,
+		"drawing":              ContentTypeDrawing,
+		"extended-properties":  "application/vnd.openxmlformats-officedocument.extended-properties+xml",
+		"externalLink":         "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml",
+		"officeDocument":       "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml",
+		"person":               ContentTypeSpreadSheetMLPerson,
+		"pivotCacheDefinition": ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"pivotTable":           ContentTypeSpreadSheetMLPivotTable,
+		"sharedStrings":        ContentTypeSpreadSheetMLSharedStrings,
+		"sheetMetadata":        ContentTypeSpreadSheetMLSheetMetadata,
+		"styles":               "application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml",
+		"table":                ContentTypeSpreadSheetMLTable,
+		"theme":                "application/vnd.openxmlformats-officedocument.theme+xml",
+		"threadedComment":      ContentTypeSpreadSheetMLThreadedComments,
+		"worksheet":            ContentTypeSpreadSheetMLWorksheet,
+	}
+)
+
+// repairPackage provides a function to repair the common corruptions of the
+// package parts, which removes the invalid XML characters, fixes the out of
+// range dimension references of the worksheets, renames or removes the
+// duplicate relationship IDs and adds the missing content type overrides.
+// The repairs will be reported by the given function if it's not nil.
+func (f *File) repairPackage(log func(part, description string)) error {
+	report := func(part, format string, a ...interface{}) {
+		if log != nil {
+			log(part, fmt.Sprintf(format, a...))
+		}
+	}
+	names := make([]string, 0, len(f.XLSX))
+	for name := range f.XLSX {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ext := strings.ToLower(path.Ext(name))
+		if ext != ".xml" && ext != ".rels" && ext != ".vml" {
+			continue
+		}
+		content, err := f.readPart(name)
+		if err != nil {
+			return err
+		}
+		repaired, count := removeInvalidXMLChars(content)
+		if count > 0 {
+			report(name, "removed %d invalid XML characters", count)
+		}
+		if isWorksheetPart(name) {
+			var dimension bool
+			if repaired, dimension = repairSheetDimension(name, repaired, report); dimension {
+				count++
+			}
+		}
+		if count > 0 {
+			f.XLSX[name] = repaired
+			delete(f.lazyParts, name)
+		}
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, ".rels") {
+			f.repairRelationships(name, report)
+		}
+	}
+	f.repairContentTypes(names, report)
+	return nil
+}
+
+// isXMLChar provides a function to check if the character is allowed in the
+// XML 1.0 document.
+func isXMLChar(r rune) bool {
+	return r == 0x09 || r == 0x0A || r == 0x0D ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
+
+// removeInvalidXMLChars provides a function to remove the characters and the
+// numeric character references which are not allowed in the XML 1.0
+// document, and returns the content and the number of the removed characters.
+// The invalid UTF-8 sequences will be kept, since the content could be in the
+// other charset.
+func removeInvalidXMLChars(content []byte) ([]byte, int) {
+	var (
+		out   []byte
+		count int
+	)
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRune(content[i:])
+		invalid := size > 1 && !isXMLChar(r) || size == 1 && r != utf8.RuneError && !isXMLChar(r)
+		if r == '&' {
+			if match := charRefRegexp.Find(content[i:]); match != nil {
+				ref := string(match[2 : len(match)-1])
+				base := 10
+				if ref[0] == 'x' {
+					ref, base = ref[1:], 16
+				}
+				if n, err := strconv.ParseInt(ref, base, 32); err != nil || !isXMLChar(rune(n)) {
+					invalid, size = true, len(match)
+				}
+			}
+		}
+		if invalid {
+			if out == nil {
+				out = append(make([]byte, 0, len(content)), content[:i]...)
+			}
+			count++
+		} else if out != nil {
+			out = append(out, content[i:i+size]...)
+		}
+		i += size
+	}
+	if out == nil {
+		return content, 0
+	}
+	return out, count
+}
+
+// repairSheetDimension provides a function to fix the dimension reference of
+// the worksheet which is out of the range of the worksheet, the invalid
+// dimension element will be removed. It returns the content and whether the
+// dimension has been repaired.
+func repairSheetDimension(name string, content []byte, report func(part, format string, a ...interface{})) ([]byte, bool) {
+	loc := dimensionRegexp.FindSubmatchIndex(content)
+	if loc == nil {
+		return content, false
+	}
+	ref := string(content[loc[4]:loc[5]])
+	var (
+		cells   []string
+		clamped bool
+	)
+	for _, cell := range strings.Split(ref, ":") {
+		col, row, err := SplitCellName(strings.Replace(cell, "$", "", -1))
+		if err != nil || len(cells) == 2 {
+			cells = nil
+			break
+		}
+		colNum, err := ColumnNameToNumber(col)
+		if err != nil {
+			if strings.IndexFunc(strings.ToUpper(col), func(r rune) bool { return r < 'A' || r > 'Z' }) != -1 {
+				cells = nil
+				break
+			}
+			colNum = TotalColumns
+		}
+		if err != nil || row > TotalRows {
+			if row > TotalRows {
+				row = TotalRows
+			}
+			cell, _ = CoordinatesToCellName(colNum, row)
+			clamped = true
+		}
+		cells = append(cells, cell)
+	}
+	if cells != nil && !clamped {
+		return content, false
+	}
+	repaired := strings.Join(cells, ":")
+	out := append(make([]byte, 0, len(content)), content[:loc[0]]...)
+	if cells == nil {
+		report(name, "removed the invalid dimension reference %s", ref)
+	} else {
+		report(name, "changed the dimension reference from %s to %s", ref, repaired)
+		out = append(out, content[loc[0]:loc[4]]...)
+		out = append(out, repaired...)
+		out = append(out, content[loc[5]:loc[1]]...)
+	}
+	return append(out, content[loc[1]:]...), true
+}
+
+// repairRelationships provides a function to remove the duplicate
+// relationships and rename the relationships which have the same ID but
+// refer to the different parts by given path of the relationships part.
+func (f *File) repairRelationships(name string, report func(part, format string, a ...interface{})) {
+	var rels xlsxRelationships
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(name)))).
+		Decode(&rels); err != nil && err != io.EOF {
+		report(name, "skipped the invalid relationships part: %s", err)
+		return
+	}
+	var maxID int
+	for _, rel := range rels.Relationships {
+		if ID, err := strconv.Atoi(strings.TrimPrefix(rel.ID, "rId")); err == nil && ID > maxID {
+			maxID = ID
+		}
+	}
+	var (
+		changed       bool
+		relationships []xlsxRelationship
+		IDs           = map[string]xlsxRelationship{}
+	)
+	for _, rel := range rels.Relationships {
+		if prev, ok := IDs[rel.ID]; ok {
+			changed = true
+			if prev.Type == rel.Type && prev.Target == rel.Target && prev.TargetMode == rel.TargetMode {
+				report(name, "removed the duplicate relationship %s", rel.ID)
+				continue
+			}
+			maxID++
+			ID := "rId" + strconv.Itoa(maxID)
+			report(name, "renamed the duplicate relationship ID %s to %s", rel.ID, ID)
+			rel.ID = ID
+		}
+		IDs[rel.ID] = rel
+		relationships = append(relationships, rel)
+	}
+	if changed {
+		rels.Relationships = relationships
+		f.Relationships[name] = &rels
+	}
+}
+
+// repairContentTypes provides a function to add the missing content type
+// overrides of the parts which are referred by the relationships by given
+// path of the parts in the package.
+func (f *File) repairContentTypes(names []string, report func(part, format string, a ...interface{})) {
+	content := f.contentTypesReader()
+	partNames := map[string]bool{}
+	for _, override := range content.Overrides {
+		partNames[strings.ToLower(override.PartName)] = true
+	}
+	var macro bool
+	for _, name := range names {
+		if strings.HasSuffix(strings.ToLower(name), "vbaproject.bin") {
+			macro = true
+		}
+	}
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".rels") {
+			continue
+		}
+		rels := f.relsReader(name)
+		if rels == nil {
+			continue
+		}
+		dir := path.Dir(path.Dir(name))
+		for _, rel := range rels.Relationships {
+			relType := path.Base(rel.Type)
+			contentType, ok := relationshipContentTypes[relType]
+			if !ok || rel.TargetMode == "External" {
+				continue
+			}
+			if relType == "officeDocument" && macro {
+				contentType = ContentTypeMacro
+			}
+			part := path.Join(dir, rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				part = strings.TrimPrefix(rel.Target, "/")
+			}
+			if _, ok := f.XLSX[part]; !ok || partNames[strings.ToLower("/"+part)] {
+				continue
+			}
+			content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/" + part, ContentType: contentType})
+			partNames[strings.ToLower("/"+part)] = true
+			report("[Content_Types].xml", "added the content type override of %s", "/"+part)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// prepareCorruptedBook provides a function to create the spreadsheet with the
+// parts changed by given function.
+func prepareCorruptedBook(t *testing.T, fn func(name string, content []byte) []byte) []byte {
+	f := NewFile(Options{InlineStrings: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 100))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "https://github.com/xuri/excelize", "External"))
+	src, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(src.Bytes()), int64(src.Len()))
+	assert.NoError(t, err)
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, file := range zr.File {
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		fw, err := zw.Create(file.Name)
+		assert.NoError(t, err)
+		_, err = fw.Write(fn(file.Name, content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestOpenReaderRepair(t *testing.T) {
+	b := prepareCorruptedBook(t, func(name string, content []byte) []byte {
+		switch name {
+		case "xl/worksheets/sheet1.xml":
+			content = bytes.Replace(content, []byte("Hello"), []byte("He\x01llo&#x1;&#11;&#10;"), 1)
+			return dimensionRegexp.ReplaceAll(content, []byte(`<dimension ref="A1:XFE1048577"/>`))
+		case "xl/_rels/workbook.xml.rels":
+			return bytes.Replace(content, []byte("</Relationships>"), []byte(
+				`<Relationship Id="rId1" Target="worksheets/sheet1.xml" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"></Relationship>`+
+					`<Relationship Id="rId2" Target="calcChain.xml" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/calcChain"></Relationship>`+
+					`</Relationships>`), 1)
+		case "[Content_Types].xml":
+			return regexp.MustCompile(`<Override PartName="/xl/worksheets/sheet1.xml"[^>]*>(</Override>)?`).ReplaceAll(content, nil)
+		}
+		return content
+	})
+
+	// Test open the corrupted spreadsheet without repair.
+	f, err := OpenReader(bytes.NewReader(b))
+	assert.NoError(t, err)
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 2: illegal character code U+0001")
+
+	// Test open the corrupted spreadsheet with repair.
+	var repairs []string
+	f, err = OpenReader(bytes.NewReader(b), Options{Repair: true, RepairLog: func(part, description string) {
+		repairs = append(repairs, part+": "+description)
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"xl/worksheets/sheet1.xml: removed 3 invalid XML characters",
+		"xl/worksheets/sheet1.xml: changed the dimension reference from A1:XFE1048577 to A1:XFD1048576",
+		"xl/_rels/workbook.xml.rels: removed the duplicate relationship rId1",
+		"xl/_rels/workbook.xml.rels: renamed the duplicate relationship ID rId2 to rId4",
+		"[Content_Types].xml: added the content type override of /xl/worksheets/sheet1.xml",
+	}, repairs)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello\n", val)
+	val, err = f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", val)
+	assert.Equal(t, "A1:XFD1048576", f.Sheet["xl/worksheets/sheet1.xml"].Dimension.Ref)
+	rels := f.relsReader("xl/_rels/workbook.xml.rels")
+	IDs := map[string]bool{}
+	for _, rel := range rels.Relationships {
+		assert.False(t, IDs[rel.ID], rel.ID)
+		IDs[rel.ID] = true
+	}
+	assert.Len(t, IDs, 5)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderRepair.xlsx")))
+
+	// Test open the repaired spreadsheet with repair.
+	repairs = nil
+	b, err = ioutil.ReadFile(filepath.Join("test", "TestOpenReaderRepair.xlsx"))
+	assert.NoError(t, err)
+	_, err = OpenReader(bytes.NewReader(b), Options{Repair: true, RepairLog: func(part, description string) {
+		repairs = append(repairs, part+": "+description)
+	}})
+	assert.NoError(t, err)
+	assert.Empty(t, repairs)
+
+	// Test open the spreadsheet with the invalid dimension and relationships
+	// part with repair.
+	b = prepareCorruptedBook(t, func(name string, content []byte) []byte {
+		switch name {
+		case "xl/worksheets/sheet1.xml":
+			return dimensionRegexp.ReplaceAll(content, []byte(`<dimension ref="A1:B"/>`))
+		case "xl/worksheets/_rels/sheet1.xml.rels":
+			return []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship`)
+		}
+		return content
+	})
+	repairs = nil
+	f, err = OpenReader(bytes.NewReader(b), Options{Repair: true, RepairLog: func(part, description string) {
+		repairs = append(repairs, part+": "+description)
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"xl/worksheets/sheet1.xml: removed the invalid dimension reference A1:B",
+		"xl/worksheets/_rels/sheet1.xml.rels: skipped the invalid relationships part: XML syntax error on line 1: unexpected EOF",
+	}, repairs)
+	assert.Nil(t, f.Sheet["xl/worksheets/sheet1.xml"])
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.Dimension)
+}
+
+func TestRemoveInvalidXMLChars(t *testing.T) {
+	for _, c := range []struct {
+		content, expected string
+		count             int
+	}{
+		{"", "", 0},
+		{"<a>b</a>", "<a>b</a>", 0},
+		{"<a>\x00b\x1F\t\r\n</a>", "<a>b\t\r\n</a>", 2},
+		{"<a>\uFFFE\uFFFF\U0001F600</a>", "<a>\U0001F600</a>", 2},
+		{"<a>&#0;&#x9;&#xD800;&#65;</a>", "<a>&#x9;&#65;</a>", 2},
+		{"<a>&#99999999999;&amp;</a>", "<a>&amp;</a>", 1},
+		{"<a>\xff\xfe</a>", "<a>\xff\xfe</a>", 0},
+	} {
+		actual, count := removeInvalidXMLChars([]byte(c.content))
+		assert.Equal(t, c.expected, string(actual), c.content)
+		assert.Equal(t, c.count, count, c.content)
+	}
+}
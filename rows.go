@@ -13,10 +13,12 @@ package excelize
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"math"
 	"strconv"
@@ -40,17 +42,38 @@ import (
 //    }
 //
 func (f *File) GetRows(sheet string) ([][]string, error) {
+	return f.GetRowsContext(context.Background(), sheet)
+}
+
+// GetRowsContext return all the rows in a sheet by given worksheet name (case
+// sensitive) with the context. The reading will be stopped with the error of
+// the context once the context is canceled or its deadline is exceeded,
+// which could be used to abort reading the worksheet with huge amounts of
+// data. For example, read the rows with the HTTP request context:
+//
+//    rows, err := f.GetRowsContext(r.Context(), "Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//
+func (f *File) GetRowsContext(ctx context.Context, sheet string) ([][]string, error) {
 	rows, err := f.Rows(sheet)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	results := make([][]string, 0, 64)
 	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		row, err := rows.Columns()
 		if err != nil {
 			break
 		}
 		results = append(results, row)
+		f.reportProgress("rows", rows.curRow, rows.totalRow)
 	}
 	return results, nil
 }
@@ -61,7 +84,9 @@ type Rows struct {
 	curRow, totalRow, stashRow int
 	sheet                      string
 	f                          *File
+	rawData                    io.ReadCloser
 	decoder                    *xml.Decoder
+	sharedFormulas             map[string]string
 }
 
 // Next will return true if find the next row element.
@@ -75,11 +100,65 @@ func (rows *Rows) Error() error {
 	return rows.err
 }
 
+// Close closes the reader of the worksheet opened by the rows iterator.
+func (rows *Rows) Close() error {
+	if rows.rawData != nil {
+		return rows.rawData.Close()
+	}
+	return nil
+}
+
 // Columns return the current row's column values.
 func (rows *Rows) Columns() ([]string, error) {
-	var rowIterator rowXMLIterator
+	rowIterator := rows.readRow(false)
+	return rowIterator.columns, rowIterator.err
+}
+
+// Cells return the current row's cells with the typed values, the style
+// index and the formula of each cell, which could be used to read the
+// worksheet in one pass without querying each cell again. The value of the
+// cell will be a float64, time.Time, bool, CellError or string depends on
+// the type of the cell value, and nil for the blank cell. The number with
+// date number format will be converted to time.Time. Note that call either
+// Columns or Cells once for each row. For example:
+//
+//    rows, err := f.Rows("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    defer rows.Close()
+//    for rows.Next() {
+//        cells, err := rows.Cells()
+//        if err != nil {
+//            fmt.Println(err)
+//        }
+//        for _, cell := range cells {
+//            switch v := cell.Value.(type) {
+//            case float64:
+//                fmt.Println("number", v, "style", cell.StyleID)
+//            case time.Time:
+//                fmt.Println("date", v)
+//            case excelize.CellError:
+//                fmt.Println("error", v, "formula", cell.Formula)
+//            }
+//        }
+//    }
+//
+func (rows *Rows) Cells() ([]Cell, error) {
+	rowIterator := rows.readRow(true)
+	return rowIterator.cells, rowIterator.err
+}
+
+// readRow provides a function to read the current row of the worksheet, the
+// typed cells will be read instead of the formatted values if typed is true.
+func (rows *Rows) readRow(typed bool) *rowXMLIterator {
+	rowIterator := &rowXMLIterator{typed: typed}
 	if rows.stashRow >= rows.curRow {
-		return rowIterator.columns, rowIterator.err
+		return rowIterator
+	}
+	if rows.sharedFormulas == nil {
+		rows.sharedFormulas = map[string]string{}
 	}
 	rowIterator.rows = rows
 	rowIterator.d = rows.f.sharedStringsReader()
@@ -98,12 +177,12 @@ func (rows *Rows) Columns() ([]string, error) {
 				}
 				if rowIterator.row > rowIterator.rows.curRow {
 					rowIterator.rows.stashRow = rowIterator.row - 1
-					return rowIterator.columns, rowIterator.err
+					return rowIterator
 				}
 			}
-			rowXMLHandler(&rowIterator, &xmlElement)
+			rowXMLHandler(rowIterator, &xmlElement)
 			if rowIterator.err != nil {
-				return rowIterator.columns, rowIterator.err
+				return rowIterator
 			}
 		case xml.EndElement:
 			rowIterator.inElement = xmlElement.Name.Local
@@ -111,14 +190,14 @@ func (rows *Rows) Columns() ([]string, error) {
 				rowIterator.row = rowIterator.rows.curRow
 			}
 			if rowIterator.inElement == "row" && rowIterator.row+1 < rowIterator.rows.curRow {
-				return rowIterator.columns, rowIterator.err
+				return rowIterator
 			}
 			if rowIterator.inElement == "sheetData" {
-				return rowIterator.columns, rowIterator.err
+				return rowIterator
 			}
 		}
 	}
-	return rowIterator.columns, rowIterator.err
+	return rowIterator
 }
 
 // appendSpace append blank characters to slice by given length and source slice.
@@ -129,21 +208,14 @@ func appendSpace(l int, s []string) []string {
 	return s
 }
 
-// ErrSheetNotExist defines an error of sheet is not exist
-type ErrSheetNotExist struct {
-	SheetName string
-}
-
-func (err ErrSheetNotExist) Error() string {
-	return fmt.Sprintf("sheet %s is not exist", string(err.SheetName))
-}
-
 // rowXMLIterator defined runtime use field for the worksheet row SAX parser.
 type rowXMLIterator struct {
 	err                 error
 	inElement           string
 	attrR, cellCol, row int
+	typed               bool
 	columns             []string
+	cells               []Cell
 	rows                *Rows
 	d                   *xlsxSST
 }
@@ -160,6 +232,19 @@ func rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement) {
 				return
 			}
 		}
+		// Record the shared formulas for the following rows in both modes.
+		formula := cellFormula(&colCell, rowIterator.rows.sharedFormulas)
+		if rowIterator.typed {
+			for i := len(rowIterator.cells) + 1; i < rowIterator.cellCol; i++ {
+				rowIterator.cells = append(rowIterator.cells, Cell{})
+			}
+			rowIterator.cells = append(rowIterator.cells, Cell{
+				StyleID: colCell.S,
+				Formula: formula,
+				Value:   rowIterator.rows.f.cellTypedValue(&colCell, rowIterator.d),
+			})
+			return
+		}
 		blank := rowIterator.cellCol - len(rowIterator.columns)
 		val, _ := colCell.getValueFrom(rowIterator.rows.f, rowIterator.d)
 		rowIterator.columns = append(appendSpace(blank, rowIterator.columns), val)
@@ -167,13 +252,16 @@ func rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement) {
 }
 
 // Rows returns a rows iterator, used for streaming reading data for a
-// worksheet with a large data. For example:
+// worksheet with a large data. The worksheet which has not been decompressed
+// or stored in the temporary file will be read as a stream, call Close to
+// close the reader after using the iterator. For example:
 //
 //    rows, err := f.Rows("Sheet1")
 //    if err != nil {
 //        fmt.Println(err)
 //        return
 //    }
+//    defer rows.Close()
 //    for rows.Next() {
 //        row, err := rows.Columns()
 //        if err != nil {
@@ -190,21 +278,26 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	if !ok {
 		return nil, ErrSheetNotExist{sheet}
 	}
-	if f.Sheet[name] != nil {
-		// flush data
-		output, _ := xml.Marshal(f.Sheet[name])
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
-	}
 	var (
 		err       error
 		inElement string
 		row       int
 		rows      Rows
 	)
-	decoder := f.xmlNewDecoder(bytes.NewReader(f.readXML(name)))
+	rc, err := f.readSheetXMLReader(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	decoder := f.xmlNewDecoder(rc)
 	for {
 		token, _ := decoder.Token()
 		if token == nil {
+			// The worksheet which could not be decompressed should not be
+			// read as an empty worksheet.
+			if _, err = io.Copy(ioutil.Discard, rc); err != nil {
+				return nil, err
+			}
 			break
 		}
 		switch xmlElement := token.(type) {
@@ -226,7 +319,10 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 			if xmlElement.Name.Local == "sheetData" {
 				rows.f = f
 				rows.sheet = name
-				rows.decoder = f.xmlNewDecoder(bytes.NewReader(f.readXML(name)))
+				if rows.rawData, err = f.readSheetXMLReader(name); err != nil {
+					return &rows, err
+				}
+				rows.decoder = f.xmlNewDecoder(rows.rawData)
 				return &rows, nil
 			}
 		default:
@@ -467,6 +563,47 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return ws.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// GetHiddenRows provides a function to get all hidden rows by given worksheet
+// name in one call, the result is the ascending sorted Excel row numbers. For
+// example, get all hidden rows in Sheet1:
+//
+//    rows, err := f.GetHiddenRows("Sheet1")
+//
+func (f *File) GetHiddenRows(sheet string) ([]int, error) {
+	var rows []int
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return rows, err
+	}
+	for _, rowData := range ws.SheetData.Row {
+		if rowData.Hidden {
+			rows = append(rows, rowData.R)
+		}
+	}
+	return rows, err
+}
+
+// GetRowOutlineLevels provides a function to get the outline levels of all
+// rows by given worksheet name in one call, the result is a map of the Excel
+// row number to the outline level, rows without outline level are not
+// included. For example, get outline levels of the rows in Sheet1:
+//
+//    levels, err := f.GetRowOutlineLevels("Sheet1")
+//
+func (f *File) GetRowOutlineLevels(sheet string) (map[int]uint8, error) {
+	levels := make(map[int]uint8)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return levels, err
+	}
+	for _, rowData := range ws.SheetData.Row {
+		if rowData.OutlineLevel > 0 {
+			levels[rowData.R] = rowData.OutlineLevel
+		}
+	}
+	return levels, err
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -539,6 +676,7 @@ func (f *File) DuplicateRow(sheet string, row int) error {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
+	f.clearCalcCache()
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -2,14 +2,40 @@ package excelize
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestGetRowsContext(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", 2))
+	var progress [][2]int
+	f.progress = func(stage string, current, total int) {
+		assert.Equal(t, "rows", stage)
+		progress = append(progress, [2]int{current, total})
+	}
+	rows, err := f.GetRowsContext(context.Background(), "Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, nil, {"", "2"}}, rows)
+	assert.Equal(t, [][2]int{{1, 3}, {2, 3}, {3, 3}}, progress)
+
+	// Test get rows with the canceled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = f.GetRowsContext(ctx, "Sheet1")
+	assert.EqualError(t, err, "context canceled")
+	// Test get rows of the worksheet which is not exist.
+	_, err = f.GetRowsContext(context.Background(), "SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 
@@ -179,6 +205,105 @@ func TestColumns(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRowsCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	style, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	delete(f.Sheet, "xl/worksheets/sheet1.xml")
+	f.XLSX["xl/worksheets/sheet1.xml"] = []byte(fmt.Sprintf(`<worksheet><sheetData>`+
+		`<row r="1"><c r="A1"><v>1.5</v></c><c r="C1" s="%d"><v>44197</v></c><c r="D1" t="b"><v>1</v></c><c r="E1" t="e"><f>1/0</f><v>#DIV/0!</v></c><c r="F1" t="s"><v>0</v></c></row>`+
+		`<row r="3"><c r="A3"><f t="shared" ref="A3:B3" si="0">A1*2</f><v>3</v></c><c r="B3"><f t="shared" si="0"/><v>0</v></c><c r="C3" t="d"><v>2021-01-01T00:00:00Z</v></c><c r="D3" t="inlineStr"><is><t>x</t></is></c><c r="E3" t="str"><v>s</v></c><c r="F3"><v>A</v></c></row>`+
+		`</sheetData></worksheet>`, style))
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := [][]Cell{
+		{{Value: 1.5}, {}, {StyleID: style, Value: date}, {Value: true}, {Formula: "1/0", Value: CellErrorDiv0}, {Value: "Hello"}},
+		nil,
+		{{Formula: "A1*2", Value: 3.0}, {Formula: "A1*2", Value: 0.0}, {Value: date}, {Value: "x"}, {Value: "s"}, {Value: "A"}},
+	}
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var results [][]Cell
+	for rows.Next() {
+		cells, err := rows.Cells()
+		assert.NoError(t, err)
+		results = append(results, cells)
+	}
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, expected, results)
+
+	// Test read the cells of the columns.
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	for col := 0; cols.Next(); col++ {
+		cells, err := cols.Cells()
+		assert.NoError(t, err)
+		for row, cell := range cells {
+			if col < len(expected[row]) {
+				assert.Equal(t, expected[row][col], cell)
+				continue
+			}
+			assert.Equal(t, Cell{}, cell)
+		}
+	}
+
+	// Test read the cells with the time location and the shared formula
+	// master cell read by Columns.
+	location, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	f.timeLocation = location
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	cells, err := rows.Cells()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, location), cells[2].Value)
+	assert.True(t, rows.Next())
+	assert.True(t, rows.Next())
+	cells, err = rows.Cells()
+	assert.NoError(t, err)
+	assert.Equal(t, date.In(location), cells[2].Value)
+	assert.NoError(t, rows.Close())
+
+	// Test read the cells with the invalid cell reference.
+	f.XLSX["xl/worksheets/sheet1.xml"] = []byte(`<worksheet><sheetData><row r="1"><c r="A1"><v>1</v></c><c r="B"><v>1</v></c></row></sheetData></worksheet>`)
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Cells()
+	assert.EqualError(t, err, `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	assert.NoError(t, rows.Close())
+	cols, err = f.Cols("Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	cols.f, cols.curCol = f, 1
+	_, err = cols.Cells()
+	assert.EqualError(t, err, `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func TestCellTypedValue(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	d := &xlsxSST{}
+	for _, c := range []struct {
+		cell     xlsxC
+		expected interface{}
+	}{
+		{xlsxC{T: "b", V: "A"}, "A"},
+		{xlsxC{T: "d", V: "A"}, "A"},
+		{xlsxC{S: style, V: "A"}, "A"},
+		{xlsxC{V: "A"}, "A"},
+		{xlsxC{T: "s", V: "1"}, "1"},
+		{xlsxC{T: "s"}, nil},
+		{xlsxC{T: "inlineStr"}, nil},
+		{xlsxC{T: "str"}, ""},
+		{xlsxC{T: "b", V: "FALSE"}, false},
+		{xlsxC{T: "e", V: "#N/A"}, CellErrorNA},
+	} {
+		assert.Equal(t, c.expected, f.cellTypedValue(&c.cell, d), c.cell)
+	}
+}
+
 func TestSharedStringsReader(t *testing.T) {
 	f := NewFile()
 	f.XLSX["xl/sharedStrings.xml"] = MacintoshCyrillicCharset
@@ -213,6 +338,35 @@ func TestRowVisibility(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowVisibility.xlsx")))
 }
 
+func TestGetHiddenRows(t *testing.T) {
+	f := NewFile()
+	rows, err := f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, true))
+	rows, err = f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 5}, rows)
+	// Test get hidden rows on not exists worksheet.
+	_, err = f.GetHiddenRows("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestGetRowOutlineLevels(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 2, 1))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 4, 3))
+	levels, err := f.GetRowOutlineLevels("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]uint8{2: 1, 4: 3}, levels)
+	// Test get row outline levels on not exists worksheet.
+	_, err = f.GetRowOutlineLevels("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestRemoveRow(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
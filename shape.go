@@ -12,11 +12,257 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"strconv"
 	"strings"
 )
 
+// presetShapeTypes defined the preset geometries enumerated in the
+// ST_ShapeType list.
+var presetShapeTypes = map[string]bool{
+	"accentBorderCallout1":       true,
+	"accentBorderCallout2":       true,
+	"accentBorderCallout3":       true,
+	"accentCallout1":             true,
+	"accentCallout2":             true,
+	"accentCallout3":             true,
+	"actionButtonBackPrevious":   true,
+	"actionButtonBeginning":      true,
+	"actionButtonBlank":          true,
+	"actionButtonDocument":       true,
+	"actionButtonEnd":            true,
+	"actionButtonForwardNext":    true,
+	"actionButtonHelp":           true,
+	"actionButtonHome":           true,
+	"actionButtonInformation":    true,
+	"actionButtonMovie":          true,
+	"actionButtonReturn":         true,
+	"actionButtonSound":          true,
+	"arc":                        true,
+	"bentArrow":                  true,
+	"bentConnector2":             true,
+	"bentConnector3":             true,
+	"bentConnector4":             true,
+	"bentConnector5":             true,
+	"bentUpArrow":                true,
+	"bevel":                      true,
+	"blockArc":                   true,
+	"borderCallout1":             true,
+	"borderCallout2":             true,
+	"borderCallout3":             true,
+	"bracePair":                  true,
+	"bracketPair":                true,
+	"callout1":                   true,
+	"callout2":                   true,
+	"callout3":                   true,
+	"can":                        true,
+	"chartPlus":                  true,
+	"chartStar":                  true,
+	"chartX":                     true,
+	"chevron":                    true,
+	"chord":                      true,
+	"circularArrow":              true,
+	"cloud":                      true,
+	"cloudCallout":               true,
+	"corner":                     true,
+	"cornerTabs":                 true,
+	"cube":                       true,
+	"curvedConnector2":           true,
+	"curvedConnector3":           true,
+	"curvedConnector4":           true,
+	"curvedConnector5":           true,
+	"curvedDownArrow":            true,
+	"curvedLeftArrow":            true,
+	"curvedRightArrow":           true,
+	"curvedUpArrow":              true,
+	"decagon":                    true,
+	"diagStripe":                 true,
+	"diamond":                    true,
+	"dodecagon":                  true,
+	"donut":                      true,
+	"doubleWave":                 true,
+	"downArrow":                  true,
+	"downArrowCallout":           true,
+	"ellipse":                    true,
+	"ellipseRibbon":              true,
+	"ellipseRibbon2":             true,
+	"flowChartAlternateProcess":  true,
+	"flowChartCollate":           true,
+	"flowChartConnector":         true,
+	"flowChartDecision":          true,
+	"flowChartDelay":             true,
+	"flowChartDisplay":           true,
+	"flowChartDocument":          true,
+	"flowChartExtract":           true,
+	"flowChartInputOutput":       true,
+	"flowChartInternalStorage":   true,
+	"flowChartMagneticDisk":      true,
+	"flowChartMagneticDrum":      true,
+	"flowChartMagneticTape":      true,
+	"flowChartManualInput":       true,
+	"flowChartManualOperation":   true,
+	"flowChartMerge":             true,
+	"flowChartMultidocument":     true,
+	"flowChartOfflineStorage":    true,
+	"flowChartOffpageConnector":  true,
+	"flowChartOnlineStorage":     true,
+	"flowChartOr":                true,
+	"flowChartPredefinedProcess": true,
+	"flowChartPreparation":       true,
+	"flowChartProcess":           true,
+	"flowChartPunchedCard":       true,
+	"flowChartPunchedTape":       true,
+	"flowChartSort":              true,
+	"flowChartSummingJunction":   true,
+	"flowChartTerminator":        true,
+	"foldedCorner":               true,
+	"frame":                      true,
+	"funnel":                     true,
+	"gear6":                      true,
+	"gear9":                      true,
+	"halfFrame":                  true,
+	"heart":                      true,
+	"heptagon":                   true,
+	"hexagon":                    true,
+	"homePlate":                  true,
+	"horizontalScroll":           true,
+	"irregularSeal1":             true,
+	"irregularSeal2":             true,
+	"leftArrow":                  true,
+	"leftArrowCallout":           true,
+	"leftBrace":                  true,
+	"leftBracket":                true,
+	"leftCircularArrow":          true,
+	"leftRightArrow":             true,
+	"leftRightArrowCallout":      true,
+	"leftRightCircularArrow":     true,
+	"leftRightRibbon":            true,
+	"leftRightUpArrow":           true,
+	"leftUpArrow":                true,
+	"lightningBolt":              true,
+	"line":                       true,
+	"lineInv":                    true,
+	"mathDivide":                 true,
+	"mathEqual":                  true,
+	"mathMinus":                  true,
+	"mathMultiply":               true,
+	"mathNotEqual":               true,
+	"mathPlus":                   true,
+	"moon":                       true,
+	"nonIsoscelesTrapezoid":      true,
+	"noSmoking":                  true,
+	"notchedRightArrow":          true,
+	"octagon":                    true,
+	"parallelogram":              true,
+	"pentagon":                   true,
+	"pie":                        true,
+	"pieWedge":                   true,
+	"plaque":                     true,
+	"plaqueTabs":                 true,
+	"plus":                       true,
+	"quadArrow":                  true,
+	"quadArrowCallout":           true,
+	"rect":                       true,
+	"ribbon":                     true,
+	"ribbon2":                    true,
+	"rightArrow":                 true,
+	"rightArrowCallout":          true,
+	"rightBrace":                 true,
+	"rightBracket":               true,
+	"round1Rect":                 true,
+	"round2DiagRect":             true,
+	"round2SameRect":             true,
+	"roundRect":                  true,
+	"rtTriangle":                 true,
+	"smileyFace":                 true,
+	"snip1Rect":                  true,
+	"snip2DiagRect":              true,
+	"snip2SameRect":              true,
+	"snipRoundRect":              true,
+	"squareTabs":                 true,
+	"star10":                     true,
+	"star12":                     true,
+	"star16":                     true,
+	"star24":                     true,
+	"star32":                     true,
+	"star4":                      true,
+	"star5":                      true,
+	"star6":                      true,
+	"star7":                      true,
+	"star8":                      true,
+	"straightConnector1":         true,
+	"stripedRightArrow":          true,
+	"sun":                        true,
+	"swooshArrow":                true,
+	"teardrop":                   true,
+	"trapezoid":                  true,
+	"triangle":                   true,
+	"upArrow":                    true,
+	"upArrowCallout":             true,
+	"upDownArrow":                true,
+	"upDownArrowCallout":         true,
+	"uturnArrow":                 true,
+	"verticalScroll":             true,
+	"wave":                       true,
+	"wedgeEllipseCallout":        true,
+	"wedgeRectCallout":           true,
+	"wedgeRoundRectCallout":      true,
+}
+
+// connectorShapeTypes defined the preset geometries of the connection
+// shapes.
+var connectorShapeTypes = map[string]bool{
+	"line":               true,
+	"straightConnector1": true,
+	"bentConnector2":     true,
+	"bentConnector3":     true,
+	"bentConnector4":     true,
+	"bentConnector5":     true,
+	"curvedConnector2":   true,
+	"curvedConnector3":   true,
+	"curvedConnector4":   true,
+	"curvedConnector5":   true,
+}
+
+// lineEndTypes defined the types of the arrow at the head or tail of the
+// line.
+var lineEndTypes = map[string]bool{
+	"none":     true,
+	"triangle": true,
+	"stealth":  true,
+	"diamond":  true,
+	"oval":     true,
+	"arrow":    true,
+}
+
+// textUnderlineTypes defined the types of text underline in the shape.
+var textUnderlineTypes = map[string]bool{
+	"none":            true,
+	"words":           true,
+	"sng":             true,
+	"dbl":             true,
+	"heavy":           true,
+	"dotted":          true,
+	"dottedHeavy":     true,
+	"dash":            true,
+	"dashHeavy":       true,
+	"dashLong":        true,
+	"dashLongHeavy":   true,
+	"dotDash":         true,
+	"dotDashHeavy":    true,
+	"dotDotDash":      true,
+	"dotDotDashHeavy": true,
+	"wavy":            true,
+	"wavyHeavy":       true,
+	"wavyDbl":         true,
+}
+
 // parseFormatShapeSet provides a function to parse the format settings of the
 // shape with default value.
 func parseFormatShapeSet(formatSet string) (*formatShape, error) {
@@ -34,6 +280,17 @@ func parseFormatShapeSet(formatSet string) (*formatShape, error) {
 		},
 	}
 	err := json.Unmarshal([]byte(formatSet), &format)
+	if err != nil {
+		return &format, err
+	}
+	if !presetShapeTypes[format.Type] {
+		return &format, errors.New("unsupported shape type")
+	}
+	for _, arrow := range []string{format.Line.HeadArrow, format.Line.TailArrow} {
+		if arrow != "" && !lineEndTypes[arrow] {
+			return &format, errors.New("unsupported line end type")
+		}
+	}
 	return &format, err
 }
 
@@ -44,6 +301,30 @@ func parseFormatShapeSet(formatSet string) (*formatShape, error) {
 //
 //    err := f.AddShape("Sheet1", "G6", `{"type":"rect","color":{"line":"#4286F4","fill":"#8eb9ff"},"paragraph":[{"text":"Rectangle Shape","font":{"bold":true,"italic":true,"family":"Times New Roman","size":36,"color":"#777777","underline":"sng"}}],"width":180,"height": 90}`)
 //
+// The "name" specifies the name of the shape, the default name is "Shape N".
+// The "rotation" specifies the rotation angle of the shape in degrees, and
+// the "flip_h" and "flip_v" specify flip the shape horizontally or
+// vertically. The "line" specifies the width of the line in points and the
+// arrow types of the line ends, the following shows the type of arrow
+// supported by excelize:
+//
+//    none
+//    triangle
+//    stealth
+//    diamond
+//    oval
+//    arrow
+//
+// The connector shapes (line, straightConnector1, bentConnector2 -
+// bentConnector5 and curvedConnector2 - curvedConnector5) could be used to
+// connect two shapes by given names with "connector" settings, and the cell
+// will be ignored in this case, the connector will be placed between the
+// shapes. For example, connect two shapes with an arrow in Sheet1:
+//
+//    err := f.AddShape("Sheet1", "B2", `{"type":"rect","name":"Start","paragraph":[{"text":"Start"}],"width":120,"height":60}`)
+//    err = f.AddShape("Sheet1", "F2", `{"type":"ellipse","name":"End","paragraph":[{"text":"End"}],"width":120,"height":60}`)
+//    err = f.AddShape("Sheet1", "A1", `{"type":"straightConnector1","line":{"width":1.5,"tail_arrow":"triangle"},"connector":{"start_shape":"Start","end_shape":"End"}}`)
+//
 // The following shows the type of shape supported by excelize:
 //
 //    accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -300,27 +581,6 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 	colIdx := fromCol - 1
 	rowIdx := fromRow - 1
 
-	textUnderlineType := map[string]bool{
-		"none":            true,
-		"words":           true,
-		"sng":             true,
-		"dbl":             true,
-		"heavy":           true,
-		"dotted":          true,
-		"dottedHeavy":     true,
-		"dash":            true,
-		"dashHeavy":       true,
-		"dashLong":        true,
-		"dashLongHeavy":   true,
-		"dotDash":         true,
-		"dotDashHeavy":    true,
-		"dotDotDash":      true,
-		"dotDotDashHeavy": true,
-		"wavy":            true,
-		"wavyHeavy":       true,
-		"wavyDbl":         true,
-	}
-
 	width := int(float64(formatSet.Width) * formatSet.Format.XScale)
 	height := int(float64(formatSet.Height) * formatSet.Format.YScale)
 
@@ -328,6 +588,19 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 		f.positionObjectPixels(sheet, colIdx, rowIdx, formatSet.Format.OffsetX, formatSet.Format.OffsetY,
 			width, height)
 	content, cNvPrID := f.drawingParser(drawingXML)
+	shapes, err := f.getDrawingShapes(sheet, content)
+	if err != nil {
+		return err
+	}
+	for _, shape := range shapes {
+		if shape.id >= cNvPrID {
+			cNvPrID = shape.id + 1
+		}
+	}
+	name := formatSet.Name
+	if name == "" {
+		name = "Shape " + strconv.Itoa(cNvPrID)
+	}
 	twoCellAnchor := xdrCellAnchor{}
 	twoCellAnchor.EditAs = formatSet.Format.Positioning
 	from := xlsxFrom{}
@@ -342,20 +615,29 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 	to.RowOff = y2 * EMU
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
+	x, y := f.cellAnchorToPixels(sheet, from.Col, from.ColOff, from.Row, from.RowOff)
 	shape := xdrSp{
 		NvSpPr: &xdrNvSpPr{
 			CNvPr: &xlsxCNvPr{
 				ID:   cNvPrID,
-				Name: "Shape " + strconv.Itoa(cNvPrID),
+				Name: name,
 			},
 			CNvSpPr: &xdrCNvSpPr{
 				TxBox: true,
 			},
 		},
 		SpPr: &xlsxSpPr{
+			Xfrm: xlsxXfrm{
+				Rot:   int(formatSet.Rotation * 60000),
+				FlipH: formatSet.FlipH,
+				FlipV: formatSet.FlipV,
+				Off:   xlsxOff{X: x * EMU, Y: y * EMU},
+				Ext:   xlsxExt{Cx: width * EMU, Cy: height * EMU},
+			},
 			PrstGeom: xlsxPrstGeom{
 				Prst: formatSet.Type,
 			},
+			Ln: setShapeLine(formatSet.Line),
 		},
 		Style: &xdrStyle{
 			LnRef:     setShapeRef(formatSet.Color.Line, 2),
@@ -394,43 +676,31 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 		}
 	}
 	for _, p := range formatSet.Paragraph {
-		u := p.Font.Underline
-		_, ok := textUnderlineType[u]
-		if !ok {
-			u = "none"
-		}
-		text := p.Text
-		if text == "" {
-			text = " "
-		}
-		paragraph := &aP{
-			R: &aR{
-				RPr: aRPr{
-					I:       p.Font.Italic,
-					B:       p.Font.Bold,
-					Lang:    "en-US",
-					AltLang: "en-US",
-					U:       u,
-					Sz:      p.Font.Size * 100,
-					Latin:   &aLatin{Typeface: p.Font.Family},
-				},
-				T: text,
-			},
+		shape.TxBody.P = append(shape.TxBody.P, &aP{
+			R: []*aR{newShapeTextRun(p.Text, p.Font)},
 			EndParaRPr: &aEndParaRPr{
 				Lang: "en-US",
 			},
+		})
+	}
+	if connectorShapeTypes[formatSet.Type] {
+		cxnSp := xdrCxnSp{
+			NvCxnSpPr: &xdrNvCxnSpPr{
+				CNvPr:      shape.NvSpPr.CNvPr,
+				CNvCxnSpPr: &xdrCNvCxnSpPr{},
+			},
+			SpPr:  shape.SpPr,
+			Style: shape.Style,
 		}
-		srgbClr := strings.Replace(strings.ToUpper(p.Font.Color), "#", "", -1)
-		if len(srgbClr) == 6 {
-			paragraph.R.RPr.SolidFill = &aSolidFill{
-				SrgbClr: &attrValString{
-					Val: stringPtr(srgbClr),
-				},
+		if formatSet.Connector.StartShape != "" || formatSet.Connector.EndShape != "" {
+			if err = f.setConnectorShape(sheet, &twoCellAnchor, &cxnSp, shapes, &formatSet.Connector); err != nil {
+				return err
 			}
 		}
-		shape.TxBody.P = append(shape.TxBody.P, paragraph)
+		twoCellAnchor.CxnSp = &cxnSp
+	} else {
+		twoCellAnchor.Sp = &shape
 	}
-	twoCellAnchor.Sp = &shape
 	twoCellAnchor.ClientData = &xdrClientData{
 		FLocksWithSheet:  formatSet.Format.FLocksWithSheet,
 		FPrintsWithSheet: formatSet.Format.FPrintsWithSheet,
@@ -440,6 +710,42 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, formatSet *format
 	return err
 }
 
+// newShapeTextRun provides a function to create the text run of the shape by
+// given text and font settings.
+func newShapeTextRun(text string, font Font) *aR {
+	u := font.Underline
+	if !textUnderlineTypes[u] {
+		u = "none"
+	}
+	if text == "" {
+		text = " "
+	}
+	run := &aR{
+		RPr: aRPr{
+			I:       font.Italic,
+			B:       font.Bold,
+			Lang:    "en-US",
+			AltLang: "en-US",
+			U:       u,
+			Sz:      font.Size * 100,
+			Latin:   &aLatin{Typeface: font.Family},
+		},
+		T: text,
+	}
+	if font.Strike {
+		run.RPr.Strike = "sngStrike"
+	}
+	srgbClr := strings.Replace(strings.ToUpper(font.Color), "#", "", -1)
+	if len(srgbClr) == 6 {
+		run.RPr.SolidFill = &aSolidFill{
+			SrgbClr: &aSrgbClr{
+				Val: stringPtr(srgbClr),
+			},
+		}
+	}
+	return run
+}
+
 // setShapeRef provides a function to set color with hex model by given actual
 // color value.
 func setShapeRef(color string, i int) *aRef {
@@ -460,3 +766,394 @@ func setShapeRef(color string, i int) *aRef {
 		},
 	}
 }
+
+// setShapeLine provides a function to set the line width and the arrow types
+// of the line ends by given line format settings.
+func setShapeLine(line formatShapeLine) *aLn {
+	if line.Width == 0 && line.HeadArrow == "" && line.TailArrow == "" {
+		return nil
+	}
+	ln := &aLn{W: int(line.Width * 12700)}
+	if line.HeadArrow != "" {
+		ln.HeadEnd = &aLineEnd{Type: line.HeadArrow}
+	}
+	if line.TailArrow != "" {
+		ln.TailEnd = &aLineEnd{Type: line.TailArrow}
+	}
+	return ln
+}
+
+// drawingShape defined the shape in the drawing part with the identifier,
+// the position in pixels and the cell anchor of the shape. The group will
+// be true if the shape is a group shape, and the grouped will be true if the
+// shape is contained in a group shape.
+type drawingShape struct {
+	Shape
+	id, x, y       int
+	stCxn, endCxn  int
+	group, grouped bool
+	anchor         *xdrCellAnchor
+}
+
+// setSp provides a function to set the properties of the drawing shape by
+// given decoded shape.
+func (s *drawingShape) setSp(sp *decodeSp) {
+	if sp.NvSpPr != nil && sp.NvSpPr.CNvPr != nil {
+		s.id, s.Name = sp.NvSpPr.CNvPr.ID, sp.NvSpPr.CNvPr.Name
+	}
+	s.setSpPr(sp.SpPr)
+	if sp.TxBody != nil {
+		var paragraphs []string
+		for _, p := range sp.TxBody.P {
+			var text string
+			for _, r := range p.R {
+				text += r.T
+			}
+			paragraphs = append(paragraphs, text)
+		}
+		s.Text = strings.Join(paragraphs, "\n")
+	}
+}
+
+// setCxnSp provides a function to set the properties of the drawing shape
+// by given decoded connection shape.
+func (s *drawingShape) setCxnSp(cxnSp *decodeCxnSp) {
+	if nvCxnSpPr := cxnSp.NvCxnSpPr; nvCxnSpPr != nil {
+		if nvCxnSpPr.CNvPr != nil {
+			s.id, s.Name = nvCxnSpPr.CNvPr.ID, nvCxnSpPr.CNvPr.Name
+		}
+		if nvCxnSpPr.CNvCxnSpPr != nil && nvCxnSpPr.CNvCxnSpPr.StCxn != nil {
+			s.stCxn = nvCxnSpPr.CNvCxnSpPr.StCxn.ID
+		}
+		if nvCxnSpPr.CNvCxnSpPr != nil && nvCxnSpPr.CNvCxnSpPr.EndCxn != nil {
+			s.endCxn = nvCxnSpPr.CNvCxnSpPr.EndCxn.ID
+		}
+	}
+	s.setSpPr(cxnSp.SpPr)
+}
+
+// setSpPr provides a function to set the preset geometry and the transform
+// of the drawing shape by given decoded shape properties.
+func (s *drawingShape) setSpPr(spPr *decodeSpPr) {
+	if spPr == nil {
+		return
+	}
+	s.Type = spPr.PrstGeom.Prst
+	s.Rotation = float64(spPr.Xfrm.Rot) / 60000
+	s.FlipH, s.FlipV = spPr.Xfrm.FlipH, spPr.Xfrm.FlipV
+}
+
+// getDrawingShapes provides a function to get the shapes, connection shapes
+// and group shapes with the shapes in the groups by given worksheet name and
+// drawing part.
+func (f *File) getDrawingShapes(sheet string, wsDr *xlsxWsDr) ([]drawingShape, error) {
+	var shapes []drawingShape
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.OneCellAnchor, wsDr.TwoCellAnchor} {
+		for _, anchor := range anchors {
+			deAnchor, err := f.decodeDrawingAnchor(anchor)
+			if err != nil {
+				return shapes, err
+			}
+			if deAnchor.From == nil {
+				continue
+			}
+			shape := drawingShape{anchor: anchor}
+			shape.Cell, _ = CoordinatesToCellName(deAnchor.From.Col+1, deAnchor.From.Row+1)
+			shape.x, shape.y = f.cellAnchorToPixels(sheet, deAnchor.From.Col, deAnchor.From.ColOff, deAnchor.From.Row, deAnchor.From.RowOff)
+			if deAnchor.To != nil {
+				x2, y2 := f.cellAnchorToPixels(sheet, deAnchor.To.Col, deAnchor.To.ColOff, deAnchor.To.Row, deAnchor.To.RowOff)
+				shape.Width, shape.Height = x2-shape.x, y2-shape.y
+			} else if deAnchor.Ext != nil {
+				shape.Width, shape.Height = deAnchor.Ext.Cx/EMU, deAnchor.Ext.Cy/EMU
+			}
+			switch {
+			case deAnchor.Sp != nil:
+				shape.setSp(deAnchor.Sp)
+			case deAnchor.CxnSp != nil:
+				shape.setCxnSp(deAnchor.CxnSp)
+			case deAnchor.GrpSp != nil:
+				shapes = append(shapes, getGroupShapes(shape, deAnchor.GrpSp)...)
+				continue
+			default:
+				continue
+			}
+			shapes = append(shapes, shape)
+		}
+	}
+	return shapes, nil
+}
+
+// getGroupShapes provides a function to get the group shape and the shapes
+// in the group by given group shape with the position and decoded group
+// shape. The position of the shapes in the group will be converted from the
+// child coordinate space of the group.
+func getGroupShapes(group drawingShape, grpSp *decodeGrpSp) []drawingShape {
+	group.group = true
+	if grpSp.NvGrpSpPr != nil && grpSp.NvGrpSpPr.CNvPr != nil {
+		group.id, group.Name = grpSp.NvGrpSpPr.CNvPr.ID, grpSp.NvGrpSpPr.CNvPr.Name
+	}
+	var xfrm decodeXfrm
+	if grpSp.GrpSpPr != nil {
+		xfrm = grpSp.GrpSpPr.Xfrm
+	}
+	scaleX, scaleY := 1.0, 1.0
+	if xfrm.ChExt.Cx != 0 && xfrm.ChExt.Cy != 0 {
+		scaleX = float64(group.Width*EMU) / float64(xfrm.ChExt.Cx)
+		scaleY = float64(group.Height*EMU) / float64(xfrm.ChExt.Cy)
+	}
+	shapes := []drawingShape{group}
+	setChild := func(shape drawingShape, spPr *decodeSpPr) {
+		shape.Cell, shape.Group, shape.grouped = group.Cell, group.Name, true
+		if spPr != nil {
+			shape.x = group.x + int(float64(spPr.Xfrm.Off.X-xfrm.ChOff.X)*scaleX)/EMU
+			shape.y = group.y + int(float64(spPr.Xfrm.Off.Y-xfrm.ChOff.Y)*scaleY)/EMU
+			shape.Width = int(float64(spPr.Xfrm.Ext.Cx)*scaleX) / EMU
+			shape.Height = int(float64(spPr.Xfrm.Ext.Cy)*scaleY) / EMU
+		}
+		shapes = append(shapes, shape)
+	}
+	for _, sp := range grpSp.Sp {
+		var shape drawingShape
+		shape.setSp(sp)
+		setChild(shape, sp.SpPr)
+	}
+	for _, cxnSp := range grpSp.CxnSp {
+		var shape drawingShape
+		shape.setCxnSp(cxnSp)
+		setChild(shape, cxnSp.SpPr)
+	}
+	return shapes
+}
+
+// findDrawingShape provides a function to find the shape by given shape
+// name.
+func findDrawingShape(shapes []drawingShape, name string) (*drawingShape, error) {
+	for i := range shapes {
+		if shapes[i].Name == name && !shapes[i].group {
+			return &shapes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("shape %s is not exist", name)
+}
+
+// setConnectorShape provides a function to place the connection shape between
+// the start and end shapes by given worksheet name, cell anchor, connection
+// shape, shapes in the drawing part and connector settings. The connector
+// will be connected with the connection sites on the facing sides of the
+// shapes.
+func (f *File) setConnectorShape(sheet string, anchor *xdrCellAnchor, cxnSp *xdrCxnSp, shapes []drawingShape, connector *formatShapeConnector) error {
+	start, err := findDrawingShape(shapes, connector.StartShape)
+	if err != nil {
+		return err
+	}
+	end, err := findDrawingShape(shapes, connector.EndShape)
+	if err != nil {
+		return err
+	}
+	sx, sy := start.x+start.Width/2, start.y+start.Height/2
+	ex, ey := end.x+end.Width/2, end.y+end.Height/2
+	var stIdx, endIdx, x1, y1, x2, y2 int
+	if math.Abs(float64(ex-sx)) >= math.Abs(float64(ey-sy)) {
+		stIdx, endIdx, x1, y1, x2, y2 = 3, 1, start.x+start.Width, sy, end.x, ey
+		if ex < sx {
+			stIdx, endIdx, x1, x2 = 1, 3, start.x, end.x+end.Width
+		}
+	} else {
+		stIdx, endIdx, x1, y1, x2, y2 = 2, 0, sx, start.y+start.Height, ex, end.y
+		if ey < sy {
+			stIdx, endIdx, y1, y2 = 0, 2, start.y, end.y+end.Height
+		}
+	}
+	cxnSp.NvCxnSpPr.CNvCxnSpPr = &xdrCNvCxnSpPr{
+		StCxn:  &aCxn{ID: start.id, Idx: stIdx},
+		EndCxn: &aCxn{ID: end.id, Idx: endIdx},
+	}
+	left, top := int(math.Min(float64(x1), float64(x2))), int(math.Min(float64(y1), float64(y2)))
+	right, bottom := int(math.Max(float64(x1), float64(x2))), int(math.Max(float64(y1), float64(y2)))
+	cxnSp.SpPr.Xfrm.FlipH, cxnSp.SpPr.Xfrm.FlipV = x2 < x1, y2 < y1
+	cxnSp.SpPr.Xfrm.Off = xlsxOff{X: left * EMU, Y: top * EMU}
+	cxnSp.SpPr.Xfrm.Ext = xlsxExt{Cx: (right - left) * EMU, Cy: (bottom - top) * EMU}
+	from, to := xlsxFrom{}, xlsxTo{}
+	from.Col, from.ColOff, from.Row, from.RowOff = f.pixelsToCellAnchor(sheet, left, top)
+	to.Col, to.ColOff, to.Row, to.RowOff = f.pixelsToCellAnchor(sheet, right, bottom)
+	anchor.From, anchor.To = &from, &to
+	return err
+}
+
+// GetShapes provides a function to get the shapes, connectors and the shapes
+// in the group shapes in a worksheet by given worksheet name. The group
+// shapes are not included in the result, and the Group field of the shapes
+// will be the name of the group shape. For example, get the name and text of
+// the shapes in Sheet1:
+//
+//    shapes, err := f.GetShapes("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    for _, shape := range shapes {
+//        fmt.Println(shape.Cell, shape.Name, shape.Type, shape.Text)
+//    }
+//
+func (f *File) GetShapes(sheet string) ([]Shape, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var shapes []Shape
+	if ws.Drawing == nil {
+		return shapes, err
+	}
+	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
+	if _, ok := f.XLSX[drawingXML]; !ok && f.Drawings[drawingXML] == nil {
+		return shapes, err
+	}
+	wsDr, _ := f.drawingParser(drawingXML)
+	drawingShapes, err := f.getDrawingShapes(sheet, wsDr)
+	if err != nil {
+		return shapes, err
+	}
+	names := make(map[int]string)
+	for _, shape := range drawingShapes {
+		names[shape.id] = shape.Name
+	}
+	for _, shape := range drawingShapes {
+		if shape.group {
+			continue
+		}
+		shape.StartShape, shape.EndShape = names[shape.stCxn], names[shape.endCxn]
+		shapes = append(shapes, shape.Shape)
+	}
+	return shapes, err
+}
+
+// GroupShapes provides a function to group the shapes and connectors by
+// given worksheet name, group shape name and names of the shapes. The group
+// shape name is optional, the default name is "Group N". At least two shapes
+// are required for the group shape. For example, group the shapes named
+// "Start" and "End" with the connector named "Arrow" in Sheet1:
+//
+//    err := f.GroupShapes("Sheet1", "Flow", "Start", "End", "Arrow")
+//
+func (f *File) GroupShapes(sheet, name string, shapes ...string) error {
+	if len(shapes) < 2 {
+		return errors.New("at least two shapes are required for the group shape")
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Drawing == nil {
+		return fmt.Errorf("shape %s is not exist", shapes[0])
+	}
+	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
+	wsDr, cNvPrID := f.drawingParser(drawingXML)
+	drawingShapes, err := f.getDrawingShapes(sheet, wsDr)
+	if err != nil {
+		return err
+	}
+	for _, shape := range drawingShapes {
+		if shape.id >= cNvPrID {
+			cNvPrID = shape.id + 1
+		}
+	}
+	var (
+		content                  strings.Builder
+		left, top, right, bottom = math.MaxInt32, math.MaxInt32, 0, 0
+		anchors                  = make(map[*xdrCellAnchor]bool)
+	)
+	for _, shapeName := range shapes {
+		shape, err := findDrawingShape(drawingShapes, shapeName)
+		if err != nil {
+			return err
+		}
+		if shape.grouped || anchors[shape.anchor] {
+			return fmt.Errorf("shape %s has been grouped", shapeName)
+		}
+		output, err := f.getShapeXML(shape.anchor)
+		if err != nil {
+			return err
+		}
+		content.WriteString(output)
+		anchors[shape.anchor] = true
+		left, top = int(math.Min(float64(left), float64(shape.x))), int(math.Min(float64(top), float64(shape.y)))
+		right = int(math.Max(float64(right), float64(shape.x+shape.Width)))
+		bottom = int(math.Max(float64(bottom), float64(shape.y+shape.Height)))
+	}
+	for _, cellAnchors := range []*[]*xdrCellAnchor{&wsDr.OneCellAnchor, &wsDr.TwoCellAnchor} {
+		for idx := 0; idx < len(*cellAnchors); idx++ {
+			if anchors[(*cellAnchors)[idx]] {
+				*cellAnchors = append((*cellAnchors)[:idx], (*cellAnchors)[idx+1:]...)
+				idx--
+			}
+		}
+	}
+	if name == "" {
+		name = "Group " + strconv.Itoa(cNvPrID)
+	}
+	off, ext := xlsxOff{X: left * EMU, Y: top * EMU}, xlsxExt{Cx: (right - left) * EMU, Cy: (bottom - top) * EMU}
+	from, to := xlsxFrom{}, xlsxTo{}
+	from.Col, from.ColOff, from.Row, from.RowOff = f.pixelsToCellAnchor(sheet, left, top)
+	to.Col, to.ColOff, to.Row, to.RowOff = f.pixelsToCellAnchor(sheet, right, bottom)
+	wsDr.TwoCellAnchor = append(wsDr.TwoCellAnchor, &xdrCellAnchor{
+		From: &from,
+		To:   &to,
+		GrpSp: &xdrGrpSp{
+			NvGrpSpPr: &xdrNvGrpSpPr{CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: name}},
+			GrpSpPr:   &xdrGrpSpPr{Xfrm: &xlsxXfrm{Off: off, Ext: ext, ChOff: &off, ChExt: &ext}},
+			Content:   content.String(),
+		},
+		ClientData: &xdrClientData{FPrintsWithSheet: true},
+	})
+	f.Drawings[drawingXML] = wsDr
+	return err
+}
+
+// getShapeXML provides a function to get the XML of the shape or connection
+// shape by given cell anchor.
+func (f *File) getShapeXML(anchor *xdrCellAnchor) (string, error) {
+	var (
+		buf     bytes.Buffer
+		enc     = xml.NewEncoder(&buf)
+		deShape decodeShapeXML
+		err     error
+	)
+	switch {
+	case anchor.Sp != nil:
+		err = enc.EncodeElement(anchor.Sp, xml.StartElement{Name: xml.Name{Local: "xdr:sp"}})
+	case anchor.CxnSp != nil:
+		err = enc.EncodeElement(anchor.CxnSp, xml.StartElement{Name: xml.Name{Local: "xdr:cxnSp"}})
+	default:
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeShapeXML>" + anchor.GraphicFrame + "</decodeShapeXML>")).
+			Decode(&deShape); err != nil && err != io.EOF {
+			return "", fmt.Errorf("xml decode error: %s", err)
+		}
+		if deShape.Sp != nil {
+			err = enc.EncodeElement(deShape.Sp, xml.StartElement{Name: xml.Name{Local: "xdr:sp"}})
+		}
+		if deShape.CxnSp != nil {
+			err = enc.EncodeElement(deShape.CxnSp, xml.StartElement{Name: xml.Name{Local: "xdr:cxnSp"}})
+		}
+	}
+	return buf.String(), err
+}
+
+// DeleteShape provides a function to delete the shapes, connectors and group
+// shapes in a worksheet by given worksheet name and cell reference of the
+// top-left cell of the shapes.
+func (f *File) DeleteShape(sheet, cell string) (err error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return
+	}
+	col--
+	row--
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return
+	}
+	if ws.Drawing == nil {
+		return
+	}
+	drawingXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl", -1)
+	return f.deleteDrawing(col, row, drawingXML, "Shape")
+}
@@ -26,3 +26,138 @@ func TestAddShape(t *testing.T) {
 	assert.NoError(t, f.AddShape("Sheet1", "A1", `{"type":"ellipseRibbon", "color":{"line":"#4286f4","fill":"#8eb9ff"}, "paragraph":[{"font":{"bold":true,"italic":true,"family":"Times New Roman","size":36,"color":"#777777","underline":"single"}}], "height": 90}`))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShape2.xlsx")))
 }
+
+func TestAddShapeConnector(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "B2", `{"type":"rect","name":"Start","paragraph":[{"text":"Start"}],"width":120,"height":60}`))
+	assert.NoError(t, f.AddShape("Sheet1", "H2", `{"type":"ellipse","name":"End","paragraph":[{"text":"End"}],"width":120,"height":60}`))
+	assert.NoError(t, f.AddShape("Sheet1", "B10", `{"type":"diamond","name":"Bottom","rotation":45,"flip_h":true,"width":80,"height":80}`))
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{"type":"straightConnector1","name":"Arrow","line":{"width":1.5,"tail_arrow":"triangle"},"connector":{"start_shape":"Start","end_shape":"End"}}`))
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{"type":"bentConnector3","name":"Elbow","line":{"head_arrow":"oval","tail_arrow":"stealth"},"connector":{"start_shape":"Bottom","end_shape":"Start"}}`))
+	assert.NoError(t, f.AddShape("Sheet1", "K2", `{"type":"line","width":100,"height":0}`))
+
+	check := func(f *File) {
+		shapes, err := f.GetShapes("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, shapes, 6) {
+			return
+		}
+		assert.Equal(t, Shape{Cell: "B2", Type: "rect", Name: "Start", Width: 120, Height: 60, Text: "Start"}, shapes[0])
+		assert.Equal(t, Shape{Cell: "B10", Type: "diamond", Name: "Bottom", Width: 80, Height: 80, Rotation: 45, FlipH: true, Text: " "}, shapes[2])
+		assert.Equal(t, "straightConnector1", shapes[3].Type)
+		assert.Equal(t, "Start", shapes[3].StartShape)
+		assert.Equal(t, "End", shapes[3].EndShape)
+		assert.Equal(t, 64*6-120, shapes[3].Width)
+		assert.Equal(t, "Bottom", shapes[4].StartShape)
+		assert.Equal(t, "Start", shapes[4].EndShape)
+		assert.True(t, shapes[4].FlipV)
+		assert.Equal(t, Shape{Cell: "K2", Type: "line", Name: "Shape 7", Width: 100, Text: ""}, shapes[5])
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeConnector.xlsx")))
+	drawing := string(f.XLSX["xl/drawings/drawing1.xml"])
+	assert.Contains(t, drawing, `<a:stCxn id="2" idx="3"></a:stCxn><a:endCxn id="3" idx="1"></a:endCxn>`)
+	assert.Contains(t, drawing, `<a:ln w="19050"><a:tailEnd type="triangle"></a:tailEnd></a:ln>`)
+	assert.Contains(t, drawing, `<a:xfrm rot="2700000" flipH="true">`)
+
+	f, err := OpenFile(filepath.Join("test", "TestAddShapeConnector.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test add connector on the worksheet loaded with the shapes.
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{"type":"curvedConnector3","connector":{"start_shape":"End","end_shape":"Bottom"}}`))
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 7)
+	assert.Equal(t, Shape{Cell: shapes[6].Cell, Type: "curvedConnector3", Name: "Shape 8", Width: shapes[6].Width, Height: shapes[6].Height, FlipH: true, StartShape: "End", EndShape: "Bottom"}, shapes[6])
+
+	// Test add connector with not exist shapes.
+	assert.EqualError(t, f.AddShape("Sheet1", "A1", `{"type":"line","connector":{"start_shape":"Start","end_shape":"ShapeN"}}`), "shape ShapeN is not exist")
+	assert.EqualError(t, f.AddShape("Sheet1", "A1", `{"type":"line","connector":{"start_shape":"ShapeN"}}`), "shape ShapeN is not exist")
+	// Test add shape with unsupported shape type and line end type.
+	assert.EqualError(t, f.AddShape("Sheet1", "A1", `{"type":"unknown"}`), "unsupported shape type")
+	assert.EqualError(t, f.AddShape("Sheet1", "A1", `{"type":"line","line":{"tail_arrow":"unknown"}}`), "unsupported line end type")
+}
+
+func TestGroupShapes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "B2", `{"type":"rect","name":"Start","paragraph":[{"text":"Start"}],"width":120,"height":60}`))
+	assert.NoError(t, f.AddShape("Sheet1", "F6", `{"type":"rect","name":"End","paragraph":[{"text":"End"},{"text":"Shape"}],"width":120,"height":60}`))
+	assert.NoError(t, f.AddShape("Sheet1", "A1", `{"type":"straightConnector1","name":"Arrow","connector":{"start_shape":"Start","end_shape":"End"}}`))
+	assert.NoError(t, f.AddShape("Sheet1", "J2", `{"type":"star5","name":"Star"}`))
+	assert.NoError(t, f.AddChart("Sheet1", "L2", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$B$1","values":"Sheet1!$B$2"}]}`))
+	assert.NoError(t, f.GroupShapes("Sheet1", "Flow", "Start", "End", "Arrow"))
+
+	check := func(f *File) {
+		shapes, err := f.GetShapes("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, shapes, 4) {
+			return
+		}
+		assert.Equal(t, Shape{Cell: "J2", Type: "star5", Name: "Star", Width: 160, Height: 160, Text: " "}, shapes[0])
+		assert.Equal(t, Shape{Cell: "B2", Type: "rect", Name: "Start", Group: "Flow", Width: 120, Height: 60, Text: "Start"}, shapes[1])
+		assert.Equal(t, Shape{Cell: "B2", Type: "rect", Name: "End", Group: "Flow", Width: 120, Height: 60, Text: "End\nShape"}, shapes[2])
+		assert.Equal(t, "Start", shapes[3].StartShape)
+		assert.Equal(t, "End", shapes[3].EndShape)
+		assert.Equal(t, "Flow", shapes[3].Group)
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupShapes.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestGroupShapes.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test group shapes which have been grouped.
+	assert.EqualError(t, f.GroupShapes("Sheet1", "", "Star", "Start"), "shape Start has been grouped")
+	assert.EqualError(t, f.GroupShapes("Sheet1", "", "Star", "Star"), "shape Star has been grouped")
+	// Test group shapes with not exist shape.
+	assert.EqualError(t, f.GroupShapes("Sheet1", "", "Star", "ShapeN"), "shape ShapeN is not exist")
+	assert.EqualError(t, f.GroupShapes("Sheet1", "", "Star"), "at least two shapes are required for the group shape")
+	// Test group shapes loaded from the file.
+	assert.NoError(t, f.AddShape("Sheet1", "J12", `{"type":"ellipse","name":"Circle"}`))
+	assert.NoError(t, f.GroupShapes("Sheet1", "", "Star", "Circle"))
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 5)
+	assert.Equal(t, "Group 9", shapes[3].Group)
+	assert.Equal(t, "Group 9", shapes[4].Group)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupShapes.xlsx")))
+
+	// Test group shapes on the worksheet without drawing.
+	assert.EqualError(t, f.GroupShapes("Sheet2", "", "Start", "End"), "sheet Sheet2 is not exist")
+	f.NewSheet("Sheet2")
+	assert.EqualError(t, f.GroupShapes("Sheet2", "", "Start", "End"), "shape Start is not exist")
+}
+
+func TestDeleteShape(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "B2", `{"type":"rect","name":"Start"}`))
+	assert.NoError(t, f.AddShape("Sheet1", "F2", `{"type":"rect","name":"End"}`))
+	assert.NoError(t, f.AddChart("Sheet1", "B2", `{"type":"col","series":[{"name":"Sheet1!$A$1","categories":"Sheet1!$B$1","values":"Sheet1!$B$2"}]}`))
+	assert.NoError(t, f.AddPicture("Sheet1", "F2", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.DeleteShape("Sheet1", "B2"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteShape.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestDeleteShape.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteShape("Sheet1", "F2"))
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 0)
+	pics, err := f.GetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Contains(t, string(f.XLSX["xl/drawings/drawing1.xml"]), "graphicFrame")
+	// Test delete shape with invalid cell coordinates.
+	assert.EqualError(t, f.DeleteShape("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test delete shape on not exist worksheet.
+	assert.EqualError(t, f.DeleteShape("SheetN", "A1"), "sheet SheetN is not exist")
+	// Test delete shape on the worksheet without drawing.
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.DeleteShape("Sheet2", "A1"))
+	// Test get shapes on the worksheet without drawing and not exist worksheet.
+	shapes, err = f.GetShapes("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 0)
+	_, err = f.GetShapes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
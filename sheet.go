@@ -25,8 +25,10 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/mohae/deepcopy"
@@ -69,7 +71,8 @@ func (f *File) NewSheet(name string) int {
 // [Content_Types].xml structure after deserialization.
 func (f *File) contentTypesReader() *xlsxTypes {
 	var err error
-
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if f.ContentTypes == nil {
 		f.ContentTypes = new(xlsxTypes)
 		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("[Content_Types].xml")))).
@@ -86,7 +89,9 @@ func (f *File) contentTypesReader() *xlsxTypes {
 func (f *File) contentTypesWriter() {
 	if f.ContentTypes != nil {
 		output, _ := xml.Marshal(f.ContentTypes)
-		f.saveFileList("[Content_Types].xml", output)
+		if f.isPartChanged("[Content_Types].xml", output, new(xlsxTypes)) {
+			f.saveFileList("[Content_Types].xml", output)
+		}
 	}
 }
 
@@ -142,35 +147,188 @@ func (f *File) workbookReader() *xlsxWorkbook {
 func (f *File) workBookWriter() {
 	if f.WorkBook != nil {
 		output, _ := xml.Marshal(f.WorkBook)
-		f.saveFileList(f.getWorkbookPath(), replaceRelationshipsBytes(f.replaceNameSpaceBytes(f.getWorkbookPath(), output)))
+		if wbPath := f.getWorkbookPath(); f.isPartChanged(wbPath, output, new(xlsxWorkbook)) {
+			f.saveFileList(wbPath, replaceRelationshipsBytes(f.replaceNameSpaceBytes(wbPath, output)))
+		}
 	}
 }
 
 // workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
-// serialize structure.
-func (f *File) workSheetWriter() {
-	var arr []byte
-	buffer := bytes.NewBuffer(arr)
-	encoder := xml.NewEncoder(buffer)
+// serialize structure. The worksheets are serialized concurrently, and the
+// number of the goroutines is bounded by the GOMAXPROCS.
+func (f *File) workSheetWriter() error {
+	var paths []string
 	for p, sheet := range f.Sheet {
 		if sheet != nil {
-			for k, v := range sheet.SheetData.Row {
-				f.Sheet[p].SheetData.Row[k].C = trimCell(v.C)
-			}
-			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p, SourceRelationship)
+			f.prepareWorkSheet(p, sheet)
+			paths = append(paths, p)
+		}
+	}
+	var (
+		wg      sync.WaitGroup
+		jobs    = make(chan int)
+		results = make([]encodedWorkSheet, len(paths))
+		workers = runtime.GOMAXPROCS(0)
+	)
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = f.marshalWorkSheet(paths[idx], f.Sheet[paths[idx]])
 			}
-			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p, replaceRelationshipsBytes(f.replaceNameSpaceBytes(p, buffer.Bytes())))
-			ok := f.checked[p]
-			if ok {
-				delete(f.Sheet, p)
-				f.checked[p] = false
+		}()
+	}
+	for idx := range paths {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	var err error
+	for idx, p := range paths {
+		if results[idx].err != nil {
+			if err == nil {
+				err = results[idx].err
 			}
-			buffer.Reset()
+			continue
+		}
+		f.saveWorkSheet(p, results[idx])
+		if f.checked[p] {
+			delete(f.Sheet, p)
+			f.checked[p] = false
 		}
 	}
+	return err
+}
+
+// encodedWorkSheet directly maps the serialized worksheet. The content will
+// be stored in the temporary file if the tmp is not empty.
+type encodedWorkSheet struct {
+	content []byte
+	tmp     string
+	err     error
+}
+
+// prepareWorkSheet provides a function to trim the blank cells and add the
+// namespaces of the worksheet before serialization by given path of the
+// worksheet part and the worksheet.
+func (f *File) prepareWorkSheet(p string, sheet *xlsxWorksheet) {
+	for k, v := range sheet.SheetData.Row {
+		sheet.SheetData.Row[k].C = trimCell(v.C)
+	}
+	if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
+		f.addNameSpaces(p, SourceRelationship)
+	}
+}
+
+// marshalWorkSheet provides a function to serialize the worksheet by given
+// path of the worksheet part and the worksheet, which is safe for concurrent
+// use after the worksheet has been prepared. If the MaxInMemoryPartSize is
+// specified, the rows of the worksheet will be serialized one by one, and
+// stored in the temporary file once the size exceeds the limit.
+func (f *File) marshalWorkSheet(p string, sheet *xlsxWorksheet) encodedWorkSheet {
+	buffer := bytes.NewBufferString(XMLHeader)
+	encoder := xml.NewEncoder(buffer)
+	if f.maxInMemoryPart <= 0 {
+		_ = encoder.Encode(sheet)
+		return encodedWorkSheet{content: replaceRelationshipsBytes(f.replaceNameSpaceBytes(p, buffer.Bytes()))}
+	}
+	sheetData := sheet.SheetData
+	sheet.SheetData = xlsxSheetData{}
+	_ = encoder.Encode(sheet)
+	sheet.SheetData = sheetData
+	content := replaceRelationshipsBytes(f.replaceNameSpaceBytes(p, buffer.Bytes()))
+	idx := bytes.Index(content, []byte("<sheetData>"))
+	if idx == -1 {
+		buffer.Reset()
+		buffer.WriteString(XMLHeader)
+		_ = encoder.Encode(sheet)
+		return encodedWorkSheet{content: replaceRelationshipsBytes(f.replaceNameSpaceBytes(p, buffer.Bytes()))}
+	}
+	idx += len("<sheetData>")
+	bw := bufferedWriter{limit: f.maxInMemoryPart}
+	defer bw.Close()
+	_, _ = bw.Write(content[:idx])
+	rowEncoder, start := xml.NewEncoder(&bw), xml.StartElement{Name: xml.Name{Local: "row"}}
+	for _, row := range sheetData.Row {
+		_ = rowEncoder.EncodeElement(row, start)
+		if err := bw.Sync(); err != nil {
+			return encodedWorkSheet{err: err}
+		}
+	}
+	_, _ = bw.Write(content[idx:])
+	if bw.tmp == nil {
+		return encodedWorkSheet{content: append([]byte{}, bw.buf.Bytes()...)}
+	}
+	if err := bw.Flush(); err != nil {
+		return encodedWorkSheet{err: err}
+	}
+	tmp := bw.tmp
+	bw.tmp = nil
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return encodedWorkSheet{err: err}
+	}
+	return encodedWorkSheet{tmp: tmp.Name()}
+}
+
+// saveWorkSheet provides a function to save the serialized worksheet to the
+// file list or the temporary file by given path of the worksheet part and
+// the serialized worksheet.
+func (f *File) saveWorkSheet(p string, encoded encodedWorkSheet) {
+	if encoded.tmp != "" {
+		f.setTempFile(p, encoded.tmp)
+		return
+	}
+	f.removeTempFile(p)
+	f.XLSX[p] = encoded.content
+}
+
+// ReleaseSheet provides a function to release the parsed worksheet from
+// memory by given worksheet name. The changes of the worksheet will be
+// serialized and kept, and the worksheet will be parsed again when it is
+// accessed next time. This function is useful to reduce the memory usage
+// when reading or updating the worksheets of a large workbook one by one.
+// For example, get the rows of each worksheet and release it:
+//
+//    for _, sheet := range f.GetSheetList() {
+//        rows, err := f.GetRows(sheet)
+//        if err != nil {
+//            fmt.Println(err)
+//            return
+//        }
+//        // ...
+//        if err = f.ReleaseSheet(sheet); err != nil {
+//            fmt.Println(err)
+//            return
+//        }
+//    }
+//
+func (f *File) ReleaseSheet(sheet string) error {
+	f.Lock()
+	defer f.Unlock()
+	name, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	ws, ok := f.Sheet[name]
+	if !ok || ws == nil {
+		return nil
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	f.prepareWorkSheet(name, ws)
+	encoded := f.marshalWorkSheet(name, ws)
+	if encoded.err != nil {
+		return encoded.err
+	}
+	f.saveWorkSheet(name, encoded)
+	delete(f.Sheet, name)
+	delete(f.checked, name)
+	return nil
 }
 
 // trimCell provides a function to trim blank cells which created by fillColumns.
@@ -234,6 +392,9 @@ func (f *File) relsWriter() {
 	for path, rel := range f.Relationships {
 		if rel != nil {
 			output, _ := xml.Marshal(rel)
+			if !f.isPartChanged(path, output, new(xlsxRelationships)) {
+				continue
+			}
 			if strings.HasPrefix(path, "xl/worksheets/sheet/rels/sheet") {
 				output = f.replaceNameSpaceBytes(path, output)
 			}
@@ -344,6 +505,7 @@ func (f *File) getActiveSheetID() int {
 // sheet name in the formula or reference associated with the cell. So there
 // may be problem formula error or reference missing.
 func (f *File) SetSheetName(oldName, newName string) {
+	f.clearCalcCache()
 	oldName = trimSheetName(oldName)
 	newName = trimSheetName(newName)
 	if newName == oldName {
@@ -500,6 +662,7 @@ func (f *File) SetSheetBackground(sheet, picture string) error {
 // value of the deleted worksheet, it will cause a file error when you open it.
 // This function will be invalid when only the one worksheet is left.
 func (f *File) DeleteSheet(name string) {
+	f.clearCalcCache()
 	if f.SheetCount == 1 || f.GetSheetIndex(name) == -1 {
 		return
 	}
@@ -587,6 +750,7 @@ func (f *File) deleteSheetFromContentTypes(target string) {
 //    return err
 //
 func (f *File) CopySheet(from, to int) error {
+	f.clearCalcCache()
 	if from < 0 || to < 0 || from == to || f.GetSheetName(from) == "" || f.GetSheetName(to) == "" {
 		return errors.New("invalid worksheet index")
 	}
@@ -794,6 +958,26 @@ func (f *File) SetPanes(sheet, panes string) error {
 	return err
 }
 
+// GetFrozenPanes provides a function to get the number of frozen columns and
+// rows by given worksheet name, which could be used as the offsets of the
+// header rows or columns when iterating the worksheet. Zero will be returned
+// if there are no freeze panes in the worksheet. For example, get the number
+// of frozen columns and rows of Sheet1:
+//
+//    cols, rows, err := f.GetFrozenPanes("Sheet1")
+//
+func (f *File) GetFrozenPanes(sheet string) (int, int, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+		return 0, 0, err
+	}
+	p := ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Pane
+	if p == nil || (p.State != "frozen" && p.State != "frozenSplit") {
+		return 0, 0, err
+	}
+	return int(p.XSplit), int(p.YSplit), err
+}
+
 // GetSheetVisible provides a function to get worksheet visible by given worksheet
 // name. For example, get visible state of Sheet1:
 //
@@ -839,11 +1023,6 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	if !ok {
 		return result, ErrSheetNotExist{sheet}
 	}
-	if f.Sheet[name] != nil {
-		// flush data
-		output, _ := xml.Marshal(f.Sheet[name])
-		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
-	}
 	return f.searchSheet(name, value, regSearch)
 }
 
@@ -857,7 +1036,12 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 	)
 
 	d = f.sharedStringsReader()
-	decoder := f.xmlNewDecoder(bytes.NewReader(f.readXML(name)))
+	rc, err := f.readSheetXMLReader(name)
+	if err != nil {
+		return
+	}
+	defer rc.Close()
+	decoder := f.xmlNewDecoder(rc)
 	for {
 		var token xml.Token
 		token, err = decoder.Token()
@@ -1480,6 +1664,7 @@ func (f *File) GetPageLayout(sheet string, opts ...PageLayoutOptionPtr) error {
 //    })
 //
 func (f *File) SetDefinedName(definedName *DefinedName) error {
+	f.clearCalcCache()
 	wb := f.workbookReader()
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
@@ -1521,6 +1706,7 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 //    })
 //
 func (f *File) DeleteDefinedName(definedName *DefinedName) error {
+	f.clearCalcCache()
 	wb := f.workbookReader()
 	if wb.DefinedNames != nil {
 		for idx, dn := range wb.DefinedNames.DefinedName {
@@ -1724,11 +1910,115 @@ func (f *File) RemovePageBreak(sheet, cell string) (err error) {
 	return
 }
 
+// GetUsedRange provides a function to get the used range of the worksheet by
+// given worksheet name. The used range is calculated from the cells which
+// contain a value, formula or inline string, so that the empty trailing rows
+// and columns (e.g. cells only with style) and stale dimension element of the
+// worksheet will be ignored. The empty string will be returned if there is no
+// data in the worksheet. For example, get used range of Sheet1:
+//
+//    ref, err := f.GetUsedRange("Sheet1")
+//
+func (f *File) GetUsedRange(sheet string) (string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	coordinates := getUsedCoordinates(ws)
+	if coordinates == nil {
+		return "", err
+	}
+	return f.coordinatesToAreaRef(coordinates)
+}
+
+// TrimSheet provides a function to remove empty trailing rows and columns
+// outside of the used range in the worksheet by given worksheet name, and
+// update the dimension of the worksheet with the used range. This reduces the
+// size of the workbook before saving. Note that the formatting of the rows
+// and cells outside the used range will be removed. For example, trim the
+// worksheet named Sheet1:
+//
+//    err := f.TrimSheet("Sheet1")
+//
+func (f *File) TrimSheet(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	coordinates := getUsedCoordinates(ws)
+	if coordinates == nil {
+		ws.SheetData.Row = nil
+		ws.Dimension = &xlsxDimension{Ref: "A1"}
+		return err
+	}
+	rows := ws.SheetData.Row[:0]
+	for _, row := range ws.SheetData.Row {
+		if row.R > coordinates[3] {
+			continue
+		}
+		cells := row.C[:0]
+		for _, c := range row.C {
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil || col > coordinates[2] {
+				continue
+			}
+			cells = append(cells, c)
+		}
+		row.C = cells
+		rows = append(rows, row)
+	}
+	ws.SheetData.Row = rows
+	ref, err := f.coordinatesToAreaRef(coordinates)
+	ws.Dimension = &xlsxDimension{Ref: ref}
+	return err
+}
+
+// getUsedCoordinates provides a function to get the coordinates of the used
+// range in the worksheet. It returns nil if there is no data in the
+// worksheet.
+func getUsedCoordinates(ws *xlsxWorksheet) []int {
+	var coordinates []int
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		for colIdx := range rowData.C {
+			if !rowData.C[colIdx].hasData() {
+				continue
+			}
+			col, row, err := CellNameToCoordinates(rowData.C[colIdx].R)
+			if err != nil {
+				continue
+			}
+			if coordinates == nil {
+				coordinates = []int{col, row, col, row}
+				continue
+			}
+			if col < coordinates[0] {
+				coordinates[0] = col
+			}
+			if row < coordinates[1] {
+				coordinates[1] = row
+			}
+			if col > coordinates[2] {
+				coordinates[2] = col
+			}
+			if row > coordinates[3] {
+				coordinates[3] = row
+			}
+		}
+	}
+	return coordinates
+}
+
 // relsReader provides a function to get the pointer to the structure
 // after deserialization of xl/worksheets/_rels/sheet%d.xml.rels.
 func (f *File) relsReader(path string) *xlsxRelationships {
 	var err error
-
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if f.Relationships[path] == nil {
 		_, ok := f.XLSX[path]
 		if ok {
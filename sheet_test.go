@@ -104,6 +104,28 @@ func TestSetPane(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPane.xlsx")))
 }
 
+func TestGetFrozenPanes(t *testing.T) {
+	f := NewFile()
+	cols, rows, err := f.GetFrozenPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cols)
+	assert.Equal(t, 0, rows)
+	assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":true,"split":false,"x_split":1,"y_split":2,"top_left_cell":"B3","active_pane":"bottomRight"}`))
+	cols, rows, err = f.GetFrozenPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cols)
+	assert.Equal(t, 2, rows)
+	// Test get frozen panes with split panes.
+	assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":false,"split":true,"x_split":3270,"y_split":1800,"top_left_cell":"N57","active_pane":"bottomLeft"}`))
+	cols, rows, err = f.GetFrozenPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cols)
+	assert.Equal(t, 0, rows)
+	// Test get frozen panes on not exists worksheet.
+	_, _, err = f.GetFrozenPanes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestPageLayoutOption(t *testing.T) {
 	const sheet = "Sheet1"
 
@@ -328,6 +350,55 @@ func TestGetSheetName(t *testing.T) {
 	assert.Equal(t, "", f.GetSheetName(2))
 }
 
+func TestWorkSheetWriter(t *testing.T) {
+	for _, opts := range []Options{{}, {MaxInMemoryPartSize: 512}} {
+		f := NewFile(opts)
+		for idx := 1; idx <= 16; idx++ {
+			sheet := "Sheet" + strconv.Itoa(idx)
+			f.NewSheet(sheet)
+			for row := 1; row <= 50; row++ {
+				assert.NoError(t, f.SetSheetRow(sheet, "A"+strconv.Itoa(row), &[]interface{}{sheet, row}))
+			}
+		}
+		assert.NoError(t, f.AddPicture("Sheet16", "D1", filepath.Join("test", "images", "excel.png"), ""))
+		assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkSheetWriter.xlsx")))
+		assert.NoError(t, f.Close())
+		f, err := OpenFile(filepath.Join("test", "TestWorkSheetWriter.xlsx"))
+		assert.NoError(t, err)
+		for idx := 1; idx <= 16; idx++ {
+			sheet := "Sheet" + strconv.Itoa(idx)
+			rows, err := f.GetRows(sheet)
+			assert.NoError(t, err)
+			assert.Len(t, rows, 50)
+			assert.Equal(t, []string{sheet, "50"}, rows[49])
+		}
+		pics, err := f.GetPictures("Sheet16")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+	}
+}
+
+func TestReleaseSheet(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	// Test release the worksheet which has not been parsed.
+	assert.NoError(t, f.ReleaseSheet("Sheet2"))
+	assert.Nil(t, f.XLSX["xl/worksheets/sheet2.xml"])
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "released"))
+	assert.NoError(t, f.ReleaseSheet("Sheet1"))
+	assert.NotContains(t, f.Sheet, "xl/worksheets/sheet1.xml")
+	assert.NotNil(t, f.XLSX["xl/worksheets/sheet1.xml"])
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "released", val)
+	val, err = f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestReleaseSheet.xlsx")))
+	// Test release the not exists worksheet.
+	assert.EqualError(t, f.ReleaseSheet("SheetN"), "sheet SheetN is not exist")
+}
+
 func TestGetSheetMap(t *testing.T) {
 	expectedMap := map[int]string{
 		1: "Sheet1",
@@ -430,3 +501,57 @@ func newSheetWithSave() {
 	}
 	_ = file.Save()
 }
+
+func TestGetUsedRange(t *testing.T) {
+	f := NewFile()
+	ref, err := f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", ref)
+
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D5", "SUM(1,2)"))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"#E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "H20", style))
+	f.Sheet["xl/worksheets/sheet1.xml"].Dimension = &xlsxDimension{Ref: "A1:Z100"}
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:D5", ref)
+
+	// Test get used range on not exists worksheet.
+	_, err = f.GetUsedRange("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestTrimSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 3))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"#E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "H20", style))
+	assert.NoError(t, f.TrimSheet("Sheet1"))
+
+	ws := f.Sheet["xl/worksheets/sheet1.xml"]
+	assert.Len(t, ws.SheetData.Row, 3)
+	for _, row := range ws.SheetData.Row {
+		assert.Len(t, row.C, 3)
+	}
+	assert.Equal(t, "B2:C3", ws.Dimension.Ref)
+	val, err := f.GetCellValue("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTrimSheet.xlsx")))
+
+	// Test trim worksheet without data.
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellStyle("Sheet2", "A1", "C3", style))
+	assert.NoError(t, f.TrimSheet("Sheet2"))
+	ws, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 0)
+	assert.Equal(t, "A1", ws.Dimension.Ref)
+
+	// Test trim not exists worksheet.
+	assert.EqualError(t, f.TrimSheet("SheetN"), "sheet SheetN is not exist")
+}
@@ -0,0 +1,211 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlNumFmts defined the default number formats of the database column
+// types, the other date and time values will be formatted as the date time.
+var sqlNumFmts = map[string]int{"DATE": 14, "TIME": 21}
+
+// SQLOptions directly maps the options for importing the database/sql result
+// set into the worksheet. The Cell is the top-left cell of the imported data,
+// the default value is "A1". The header row will be created with the column
+// names of the result set and the style specified by HeaderStyle, set
+// SkipHeader to import the values only. The NULL values will be left blank,
+// or stored as the text specified by NullValue. The NumFmts maps the database
+// type names of the columns, such as "DECIMAL" and "DATE", to the built-in
+// number format IDs, the default number format of the "DATE" and "TIME"
+// columns are 14 and 21, and other date time values are 22. Set Stream to
+// write the rows with the StreamWriter, which replaces the existing data of
+// the worksheet. Set Table to create a table over the imported data with the
+// TableFormat, see AddTable for details on the table format.
+type SQLOptions struct {
+	Cell        string
+	SkipHeader  bool
+	HeaderStyle int
+	NullValue   string
+	NumFmts     map[string]int
+	Stream      bool
+	Table       bool
+	TableFormat string
+}
+
+// ImportSQLRows provides a function to read the result set of the database
+// query and set the values into the worksheet by given worksheet name, the
+// rows and the import options. The integers, floats, booleans and times will
+// be stored as the numeric, boolean and date values, the values of the
+// "DECIMAL", "NUMERIC" and "NUMBER" columns will be stored as numbers, and the
+// other values will be stored as text. The numbers with more than 15
+// significant digits will be stored as text to keep the precision. The rows
+// will not be closed by this function. For example, import the query result
+// into Sheet1 with the bold header and create a table over the result:
+//
+//    rows, err := db.Query("SELECT id, name, price, created FROM products")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    defer rows.Close()
+//    style, err := f.NewStyle(`{"font":{"bold":true}}`)
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if err := f.ImportSQLRows("Sheet1", rows, excelize.SQLOptions{
+//        HeaderStyle: style,
+//        NumFmts:     map[string]int{"DECIMAL": 2},
+//        Table:       true,
+//    }); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) ImportSQLRows(sheet string, rows *sql.Rows, opts ...SQLOptions) error {
+	options := SQLOptions{}
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Cell == "" {
+		options.Cell = "A1"
+	}
+	col, row, err := CellNameToCoordinates(options.Cell)
+	if err != nil {
+		return err
+	}
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	var sw *StreamWriter
+	if options.Stream {
+		if sw, err = f.NewStreamWriter(sheet); err != nil {
+			return err
+		}
+	} else if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	setRow := func(row int, values []interface{}) error {
+		axis, err := CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		if sw != nil {
+			return sw.SetRow(axis, values)
+		}
+		return f.setCSVRow(sheet, col, row, values)
+	}
+	var count int
+	if !options.SkipHeader {
+		header := make([]interface{}, len(columns))
+		for idx, column := range columns {
+			header[idx] = Cell{StyleID: options.HeaderStyle, Value: column.Name()}
+		}
+		if err = setRow(row, header); err != nil {
+			return err
+		}
+		count++
+	}
+	values, dest, styles := make([]interface{}, len(columns)), make([]interface{}, len(columns)), map[int]int{}
+	for idx := range dest {
+		dest[idx] = &values[idx]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+		record := make([]interface{}, len(columns))
+		for idx, value := range values {
+			if record[idx], err = f.getSQLCellValue(value, strings.ToUpper(columns[idx].DatabaseTypeName()), styles, &options); err != nil {
+				return err
+			}
+		}
+		if err = setRow(row+count, record); err != nil {
+			return err
+		}
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	if options.Table && count > 0 && len(columns) > 0 {
+		vcell, err := CoordinatesToCellName(col+len(columns)-1, row+count-1)
+		if err != nil {
+			return err
+		}
+		if sw != nil {
+			err = sw.AddTable(options.Cell, vcell, options.TableFormat)
+		} else {
+			err = f.AddTable(sheet, options.Cell, vcell, options.TableFormat)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if sw != nil {
+		return sw.Flush()
+	}
+	return err
+}
+
+// getSQLCellValue provides a function to convert the value of the database
+// column to the cell value by given value, the database type name of the
+// column, the styles cache and the import options.
+func (f *File) getSQLCellValue(value interface{}, typeName string, styles map[int]int, options *SQLOptions) (interface{}, error) {
+	switch val := value.(type) {
+	case nil:
+		if options.NullValue == "" {
+			return nil, nil
+		}
+		return options.NullValue, nil
+	case []byte:
+		value = string(val)
+	case int64:
+		if val > 999999999999999 || val < -999999999999999 {
+			value = strconv.FormatInt(val, 10)
+		}
+	}
+	if text, ok := value.(string); ok {
+		switch typeName {
+		case "DECIMAL", "NUMERIC", "NUMBER":
+			if csvNumberExp.MatchString(text) {
+				if num, err := f.inferCSVCellValue(text, false, nil, &CSVImportOptions{}); err == nil {
+					value = num
+				}
+			}
+		}
+	}
+	numFmt, ok := options.NumFmts[typeName]
+	if !ok {
+		if numFmt, ok = sqlNumFmts[typeName]; !ok {
+			if _, ok = value.(time.Time); ok {
+				numFmt = 22
+			}
+		}
+	}
+	if !ok || numFmt == 0 {
+		return value, nil
+	}
+	styleID, ok := styles[numFmt]
+	if !ok {
+		var err error
+		if styleID, err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+			return nil, err
+		}
+		styles[numFmt] = styleID
+	}
+	return Cell{StyleID: styleID, Value: value}, nil
+}
@@ -0,0 +1,147 @@
+package excelize
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSQLResults defined the result sets of the test database driver by the
+// query.
+var testSQLResults = map[string]*testSQLRows{}
+
+func init() {
+	sql.Register("excelize", testSQLDriver{})
+}
+
+type (
+	testSQLDriver struct{}
+	testSQLConn   struct{}
+	testSQLStmt   struct{ query string }
+	testSQLRows   struct {
+		columns, types []string
+		values         [][]driver.Value
+		err            error
+		row            int
+	}
+)
+
+func (testSQLDriver) Open(string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+func (testSQLConn) Prepare(query string) (driver.Stmt, error) { return testSQLStmt{query}, nil }
+func (testSQLConn) Close() error                              { return nil }
+func (testSQLConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (s testSQLStmt) Close() error  { return nil }
+func (s testSQLStmt) NumInput() int { return -1 }
+func (s testSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s testSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	rows := *testSQLResults[s.query]
+	return &rows, nil
+}
+
+func (r *testSQLRows) Columns() []string                         { return r.columns }
+func (r *testSQLRows) Close() error                              { return nil }
+func (r *testSQLRows) ColumnTypeDatabaseTypeName(idx int) string { return r.types[idx] }
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.row >= len(r.values) {
+		if r.err != nil {
+			return r.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.values[r.row])
+	r.row++
+	return nil
+}
+
+func TestImportSQLRows(t *testing.T) {
+	db, err := sql.Open("excelize", "")
+	assert.NoError(t, err)
+	defer db.Close()
+	created := time.Date(2021, 3, 4, 8, 30, 0, 0, time.UTC)
+	testSQLResults["products"] = &testSQLRows{
+		columns: []string{"id", "name", "price", "born", "created", "active", "serial", "amount", "note"},
+		types:   []string{"INTEGER", "VARCHAR", "decimal", "DATE", "TIMESTAMP", "BOOLEAN", "BIGINT", "NUMERIC", "TEXT"},
+		values: [][]driver.Value{
+			{int64(1), "Alice", []byte("1.50"), created, created, true, int64(1234567890123456789), "12345678901234567890", []byte("x")},
+			{int64(2), nil, []byte("n/a"), nil, nil, false, int64(-5), "0.25", nil},
+		},
+	}
+	rows, err := db.Query("products")
+	assert.NoError(t, err)
+	f := NewFile()
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.ImportSQLRows("Sheet1", rows, SQLOptions{Cell: "B2", HeaderStyle: style, NullValue: "NULL", NumFmts: map[string]int{"DECIMAL": 2}, Table: true}))
+	result, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "id", "name", "price", "born", "created", "active", "serial", "amount", "note"},
+		{"", "1", "Alice", "1.50", "03-04-21", "3/4/21 8:29", "1", "1234567890123456789", "12345678901234567890", "x"},
+		{"", "2", "NULL", "n/a", "NULL", "NULL", "0", "-5", "0.25", "NULL"},
+	}, result)
+	for cell, expected := range map[string]CellType{"D3": CellTypeNumber, "F3": CellTypeDate, "G3": CellTypeBool, "H3": CellTypeString, "I4": CellTypeNumber} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	headerStyle, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, headerStyle)
+	tm, err := f.GetCellTime("Sheet1", "F3")
+	assert.NoError(t, err)
+	assert.Equal(t, created, tm.Round(time.Second))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestImportSQLRows.xlsx")))
+
+	// Test import SQL rows with the StreamWriter without the header.
+	rows, err = db.Query("products")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ImportSQLRows("Sheet1", rows, SQLOptions{SkipHeader: true, Stream: true, Table: true}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestImportSQLRowsStream.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestImportSQLRowsStream.xlsx"))
+	assert.NoError(t, err)
+	result, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"1", "Alice", "1.5", "03-04-21", "3/4/21 8:29", "1", "1234567890123456789", "12345678901234567890", "x"},
+		{"2", "", "n/a", "", "", "0", "-5", "0.25", ""},
+	}, result)
+
+	// Test import SQL rows with invalid options.
+	f = NewFile()
+	for _, c := range []struct {
+		options  SQLOptions
+		sheet    string
+		expected string
+	}{
+		{SQLOptions{Cell: "A"}, "Sheet1", `cannot convert cell "A" to coordinates: invalid cell name "A"`},
+		{SQLOptions{}, "SheetN", "sheet SheetN is not exist"},
+		{SQLOptions{Stream: true}, "SheetN", "sheet SheetN is not exist"},
+		{SQLOptions{Cell: "XFD1"}, "Sheet1", "column number exceeds maximum limit"},
+	} {
+		rows, err = db.Query("products")
+		assert.NoError(t, err)
+		assert.EqualError(t, f.ImportSQLRows(c.sheet, rows, c.options), c.expected)
+		assert.NoError(t, rows.Close())
+	}
+	// Test import SQL rows with the closed rows.
+	rows, err = db.Query("products")
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Close())
+	assert.EqualError(t, f.ImportSQLRows("Sheet1", rows), "sql: Rows are closed")
+	// Test import SQL rows with the error of iteration.
+	testSQLResults["failed"] = &testSQLRows{columns: []string{"id"}, types: []string{"INTEGER"}, values: [][]driver.Value{{int64(1)}}, err: errors.New("connection lost")}
+	rows, err = db.Query("failed")
+	assert.NoError(t, err)
+	assert.EqualError(t, f.ImportSQLRows("Sheet1", rows), "connection lost")
+}
@@ -81,13 +81,14 @@ type StreamWriter struct {
 func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	sheetID := f.getSheetID(sheet)
 	if sheetID == -1 {
-		return nil, fmt.Errorf("sheet %s is not exist", sheet)
+		return nil, ErrSheetNotExist{sheet}
 	}
 	sw := &StreamWriter{
 		File:    f,
 		Sheet:   sheet,
 		SheetID: sheetID,
 	}
+	sw.rawData.limit = f.maxInMemoryPart
 	var err error
 	sw.worksheet, err = f.workSheetReader(sheet)
 	if err != nil {
@@ -277,7 +278,8 @@ func getRowElement(token xml.Token, hrow int) (startElement xml.StartElement, ok
 }
 
 // Cell can be used directly in StreamWriter.SetRow to specify a style and
-// a value.
+// a value, and it's also returned by the Cells function of the rows and
+// columns iterators with the typed value of the cell.
 type Cell struct {
 	StyleID int
 	Formula string
@@ -312,7 +314,12 @@ func (sw *StreamWriter) SetRow(axis string, values []interface{}) error {
 			val = v.Value
 			setCellFormula(&c, v.Formula)
 		}
-		if err = setCellValFunc(&c, val); err != nil {
+		if v, ok := val.(time.Time); ok {
+			c.T, c.V, _, err = setCellTime(sw.File.normalizeTime(v), sw.File.date1904())
+		} else {
+			err = setCellValFunc(&c, val)
+		}
+		if err != nil {
 			_, _ = sw.rawData.WriteString(`</row>`)
 			return err
 		}
@@ -345,7 +352,12 @@ func setCellValFunc(c *xlsxC, val interface{}) (err error) {
 	case time.Duration:
 		c.T, c.V = setCellDuration(val)
 	case time.Time:
-		c.T, c.V, _, err = setCellTime(val)
+		c.T, c.V, _, err = setCellTime(val, false)
+	case CellError:
+		if !validCellError(val) {
+			return fmt.Errorf("invalid error value %q", val)
+		}
+		c.T, c.V = "e", string(val)
 	case bool:
 		c.T, c.V = setCellBool(val)
 	case nil:
@@ -412,6 +424,7 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 
 // Flush ending the streaming writing process.
 func (sw *StreamWriter) Flush() error {
+	sw.File.clearCalcCache()
 	_, _ = sw.rawData.WriteString(`</sheetData>`)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 38)
 	_, _ = sw.rawData.WriteString(sw.tableParts)
@@ -444,10 +457,12 @@ func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 // bufferedWriter uses a temp file to store an extended buffer. Writes are
 // always made to an in-memory buffer, which will always succeed. The buffer
 // is written to the temp file with Sync, which may return an error.
-// Therefore, Sync should be periodically called and the error checked.
+// Therefore, Sync should be periodically called and the error checked. The
+// limit is the size of the in-memory buffer, the default size is 16 MB.
 type bufferedWriter struct {
-	tmp *os.File
-	buf bytes.Buffer
+	tmp   *os.File
+	buf   bytes.Buffer
+	limit int64
 }
 
 // Write to the in-memory buffer. The err is always nil.
@@ -480,8 +495,11 @@ func (bw *bufferedWriter) Reader() (io.Reader, error) {
 // buffer has grown large enough. Any error will be returned.
 func (bw *bufferedWriter) Sync() (err error) {
 	// Try to use local storage
-	const chunk = 1 << 24
-	if bw.buf.Len() < chunk {
+	chunk := int64(1 << 24)
+	if bw.limit > 0 {
+		chunk = bw.limit
+	}
+	if int64(bw.buf.Len()) < chunk {
 		return nil
 	}
 	if bw.tmp == nil {
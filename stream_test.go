@@ -58,6 +58,8 @@ func TestStreamWriter(t *testing.T) {
 	assert.NoError(t, streamWriter.SetRow("A4", []interface{}{Cell{StyleID: styleID}, Cell{Formula: "SUM(A10,B10)"}}))
 	assert.NoError(t, streamWriter.SetRow("A5", []interface{}{&Cell{StyleID: styleID, Value: "cell"}, &Cell{Formula: "SUM(A10,B10)"}}))
 	assert.EqualError(t, streamWriter.SetRow("A6", []interface{}{time.Now()}), "only UTC time expected")
+	assert.NoError(t, streamWriter.SetRow("A7", []interface{}{CellErrorNA}))
+	assert.EqualError(t, streamWriter.SetRow("A8", []interface{}{CellError("#ERR")}), `invalid error value "#ERR"`)
 
 	for rowID := 10; rowID <= 51200; rowID++ {
 		row := make([]interface{}, 50)
@@ -0,0 +1,365 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// structField defined the column settings of the struct field parsed from
+// the struct tags.
+type structField struct {
+	index  []int
+	header string
+	format string
+	style  string
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// parseStructFields provides a function to parse the columns from the fields
+// of the struct type by given type. The fields of the embedded struct will be
+// treated as the fields of the outer struct.
+func parseStructFields(t reflect.Type, index []int) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("excel")
+		if tag == "-" || field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		if typ := field.Type; field.Anonymous && tag == "" {
+			if typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+			if typ.Kind() == reflect.Struct && typ != timeType {
+				fields = append(fields, parseStructFields(typ, idx)...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		header := tag
+		if header == "" {
+			header = field.Name
+		}
+		fields = append(fields, structField{
+			index:  idx,
+			header: header,
+			format: field.Tag.Get("excel_format"),
+			style:  field.Tag.Get("excel_style"),
+		})
+	}
+	return fields
+}
+
+// structSliceType provides a function to get the struct type of the elements
+// in the slice, and whether the elements are pointers to struct.
+func structSliceType(t reflect.Type) (reflect.Type, bool, error) {
+	elem := t.Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, false, errors.New("slice of struct expected")
+	}
+	return elem, isPtr, nil
+}
+
+// WriteStructs provides a function to write a slice of struct or pointer to
+// struct to the worksheet by given worksheet name, the top-left cell and the
+// slice. The header row is written at the given cell with the column headers
+// of the fields, and each element of the slice is written as a row below the
+// header row. The columns are driven by the struct tags of the fields:
+//
+//    excel         The column header, defaults to the name of the field. The
+//                  field will be ignored if the value is "-".
+//    excel_format  The custom number format of the column, such as "0.00%".
+//    excel_style   The style of the column in the same JSON format as
+//                  NewStyle.
+//
+// Unexported fields are ignored, nil pointer fields are written as blank
+// cells and nil elements of the slice are skipped. For example, write the records to Sheet1:
+//
+//    type Record struct {
+//        Name    string    `excel:"Name"`
+//        Amount  float64   `excel:"Amount" excel_format:"#,##0.00"`
+//        Created time.Time `excel:"Created At" excel_format:"yyyy-mm-dd"`
+//        Note    string    `excel:"-"`
+//    }
+//    err := f.WriteStructs("Sheet1", "A1", records)
+//
+func (f *File) WriteStructs(sheet, cell string, slice interface{}) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return errors.New("slice of struct expected")
+	}
+	elemType, isPtr, err := structSliceType(v.Type())
+	if err != nil {
+		return err
+	}
+	fields := parseStructFields(elemType, nil)
+	headers := make([]interface{}, len(fields))
+	for i, field := range fields {
+		headers[i] = field.header
+	}
+	if err = f.SetSheetRow(sheet, cell, &headers); err != nil {
+		return err
+	}
+	var rows int
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if isPtr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		rows++
+		for j, field := range fields {
+			fieldValue, ok := structFieldByIndex(elem, field.index)
+			if !ok {
+				continue
+			}
+			axis, _ := CoordinatesToCellName(col+j, row+rows)
+			if err = f.SetCellValue(sheet, axis, fieldValue.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	if rows == 0 {
+		return err
+	}
+	for j, field := range fields {
+		if field.format == "" && field.style == "" {
+			continue
+		}
+		style, err := f.newStructFieldStyle(field)
+		if err != nil {
+			return err
+		}
+		hcell, _ := CoordinatesToCellName(col+j, row+1)
+		vcell, _ := CoordinatesToCellName(col+j, row+rows)
+		if err = f.SetCellStyle(sheet, hcell, vcell, style); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// structFieldByIndex provides a function to get the nested field by given
+// struct value and index sequence, the pointer fields will be dereferenced.
+// Boolean type value ok will be false if the field is a nil pointer.
+func structFieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// newStructFieldStyle provides a function to create the style of the column
+// by given struct field settings.
+func (f *File) newStructFieldStyle(field structField) (int, error) {
+	style := map[string]interface{}{}
+	if field.style != "" {
+		if err := json.Unmarshal([]byte(field.style), &style); err != nil {
+			return 0, err
+		}
+	}
+	if field.format != "" {
+		style["custom_number_format"] = field.format
+	}
+	b, _ := json.Marshal(style)
+	return f.NewStyle(string(b))
+}
+
+// ReadStructs provides a function to read the rows of the worksheet into the
+// slice of struct or pointer to struct by given worksheet name and the
+// pointer to the slice. The first non-empty row of the worksheet is treated
+// as the header row, and the columns are mapped to the fields by the column
+// headers in the same struct tags as WriteStructs. The blank rows will be
+// skipped. For example, read the records from Sheet1:
+//
+//    var records []Record
+//    err := f.ReadStructs("Sheet1", &records)
+//
+// The number cells with date format or the RFC 3339 formatted strings can be
+// read into the time.Time fields, and the CellError will be returned if the
+// cell contains an error value.
+func (f *File) ReadStructs(sheet string, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return errors.New("pointer to slice expected")
+	}
+	v = v.Elem()
+	elemType, isPtr, err := structSliceType(v.Type())
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	fields := parseStructFields(elemType, nil)
+	columns, headerRow := map[int]structField{}, -1
+	for r, row := range ws.SheetData.Row {
+		for c := range row.C {
+			if !row.C[c].hasData() {
+				continue
+			}
+			headerRow = r
+			header := strings.TrimSpace(f.cellRawValue(&row.C[c]))
+			for _, field := range fields {
+				if field.header == header {
+					columns[c] = field
+				}
+			}
+		}
+		if headerRow != -1 {
+			break
+		}
+	}
+	if headerRow == -1 {
+		return err
+	}
+	for r := headerRow + 1; r < len(ws.SheetData.Row); r++ {
+		row := ws.SheetData.Row[r]
+		var blank = true
+		for c := range row.C {
+			blank = blank && !row.C[c].hasData()
+		}
+		if blank {
+			continue
+		}
+		elem := reflect.New(elemType).Elem()
+		for c, field := range columns {
+			if c >= len(row.C) || !row.C[c].hasData() {
+				continue
+			}
+			if err = f.setStructField(elem, field.index, &row.C[c]); err != nil {
+				return fmt.Errorf("cell %s: %v", row.C[c].R, err)
+			}
+		}
+		if isPtr {
+			elem = elem.Addr()
+		}
+		v.Set(reflect.Append(v, elem))
+	}
+	return nil
+}
+
+// setStructField provides a function to set the nested field of the struct
+// by given struct value, index sequence and cell, the nil pointer fields
+// will be allocated. The nil embedded pointers to unexported struct could not
+// be allocated, and an error will be returned for them.
+func (f *File) setStructField(v reflect.Value, index []int, c *xlsxC) error {
+	for _, idx := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return fmt.Errorf("cannot set embedded pointer to unexported struct: %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if c.T == "e" {
+		return CellError(c.V)
+	}
+	raw := f.cellRawValue(c)
+	if v.Type() == timeType {
+		var tm time.Time
+		n, err := strconv.ParseFloat(raw, 64)
+		if c.T == "" || c.T == "n" {
+			if err != nil {
+				return err
+			}
+			tm = f.localTime(timeFromExcelTime(n, f.date1904()))
+		} else if tm, err = time.Parse(time.RFC3339Nano, strings.TrimSpace(raw)); err != nil {
+			return err
+		} else if f.timeLocation != nil {
+			tm = tm.In(f.timeLocation)
+		}
+		v.Set(reflect.ValueOf(tm))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if c.T == "" || c.T == "n" {
+			raw = f.formattedValue(c.S, raw)
+		}
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
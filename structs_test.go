@@ -0,0 +1,119 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type structsTestBase struct {
+	ID int `excel:"ID"`
+}
+
+type structsTestRecord struct {
+	structsTestBase
+	Name    string    `excel:"Name"`
+	Amount  float64   `excel:"Amount" excel_format:"#,##0.00" excel_style:"{\"font\":{\"bold\":true}}"`
+	Rate    *float64  `excel:"Rate" excel_format:"0.00%"`
+	Active  bool      `excel:"Active"`
+	Created time.Time `excel:"Created At" excel_format:"yyyy-mm-dd"`
+	Count   uint8
+	Note    string `excel:"-"`
+	secret  string
+}
+
+func TestWriteStructs(t *testing.T) {
+	f := NewFile()
+	rate := 0.25
+	created := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	records := []structsTestRecord{
+		{structsTestBase{1}, "Alice", 1234.5, &rate, true, created, 3, "note", "secret"},
+		{structsTestBase{2}, "Bob", 10, nil, false, created.AddDate(0, 0, 1), 0, "", ""},
+	}
+	assert.NoError(t, f.WriteStructs("Sheet1", "B2", records))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "ID", "Name", "Amount", "Rate", "Active", "Created At", "Count"},
+		{"", "1", "Alice", "1234.5", "0.25", "1", "2020-10-01", "3"},
+		{"", "2", "Bob", "10", "", "0", "2020-10-02", "0"},
+	}, rows)
+	style, err := f.GetCellStyle("Sheet1", "D3")
+	assert.NoError(t, err)
+	assert.True(t, f.Styles.Fonts.Font[*f.Styles.CellXfs.Xf[style].FontID].B != nil)
+	assert.Equal(t, "#,##0.00", f.Styles.NumFmts.NumFmt[0].FormatCode)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWriteStructs.xlsx")))
+
+	// Test write structs with pointer to slice of pointer to struct.
+	f = NewFile()
+	assert.NoError(t, f.WriteStructs("Sheet1", "A1", &[]*structsTestRecord{&records[0], nil}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	// Test write structs with invalid arguments.
+	assert.EqualError(t, f.WriteStructs("Sheet1", "A", records), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.WriteStructs("Sheet1", "A1", records[0]), "slice of struct expected")
+	assert.EqualError(t, f.WriteStructs("Sheet1", "A1", []int{1}), "slice of struct expected")
+	assert.EqualError(t, f.WriteStructs("SheetN", "A1", records), "sheet SheetN is not exist")
+	assert.EqualError(t, f.WriteStructs("Sheet1", "A1", []struct {
+		Name string `excel_style:"{"`
+	}{{}}), "unexpected end of JSON input")
+}
+
+func TestReadStructs(t *testing.T) {
+	f := NewFile()
+	rate := 0.25
+	created := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	records := []structsTestRecord{
+		{structsTestBase{1}, "Alice", 1234.5, &rate, true, created, 3, "", ""},
+		{structsTestBase{2}, "Bob", 10, nil, false, created.AddDate(0, 0, 1), 0, "", ""},
+	}
+	assert.NoError(t, f.WriteStructs("Sheet1", "B2", records))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", nil))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B6", &[]interface{}{"3", "Carol", "1.5", nil, "true", "2020-10-03T00:00:00Z", "7"}))
+
+	var result []structsTestRecord
+	assert.NoError(t, f.ReadStructs("Sheet1", &result))
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, records[0], result[0])
+		assert.Equal(t, records[1], result[1])
+		assert.Equal(t, structsTestRecord{structsTestBase{3}, "Carol", 1.5, nil, true, created.AddDate(0, 0, 2), 7, "", ""}, result[2])
+	}
+	var ptrs []*structsTestRecord
+	assert.NoError(t, f.ReadStructs("Sheet1", &ptrs))
+	assert.Len(t, ptrs, 3)
+
+	// Test read structs from the cell with error value.
+	assert.NoError(t, f.SetCellError("Sheet1", "D4", CellErrorNA))
+	assert.EqualError(t, f.ReadStructs("Sheet1", &result), "cell D4: #N/A")
+	// Test read structs from the cell with invalid value.
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", "text"))
+	assert.EqualError(t, f.ReadStructs("Sheet1", &result), `cell D4: strconv.ParseFloat: parsing "text": invalid syntax`)
+
+	// Test read structs with invalid arguments.
+	assert.EqualError(t, f.ReadStructs("Sheet1", result), "pointer to slice expected")
+	assert.EqualError(t, f.ReadStructs("Sheet1", &[]int{}), "slice of struct expected")
+	assert.EqualError(t, f.ReadStructs("SheetN", &result), "sheet SheetN is not exist")
+	var unsupported []struct {
+		Name []string
+	}
+	f = NewFile()
+	assert.NoError(t, f.ReadStructs("Sheet1", &unsupported))
+	assert.Len(t, unsupported, 0)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A"}))
+	assert.EqualError(t, f.ReadStructs("Sheet1", &unsupported), "cell A2: unsupported field type []string")
+
+	// Test read structs with the embedded pointer to unexported struct.
+	type embedded struct {
+		Name string
+	}
+	var embeddedPtrs []struct {
+		*embedded
+	}
+	assert.EqualError(t, f.ReadStructs("Sheet1", &embeddedPtrs), "cell A2: cannot set embedded pointer to unexported struct: excelize.embedded")
+}
@@ -1077,7 +1077,9 @@ func (f *File) stylesReader() *xlsxStyleSheet {
 func (f *File) styleSheetWriter() {
 	if f.Styles != nil {
 		output, _ := xml.Marshal(f.Styles)
-		f.saveFileList("xl/styles.xml", f.replaceNameSpaceBytes("xl/styles.xml", output))
+		if f.isPartChanged("xl/styles.xml", output, new(xlsxStyleSheet)) {
+			f.saveFileList("xl/styles.xml", f.replaceNameSpaceBytes("xl/styles.xml", output))
+		}
 	}
 }
 
@@ -1086,7 +1088,9 @@ func (f *File) styleSheetWriter() {
 func (f *File) sharedStringsWriter() {
 	if f.SharedStrings != nil {
 		output, _ := xml.Marshal(f.SharedStrings)
-		f.saveFileList("xl/sharedStrings.xml", f.replaceNameSpaceBytes("xl/sharedStrings.xml", output))
+		if f.isPartChanged("xl/sharedStrings.xml", output, new(xlsxSST)) {
+			f.saveFileList("xl/sharedStrings.xml", f.replaceNameSpaceBytes("xl/sharedStrings.xml", output))
+		}
 	}
 }
 
@@ -1976,6 +1980,12 @@ func parseFormatStyleSet(style interface{}) (*Style, error) {
 //
 // Cell Sheet1!A6 in the Excel Application: martes, 04 de Julio de 2017
 //
+// Set quote_prefix to store the text value of the cell as is, the spreadsheet
+// application will not interpret the value that looks like a number, date or
+// formula, such as "00123" or "=A1", and not flag the number stored as text:
+//
+//    style, err := f.NewStyle(`{"quote_prefix":true}`)
+//
 func (f *File) NewStyle(style interface{}) (int, error) {
 	var fs *Style
 	var err error
@@ -2028,6 +2038,9 @@ func (f *File) NewStyle(style interface{}) (int, error) {
 	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
 	applyProtection, protection := fs.Protection != nil, newProtection(fs)
 	cellXfsID = setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	if fs.QuotePrefix {
+		s.CellXfs.Xf[cellXfsID].QuotePrefix = boolPtr(true)
+	}
 	return cellXfsID, nil
 }
 
@@ -2071,6 +2084,9 @@ var getXfIDFuncs = map[string]func(int, xlsxXf, *Style) bool{
 		}
 		return reflect.DeepEqual(xf.Protection, newProtection(style)) && xf.ApplyProtection != nil && *xf.ApplyProtection
 	},
+	"quotePrefix": func(ID int, xf xlsxXf, style *Style) bool {
+		return style.QuotePrefix == (xf.QuotePrefix != nil && *xf.QuotePrefix)
+	},
 }
 
 // getStyleID provides a function to get styleID by given style. If given
@@ -2090,7 +2106,8 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (styleID int) {
 			getXfIDFuncs["fill"](fillID, xf, style) &&
 			getXfIDFuncs["border"](borderID, xf, style) &&
 			getXfIDFuncs["alignment"](0, xf, style) &&
-			getXfIDFuncs["protection"](0, xf, style) {
+			getXfIDFuncs["protection"](0, xf, style) &&
+			getXfIDFuncs["quotePrefix"](0, xf, style) {
 			styleID = xfID
 			return
 		}
@@ -2595,6 +2612,67 @@ func (f *File) GetCellStyle(sheet, axis string) (int, error) {
 	return f.prepareCellStyle(ws, col, cellData.S), err
 }
 
+// GetCellQuotePrefix provides a function to get the quote prefix setting of
+// the cell style by given worksheet name and cell coordinates. Boolean type
+// value true means the text value of the cell will be stored as is.
+func (f *File) GetCellQuotePrefix(sheet, axis string) (bool, error) {
+	styleID, err := f.GetCellStyle(sheet, axis)
+	if err != nil {
+		return false, err
+	}
+	s := f.stylesReader()
+	if s.CellXfs == nil || styleID >= len(s.CellXfs.Xf) {
+		return false, err
+	}
+	xf := s.CellXfs.Xf[styleID]
+	return xf.QuotePrefix != nil && *xf.QuotePrefix, err
+}
+
+// SetCellQuotePrefix provides a function to set or unset the quote prefix of
+// the cell style by given worksheet name, cell coordinates and the setting,
+// the other formatting of the cell style will be kept. For example, keep the
+// value of Sheet1!A1 as the text in the spreadsheet application:
+//
+//    err := f.SetCellQuotePrefix("Sheet1", "A1", true)
+//
+func (f *File) SetCellQuotePrefix(sheet, axis string, quotePrefix bool) error {
+	styleID, err := f.GetCellStyle(sheet, axis)
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, axis, axis, f.getQuotePrefixStyleID(styleID, quotePrefix))
+}
+
+// getQuotePrefixStyleID provides a function to get the ID of the cell style
+// which is the same as the given cell style except for the quote prefix
+// setting, the style will be created if not exists.
+func (f *File) getQuotePrefixStyleID(styleID int, quotePrefix bool) int {
+	s := f.stylesReader()
+	if s.CellXfs == nil || styleID >= len(s.CellXfs.Xf) {
+		return styleID
+	}
+	normalize := func(xf xlsxXf) xlsxXf {
+		xf.QuotePrefix = nil
+		return xf
+	}
+	xf := s.CellXfs.Xf[styleID]
+	if (xf.QuotePrefix != nil && *xf.QuotePrefix) == quotePrefix {
+		return styleID
+	}
+	for ID, x := range s.CellXfs.Xf {
+		if (x.QuotePrefix != nil && *x.QuotePrefix) == quotePrefix && reflect.DeepEqual(normalize(x), normalize(xf)) {
+			return ID
+		}
+	}
+	xf.QuotePrefix = nil
+	if quotePrefix {
+		xf.QuotePrefix = boolPtr(true)
+	}
+	s.CellXfs.Count++
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	return len(s.CellXfs.Xf) - 1
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, coordinate area and style ID. Note that diagonalDown and
 // diagonalUp type border should be use same color in the same coordinate
@@ -2661,6 +2739,7 @@ func (f *File) GetCellStyle(sheet, axis string) (int, error) {
 //    err = f.SetCellStyle("Sheet1", "H9", "H9", style)
 //
 func (f *File) SetCellStyle(sheet, hcell, vcell string, styleID int) error {
+	f.clearCalcCache()
 	hcol, hrow, err := CellNameToCoordinates(hcell)
 	if err != nil {
 		return err
@@ -278,6 +278,54 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("SheetN", "A1", "A2", 1), "sheet SheetN is not exist")
 }
 
+func TestCellQuotePrefix(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"quote_prefix":true}`)
+	assert.NoError(t, err)
+	plain, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NotEqual(t, style, plain)
+	ID, err := f.NewStyle(`{"quote_prefix":true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, style, ID)
+
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	quotePrefix, err := f.GetCellQuotePrefix("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, quotePrefix)
+
+	// Test set quote prefix will keep the other formatting of the cell.
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", plain))
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "B1", true))
+	ID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	xf := f.Styles.CellXfs.Xf[ID]
+	assert.True(t, *xf.QuotePrefix)
+	assert.Equal(t, f.Styles.CellXfs.Xf[plain].FontID, xf.FontID)
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "B1", false))
+	ID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, plain, ID)
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "C1", true))
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "D1", true))
+	ID, err = f.GetCellStyle("Sheet1", "C1")
+	assert.NoError(t, err)
+	quotePrefixID, err := f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, ID, quotePrefixID)
+
+	// Test get and set quote prefix on not exists worksheet.
+	_, err = f.GetCellQuotePrefix("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.EqualError(t, f.SetCellQuotePrefix("SheetN", "A1", true), "sheet SheetN is not exist")
+	// Test get and set quote prefix with invalid style ID.
+	assert.Equal(t, 100, f.getQuotePrefixStyleID(100, true))
+	f.Styles.CellXfs = nil
+	quotePrefix, err = f.GetCellQuotePrefix("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, quotePrefix)
+}
+
 func TestGetStyleID(t *testing.T) {
 	assert.Equal(t, -1, NewFile().getStyleID(&xlsxStyleSheet{}, nil))
 }
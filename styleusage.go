@@ -0,0 +1,246 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// StyleLocation directly maps a location where the style is used. Type is
+// one of the following values:
+//
+//     Type              | Ref
+//    -------------------+--------------------------------------------------
+//     cell              | Cell or range of the cells in a row, such as A1:C1
+//     row               | Row number, such as 2
+//     col               | Column or range of the columns, such as B:D
+//     conditionalFormat | Range of the conditional format, such as A1:A10
+//     table             | Table name, such as Table1
+//     tableColumn       | Table column, such as Table1[Sales]
+//     tableStyle        | Custom table or pivot table style name
+//
+// Sheet is the worksheet name of the location, and it's empty for the
+// custom table styles which are defined in the workbook.
+type StyleLocation struct {
+	Sheet string
+	Type  string
+	Ref   string
+}
+
+// StyleLocations is the locations where a style is used.
+type StyleLocations []StyleLocation
+
+// StyleUsage directly maps the usage of the styles in the workbook. Styles
+// is indexed by the cell style ID returned by NewStyle, NumFmts is keyed by
+// the number format ID used by the cell styles or defined in the workbook,
+// and Dxfs is indexed by the differential style ID returned by
+// NewConditionalStyle. The locations of a number format are the locations
+// of the cell styles using it. The style without any locations is unused,
+// the default cell style 0 is used by all the cells without style, so the
+// locations of it will not be reported.
+type StyleUsage struct {
+	Styles  []StyleLocations
+	NumFmts map[int]StyleLocations
+	Dxfs    []StyleLocations
+}
+
+// Sheets provides a function to get the names of the worksheets which use
+// the style, which could be used to check if the style is only used in a
+// worksheet or reused across the workbook.
+func (locations StyleLocations) Sheets() []string {
+	var sheets []string
+	for _, location := range locations {
+		if location.Sheet != "" && inStrSlice(sheets, location.Sheet) == -1 {
+			sheets = append(sheets, location.Sheet)
+		}
+	}
+	return sheets
+}
+
+// GetStyleUsage provides a function to get the usage of the cell styles,
+// number formats and differential styles in the workbook, which reports
+// where the styles are used by the cells, rows, columns, conditional formats,
+// tables and custom table styles. For example, print the unused cell styles
+// and the cell styles used in multiple worksheets:
+//
+//    usage, err := f.GetStyleUsage()
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for styleID, locations := range usage.Styles {
+//        if styleID > 0 && len(locations) == 0 {
+//            fmt.Println("unused style", styleID)
+//        }
+//        if sheets := locations.Sheets(); len(sheets) > 1 {
+//            fmt.Println("style", styleID, "used in", sheets)
+//        }
+//    }
+//
+func (f *File) GetStyleUsage() (*StyleUsage, error) {
+	s := f.stylesReader()
+	usage := &StyleUsage{NumFmts: map[int]StyleLocations{}}
+	if s.CellXfs != nil {
+		usage.Styles = make([]StyleLocations, len(s.CellXfs.Xf))
+	}
+	if s.Dxfs != nil {
+		usage.Dxfs = make([]StyleLocations, len(s.Dxfs.Dxfs))
+	}
+	addStyle := func(styleID int, location StyleLocation) {
+		if styleID > 0 && styleID < len(usage.Styles) {
+			usage.Styles[styleID] = append(usage.Styles[styleID], location)
+		}
+	}
+	addDxf := func(dxfID *int, location StyleLocation) {
+		if dxfID != nil && *dxfID >= 0 && *dxfID < len(usage.Dxfs) {
+			usage.Dxfs[*dxfID] = append(usage.Dxfs[*dxfID], location)
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		name := f.sheetMap[trimSheetName(sheet)]
+		if strings.HasPrefix(name, "xl/chartsheets") {
+			continue
+		}
+		if _, ok := f.streams[name]; ok {
+			continue
+		}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return usage, err
+		}
+		if ws.Cols != nil {
+			for _, col := range ws.Cols.Col {
+				first, _ := ColumnNumberToName(col.Min)
+				last, _ := ColumnNumberToName(col.Max)
+				addStyle(col.Style, StyleLocation{Sheet: sheet, Type: "col", Ref: first + ":" + last})
+			}
+		}
+		for _, row := range ws.SheetData.Row {
+			if row.CustomFormat {
+				addStyle(row.S, StyleLocation{Sheet: sheet, Type: "row", Ref: strconv.Itoa(row.R)})
+			}
+			getStyleUsageCells(row, func(styleID int, ref string) {
+				addStyle(styleID, StyleLocation{Sheet: sheet, Type: "cell", Ref: ref})
+			})
+		}
+		for _, cf := range ws.ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				addDxf(rule.DxfID, StyleLocation{Sheet: sheet, Type: "conditionalFormat", Ref: cf.SQRef})
+			}
+		}
+		if ws.TableParts == nil {
+			continue
+		}
+		for _, tablePart := range ws.TableParts.TableParts {
+			table, err := f.tableReader(sheet, tablePart.RID)
+			if err != nil {
+				return usage, err
+			}
+			if table == nil {
+				continue
+			}
+			for _, dxfID := range []*int{table.HeaderRowDxfID, table.DataDxfID, table.TotalsRowDxfID,
+				table.HeaderRowBorderDxfID, table.TableBorderDxfID, table.TotalsRowBorderDxfID} {
+				addDxf(dxfID, StyleLocation{Sheet: sheet, Type: "table", Ref: table.Name})
+			}
+			if table.TableColumns == nil {
+				continue
+			}
+			for _, column := range table.TableColumns.TableColumn {
+				for _, dxfID := range []*int{column.HeaderRowDxfID, column.DataDxfID, column.TotalsRowDxfID} {
+					addDxf(dxfID, StyleLocation{Sheet: sheet, Type: "tableColumn", Ref: table.Name + "[" + column.Name + "]"})
+				}
+			}
+		}
+	}
+	if s.TableStyles != nil {
+		for _, tableStyle := range s.TableStyles.TableStyles {
+			for _, element := range tableStyle.TableStyleElement {
+				addDxf(element.DxfID, StyleLocation{Type: "tableStyle", Ref: tableStyle.Name})
+			}
+		}
+	}
+	if s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			usage.NumFmts[numFmt.NumFmtID] = nil
+		}
+	}
+	if s.CellXfs != nil {
+		for styleID, xf := range s.CellXfs.Xf {
+			var numFmtID int
+			if xf.NumFmtID != nil {
+				numFmtID = *xf.NumFmtID
+			}
+			usage.NumFmts[numFmtID] = append(usage.NumFmts[numFmtID], usage.Styles[styleID]...)
+		}
+	}
+	return usage, nil
+}
+
+// getStyleUsageCells provides a function to call the given function with the
+// style ID and the reference of each range of the adjacent cells which have
+// the same style in the row.
+func getStyleUsageCells(row xlsxRow, fn func(styleID int, ref string)) {
+	var start, end, styleID, prev int
+	flush := func() {
+		if styleID == 0 {
+			return
+		}
+		ref, _ := CoordinatesToCellName(start, row.R)
+		if start != end {
+			last, _ := CoordinatesToCellName(end, row.R)
+			ref += ":" + last
+		}
+		fn(styleID, ref)
+	}
+	for i, c := range row.C {
+		col := i + 1
+		if c.R != "" {
+			col, _, _ = CellNameToCoordinates(c.R)
+		}
+		if c.S == styleID && col == prev+1 {
+			end, prev = col, col
+			continue
+		}
+		flush()
+		start, end, styleID, prev = col, col, c.S, col
+	}
+	flush()
+}
+
+// tableReader provides a function to get the pointer to the structure after
+// deserialization of the table by given worksheet name and relationship ID
+// of the table part, it returns nil if the table part is not exist.
+func (f *File) tableReader(sheet, rID string) (*xlsxTable, error) {
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	if target == "" {
+		return nil, nil
+	}
+	tablePath := path.Join("xl/worksheets", target)
+	if strings.HasPrefix(target, "/") {
+		tablePath = strings.TrimPrefix(target, "/")
+	}
+	content := f.readXML(tablePath)
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var table xlsxTable
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(&table); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &table, nil
+}
@@ -0,0 +1,97 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStyleUsage(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	bold, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	date, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	unused, err := f.NewStyle(&Style{CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C1", bold))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E1", "E1", bold))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "D2", date))
+	assert.NoError(t, f.SetCellStyle("Sheet2", "B2", "B3", bold))
+	assert.NoError(t, f.SetColStyle("Sheet2", "D:F", date))
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	ws.SheetData.Row[1].S, ws.SheetData.Row[1].CustomFormat = date, true
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	header, err := f.NewConditionalStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%d,"value":"6"}]`, format)))
+	assert.NoError(t, f.AddTable("Sheet2", "H1", "I3", `{"table_name":"Sales"}`))
+	f.XLSX["xl/tables/table1.xml"] = []byte(strings.Replace(string(f.XLSX["xl/tables/table1.xml"]), `<tableColumn id="1"`,
+		fmt.Sprintf(`<tableColumn id="1" dataDxfId="%d"`, format), 1))
+	f.XLSX["xl/tables/table1.xml"] = []byte(strings.Replace(string(f.XLSX["xl/tables/table1.xml"]), `<table `,
+		fmt.Sprintf(`<table headerRowDxfId="%d" `, header), 1))
+	assert.NoError(t, f.AddPivotTableStyle(&PivotTableStyle{Name: "Brand", HeaderRow: &header}))
+
+	usage, err := f.GetStyleUsage()
+	assert.NoError(t, err)
+	assert.Len(t, usage.Styles, 4)
+	assert.Empty(t, usage.Styles[0])
+	assert.Equal(t, StyleLocations{
+		{Sheet: "Sheet1", Type: "cell", Ref: "A1:C1"},
+		{Sheet: "Sheet1", Type: "cell", Ref: "E1"},
+		{Sheet: "Sheet2", Type: "cell", Ref: "B2"},
+		{Sheet: "Sheet2", Type: "cell", Ref: "B3"},
+	}, usage.Styles[bold])
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, usage.Styles[bold].Sheets())
+	assert.Equal(t, StyleLocations{
+		{Sheet: "Sheet1", Type: "cell", Ref: "D2"},
+		{Sheet: "Sheet2", Type: "col", Ref: "D:D"},
+		{Sheet: "Sheet2", Type: "col", Ref: "E:E"},
+		{Sheet: "Sheet2", Type: "col", Ref: "F:F"},
+		{Sheet: "Sheet2", Type: "row", Ref: "2"},
+	}, usage.Styles[date])
+	assert.Empty(t, usage.Styles[unused])
+	assert.Equal(t, usage.Styles[date], usage.NumFmts[14])
+	assert.Equal(t, usage.Styles[bold], usage.NumFmts[0])
+	assert.Contains(t, usage.NumFmts, 164)
+	assert.Empty(t, usage.NumFmts[164])
+	assert.Equal(t, []StyleLocations{
+		{{Sheet: "Sheet1", Type: "conditionalFormat", Ref: "A1:A10"}, {Sheet: "Sheet2", Type: "tableColumn", Ref: "Sales[Column1]"}},
+		{{Sheet: "Sheet2", Type: "table", Ref: "Sales"}, {Type: "tableStyle", Ref: "Brand"}},
+	}, usage.Dxfs)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, usage.Dxfs[format].Sheets())
+	assert.Equal(t, []string{"Sheet2"}, usage.Dxfs[header].Sheets())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetStyleUsage.xlsx")))
+
+	// Test get style usage with the table part which is not exist.
+	delete(f.XLSX, "xl/tables/table1.xml")
+	usage, err = f.GetStyleUsage()
+	assert.NoError(t, err)
+	assert.Len(t, usage.Dxfs[header], 1)
+	f.Relationships["xl/worksheets/_rels/sheet2.xml.rels"].Relationships[0].Target = "/xl/tables/table1.xml"
+	_, err = f.GetStyleUsage()
+	assert.NoError(t, err)
+	f.Relationships["xl/worksheets/_rels/sheet2.xml.rels"].Relationships = nil
+	_, err = f.GetStyleUsage()
+	assert.NoError(t, err)
+
+	// Test get style usage with the unsupported charset table.
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B2", ""))
+	f.XLSX["xl/tables/table1.xml"] = MacintoshCyrillicCharset
+	_, err = f.GetStyleUsage()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get style usage with the unsupported charset worksheet.
+	f = NewFile()
+	f.Sheet = map[string]*xlsxWorksheet{}
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	_, err = f.GetStyleUsage()
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
@@ -0,0 +1,439 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// templatePlaceholder matches the placeholders such as {{.Field}} and
+// {{.Order.Items.Name}} in the cell values of the template.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*\.([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\s*\}\}`)
+
+// templateCell defined the cell of the template row with the placeholders.
+type templateCell struct {
+	cell  string
+	text  string
+	paths [][]string
+}
+
+// ExecuteTemplate provides a function to fill the template spreadsheet by
+// given data, which could be a struct, a map with string keys or a pointer
+// to them. The placeholders such as {{.Field}} in the cell values of all the
+// worksheets will be replaced by the values of the fields or the map keys,
+// and the nested fields could be specified as {{.Order.Customer}}. The cell
+// which only contains a placeholder will be set by the typed value, such as
+// number, boolean and time.Time, otherwise the placeholders will be replaced
+// by the formatted values in the string.
+//
+// The row with the placeholders referencing the fields of the elements of a
+// slice, such as {{.Items.Name}}, will be expanded to one row for each
+// element. The styles, the single row merged cells and the formulas of the
+// row will be cloned for each element, the references to the same row in the
+// formulas will be moved with the cloned rows, the references to the rows
+// below in the formulas of all the worksheets and the defined names will be
+// moved down, and the ranges end at the row, such as SUM(D2:D5) below the
+// expanded row 5, will be extended to cover all the expanded rows. The row
+// will be removed if the slice is empty.
+//
+// After that, the defined names which have the same names as the fields or
+// the map keys will be filled with the values: the single value will be set
+// to the top left cell of the range, the slice of values will be set down
+// the range in one column or across the range otherwise, and each element
+// of the slice of slices or structs will be set as a row starting from the
+// top left cell of the range. For example, fill the invoice template:
+//
+//    type Item struct {
+//        Name     string
+//        Quantity int
+//        Price    float64
+//    }
+//    f, err := excelize.OpenFile("Invoice Template.xlsx")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    err = f.ExecuteTemplate(map[string]interface{}{
+//        "Customer": "ACME",
+//        "Date":     time.Now(),
+//        "Items":    []Item{{"Apple", 3, 1.5}, {"Orange", 2, 2.5}},
+//    })
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    err = f.SaveAs("Invoice.xlsx")
+//
+func (f *File) ExecuteTemplate(data interface{}) error {
+	root := reflect.ValueOf(data)
+	for _, sheet := range f.GetSheetList() {
+		if strings.HasPrefix(f.sheetMap[sheet], "xl/chartsheets") {
+			continue
+		}
+		if err := f.executeSheetTemplate(sheet, root); err != nil {
+			return err
+		}
+	}
+	return f.executeDefinedNamesTemplate(root)
+}
+
+// executeSheetTemplate provides a function to replace the placeholders and
+// expand the rows of the slices in the worksheet by given worksheet name and
+// data.
+func (f *File) executeSheetTemplate(sheet string, root reflect.Value) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for idx := 0; idx < len(ws.SheetData.Row); {
+		row := ws.SheetData.Row[idx].R
+		cells, err := f.templateCells(sheet, ws.SheetData.Row[idx])
+		if err != nil {
+			return err
+		}
+		next := row + 1
+		slicePath, slice, err := templateRowSlice(root, cells)
+		if err != nil {
+			return err
+		}
+		if slicePath == nil {
+			if err = f.setTemplateCells(sheet, cells, nil, root, root); err != nil {
+				return err
+			}
+		} else if next, err = f.expandTemplateRow(sheet, row, cells, slicePath, root, slice); err != nil {
+			return err
+		}
+		for idx < len(ws.SheetData.Row) && ws.SheetData.Row[idx].R < next {
+			idx++
+		}
+	}
+	return err
+}
+
+// templateCells provides a function to get the cells with the placeholders
+// by given worksheet name and row.
+func (f *File) templateCells(sheet string, row xlsxRow) ([]templateCell, error) {
+	var cells []templateCell
+	for _, c := range row.C {
+		if c.F != nil || (c.T != "s" && c.T != "inlineStr" && c.T != "str") {
+			continue
+		}
+		text, err := f.GetCellValue(sheet, c.R)
+		if err != nil {
+			return cells, err
+		}
+		matches := templatePlaceholder.FindAllStringSubmatch(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		cell := templateCell{cell: c.R, text: text}
+		for _, match := range matches {
+			cell.paths = append(cell.paths, strings.Split(match[1], "."))
+		}
+		cells = append(cells, cell)
+	}
+	return cells, nil
+}
+
+// templateRowSlice provides a function to find the slice referenced by the
+// placeholders of the row by given data and the cells of the row. The path
+// to the slice will be returned, which is nil if there is no slice
+// referenced by the row.
+func templateRowSlice(root reflect.Value, cells []templateCell) ([]string, reflect.Value, error) {
+	var (
+		slicePath []string
+		slice     reflect.Value
+	)
+	for _, cell := range cells {
+		for _, path := range cell.paths {
+			v := root
+			for i := 0; i <= len(path); i++ {
+				if v = templateIndirect(v); isRowValue(v) {
+					if slicePath != nil && strings.Join(slicePath, ".") != strings.Join(path[:i], ".") {
+						return nil, slice, fmt.Errorf("template row of cell %s references multiple slices", cell.cell)
+					}
+					slicePath, slice = path[:i], v
+					break
+				}
+				if i == len(path) {
+					break
+				}
+				var err error
+				if v, err = templateField(v, path[i]); err != nil {
+					return nil, slice, err
+				}
+			}
+		}
+	}
+	return slicePath, slice, nil
+}
+
+// expandTemplateRow provides a function to expand the template row for each
+// element of the slice, and returns the row number next to the expanded
+// rows.
+func (f *File) expandTemplateRow(sheet string, row int, cells []templateCell, slicePath []string, root, slice reflect.Value) (int, error) {
+	n := slice.Len()
+	if n == 0 {
+		if err := f.RemoveRow(sheet, row); err != nil {
+			return row, err
+		}
+		return row, f.adjustTemplateFormulas(sheet, row, n)
+	}
+	for i := 1; i < n; i++ {
+		if err := f.DuplicateRowTo(sheet, row, row+i); err != nil {
+			return row, err
+		}
+	}
+	if err := f.adjustTemplateFormulas(sheet, row, n); err != nil {
+		return row, err
+	}
+	for i := 0; i < n; i++ {
+		rowCells := make([]templateCell, len(cells))
+		for j, cell := range cells {
+			col, _, _ := CellNameToCoordinates(cell.cell)
+			rowCells[j] = cell
+			rowCells[j].cell, _ = CoordinatesToCellName(col, row+i)
+		}
+		if err := f.setTemplateCells(sheet, rowCells, slicePath, root, slice.Index(i)); err != nil {
+			return row, err
+		}
+	}
+	return row + n, nil
+}
+
+// setTemplateCells provides a function to replace the placeholders of the
+// cells by given worksheet name, cells, the path to the slice, the data and
+// the element of the slice.
+func (f *File) setTemplateCells(sheet string, cells []templateCell, slicePath []string, root, elem reflect.Value) error {
+	prefix := strings.Join(slicePath, ".")
+	for _, cell := range cells {
+		values := make([]interface{}, len(cell.paths))
+		for i, path := range cell.paths {
+			v, p := root, path
+			if slicePath != nil && len(path) >= len(slicePath) && strings.Join(path[:len(slicePath)], ".") == prefix {
+				v, p = elem, path[len(slicePath):]
+			}
+			var err error
+			for _, name := range p {
+				if v, err = templateField(v, name); err != nil {
+					return err
+				}
+			}
+			if v = templateIndirect(v); v.IsValid() {
+				values[i] = v.Interface()
+			}
+		}
+		if loc := templatePlaceholder.FindStringIndex(cell.text); len(values) == 1 && loc[0] == 0 && loc[1] == len(cell.text) {
+			if err := f.SetCellValue(sheet, cell.cell, values[0]); err != nil {
+				return err
+			}
+			continue
+		}
+		var i int
+		text := templatePlaceholder.ReplaceAllStringFunc(cell.text, func(string) string {
+			value := values[i]
+			i++
+			if value == nil {
+				return ""
+			}
+			return fmt.Sprint(value)
+		})
+		if err := f.SetCellStr(sheet, cell.cell, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateIndirect provides a function to dereference the pointers and the
+// interfaces of the value, the invalid value will be returned for the nil.
+func templateIndirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// templateField provides a function to get the field of the struct or the
+// value of the map by given value and the name, the invalid value will be
+// returned for the nil value or the missing map key.
+func templateField(v reflect.Value, name string) (reflect.Value, error) {
+	if v = templateIndirect(v); !v.IsValid() {
+		return v, nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if field, ok := v.Type().FieldByName(name); ok && field.PkgPath == "" {
+			return v.FieldByName(name), nil
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			return v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("template field %s is not exist", name)
+}
+
+// adjustTemplateFormulas provides a function to adjust the references of the
+// formulas in all the worksheets and the defined names after the row of the
+// given worksheet has been expanded to the given number of rows.
+func (f *File) adjustTemplateFormulas(sheet string, row, n int) error {
+	for _, name := range f.GetSheetList() {
+		if strings.HasPrefix(f.sheetMap[name], "xl/chartsheets") {
+			continue
+		}
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			return err
+		}
+		local := strings.EqualFold(name, sheet)
+		ws.Lock()
+		for r := range ws.SheetData.Row {
+			offset := -1
+			if cur := ws.SheetData.Row[r].R; local && cur >= row && cur < row+n {
+				offset = cur - row
+			}
+			for c := range ws.SheetData.Row[r].C {
+				if cell := &ws.SheetData.Row[r].C[c]; cell.F != nil && cell.F.Content != "" {
+					cell.F.Content = adjustTemplateFormula(cell.F.Content, sheet, local, row, n, offset)
+				}
+			}
+		}
+		ws.Unlock()
+	}
+	if wb := f.workbookReader(); wb.DefinedNames != nil {
+		for idx := range wb.DefinedNames.DefinedName {
+			dn := &wb.DefinedNames.DefinedName[idx]
+			dn.Data = adjustTemplateFormula(dn.Data, sheet, false, row, n, -1)
+		}
+	}
+	return nil
+}
+
+// adjustTemplateFormula provides a function to adjust the references of the
+// formula by given formula, the worksheet name of the expanded row, if the
+// formula is in the same worksheet, the expanded row, the number of the
+// expanded rows and the offset of the formula cell in the expanded rows,
+// which is -1 if the formula cell is out of the expanded rows. The
+// references without the worksheet name will only be changed in the same
+// worksheet, and the references inside the string literals and the
+// references to the other worksheets will not be changed.
+func adjustTemplateFormula(formula, sheet string, local bool, row, n, offset int) string {
+	adjust := func(col, ref string, first int, isLast bool) string {
+		r, _ := strconv.Atoi(ref)
+		switch {
+		case offset >= 0 && r == row && !strings.HasSuffix(col, "$"):
+			r += offset
+		case r > row, isLast && r == row && first <= row && offset < 0:
+			r += n - 1
+		}
+		return col + strconv.Itoa(r)
+	}
+	return replaceCellReferences(formula, func(name string, cells [][]string) (string, bool) {
+		if name == "" && !local || name != "" && !strings.EqualFold(name, sheet) {
+			return "", false
+		}
+		first, _ := strconv.Atoi(cells[0][1])
+		refs := make([]string, len(cells))
+		for i, cell := range cells {
+			refs[i] = adjust(cell[0], cell[1], first, i > 0)
+		}
+		return strings.Join(refs, ":"), true
+	})
+}
+
+// executeDefinedNamesTemplate provides a function to fill the defined names
+// which have the same names as the fields or the map keys by given data.
+func (f *File) executeDefinedNamesTemplate(root reflect.Value) error {
+	for _, dn := range f.GetDefinedName() {
+		v, err := templateField(root, dn.Name)
+		if v = templateIndirect(v); err != nil || !v.IsValid() {
+			continue
+		}
+		idx := strings.LastIndex(dn.RefersTo, "!")
+		if idx == -1 {
+			continue
+		}
+		sheet := strings.Replace(strings.Trim(dn.RefersTo[:idx], "'"), "''", "'", -1)
+		rng := strings.Split(strings.Replace(strings.TrimPrefix(dn.RefersTo[idx+1:], "="), "$", "", -1), ":")
+		if len(rng) == 1 {
+			rng = append(rng, rng[0])
+		}
+		coordinates, err := areaRangeToCoordinates(rng[0], rng[len(rng)-1])
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if err = f.setDefinedNameTemplate(sheet, coordinates, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDefinedNameTemplate provides a function to set the values of the range
+// of the defined name by given worksheet name, the coordinates of the range
+// and the value.
+func (f *File) setDefinedNameTemplate(sheet string, coordinates []int, v reflect.Value) error {
+	col, row := coordinates[0], coordinates[1]
+	set := func(c, r int, value reflect.Value) error {
+		cell, err := CoordinatesToCellName(c, r)
+		if err != nil {
+			return err
+		}
+		var val interface{}
+		if value = templateIndirect(value); value.IsValid() {
+			val = value.Interface()
+		}
+		return f.SetCellValue(sheet, cell, val)
+	}
+	if !isRowValue(v) {
+		return set(col, row, v)
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := templateIndirect(v.Index(i))
+		switch {
+		case isRowValue(elem):
+			for j := 0; j < elem.Len(); j++ {
+				if err := set(col+j, row+i, elem.Index(j)); err != nil {
+					return err
+				}
+			}
+		case elem.Kind() == reflect.Struct && elem.Type() != timeType:
+			for j, field := range parseStructFields(elem.Type(), nil) {
+				value, ok := structFieldByIndex(elem, field.index)
+				if !ok {
+					value = reflect.Value{}
+				}
+				if err := set(col+j, row+i, value); err != nil {
+					return err
+				}
+			}
+		case coordinates[0] == coordinates[2]:
+			if err := set(col, row+i, elem); err != nil {
+				return err
+			}
+		default:
+			if err := set(col+i, row, elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteTemplate(t *testing.T) {
+	type Item struct {
+		Name     string
+		Quantity int
+		Price    float64
+	}
+	type Invoice struct {
+		Customer *string
+		Date     time.Time
+		Items    []Item
+		Empty    []*Item
+		Notes    []string
+		Matrix   [][]int
+		Rows     []Item
+		Total    interface{}
+		note     string
+	}
+	f := NewFile()
+	for cell, value := range map[string]string{
+		"A1": "Invoice for {{ .Customer }} on {{.Date}}", "B1": "{{.Date}}", "C1": "{{.Total}}",
+		"A2": "Name", "A3": "{{.Items.Name}}", "B3": "{{.Items.Quantity}}", "C3": "{{.Items.Price}}",
+		"A4": "Total", "A6": "{{.Empty.Name}}",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, formula := range map[string]string{
+		"D3": "B3*C3", "D4": "SUM(D2:D3)", "D5": "D4*2+LOG10(100)&\"D3\"", "D7": "$D$3+D7+Sheet2!D4",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "E3", "F3"))
+	for name, ref := range map[string]string{
+		"Notes": "Sheet1!$H$1:$H$3", "Matrix": "Sheet1!$J$1:$K$2", "Rows": "Sheet1!$M$1", "Total": "Sheet1!$P$1", "Customer": "Sheet1!$Q$1:$R$1",
+	} {
+		assert.NoError(t, f.SetDefinedName(&DefinedName{Name: name, RefersTo: ref}))
+	}
+	customer := "ACME"
+	date := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.ExecuteTemplate(&Invoice{
+		Customer: &customer, Date: date,
+		Items:  []Item{{"Apple", 3, 1.5}, {"Orange", 2, 2.5}, {"Pear", 1, 4}},
+		Notes:  []string{"a", "b"},
+		Matrix: [][]int{{1, 2}, {3, 4}},
+		Rows:   []Item{{"Lemon", 5, 0.5}},
+		Total:  12.5,
+		note:   "unexported",
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestExecuteTemplate.xlsx")))
+
+	for cell, expected := range map[string]string{
+		"A1": "Invoice for ACME on " + date.String(), "B1": "5/1/21 12:00", "C1": "12.5",
+		"A3": "Apple", "B3": "3", "C3": "1.5", "A4": "Orange", "B4": "2", "C4": "2.5", "A5": "Pear",
+		"A6": "Total", "H1": "a", "H2": "b", "H3": "", "J1": "1", "K2": "4",
+		"M1": "Lemon", "N1": "5", "O1": "0.5", "P1": "12.5", "Q1": "ACME",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{
+		"D3": "B3*C3", "D4": "B4*C4", "D5": "B5*C5", "D6": "SUM(D2:D5)", "D7": "D6*2+LOG10(100)&\"D3\"", "D8": "$D$3+D8+Sheet2!D4",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test the styles and the merged cells are cloned for each element.
+	for _, cell := range []string{"A4", "A5"} {
+		s, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, s)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 3)
+
+	// Test execute template with the map.
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "{{.Items}}"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "{{.Title}}"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "{{.Missing}}"))
+	assert.NoError(t, f.ExecuteTemplate(map[string]interface{}{"Items": []int{1, 2}, "Title": nil}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "", ""}, {"2", "", ""}}, rows)
+
+	// Test execute template with the invalid placeholders.
+	for value, field := range map[string]string{"{{.Unknown}}": "Unknown", "{{.Items.Unknown}}": "Unknown", "{{.Items.Name.Value}}": "Value", "{{.note}}": "note"} {
+		f = NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", value))
+		assert.EqualError(t, f.ExecuteTemplate(Invoice{Items: []Item{{}}}), "template field "+field+" is not exist", value)
+	}
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"{{.Items.Name}}", "{{.Rows.Name}}"}))
+	assert.EqualError(t, f.ExecuteTemplate(Invoice{}), "template row of cell B1 references multiple slices")
+	// Test execute template with the invalid defined name reference.
+	f = NewFile()
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$A$0"}))
+	assert.EqualError(t, f.ExecuteTemplate(Invoice{Total: 1}), `cannot convert cell "A0" to coordinates: invalid cell name "A0"`)
+	// Test execute template with the unsupported charset worksheet.
+	f = NewFile()
+	f.Sheet = map[string]*xlsxWorksheet{}
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	assert.EqualError(t, f.ExecuteTemplate(Invoice{}), "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAdjustTemplateFormula(t *testing.T) {
+	for _, c := range []struct {
+		formula        string
+		row, n, offset int
+		expected       string
+	}{
+		{"A5+A6", 5, 3, 2, "A7+A8"},
+		{"SUM(A1:A5)+A5", 5, 3, -1, "SUM(A1:A7)+A5"},
+		{"SUM(A5:A5)", 5, 3, -1, "SUM(A5:A7)"},
+		{"SUM(A1:A5)+A6", 5, 0, -1, "SUM(A1:A4)+A5"},
+		{"A$5+$A5+'S'!A6+\"A6\"+LOG10(A6)+Q1Sales", 5, 2, 1, "A$5+$A6+'S'!A6+\"A6\"+LOG10(A7)+Q1Sales"},
+		{"Sheet1!A6+sheet1!$A$6:$A$9+'Sheet1'!A5", 5, 3, -1, "Sheet1!A8+sheet1!$A$8:$A$11+'Sheet1'!A5"},
+	} {
+		assert.Equal(t, c.expected, adjustTemplateFormula(c.formula, "Sheet1", true, c.row, c.n, c.offset), c.formula)
+	}
+	// Test adjust the formula in the other worksheet or the defined name.
+	assert.Equal(t, "A6+Sheet1!A8+'It''s'!A6+Sheet2!A6", adjustTemplateFormula("A6+Sheet1!A6+'It''s'!A6+Sheet2!A6", "Sheet1", false, 5, 3, -1))
+	assert.Equal(t, "'It''s'!$B$8", adjustTemplateFormula("'It''s'!$B$6", "It's", false, 5, 3, -1))
+}
+
+func TestExecuteTemplateAdjustReferences(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"{{.Items}}"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "Total"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C4", "Sheet1!B4*2"))
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!B4+SUM(Sheet1!A2:A3)"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$B$4"}))
+	assert.NoError(t, f.ExecuteTemplate(map[string]interface{}{"Items": []int{1, 2, 3}, "Total": 6}))
+	for cell, expected := range map[string]string{"A3": "1", "A4": "2", "A5": "3", "A6": "Total", "B6": "6"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "C6")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!B6*2", formula)
+	formula, err = f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!B6+SUM(Sheet1!A2:A5)", formula)
+	assert.Equal(t, "Sheet1!$B$6", f.GetDefinedName()[0].RefersTo)
+
+	// Test extend the range which only contains the template row.
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "{{.Items}}"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B4", "SUM(B2:B2)"))
+	assert.NoError(t, f.ExecuteTemplate(map[string]interface{}{"Items": []int{1, 2, 3}}))
+	formula, err = f.GetCellFormula("Sheet1", "B6")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2:B4)", formula)
+	result, err := f.CalcCellValue("Sheet1", "B6")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", result)
+}
@@ -0,0 +1,284 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// textBoxAlignTypes defined the horizontal alignment types of the
+// paragraph in the text box.
+var textBoxAlignTypes = map[string]string{
+	"left":        "l",
+	"center":      "ctr",
+	"right":       "r",
+	"justify":     "just",
+	"distributed": "dist",
+}
+
+// textBoxVerticalAlignTypes defined the vertical alignment types of the text
+// in the text box.
+var textBoxVerticalAlignTypes = map[string]string{
+	"top":    "t",
+	"center": "ctr",
+	"bottom": "b",
+}
+
+// textBoxDirectionTypes defined the text direction types of the text box.
+var textBoxDirectionTypes = map[string]bool{
+	"horz":           true,
+	"vert":           true,
+	"vert270":        true,
+	"wordArtVert":    true,
+	"eaVert":         true,
+	"mongolianVert":  true,
+	"wordArtVertRtl": true,
+}
+
+// parseFormatTextBoxSet provides a function to parse the format settings of
+// the text box with default value.
+func parseFormatTextBoxSet(formatSet string) (*formatTextBox, error) {
+	format := formatTextBox{
+		Width:    160,
+		Height:   80,
+		WrapText: true,
+		Format: formatPicture{
+			FPrintsWithSheet: true,
+			XScale:           1.0,
+			YScale:           1.0,
+		},
+	}
+	if err := json.Unmarshal([]byte(formatSet), &format); err != nil {
+		return &format, err
+	}
+	if format.TextDirection != "" && !textBoxDirectionTypes[format.TextDirection] {
+		return &format, errors.New("unsupported text direction")
+	}
+	if _, ok := textBoxVerticalAlignTypes[format.VerticalAlign]; format.VerticalAlign != "" && !ok {
+		return &format, errors.New("unsupported vertical alignment")
+	}
+	for _, p := range format.Paragraph {
+		if _, ok := textBoxAlignTypes[p.Align]; p.Align != "" && !ok {
+			return &format, errors.New("unsupported paragraph alignment")
+		}
+	}
+	for _, arrow := range []string{format.Line.HeadArrow, format.Line.TailArrow} {
+		if arrow != "" && !lineEndTypes[arrow] {
+			return &format, errors.New("unsupported line end type")
+		}
+	}
+	return &format, nil
+}
+
+// AddTextBox provides the method to add text box in a worksheet by given
+// worksheet name, cell reference and format set. The text box contains
+// multiple paragraphs, and each paragraph contains the text runs with
+// different font settings. For example, add a text box with two paragraphs
+// in Sheet1:
+//
+//    err := f.AddTextBox("Sheet1", "B2", `{
+//        "name": "Notes",
+//        "width": 240,
+//        "height": 100,
+//        "color": {"line": "#4286F4", "fill": "#EEF3FB"},
+//        "margin": {"left": 8, "right": 8, "top": 4, "bottom": 4},
+//        "vertical_align": "center",
+//        "paragraph": [
+//        {
+//            "align": "center",
+//            "runs": [
+//            {
+//                "text": "Quarterly ",
+//                "font": {"bold": true, "size": 14, "color": "#2980B9"}
+//            },
+//            {
+//                "text": "Report",
+//                "font": {"italic": true, "size": 14}
+//            }]
+//        },
+//        {
+//            "runs": [{"text": "Prepared by the finance team."}]
+//        }]
+//    }`)
+//
+// The "width" and "height" specify the size of the text box in pixels, the
+// default size is 160 x 80. The "margin" specifies the internal margins
+// between the text and the border of the text box in pixels. The "autofit"
+// specifies resize the text box to fit the text, and the "wrap_text"
+// specifies wrap the text in the text box, the default value is true. The
+// "rotation" specifies the rotation angle of the text box in degrees.
+//
+// The "vertical_align" specifies the vertical alignment of the text, the
+// following shows the type of vertical alignment supported by excelize:
+//
+//    top
+//    center
+//    bottom
+//
+// The "align" specifies the horizontal alignment of the paragraph, the
+// following shows the type of paragraph alignment supported by excelize:
+//
+//    left
+//    center
+//    right
+//    justify
+//    distributed
+//
+// The "text_direction" specifies the direction of the text, the following
+// shows the type of text direction supported by excelize:
+//
+//    horz           (Horizontal text)
+//    vert           (Rotate all text 90 degrees)
+//    vert270        (Rotate all text 270 degrees)
+//    wordArtVert    (Stacked text)
+//    eaVert         (East Asian vertical text)
+//    mongolianVert  (Mongolian vertical text)
+//    wordArtVertRtl (Stacked text from right to left)
+//
+func (f *File) AddTextBox(sheet, cell, format string) error {
+	formatSet, err := parseFormatTextBoxSet(format)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	if err = f.addDrawingTextBox(sheet, drawingXML, cell, formatSet); err != nil {
+		return err
+	}
+	f.addContentTypePart(drawingID, "drawings")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// addDrawingTextBox provides a function to add text box shape by given
+// worksheet name, drawingXML, cell reference and format sets.
+func (f *File) addDrawingTextBox(sheet, drawingXML, cell string, formatSet *formatTextBox) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	width := int(float64(formatSet.Width) * formatSet.Format.XScale)
+	height := int(float64(formatSet.Height) * formatSet.Format.YScale)
+	colStart, rowStart, colEnd, rowEnd, x2, y2 :=
+		f.positionObjectPixels(sheet, col-1, row-1, formatSet.Format.OffsetX, formatSet.Format.OffsetY, width, height)
+	content, cNvPrID := f.drawingParser(drawingXML)
+	shapes, err := f.getDrawingShapes(sheet, content)
+	if err != nil {
+		return err
+	}
+	for _, shape := range shapes {
+		if shape.id >= cNvPrID {
+			cNvPrID = shape.id + 1
+		}
+	}
+	name := formatSet.Name
+	if name == "" {
+		name = "TextBox " + strconv.Itoa(cNvPrID)
+	}
+	twoCellAnchor := xdrCellAnchor{
+		EditAs: formatSet.Format.Positioning,
+		From: &xlsxFrom{
+			Col: colStart, ColOff: formatSet.Format.OffsetX * EMU,
+			Row: rowStart, RowOff: formatSet.Format.OffsetY * EMU,
+		},
+		To: &xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU},
+		ClientData: &xdrClientData{
+			FLocksWithSheet:  formatSet.Format.FLocksWithSheet,
+			FPrintsWithSheet: formatSet.Format.FPrintsWithSheet,
+		},
+	}
+	x, y := f.cellAnchorToPixels(sheet, colStart, formatSet.Format.OffsetX*EMU, rowStart, formatSet.Format.OffsetY*EMU)
+	shape := xdrSp{
+		NvSpPr: &xdrNvSpPr{
+			CNvPr:   &xlsxCNvPr{ID: cNvPrID, Name: name},
+			CNvSpPr: &xdrCNvSpPr{TxBox: true},
+		},
+		SpPr: &xlsxSpPr{
+			Xfrm: xlsxXfrm{
+				Rot: int(formatSet.Rotation * 60000),
+				Off: xlsxOff{X: x * EMU, Y: y * EMU},
+				Ext: xlsxExt{Cx: width * EMU, Cy: height * EMU},
+			},
+			PrstGeom: xlsxPrstGeom{Prst: "rect"},
+			Ln:       setShapeLine(formatSet.Line),
+		},
+		Style: &xdrStyle{
+			LnRef:     setShapeRef(formatSet.Color.Line, 2),
+			FillRef:   setShapeRef(formatSet.Color.Fill, 1),
+			EffectRef: setShapeRef(formatSet.Color.Effect, 0),
+			FontRef: &aFontRef{
+				Idx:       "minor",
+				SchemeClr: &attrValString{Val: stringPtr("tx1")},
+			},
+		},
+		TxBody: &xdrTxBody{BodyPr: newTextBoxBodyPr(formatSet)},
+	}
+	for _, p := range formatSet.Paragraph {
+		paragraph := &aP{EndParaRPr: &aEndParaRPr{Lang: "en-US"}}
+		if p.Align != "" {
+			paragraph.PPr = &aPPr{Algn: textBoxAlignTypes[p.Align]}
+		}
+		for _, r := range p.Runs {
+			paragraph.R = append(paragraph.R, newShapeTextRun(r.Text, r.Font))
+		}
+		shape.TxBody.P = append(shape.TxBody.P, paragraph)
+	}
+	if len(shape.TxBody.P) == 0 {
+		shape.TxBody.P = append(shape.TxBody.P, &aP{EndParaRPr: &aEndParaRPr{Lang: "en-US"}})
+	}
+	twoCellAnchor.Sp = &shape
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.Drawings[drawingXML] = content
+	return err
+}
+
+// newTextBoxBodyPr provides a function to create the body properties of the
+// text box by given format settings.
+func newTextBoxBodyPr(formatSet *formatTextBox) *aBodyPr {
+	bodyPr := &aBodyPr{
+		VertOverflow: "clip",
+		HorzOverflow: "clip",
+		Wrap:         "none",
+		Anchor:       "t",
+		Vert:         formatSet.TextDirection,
+	}
+	if formatSet.WrapText {
+		bodyPr.Wrap = "square"
+	}
+	if formatSet.VerticalAlign != "" {
+		bodyPr.Anchor = textBoxVerticalAlignTypes[formatSet.VerticalAlign]
+	}
+	if formatSet.AutoFit {
+		bodyPr.VertOverflow, bodyPr.HorzOverflow = "", ""
+		bodyPr.SpAutoFit = &struct{}{}
+	}
+	if formatSet.Margin.Left != nil {
+		bodyPr.LIns = intPtr(*formatSet.Margin.Left * EMU)
+	}
+	if formatSet.Margin.Right != nil {
+		bodyPr.RIns = intPtr(*formatSet.Margin.Right * EMU)
+	}
+	if formatSet.Margin.Top != nil {
+		bodyPr.TIns = intPtr(*formatSet.Margin.Top * EMU)
+	}
+	if formatSet.Margin.Bottom != nil {
+		bodyPr.BIns = intPtr(*formatSet.Margin.Bottom * EMU)
+	}
+	return bodyPr
+}
@@ -0,0 +1,83 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTextBox(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddTextBox("Sheet1", "B2", `{
+		"name": "Notes",
+		"width": 240,
+		"height": 100,
+		"color": {"line": "#4286F4", "fill": "#EEF3FB"},
+		"margin": {"left": 8, "right": 8, "top": 0, "bottom": 4},
+		"vertical_align": "center",
+		"paragraph": [
+		{
+			"align": "center",
+			"runs": [
+			{
+				"text": "Quarterly ",
+				"font": {"bold": true, "size": 14, "color": "#2980B9"}
+			},
+			{
+				"text": "Report",
+				"font": {"italic": true, "strike": true, "size": 14}
+			}]
+		},
+		{
+			"runs": [{"text": "Prepared by the finance team."}]
+		}]
+	}`))
+	assert.NoError(t, f.AddTextBox("Sheet1", "G2", `{"autofit":true,"wrap_text":false,"text_direction":"vert270","rotation":30,"paragraph":[{"runs":[{"text":"Vertical"}]}]}`))
+	assert.NoError(t, f.AddTextBox("Sheet1", "G12", `{}`))
+	assert.NoError(t, f.AddShape("Sheet1", "K2", `{"type":"rect"}`))
+
+	check := func(f *File) {
+		shapes, err := f.GetShapes("Sheet1")
+		assert.NoError(t, err)
+		if !assert.Len(t, shapes, 4) {
+			return
+		}
+		assert.Equal(t, Shape{Cell: "B2", Type: "rect", Name: "Notes", Width: 240, Height: 100, Text: "Quarterly Report\nPrepared by the finance team."}, shapes[0])
+		assert.Equal(t, Shape{Cell: "G2", Type: "rect", Name: "TextBox 3", Width: 160, Height: 80, Rotation: 30, Text: "Vertical"}, shapes[1])
+		assert.Equal(t, Shape{Cell: "G12", Type: "rect", Name: "TextBox 4", Width: 160, Height: 80}, shapes[2])
+		assert.Equal(t, "Shape 5", shapes[3].Name)
+	}
+	check(f)
+	drawing, ok := f.Drawings["xl/drawings/drawing1.xml"]
+	assert.True(t, ok)
+	bodyPr := drawing.TwoCellAnchor[0].Sp.TxBody.BodyPr
+	assert.Equal(t, intPtr(8*EMU), bodyPr.LIns)
+	assert.Equal(t, intPtr(0), bodyPr.TIns)
+	assert.Equal(t, "ctr", bodyPr.Anchor)
+	assert.Equal(t, "square", bodyPr.Wrap)
+	assert.Len(t, drawing.TwoCellAnchor[0].Sp.TxBody.P[0].R, 2)
+	assert.Equal(t, "ctr", drawing.TwoCellAnchor[0].Sp.TxBody.P[0].PPr.Algn)
+	assert.Equal(t, "sngStrike", drawing.TwoCellAnchor[0].Sp.TxBody.P[0].R[1].RPr.Strike)
+	bodyPr = drawing.TwoCellAnchor[1].Sp.TxBody.BodyPr
+	assert.NotNil(t, bodyPr.SpAutoFit)
+	assert.Equal(t, "vert270", bodyPr.Vert)
+	assert.Equal(t, "none", bodyPr.Wrap)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTextBox.xlsx")))
+	assert.Contains(t, string(f.XLSX["xl/drawings/drawing1.xml"]), `<a:spAutoFit></a:spAutoFit>`)
+
+	f, err := OpenFile(filepath.Join("test", "TestAddTextBox.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+
+	// Test add text box with unsupported settings.
+	assert.EqualError(t, f.AddTextBox("Sheet1", "A1", `{"text_direction":"unknown"}`), "unsupported text direction")
+	assert.EqualError(t, f.AddTextBox("Sheet1", "A1", `{"vertical_align":"unknown"}`), "unsupported vertical alignment")
+	assert.EqualError(t, f.AddTextBox("Sheet1", "A1", `{"paragraph":[{"align":"unknown"}]}`), "unsupported paragraph alignment")
+	assert.EqualError(t, f.AddTextBox("Sheet1", "A1", `{"line":{"head_arrow":"unknown"}}`), "unsupported line end type")
+	assert.EqualError(t, f.AddTextBox("Sheet1", "A1", ""), "unexpected end of JSON input")
+	// Test add text box with invalid cell coordinates.
+	assert.EqualError(t, f.AddTextBox("Sheet1", "A", `{}`), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test add text box on not exist worksheet.
+	assert.EqualError(t, f.AddTextBox("SheetN", "A1", `{}`), "sheet SheetN is not exist")
+}
@@ -0,0 +1,390 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of the workbook checked by the Validate function.
+const (
+	MaxSheetNameLength   = 31
+	MaxDefinedNameLength = 255
+	MaxFormulaLength     = 8192
+	MaxCellStyles        = 64000
+	MaxFonts             = 1024
+)
+
+// definedNameExp defined the regular expression to check the syntax of the
+// defined name, and definedNameRefExp matches the names which conflict with
+// the A1 or R1C1 cell references.
+var (
+	definedNameExp    = regexp.MustCompile(`^[\pL_\\][\pL\pN_.\\?]*$`)
+	definedNameRefExp = regexp.MustCompile(`(?i)^([a-z]{1,3}\d+|r\d*c\d*|r|c)$`)
+)
+
+// ValidationWarning directly maps the issue of the workbook found by the
+// Validate function. The Part is the path of the package part which contains
+// the issue, the Sheet and Cell are specified if the issue is related to
+// them, and the Message describes the issue.
+type ValidationWarning struct {
+	Part    string
+	Sheet   string
+	Cell    string
+	Message string
+}
+
+// String implements the fmt.Stringer interface to get the description of the
+// validation warning.
+func (w ValidationWarning) String() string {
+	location := w.Part
+	if w.Sheet != "" {
+		location = w.Sheet
+		if w.Cell != "" {
+			location += "!" + w.Cell
+		}
+	}
+	return location + ": " + w.Message
+}
+
+// Validate provides a function to check the workbook in memory against the
+// constraints of the Office Open XML and the limits of the spreadsheet
+// application before saving it, and returns the warnings of the issues which
+// may cause the "unreadable content" error when opening the saved workbook.
+// The following issues will be checked:
+//
+//    Sheet names: empty, longer than 31 characters, with the characters
+//    : \ / ? * [ ], start or end with the apostrophe, the reserved name
+//    "History" and the duplicate names which are case-insensitive.
+//
+//    Defined names: invalid syntax, longer than 255 characters, conflict with
+//    the cell references, duplicate names in the same scope and invalid
+//    scopes.
+//
+//    Styles: more than 64000 cell styles or 1024 fonts, mismatched counts of
+//    the style collections and the cell styles which reference the not exists
+//    number formats, fonts, fills and borders.
+//
+//    Cells: invalid style indexes, shared string indexes, formulas longer
+//    than 8192 characters, texts longer than 32767 characters and overlapped
+//    merged cells.
+//
+//    References: the relationships of the workbook and worksheets which
+//    target the not exists parts, and the relationship IDs of the sheets,
+//    drawings, pictures, hyperlinks and tables which are not exist.
+//
+// For example, print the warnings before saving the workbook:
+//
+//    warnings, err := f.Validate()
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    for _, warning := range warnings {
+//        fmt.Println(warning)
+//    }
+//
+func (f *File) Validate() ([]ValidationWarning, error) {
+	var warnings []ValidationWarning
+	warn := func(part, sheet, cell, format string, args ...interface{}) {
+		warnings = append(warnings, ValidationWarning{Part: part, Sheet: sheet, Cell: cell, Message: fmt.Sprintf(format, args...)})
+	}
+	wbPath, wb := f.getWorkbookPath(), f.workbookReader()
+	wbRels := f.validateRels(f.getWorkbookRelsPath(), path.Dir(wbPath), warn)
+	names := map[string]bool{}
+	for _, sheet := range wb.Sheets.Sheet {
+		name := sheet.Name
+		switch {
+		case name == "":
+			warn(wbPath, "", "", "the sheet name is empty")
+		case utf8.RuneCountInString(name) > MaxSheetNameLength:
+			warn(wbPath, name, "", "the sheet name exceeds %d characters", MaxSheetNameLength)
+		}
+		if strings.ContainsAny(name, ":\\/?*[]") {
+			warn(wbPath, name, "", "the sheet name contains invalid characters")
+		}
+		if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+			warn(wbPath, name, "", "the sheet name starts or ends with an apostrophe")
+		}
+		if strings.EqualFold(name, "History") {
+			warn(wbPath, name, "", "the sheet name is reserved")
+		}
+		if names[strings.ToLower(name)] {
+			warn(wbPath, name, "", "the sheet name is duplicated")
+		}
+		names[strings.ToLower(name)] = true
+		if _, ok := wbRels[sheet.ID]; !ok {
+			warn(wbPath, name, "", "the relationship %s of the sheet is not exist", sheet.ID)
+		}
+	}
+	if wb.DefinedNames != nil {
+		scopes := map[string]bool{}
+		for _, definedName := range wb.DefinedNames.DefinedName {
+			name, scope := definedName.Name, -1
+			if definedName.LocalSheetID != nil {
+				scope = *definedName.LocalSheetID
+			}
+			if scope < -1 || scope >= len(wb.Sheets.Sheet) {
+				warn(wbPath, "", "", "the scope %d of the defined name %s is not exist", scope, name)
+			}
+			switch {
+			case utf8.RuneCountInString(name) > MaxDefinedNameLength:
+				warn(wbPath, "", "", "the defined name %s exceeds %d characters", name, MaxDefinedNameLength)
+			case !definedNameExp.MatchString(name) || definedNameRefExp.MatchString(name):
+				if !strings.HasPrefix(name, "_xlnm.") {
+					warn(wbPath, "", "", "the defined name %s is invalid", name)
+				}
+			}
+			key := fmt.Sprintf("%d!%s", scope, strings.ToLower(name))
+			if scopes[key] {
+				warn(wbPath, "", "", "the defined name %s is duplicated in the same scope", name)
+			}
+			scopes[key] = true
+		}
+	}
+	cellStyles := f.validateStyles(warn)
+	sst := f.sharedStringsReader()
+	for _, sst := range sst.SI {
+		if utf8.RuneCountInString(sst.String()) > TotalCellChars {
+			warn("xl/sharedStrings.xml", "", "", "the shared string exceeds %d characters", TotalCellChars)
+		}
+	}
+	for _, sheet := range wb.Sheets.Sheet {
+		if part, ok := f.sheetMap[trimSheetName(sheet.Name)]; !ok || strings.HasPrefix(part, "xl/chartsheets") {
+			continue
+		}
+		if err := f.validateSheet(sheet.Name, cellStyles, len(sst.SI), warn); err != nil {
+			return warnings, err
+		}
+	}
+	return warnings, nil
+}
+
+// validateRels provides a function to check if the targets of the internal
+// relationships are exist by given path of the relationships part, the
+// directory of the source part and the warning function, and returns the
+// relationships by ID.
+func (f *File) validateRels(relsPath, dir string, warn func(part, sheet, cell, format string, args ...interface{})) map[string]xlsxRelationship {
+	rels := map[string]xlsxRelationship{}
+	relationships := f.relsReader(relsPath)
+	if relationships == nil {
+		return rels
+	}
+	for _, rel := range relationships.Relationships {
+		rels[rel.ID] = rel
+		if rel.TargetMode == "External" {
+			continue
+		}
+		target := path.Join(dir, rel.Target)
+		if strings.HasPrefix(rel.Target, "/") {
+			target = strings.TrimPrefix(rel.Target, "/")
+		}
+		if !f.hasPart(target) {
+			warn(relsPath, "", "", "the target %s of the relationship %s is not exist", target, rel.ID)
+		}
+	}
+	return rels
+}
+
+// hasPart provides a function to check if the part of the given path exists
+// in the package or the in-memory parts which have not been saved yet.
+func (f *File) hasPart(name string) bool {
+	if _, ok := f.XLSX[name]; ok {
+		return true
+	}
+	if _, ok := f.Sheet[name]; ok {
+		return true
+	}
+	if _, ok := f.Drawings[name]; ok {
+		return true
+	}
+	if _, ok := f.Comments[name]; ok {
+		return true
+	}
+	if _, ok := f.VMLDrawing[name]; ok {
+		return true
+	}
+	if _, ok := f.DecodeVMLDrawing[name]; ok {
+		return true
+	}
+	if _, ok := f.streams[name]; ok {
+		return true
+	}
+	return name == "xl/sharedStrings.xml" && f.SharedStrings != nil
+}
+
+// validateStyles provides a function to check the style sheet by given the
+// warning function, and returns the count of the cell styles.
+func (f *File) validateStyles(warn func(part, sheet, cell, format string, args ...interface{})) int {
+	s, part := f.stylesReader(), "xl/styles.xml"
+	var numFmts, fonts, fills, borders, cellStyles int
+	customNumFmts := map[int]bool{}
+	if s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			customNumFmts[numFmt.NumFmtID] = true
+		}
+		if numFmts = len(s.NumFmts.NumFmt); s.NumFmts.Count != numFmts {
+			warn(part, "", "", "the count %d of the number formats does not match %d", s.NumFmts.Count, numFmts)
+		}
+	}
+	if s.Fonts != nil {
+		if fonts = len(s.Fonts.Font); s.Fonts.Count != fonts {
+			warn(part, "", "", "the count %d of the fonts does not match %d", s.Fonts.Count, fonts)
+		}
+	}
+	if s.Fills != nil {
+		if fills = len(s.Fills.Fill); s.Fills.Count != fills {
+			warn(part, "", "", "the count %d of the fills does not match %d", s.Fills.Count, fills)
+		}
+	}
+	if s.Borders != nil {
+		if borders = len(s.Borders.Border); s.Borders.Count != borders {
+			warn(part, "", "", "the count %d of the borders does not match %d", s.Borders.Count, borders)
+		}
+	}
+	if fonts > MaxFonts {
+		warn(part, "", "", "the number of fonts %d exceeds %d", fonts, MaxFonts)
+	}
+	if s.CellXfs == nil {
+		return 0
+	}
+	if cellStyles = len(s.CellXfs.Xf); s.CellXfs.Count != cellStyles {
+		warn(part, "", "", "the count %d of the cell styles does not match %d", s.CellXfs.Count, cellStyles)
+	}
+	if cellStyles > MaxCellStyles {
+		warn(part, "", "", "the number of cell styles %d exceeds %d", cellStyles, MaxCellStyles)
+	}
+	for idx, xf := range s.CellXfs.Xf {
+		if xf.NumFmtID != nil && *xf.NumFmtID >= 164 && !customNumFmts[*xf.NumFmtID] {
+			warn(part, "", "", "the number format %d of the cell style %d is not exist", *xf.NumFmtID, idx)
+		}
+		for _, ref := range []struct {
+			name  string
+			id    *int
+			count int
+		}{
+			{"font", xf.FontID, fonts},
+			{"fill", xf.FillID, fills},
+			{"border", xf.BorderID, borders},
+		} {
+			if ref.id != nil && (*ref.id < 0 || *ref.id >= ref.count) {
+				warn(part, "", "", "the %s %d of the cell style %d is not exist", ref.name, *ref.id, idx)
+			}
+		}
+	}
+	return cellStyles
+}
+
+// validateSheet provides a function to check the cells, merged cells and the
+// relationships of the worksheet by given worksheet name, the count of the
+// cell styles, the count of the shared strings and the warning function.
+func (f *File) validateSheet(sheet string, cellStyles, sharedStrings int, warn func(part, sheet, cell, format string, args ...interface{})) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	part := f.sheetMap[trimSheetName(sheet)]
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.S < 0 || (c.S > 0 && c.S >= cellStyles) {
+				warn(part, sheet, c.R, "the cell style %d is not exist", c.S)
+			}
+			if c.F != nil && utf8.RuneCountInString(c.F.Content) > MaxFormulaLength {
+				warn(part, sheet, c.R, "the formula exceeds %d characters", MaxFormulaLength)
+			}
+			switch c.T {
+			case "s":
+				if idx, err := parseSharedStringIndex(c.V); err != nil || idx >= sharedStrings {
+					warn(part, sheet, c.R, "the shared string %s is not exist", c.V)
+				}
+			case "inlineStr":
+				if c.IS != nil && utf8.RuneCountInString(c.IS.String()) > TotalCellChars {
+					warn(part, sheet, c.R, "the text exceeds %d characters", TotalCellChars)
+				}
+			default:
+				if utf8.RuneCountInString(c.V) > TotalCellChars {
+					warn(part, sheet, c.R, "the text exceeds %d characters", TotalCellChars)
+				}
+			}
+		}
+	}
+	if ws.MergeCells != nil {
+		var rects [][]int
+		for _, mergeCell := range ws.MergeCells.Cells {
+			ref := mergeCell.Ref
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			rect, err := f.areaRefToCoordinates(ref)
+			if err != nil {
+				warn(part, sheet, mergeCell.Ref, "the merged cell is invalid")
+				continue
+			}
+			_ = sortCoordinates(rect)
+			for _, r := range rects {
+				if rect[0] <= r[2] && r[0] <= rect[2] && rect[1] <= r[3] && r[1] <= rect[3] {
+					warn(part, sheet, mergeCell.Ref, "the merged cell overlaps with other merged cells")
+					break
+				}
+			}
+			rects = append(rects, rect)
+		}
+	}
+	relsPath := path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+	rels := f.validateRels(relsPath, path.Dir(part), warn)
+	var rIDs []string
+	if ws.Drawing != nil {
+		rIDs = append(rIDs, ws.Drawing.RID)
+	}
+	if ws.LegacyDrawing != nil {
+		rIDs = append(rIDs, ws.LegacyDrawing.RID)
+	}
+	if ws.LegacyDrawingHF != nil {
+		rIDs = append(rIDs, ws.LegacyDrawingHF.RID)
+	}
+	if ws.Picture != nil {
+		rIDs = append(rIDs, ws.Picture.RID)
+	}
+	if ws.Hyperlinks != nil {
+		for _, hyperlink := range ws.Hyperlinks.Hyperlink {
+			rIDs = append(rIDs, hyperlink.RID)
+		}
+	}
+	if ws.TableParts != nil {
+		for _, tablePart := range ws.TableParts.TableParts {
+			rIDs = append(rIDs, tablePart.RID)
+		}
+	}
+	for _, rID := range rIDs {
+		if _, ok := rels[rID]; rID != "" && !ok {
+			warn(part, sheet, "", "the relationship %s is not exist", rID)
+		}
+	}
+	return nil
+}
+
+// parseSharedStringIndex provides a function to parse the index of the
+// shared string cell.
+func parseSharedStringIndex(val string) (int, error) {
+	var idx int
+	_, err := fmt.Sscanf(val, "%d", &idx)
+	if idx < 0 {
+		return idx, fmt.Errorf("invalid shared string index %d", idx)
+	}
+	return idx, err
+}
@@ -0,0 +1,78 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	warnings, err := f.Validate()
+	assert.NoError(t, err)
+	assert.Equal(t, []ValidationWarning{{Part: "xl/styles.xml", Message: "the count 5 of the cell styles does not match 6"}}, warnings)
+
+	f = NewFile()
+	warnings, err = f.Validate()
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "="+strings.Repeat("1+", MaxFormulaLength/2)+"1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "text"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "C2"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "A1", RefersTo: "Sheet1!$A$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$2"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "D1", filepath.Join("test", "images", "excel.png"), ""))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "https://github.com/xuri/excelize", "External"))
+	wb := f.workbookReader()
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet, xlsxSheet{Name: "history", SheetID: 2, ID: "rId100"}, xlsxSheet{Name: "'" + strings.Repeat("a", 31) + "[", SheetID: 3, ID: "rId4"})
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{Name: "AMOUNT", Data: "Sheet1!$A$3"}, xlsxDefinedName{Name: "Scope", LocalSheetID: intPtr(5)})
+	s := f.stylesReader()
+	s.Fonts.Count++
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xlsxXf{NumFmtID: intPtr(200), FontID: intPtr(10), FillID: intPtr(0), BorderID: intPtr(0)})
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[1].C[0].T, ws.SheetData.Row[1].C[0].V = "s", "5"
+	ws.SheetData.Row[1].C[0].S = 10
+	ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: "C2:D3"}, &xlsxMergeCell{Ref: "A"})
+	ws.TableParts = &xlsxTableParts{TableParts: []*xlsxTablePart{{RID: "rId10"}}}
+	f.Relationships["xl/worksheets/_rels/sheet1.xml.rels"].Relationships = append(f.Relationships["xl/worksheets/_rels/sheet1.xml.rels"].Relationships, xlsxRelationship{ID: "rId11", Target: "../tables/table9.xml"})
+
+	warnings, err = f.Validate()
+	assert.NoError(t, err)
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+	assert.Equal(t, []string{
+		"history: the sheet name is reserved",
+		"history: the relationship rId100 of the sheet is not exist",
+		"'aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa[: the sheet name exceeds 31 characters",
+		"'aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa[: the sheet name contains invalid characters",
+		"'aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa[: the sheet name starts or ends with an apostrophe",
+		"xl/workbook.xml: the defined name A1 is invalid",
+		"xl/workbook.xml: the defined name AMOUNT is duplicated in the same scope",
+		"xl/workbook.xml: the scope 5 of the defined name Scope is not exist",
+		"xl/styles.xml: the count 2 of the fonts does not match 1",
+		"xl/styles.xml: the count 1 of the cell styles does not match 2",
+		"xl/styles.xml: the number format 200 of the cell style 1 is not exist",
+		"xl/styles.xml: the font 10 of the cell style 1 is not exist",
+		"Sheet1!A1: the formula exceeds 8192 characters",
+		"Sheet1!A2: the cell style 10 is not exist",
+		"Sheet1!A2: the shared string 5 is not exist",
+		"Sheet1!C2:D3: the merged cell overlaps with other merged cells",
+		"Sheet1!A: the merged cell is invalid",
+		"xl/worksheets/_rels/sheet1.xml.rels: the target xl/tables/table9.xml of the relationship rId11 is not exist",
+		"Sheet1: the relationship rId10 is not exist",
+	}, messages)
+
+	// Test validate the workbook with unsupported charset worksheet.
+	f = NewFile()
+	f.Sheet = map[string]*xlsxWorksheet{}
+	f.checked = nil
+	f.XLSX["xl/worksheets/sheet1.xml"] = MacintoshCyrillicCharset
+	_, err = f.Validate()
+	assert.EqualError(t, err, "xml decode error: XML syntax error on line 1: invalid UTF-8")
+}
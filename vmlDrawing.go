@@ -22,7 +22,7 @@ type vmlDrawing struct {
 	XMLNSx      string           `xml:"xmlns:x,attr"`
 	XMLNSmv     string           `xml:"xmlns:mv,attr"`
 	Shapelayout *xlsxShapelayout `xml:"o:shapelayout"`
-	Shapetype   *xlsxShapetype   `xml:"v:shapetype"`
+	Shapetype   []xlsxShapetype  `xml:"v:shapetype"`
 	Shape       []xlsxShape      `xml:"v:shape"`
 }
 
@@ -46,7 +46,10 @@ type xlsxShape struct {
 	ID          string   `xml:"id,attr"`
 	Type        string   `xml:"type,attr"`
 	Style       string   `xml:"style,attr"`
+	Button      string   `xml:"o:button,attr,omitempty"`
+	Filled      string   `xml:"filled,attr,omitempty"`
 	Fillcolor   string   `xml:"fillcolor,attr"`
+	Stroked     string   `xml:"stroked,attr,omitempty"`
 	Insetmode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
 	Strokecolor string   `xml:"strokecolor,attr,omitempty"`
 	Val         string   `xml:",innerxml"`
@@ -116,13 +119,14 @@ type xlsxDiv struct {
 // child elements is appropriate. Relevant groups are identified for each child
 // element.
 type xClientData struct {
-	ObjectType    string `xml:"ObjectType,attr"`
-	MoveWithCells string `xml:"x:MoveWithCells,omitempty"`
-	SizeWithCells string `xml:"x:SizeWithCells,omitempty"`
-	Anchor        string `xml:"x:Anchor"`
-	AutoFill      string `xml:"x:AutoFill"`
-	Row           int    `xml:"x:Row"`
-	Column        int    `xml:"x:Column"`
+	ObjectType    string    `xml:"ObjectType,attr"`
+	MoveWithCells string    `xml:"x:MoveWithCells,omitempty"`
+	SizeWithCells string    `xml:"x:SizeWithCells,omitempty"`
+	Anchor        string    `xml:"x:Anchor"`
+	AutoFill      string    `xml:"x:AutoFill"`
+	Row           int       `xml:"x:Row"`
+	Column        int       `xml:"x:Column"`
+	Visible       *struct{} `xml:"x:Visible"`
 }
 
 // decodeVmlDrawing defines the structure used to parse the file
@@ -133,7 +137,52 @@ type decodeVmlDrawing struct {
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	Val string `xml:",innerxml"`
+	ID          string `xml:"id,attr"`
+	Type        string `xml:"type,attr"`
+	Style       string `xml:"style,attr"`
+	Button      string `xml:"urn:schemas-microsoft-com:office:office button,attr"`
+	Filled      string `xml:"filled,attr"`
+	Fillcolor   string `xml:"fillcolor,attr"`
+	Stroked     string `xml:"stroked,attr"`
+	Strokecolor string `xml:"strokecolor,attr"`
+	Val         string `xml:",innerxml"`
+}
+
+// decodeShapeVal defines the structure used to parse the inner XML of the
+// particular shape element.
+type decodeShapeVal struct {
+	Textbox    *decodeVMLTextbox    `xml:"urn:schemas-microsoft-com:vml textbox"`
+	ClientData *decodeVMLClientData `xml:"urn:schemas-microsoft-com:office:excel ClientData"`
+}
+
+// decodeVMLTextbox defines the structure used to parse the v:textbox element
+// of the shape.
+type decodeVMLTextbox struct {
+	Div struct {
+		Font []struct {
+			Val string `xml:",chardata"`
+		} `xml:"font"`
+	} `xml:"div"`
+}
+
+// decodeVMLClientData defines the structure used to parse the x:ClientData
+// element of the shape.
+type decodeVMLClientData struct {
+	ObjectType string    `xml:"ObjectType,attr"`
+	Anchor     string    `xml:"urn:schemas-microsoft-com:office:excel Anchor"`
+	FmlaMacro  string    `xml:"urn:schemas-microsoft-com:office:excel FmlaMacro"`
+	FmlaLink   string    `xml:"urn:schemas-microsoft-com:office:excel FmlaLink"`
+	FmlaRange  string    `xml:"urn:schemas-microsoft-com:office:excel FmlaRange"`
+	Checked    int       `xml:"urn:schemas-microsoft-com:office:excel Checked"`
+	Val        int       `xml:"urn:schemas-microsoft-com:office:excel Val"`
+	Min        int       `xml:"urn:schemas-microsoft-com:office:excel Min"`
+	Max        int       `xml:"urn:schemas-microsoft-com:office:excel Max"`
+	Inc        int       `xml:"urn:schemas-microsoft-com:office:excel Inc"`
+	Page       int       `xml:"urn:schemas-microsoft-com:office:excel Page"`
+	Horiz      *struct{} `xml:"urn:schemas-microsoft-com:office:excel Horiz"`
+	Row        int       `xml:"urn:schemas-microsoft-com:office:excel Row"`
+	Column     int       `xml:"urn:schemas-microsoft-com:office:excel Column"`
+	Visible    *struct{} `xml:"urn:schemas-microsoft-com:office:excel Visible"`
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
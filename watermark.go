@@ -0,0 +1,237 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseFormatWatermarkSet provides a function to parse the format settings of
+// the watermark with default value.
+func parseFormatWatermarkSet(formatSet string) (*formatWatermark, error) {
+	format := formatWatermark{
+		Font: Font{
+			Bold:   true,
+			Family: "Calibri",
+			Size:   72,
+			Color:  "#C0C0C0",
+		},
+		Scale:        1.0,
+		Transparency: 50,
+		PrintObject:  true,
+	}
+	if err := json.Unmarshal(parseFormatSet(formatSet), &format); err != nil {
+		return &format, err
+	}
+	if format.Text == "" && format.Picture == "" {
+		return &format, errors.New("the text or picture of the watermark is required")
+	}
+	if format.Transparency < 0 || format.Transparency > 100 {
+		return &format, errors.New("the transparency of the watermark must be between 0 and 100")
+	}
+	if format.Rotation == nil {
+		format.Rotation = float64Ptr(0)
+		if format.Picture == "" {
+			format.Rotation = float64Ptr(-45)
+		}
+	}
+	if format.Text != "" && format.Width == 0 && format.Height == 0 {
+		// Estimate the size of the text by the average width of the
+		// characters and the line height of the font.
+		fontSize := format.Font.Size * 4 / 3
+		format.Width = int(float64(utf8.RuneCountInString(format.Text))*fontSize*0.6) + 20
+		format.Height = int(fontSize*1.5) + 10
+	}
+	return &format, nil
+}
+
+// AddWatermark provides the method to add a semi-transparent text or picture
+// watermark behind the other drawing objects in a worksheet by given
+// worksheet name and format set. The watermark is positioned with absolute
+// anchor, so it will not be moved or resized with the cells, and it can't be
+// selected in the spreadsheet application. The "x_offset" and "y_offset"
+// specify the position of the watermark in pixels from the top-left corner
+// of the worksheet. For example, add a text watermark "DRAFT" on Sheet1 and
+// Sheet2:
+//
+//    for _, sheet := range []string{"Sheet1", "Sheet2"} {
+//        if err := f.AddWatermark(sheet, `{
+//            "text": "DRAFT",
+//            "font":
+//            {
+//                "family": "Arial",
+//                "size": 96,
+//                "color": "#FF0000"
+//            },
+//            "x_offset": 200,
+//            "y_offset": 160,
+//            "transparency": 70
+//        }`); err != nil {
+//            fmt.Println(err)
+//        }
+//    }
+//
+// Add a picture watermark scaled to 50% which will not be printed:
+//
+//    err := f.AddWatermark("Sheet1", `{
+//        "picture": "logo.png",
+//        "scale": 0.5,
+//        "print_obj": false
+//    }`)
+//
+// The following shows the format settings of the watermark:
+//
+//    Parameter    | Default   | Explanation
+//   --------------+-----------+--------------------------------------------
+//    name         | Watermark | The name of the watermark drawing object
+//    text         |           | The text of the watermark
+//    picture      |           | The file path of the picture watermark
+//    font         | Calibri   | The font of the text, default with bold,
+//                 |           | size 72 and color #C0C0C0
+//    width        |           | The width of the watermark in pixels
+//    height       |           | The height of the watermark in pixels
+//    x_offset     | 0         | The horizontal position in pixels
+//    y_offset     | 0         | The vertical position in pixels
+//    scale        | 1.0       | The scale of the picture
+//    rotation     | -45 / 0   | The rotation angle in degrees, default -45
+//                 |           | for the text and 0 for the picture
+//    transparency | 50        | The transparency from 0 to 100 percent
+//    print_obj    | true      | Print the watermark with the worksheet
+//
+func (f *File) AddWatermark(sheet, format string) error {
+	formatSet, err := parseFormatWatermarkSet(format)
+	if err != nil {
+		return err
+	}
+	var file []byte
+	var ext string
+	if formatSet.Picture != "" {
+		var ok bool
+		if ext, ok = supportImageTypes[strings.ToLower(path.Ext(formatSet.Picture))]; !ok || ext == ".svg" {
+			return errors.New("unsupported image extension")
+		}
+		if file, err = ioutil.ReadFile(formatSet.Picture); err != nil {
+			return err
+		}
+		width, height, err := getImageSize(file, ext)
+		if err != nil {
+			return err
+		}
+		if formatSet.Width == 0 && formatSet.Height == 0 {
+			formatSet.Width, formatSet.Height = int(float64(width)*formatSet.Scale), int(float64(height)*formatSet.Scale)
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	var rID int
+	if file != nil {
+		drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
+		rID = f.addRels(drawingRels, SourceRelationshipImage, ".."+strings.TrimPrefix(f.addMedia(file, ext), "xl"), "")
+	}
+	if err = f.addDrawingWatermark(sheet, drawingXML, rID, formatSet); err != nil {
+		return err
+	}
+	f.addContentTypePart(drawingID, "drawings")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// addDrawingWatermark provides a function to add the watermark by given
+// worksheet name, drawingXML, relationship ID of the picture and format sets.
+// The watermark will be inserted as the first absolute anchor of the drawing,
+// so it will be placed at the back of the z-order.
+func (f *File) addDrawingWatermark(sheet, drawingXML string, rID int, formatSet *formatWatermark) error {
+	content, cNvPrID := f.drawingParser(drawingXML)
+	shapes, err := f.getDrawingShapes(sheet, content)
+	if err != nil {
+		return err
+	}
+	for _, shape := range shapes {
+		if shape.id >= cNvPrID {
+			cNvPrID = shape.id + 1
+		}
+	}
+	name := formatSet.Name
+	if name == "" {
+		name = "Watermark " + strconv.Itoa(cNvPrID)
+	}
+	alpha := (100 - formatSet.Transparency) * 1000
+	absoluteAnchor := xdrCellAnchor{
+		Pos: &xlsxPoint2D{X: formatSet.OffsetX * EMU, Y: formatSet.OffsetY * EMU},
+		Ext: &xlsxExt{Cx: formatSet.Width * EMU, Cy: formatSet.Height * EMU},
+		ClientData: &xdrClientData{
+			FLocksWithSheet:  true,
+			FPrintsWithSheet: formatSet.PrintObject,
+		},
+	}
+	spPr := xlsxSpPr{
+		Xfrm: xlsxXfrm{
+			Rot: int(*formatSet.Rotation * 60000),
+			Off: xlsxOff{X: formatSet.OffsetX * EMU, Y: formatSet.OffsetY * EMU},
+			Ext: xlsxExt{Cx: formatSet.Width * EMU, Cy: formatSet.Height * EMU},
+		},
+		PrstGeom: xlsxPrstGeom{Prst: "rect"},
+	}
+	if rID != 0 {
+		pic := xlsxPic{SpPr: spPr}
+		pic.NvPicPr.CNvPr.ID = cNvPrID
+		pic.NvPicPr.CNvPr.Name = name
+		pic.NvPicPr.CNvPr.Descr = path.Base(formatSet.Picture)
+		pic.NvPicPr.CNvPicPr.PicLocks = xlsxPicLocks{NoChangeAspect: true, NoSelect: true}
+		pic.BlipFill.Blip = xlsxBlip{
+			Embed:       "rId" + strconv.Itoa(rID),
+			R:           SourceRelationship.Value,
+			AlphaModFix: &aAlphaModFix{Amt: alpha},
+		}
+		absoluteAnchor.Pic = &pic
+	} else {
+		run := newShapeTextRun(formatSet.Text, formatSet.Font)
+		if run.RPr.SolidFill != nil {
+			run.RPr.SolidFill.SrgbClr.Alpha = &attrValInt{Val: intPtr(alpha)}
+		}
+		spPr.NoFill, spPr.Ln = stringPtr(""), &aLn{NoFill: " "}
+		absoluteAnchor.Sp = &xdrSp{
+			NvSpPr: &xdrNvSpPr{
+				CNvPr:   &xlsxCNvPr{ID: cNvPrID, Name: name},
+				CNvSpPr: &xdrCNvSpPr{SpLocks: &aSpLocks{NoSelect: true, NoTextEdit: true}},
+			},
+			SpPr: &spPr,
+			TxBody: &xdrTxBody{
+				BodyPr: &aBodyPr{
+					VertOverflow: "overflow",
+					HorzOverflow: "overflow",
+					Wrap:         "none",
+					Anchor:       "ctr",
+				},
+				P: []*aP{{
+					PPr:        &aPPr{Algn: "ctr"},
+					R:          []*aR{run},
+					EndParaRPr: &aEndParaRPr{Lang: "en-US"},
+				}},
+			},
+		}
+	}
+	content.AbsoluteAnchor = append([]*xdrCellAnchor{&absoluteAnchor}, content.AbsoluteAnchor...)
+	f.Drawings[drawingXML] = content
+	return err
+}
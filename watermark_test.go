@@ -0,0 +1,52 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWatermark(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), ""))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		assert.NoError(t, f.AddWatermark(sheet, `{"text":"DRAFT","x_offset":200,"y_offset":160,"transparency":70}`))
+	}
+	assert.NoError(t, f.AddWatermark("Sheet2", `{"picture":"`+filepath.ToSlash(filepath.Join("test", "images", "excel.jpg"))+`","scale":0.5,"print_obj":false}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddWatermark.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAddWatermark.xlsx"))
+	assert.NoError(t, err)
+	drawing1 := string(f.XLSX["xl/drawings/drawing1.xml"])
+	// Test the watermark is placed at the back of the z-order.
+	assert.True(t, strings.Index(drawing1, "<xdr:absoluteAnchor>") < strings.Index(drawing1, "<xdr:twoCellAnchor"))
+	assert.Contains(t, drawing1, `<xdr:pos x="1905000" y="1524000"></xdr:pos>`)
+	assert.Contains(t, drawing1, `<xdr:cNvPr id="3" name="Watermark 3" descr=""></xdr:cNvPr><xdr:cNvSpPr txBox="false"><a:spLocks noSelect="true" noTextEdit="true"></a:spLocks></xdr:cNvSpPr>`)
+	assert.Contains(t, drawing1, `<a:xfrm rot="-2700000">`)
+	assert.Contains(t, drawing1, `<a:srgbClr val="C0C0C0"><a:alpha val="30000"></a:alpha></a:srgbClr>`)
+	assert.Contains(t, drawing1, `<xdr:clientData fLocksWithSheet="true" fPrintsWithSheet="true"></xdr:clientData>`)
+	drawing2 := string(f.XLSX["xl/drawings/drawing2.xml"])
+	assert.Equal(t, 2, strings.Count(drawing2, "<xdr:absoluteAnchor>"))
+	// Test the picture watermark added later is placed behind the text watermark.
+	assert.True(t, strings.Index(drawing2, "<xdr:pic>") < strings.Index(drawing2, "<xdr:sp "))
+	assert.Contains(t, drawing2, `<a:picLocks noChangeAspect="true" noSelect="true"></a:picLocks>`)
+	assert.Contains(t, drawing2, `<a:alphaModFix amt="50000"></a:alphaModFix>`)
+	assert.Contains(t, drawing2, `<xdr:clientData fLocksWithSheet="true" fPrintsWithSheet="false"></xdr:clientData>`)
+	assert.Contains(t, drawing2, `<xdr:ext cx="952500" cy="609600"></xdr:ext>`)
+	// Test add watermark on the worksheet loaded with the watermark.
+	assert.NoError(t, f.AddWatermark("Sheet1", `{"text":"CONFIDENTIAL","name":"Confidential","rotation":0}`))
+	drawing, _ := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.Len(t, drawing.AbsoluteAnchor, 2)
+	assert.Contains(t, drawing.AbsoluteAnchor[0].Sp.NvSpPr.CNvPr.Name, "Confidential")
+
+	// Test add watermark with invalid format set.
+	assert.EqualError(t, f.AddWatermark("Sheet1", `{`), "unexpected end of JSON input")
+	assert.EqualError(t, f.AddWatermark("Sheet1", `{}`), "the text or picture of the watermark is required")
+	assert.EqualError(t, f.AddWatermark("Sheet1", `{"text":"DRAFT","transparency":101}`), "the transparency of the watermark must be between 0 and 100")
+	assert.EqualError(t, f.AddWatermark("Sheet1", `{"picture":"watermark.txt"}`), "unsupported image extension")
+	assert.Error(t, f.AddWatermark("Sheet1", `{"picture":"watermark.png"}`))
+	assert.EqualError(t, f.AddWatermark("SheetN", `{"text":"DRAFT"}`), "sheet SheetN is not exist")
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+// WorkbookPrOption is an option of the workbook properties. See
+// SetWorkbookPrOptions().
+type WorkbookPrOption interface {
+	setWorkbookPrOption(pr *xlsxWorkbookPr)
+}
+
+// WorkbookPrOptionPtr is a writable WorkbookPrOption. See
+// GetWorkbookPrOptions().
+type WorkbookPrOptionPtr interface {
+	WorkbookPrOption
+	getWorkbookPrOption(pr *xlsxWorkbookPr)
+}
+
+// Date1904 is a WorkbookPrOption
+type Date1904 bool
+
+// setWorkbookPrOption implements the WorkbookPrOption interface and specifies
+// whether the workbook uses the 1904 date system.
+func (o Date1904) setWorkbookPrOption(pr *xlsxWorkbookPr) {
+	pr.Date1904 = bool(o)
+}
+
+// getWorkbookPrOption implements the WorkbookPrOptionPtr interface and get
+// the settings of whether the workbook uses the 1904 date system.
+func (o *Date1904) getWorkbookPrOption(pr *xlsxWorkbookPr) {
+	if pr == nil {
+		*o = false
+		return
+	}
+	*o = Date1904(pr.Date1904)
+}
+
+// SetWorkbookPrOptions provides a function to sets workbook properties. Note
+// that changing the date system doesn't convert the existing date values in
+// the workbook. For example, use the 1904 date system in the workbook:
+//
+//    err := f.SetWorkbookPrOptions(excelize.Date1904(true))
+//
+// Available options:
+//
+//    Date1904(bool)
+//
+func (f *File) SetWorkbookPrOptions(opts ...WorkbookPrOption) error {
+	f.clearCalcCache()
+	wb := f.workbookReader()
+	if wb.WorkbookPr == nil {
+		wb.WorkbookPr = new(xlsxWorkbookPr)
+	}
+	for _, opt := range opts {
+		opt.setWorkbookPrOption(wb.WorkbookPr)
+	}
+	return nil
+}
+
+// GetWorkbookPrOptions provides a function to gets workbook properties.
+//
+// Available options:
+//
+//    Date1904(bool)
+//
+func (f *File) GetWorkbookPrOptions(opts ...WorkbookPrOptionPtr) error {
+	wb := f.workbookReader()
+	for _, opt := range opts {
+		opt.getWorkbookPrOption(wb.WorkbookPr)
+	}
+	return nil
+}
+
+// date1904 provides a function to check if the workbook uses the 1904 date
+// system.
+func (f *File) date1904() bool {
+	wb := f.workbookReader()
+	return wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+}
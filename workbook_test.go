@@ -0,0 +1,110 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = []WorkbookPrOption{
+	Date1904(true),
+}
+
+var _ = []WorkbookPrOptionPtr{
+	(*Date1904)(nil),
+}
+
+func ExampleFile_SetWorkbookPrOptions() {
+	f := NewFile()
+	if err := f.SetWorkbookPrOptions(Date1904(true)); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+}
+
+func ExampleFile_GetWorkbookPrOptions() {
+	f := NewFile()
+	var date1904 Date1904
+	if err := f.GetWorkbookPrOptions(&date1904); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("Defaults:")
+	fmt.Printf("- date1904: %t\n", date1904)
+	// Output:
+	// Defaults:
+	// - date1904: false
+}
+
+func TestDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookPrOptions(Date1904(true)))
+	var date1904 Date1904
+	assert.NoError(t, f.GetWorkbookPrOptions(&date1904))
+	assert.True(t, bool(date1904))
+
+	date := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", date))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10/1/20 12:00", val)
+	v, err := f.GetCellFloat("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 42643.5, v)
+	tm, err := f.GetCellTime("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, date, tm)
+
+	// Test the calc engine with the 1904 date system.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=TODAY()"))
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	today := time.Now()
+	assert.Equal(t, fmt.Sprint(daysBetween(excelMinTime1900.Unix(), time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).Unix())+1-date1904Offset), result)
+
+	// Test stream writer with the 1904 date system.
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{date}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDate1904.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestDate1904.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.GetWorkbookPrOptions(&date1904))
+	assert.True(t, bool(date1904))
+	v, err = f.GetCellFloat("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 42643.5, v)
+}
+
+func TestTimeLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	f := NewFile(Options{TimeLocation: loc})
+	// The wall clock time in the location is 2020-10-01 20:00:00.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", time.Date(2020, 10, 1, 20, 0, 0, 0, loc)))
+	for _, cell := range []string{"A1", "A2"} {
+		v, err := f.GetCellFloat("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, 44105+20.0/24, v)
+		tm, err := f.GetCellTime("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Date(2020, 10, 1, 20, 0, 0, 0, loc), tm, time.Millisecond)
+		assert.Equal(t, loc, tm.Location())
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTimeLocation.xlsx")))
+
+	// Test open workbook with time location.
+	f, err := OpenFile(filepath.Join("test", "TestTimeLocation.xlsx"), Options{TimeLocation: time.UTC})
+	assert.NoError(t, err)
+	tm, err := f.GetCellTime("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Date(2020, 10, 1, 20, 0, 0, 0, time.UTC), tm, time.Millisecond)
+
+	// Test set non-UTC time without time location.
+	f = NewFile()
+	assert.EqualError(t, f.SetCellValue("Sheet1", "A1", time.Date(2020, 10, 1, 20, 0, 0, 0, loc)), "only UTC time expected")
+}
@@ -0,0 +1,620 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import (
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Record types of the BIFF12 records in the XLSB binary parts.
+const (
+	xlsbRowHdr           = 0
+	xlsbCellBlank        = 1
+	xlsbCellRk           = 2
+	xlsbCellError        = 3
+	xlsbCellBool         = 4
+	xlsbCellReal         = 5
+	xlsbCellSt           = 6
+	xlsbCellIsst         = 7
+	xlsbFmlaString       = 8
+	xlsbFmlaNum          = 9
+	xlsbFmlaBool         = 10
+	xlsbFmlaError        = 11
+	xlsbShortBlank       = 12
+	xlsbShortIsst        = 18
+	xlsbSSTItem          = 19
+	xlsbFont             = 43
+	xlsbFmt              = 44
+	xlsbFill             = 45
+	xlsbBorder           = 46
+	xlsbXF               = 47
+	xlsbColInfo          = 60
+	xlsbCellRString      = 62
+	xlsbWbProp           = 153
+	xlsbBundleSh         = 156
+	xlsbMergeCell        = 176
+	xlsbBeginCellXFs     = 617
+	xlsbEndCellXFs       = 618
+	xlsbBeginCellStyleXF = 626
+	xlsbEndCellStyleXF   = 627
+)
+
+var (
+	// xlsbErrors defined the error values of the BErr structure.
+	xlsbErrors = map[byte]string{
+		0x00: "#NULL!", 0x07: "#DIV/0!", 0x0F: "#VALUE!", 0x17: "#REF!",
+		0x1D: "#NAME?", 0x24: "#NUM!", 0x2A: "#N/A", 0x2B: "#GETTING_DATA",
+	}
+	// xlsbPatterns defined the fill pattern types of the BrtFill record.
+	xlsbPatterns = []string{
+		"none", "solid", "mediumGray", "darkGray", "lightGray",
+		"darkHorizontal", "darkVertical", "darkDown", "darkUp", "darkGrid",
+		"darkTrellis", "lightHorizontal", "lightVertical", "lightDown",
+		"lightUp", "lightGrid", "lightTrellis", "gray125", "gray0625",
+	}
+	// xlsbBorderStyles defined the line styles of the Blxf structure.
+	xlsbBorderStyles = []string{
+		"", "thin", "medium", "dashed", "dotted", "thick", "double", "hair",
+		"mediumDashed", "dashDot", "mediumDashDot", "dashDotDot",
+		"mediumDashDotDot", "slantDashDot",
+	}
+	// xlsbHorizontal and xlsbVertical defined the alignment types of the
+	// BrtXF record.
+	xlsbHorizontal = []string{"", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}
+	xlsbVertical   = []string{"top", "center", "", "justify", "distributed"}
+	// xlsbUnderlines defined the underline types of the BrtFont record.
+	xlsbUnderlines = map[byte]string{0x01: "single", 0x02: "double", 0x21: "singleAccounting", 0x22: "doubleAccounting"}
+)
+
+// xlsbRecordReader directly maps the payload of the BIFF12 record, and keeps
+// the first error that occurred while reading the fields of the record.
+type xlsbRecordReader struct {
+	data []byte
+	err  error
+}
+
+// xlsbSheetReader directly maps the state of the XLSB worksheet reader.
+type xlsbSheetReader struct {
+	f      *File
+	ws     *xlsxWorksheet
+	sst    []int
+	row    *xlsxRow
+	col    int
+	styles int
+}
+
+// isXLSB provides a function to check if the files of the package is an
+// XLSB binary workbook.
+func isXLSB(files map[string][]byte) bool {
+	_, ok := files["xl/workbook.bin"]
+	return ok
+}
+
+// openXLSB provides a function to create the spreadsheet by given the files
+// of the XLSB binary workbook package and the options. The worksheets, shared
+// strings, cell styles, merged cells, column widths and row heights will be
+// mapped into the workbook. Formulas are kept as the cached values, since
+// the parsed formula tokens are not supported currently, and the other
+// parts, such as charts and pictures will be ignored.
+func openXLSB(files map[string][]byte, opt ...Options) (*File, error) {
+	data, ok := files["xl/_rels/workbook.bin.rels"]
+	if !ok {
+		return nil, errors.New("invalid XLSB workbook: xl/_rels/workbook.bin.rels is not exist")
+	}
+	var rels xlsxRelationships
+	if err := xml.Unmarshal(namespaceStrictToTransitional(data), &rels); err != nil {
+		return nil, err
+	}
+	targets, parts := map[string]string{}, map[string]string{}
+	for _, rel := range rels.Relationships {
+		target := path.Join("xl", rel.Target)
+		if strings.HasPrefix(rel.Target, "/") {
+			target = strings.TrimPrefix(rel.Target, "/")
+		}
+		targets[rel.ID], parts[rel.Type] = target, target
+		if rel.Type != SourceRelationshipWorkSheet {
+			targets[rel.ID] = ""
+		}
+	}
+	f := NewFile(opt...)
+	sst, err := f.readXLSBSharedStrings(files[parts[SourceRelationshipSharedStrings]])
+	if err != nil {
+		return nil, err
+	}
+	styles, err := f.readXLSBStyles(files[parts[SourceRelationshipStyles]])
+	if err != nil {
+		return nil, err
+	}
+	var sheets int
+	if err = readXLSBRecords(files["xl/workbook.bin"], func(typ int, r *xlsbRecordReader) error {
+		switch typ {
+		case xlsbWbProp:
+			if r.readUint32()&0x01 != 0 {
+				if f.WorkBook.WorkbookPr == nil {
+					f.WorkBook.WorkbookPr = &xlsxWorkbookPr{}
+				}
+				f.WorkBook.WorkbookPr.Date1904 = true
+			}
+		case xlsbBundleSh:
+			state := r.readUint32()
+			r.read(4)
+			rID, name := r.readWideString(), trimSheetName(r.readWideString())
+			if r.err != nil || targets[rID] == "" {
+				return r.err
+			}
+			content, ok := files[targets[rID]]
+			if !ok {
+				return fmt.Errorf("invalid XLSB workbook: %s is not exist", targets[rID])
+			}
+			if sheets++; sheets == 1 {
+				f.SetSheetName(f.GetSheetName(0), name)
+			} else if f.GetSheetIndex(name) != -1 {
+				return errors.New("invalid XLSB workbook: duplicate sheet name " + name)
+			} else {
+				f.NewSheet(name)
+			}
+			if err := f.readXLSBSheet(name, content, sst, styles); err != nil {
+				return err
+			}
+			if state != 0 {
+				return f.SetSheetVisible(name, false)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if sheets == 0 {
+		return nil, errors.New("invalid XLSB workbook: no worksheet found")
+	}
+	return f, nil
+}
+
+// readXLSBRecords provides a function to iterate the BIFF12 records of the
+// XLSB binary part, the record type and size are stored as variable-length
+// integers with 7 bits per byte.
+func readXLSBRecords(data []byte, fn func(typ int, r *xlsbRecordReader) error) error {
+	for offset := 0; offset < len(data); {
+		var typ, size int
+		for idx, width := range []int{2, 4} {
+			val, shift := 0, 0
+			for ; width > 0; width-- {
+				if offset >= len(data) {
+					return errors.New("invalid XLSB record")
+				}
+				b := data[offset]
+				offset++
+				val |= int(b&0x7F) << shift
+				if shift += 7; b&0x80 == 0 {
+					break
+				}
+			}
+			if idx == 0 {
+				typ = val
+				continue
+			}
+			size = val
+		}
+		if offset+size > len(data) {
+			return errors.New("invalid XLSB record")
+		}
+		r := &xlsbRecordReader{data: data[offset : offset+size]}
+		offset += size
+		if err := fn(typ, r); err != nil {
+			return err
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+	return nil
+}
+
+// read provides a function to read the given number of bytes from the
+// record, zero bytes will be returned if the record is too short.
+func (r *xlsbRecordReader) read(n int) []byte {
+	if r.err != nil || n < 0 || n > len(r.data) {
+		if r.err == nil {
+			r.err = errors.New("invalid XLSB record")
+		}
+		return make([]byte, 8)
+	}
+	data := r.data[:n]
+	r.data = r.data[n:]
+	return data
+}
+
+// readUint8 provides a function to read the unsigned 8-bit integer.
+func (r *xlsbRecordReader) readUint8() byte { return r.read(1)[0] }
+
+// readUint16 provides a function to read the little-endian unsigned 16-bit
+// integer.
+func (r *xlsbRecordReader) readUint16() uint16 { return binary.LittleEndian.Uint16(r.read(2)) }
+
+// readUint32 provides a function to read the little-endian unsigned 32-bit
+// integer.
+func (r *xlsbRecordReader) readUint32() uint32 { return binary.LittleEndian.Uint32(r.read(4)) }
+
+// readFloat64 provides a function to read the little-endian IEEE 754 double
+// precision floating-point number.
+func (r *xlsbRecordReader) readFloat64() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(r.read(8)))
+}
+
+// readWideString provides a function to read the XLWideString structure
+// with the UTF-16 characters, the null string will be read as empty.
+func (r *xlsbRecordReader) readWideString() string {
+	cch := r.readUint32()
+	if cch == math.MaxUint32 || r.err != nil {
+		return ""
+	}
+	if uint64(cch)*2 > uint64(len(r.data)) {
+		r.read(-1)
+		return ""
+	}
+	data, chars := r.read(int(cch)*2), make([]uint16, cch)
+	for idx := range chars {
+		chars[idx] = binary.LittleEndian.Uint16(data[idx*2:])
+	}
+	return string(utf16.Decode(chars))
+}
+
+// readColor provides a function to read the BrtColor structure.
+func (r *xlsbRecordReader) readColor() *xlsxColor {
+	data := r.read(8)
+	tint := float64(int16(binary.LittleEndian.Uint16(data[2:])))
+	tint /= 32767
+	switch data[0] >> 1 {
+	case 0:
+		return &xlsxColor{Auto: true}
+	case 1:
+		return &xlsxColor{Indexed: int(data[1])}
+	case 3:
+		return &xlsxColor{Theme: intPtr(int(data[1])), Tint: math.Round(tint*1e6) / 1e6}
+	}
+	return &xlsxColor{RGB: fmt.Sprintf("%02X%02X%02X%02X", data[7], data[4], data[5], data[6])}
+}
+
+// readXLSBSharedStrings provides a function to read the shared strings of
+// the XLSB binary workbook and add them into the shared strings table of the
+// workbook, the index of the strings in the workbook will be returned.
+func (f *File) readXLSBSharedStrings(data []byte) ([]int, error) {
+	var sst []int
+	return sst, readXLSBRecords(data, func(typ int, r *xlsbRecordReader) error {
+		if typ == xlsbSSTItem {
+			r.readUint8()
+			if val := r.readWideString(); r.err == nil {
+				sst = append(sst, f.setSharedString(val))
+			}
+		}
+		return nil
+	})
+}
+
+// readXLSBStyles provides a function to read the number formats, fonts,
+// fills, borders and cell formats of the XLSB binary workbook into the
+// style sheet, the count of the cell formats will be returned.
+func (f *File) readXLSBStyles(data []byte) (int, error) {
+	var (
+		numFmts                    []*xlsxNumFmt
+		fonts                      []*xlsxFont
+		fills                      []*xlsxFill
+		borders                    []*xlsxBorder
+		cellXfs, cellStyleXfs, xfs *[]xlsxXf
+	)
+	cellXfs, cellStyleXfs = &[]xlsxXf{}, &[]xlsxXf{}
+	if err := readXLSBRecords(data, func(typ int, r *xlsbRecordReader) error {
+		switch typ {
+		case xlsbFmt:
+			numFmts = append(numFmts, &xlsxNumFmt{NumFmtID: int(r.readUint16()), FormatCode: r.readWideString()})
+		case xlsbFont:
+			fonts = append(fonts, readXLSBFont(r))
+		case xlsbFill:
+			pattern, fill := int(r.readUint32()), &xlsxPatternFill{PatternType: "none"}
+			if pattern < len(xlsbPatterns) {
+				fill.PatternType = xlsbPatterns[pattern]
+			}
+			if fill.FgColor, fill.BgColor = r.readColor(), r.readColor(); pattern == 0 {
+				fill.FgColor, fill.BgColor = nil, nil
+			}
+			fills = append(fills, &xlsxFill{PatternFill: fill})
+		case xlsbBorder:
+			flags, border := r.readUint8(), &xlsxBorder{}
+			border.DiagonalDown, border.DiagonalUp = flags&0x01 != 0, flags&0x02 != 0
+			for _, line := range []*xlsxLine{&border.Top, &border.Bottom, &border.Left, &border.Right, &border.Diagonal} {
+				style := int(r.readUint8())
+				r.readUint8()
+				if color := r.readColor(); style > 0 && style < len(xlsbBorderStyles) {
+					line.Style, line.Color = xlsbBorderStyles[style], color
+				}
+			}
+			borders = append(borders, border)
+		case xlsbBeginCellXFs:
+			xfs = cellXfs
+		case xlsbBeginCellStyleXF:
+			xfs = cellStyleXfs
+		case xlsbEndCellXFs, xlsbEndCellStyleXF:
+			xfs = nil
+		case xlsbXF:
+			if xfs != nil {
+				*xfs = append(*xfs, readXLSBXf(r, xfs == cellXfs))
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	s := f.stylesReader()
+	if len(numFmts) > 0 {
+		s.NumFmts = &xlsxNumFmts{Count: len(numFmts), NumFmt: numFmts}
+	}
+	if len(fonts) > 0 {
+		s.Fonts = &xlsxFonts{Count: len(fonts), Font: fonts}
+	}
+	if len(fills) > 0 {
+		s.Fills = &xlsxFills{Count: len(fills), Fill: fills}
+	}
+	if len(borders) > 0 {
+		s.Borders = &xlsxBorders{Count: len(borders), Border: borders}
+	}
+	if len(*cellStyleXfs) > 0 {
+		s.CellStyleXfs = &xlsxCellStyleXfs{Count: len(*cellStyleXfs), Xf: *cellStyleXfs}
+	}
+	if len(*cellXfs) > 0 {
+		s.CellXfs = &xlsxCellXfs{Count: len(*cellXfs), Xf: *cellXfs}
+	}
+	return len(s.CellXfs.Xf), nil
+}
+
+// readXLSBFont provides a function to read the BrtFont record.
+func readXLSBFont(r *xlsbRecordReader) *xlsxFont {
+	size, flags, weight := float64(r.readUint16())/20, r.readUint16(), r.readUint16()
+	r.readUint16()
+	underline, family := r.readUint8(), int(r.readUint8())
+	r.read(2)
+	font := &xlsxFont{Sz: &attrValFloat{Val: float64Ptr(size)}, Color: r.readColor()}
+	scheme, name := r.readUint8(), r.readWideString()
+	font.Name = &attrValString{Val: stringPtr(name)}
+	if family > 0 {
+		font.Family = &attrValInt{Val: intPtr(family)}
+	}
+	if weight >= 700 {
+		font.B = boolPtr(true)
+	}
+	if flags&0x02 != 0 {
+		font.I = boolPtr(true)
+	}
+	if flags&0x08 != 0 {
+		font.Strike = boolPtr(true)
+	}
+	if val, ok := xlsbUnderlines[underline]; ok {
+		font.U = &attrValString{Val: stringPtr(val)}
+	}
+	if scheme == 1 || scheme == 2 {
+		font.Scheme = &attrValString{Val: stringPtr([]string{"major", "minor"}[scheme-1])}
+	}
+	return font
+}
+
+// readXLSBXf provides a function to read the BrtXF record, the parent cell
+// style format will be kept for the cell formats only.
+func readXLSBXf(r *xlsbRecordReader, cellXf bool) xlsxXf {
+	parent, numFmt, font := int(r.readUint16()), int(r.readUint16()), int(r.readUint16())
+	fill, border := int(r.readUint16()), int(r.readUint16())
+	rotation, indent, flags := int(r.readUint8()), int(r.readUint8()), r.readUint16()
+	xf := xlsxXf{NumFmtID: intPtr(numFmt), FontID: intPtr(font), FillID: intPtr(fill), BorderID: intPtr(border)}
+	if cellXf {
+		xf.XfID = intPtr(parent)
+	}
+	for _, apply := range []struct {
+		ptr   **bool
+		apply bool
+	}{
+		{&xf.ApplyNumberFormat, numFmt != 0},
+		{&xf.ApplyFont, font != 0},
+		{&xf.ApplyFill, fill != 0},
+		{&xf.ApplyBorder, border != 0},
+	} {
+		if apply.apply {
+			*apply.ptr = boolPtr(true)
+		}
+	}
+	alignment := xlsxAlignment{
+		TextRotation: rotation,
+		Indent:       indent,
+		WrapText:     flags&0x40 != 0,
+		ShrinkToFit:  flags&0x100 != 0,
+	}
+	if horizontal := int(flags & 0x07); horizontal < len(xlsbHorizontal) {
+		alignment.Horizontal = xlsbHorizontal[horizontal]
+	}
+	if vertical := int(flags >> 3 & 0x07); vertical < len(xlsbVertical) {
+		alignment.Vertical = xlsbVertical[vertical]
+	}
+	if alignment != (xlsxAlignment{}) {
+		xf.Alignment, xf.ApplyAlignment = &alignment, boolPtr(true)
+	}
+	if locked, hidden := flags&0x1000 != 0, flags&0x2000 != 0; !locked || hidden {
+		xf.Protection, xf.ApplyProtection = &xlsxProtection{Locked: locked, Hidden: hidden}, boolPtr(true)
+	}
+	return xf
+}
+
+// readXLSBSheet provides a function to read the rows, cells, columns and
+// merged cells of the XLSB binary worksheet by given worksheet name, the
+// content of the worksheet part, the shared strings index and the count of
+// the cell formats.
+func (f *File) readXLSBSheet(sheet string, data []byte, sst []int, styles int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	r := &xlsbSheetReader{f: f, ws: ws, sst: sst, styles: styles}
+	if err = readXLSBRecords(data, r.readRecord); err != nil {
+		return err
+	}
+	checkSheet(ws)
+	return checkRow(ws)
+}
+
+// readRecord provides a function to read the BIFF12 record of the XLSB
+// binary worksheet.
+func (r *xlsbSheetReader) readRecord(typ int, rd *xlsbRecordReader) error {
+	switch {
+	case typ == xlsbRowHdr:
+		row, style, height := int(rd.readUint32()), int(rd.readUint32()), float64(rd.readUint16())/20
+		flags := rd.read(2)[1]
+		if row >= TotalRows {
+			return errors.New("row number exceeds maximum limit")
+		}
+		r.ws.SheetData.Row = append(r.ws.SheetData.Row, xlsxRow{
+			R:            row + 1,
+			OutlineLevel: flags & 0x07,
+			Collapsed:    flags&0x08 != 0,
+			Hidden:       flags&0x10 != 0,
+		})
+		r.row, r.col = &r.ws.SheetData.Row[len(r.ws.SheetData.Row)-1], -1
+		if flags&0x20 != 0 {
+			r.row.Ht, r.row.CustomHeight = height, true
+		}
+		if flags&0x40 != 0 && style < r.styles {
+			r.row.S, r.row.CustomFormat = style, true
+		}
+	case typ >= xlsbCellBlank && typ <= xlsbFmlaError, typ == xlsbCellRString:
+		r.col = int(rd.readUint32())
+		return r.readCell(typ, rd)
+	case typ >= xlsbShortBlank && typ <= xlsbShortIsst:
+		r.col++
+		return r.readCell(typ-xlsbShortBlank+xlsbCellBlank, rd)
+	case typ == xlsbColInfo:
+		first, last, width := int(rd.readUint32()), int(rd.readUint32()), float64(rd.readUint32())/256
+		style, flags := int(rd.readUint32()), rd.readUint16()
+		if first >= TotalColumns {
+			return errors.New("column number exceeds maximum limit")
+		}
+		if last >= TotalColumns {
+			last = TotalColumns - 1
+		}
+		if style >= r.styles {
+			style = 0
+		}
+		if r.ws.Cols == nil {
+			r.ws.Cols = &xlsxCols{}
+		}
+		r.ws.Cols.Col = append(r.ws.Cols.Col, xlsxCol{
+			Min:          first + 1,
+			Max:          last + 1,
+			Width:        width,
+			Style:        style,
+			Hidden:       flags&0x01 != 0,
+			CustomWidth:  flags&0x02 != 0,
+			BestFit:      flags&0x04 != 0,
+			OutlineLevel: uint8(flags >> 8 & 0x07),
+			Collapsed:    flags&0x1000 != 0,
+		})
+	case typ == xlsbMergeCell:
+		firstRow, lastRow := int(rd.readUint32()), int(rd.readUint32())
+		firstCol, lastCol := int(rd.readUint32()), int(rd.readUint32())
+		if firstRow >= TotalRows || lastRow >= TotalRows {
+			return errors.New("row number exceeds maximum limit")
+		}
+		hcell, err := CoordinatesToCellName(firstCol+1, firstRow+1)
+		if err != nil {
+			return err
+		}
+		vcell, err := CoordinatesToCellName(lastCol+1, lastRow+1)
+		if err != nil {
+			return err
+		}
+		if r.ws.MergeCells == nil {
+			r.ws.MergeCells = &xlsxMergeCells{}
+		}
+		r.ws.MergeCells.Cells = append(r.ws.MergeCells.Cells, &xlsxMergeCell{Ref: hcell + ":" + vcell})
+		r.ws.MergeCells.Count = len(r.ws.MergeCells.Cells)
+	}
+	return nil
+}
+
+// readCell provides a function to read the cell record after the column of
+// the cell, the cached value of the formula cell will be read as the value.
+func (r *xlsbSheetReader) readCell(typ int, rd *xlsbRecordReader) error {
+	style := int(rd.readUint32() & 0xFFFFFF)
+	if r.row == nil || rd.err != nil {
+		return rd.err
+	}
+	axis, err := CoordinatesToCellName(r.col+1, r.row.R)
+	if err != nil {
+		return err
+	}
+	if style >= r.styles {
+		style = 0
+	}
+	c := xlsxC{R: axis, S: style}
+	switch typ {
+	case xlsbCellBlank:
+		if style == 0 {
+			return nil
+		}
+	case xlsbCellRk:
+		c.V = strconv.FormatFloat(decodeXLSBRkNumber(rd.readUint32()), 'f', -1, 64)
+	case xlsbCellReal, xlsbFmlaNum:
+		c.V = strconv.FormatFloat(rd.readFloat64(), 'f', -1, 64)
+	case xlsbCellError, xlsbFmlaError:
+		c.T, c.V = "e", xlsbErrors[rd.readUint8()]
+		if c.V == "" {
+			c.V = "#N/A"
+		}
+	case xlsbCellBool, xlsbFmlaBool:
+		c.T, c.V = "b", "0"
+		if rd.readUint8() != 0 {
+			c.V = "1"
+		}
+	case xlsbCellIsst:
+		idx := int(rd.readUint32())
+		if idx >= len(r.sst) {
+			return errors.New("invalid XLSB record")
+		}
+		c.T, c.V = "s", strconv.Itoa(r.sst[idx])
+	case xlsbCellRString:
+		rd.readUint8()
+		fallthrough
+	case xlsbCellSt, xlsbFmlaString:
+		val := rd.readWideString()
+		c.T, c.V = "s", strconv.Itoa(r.f.setSharedString(val))
+	}
+	r.row.C = append(r.row.C, c)
+	return rd.err
+}
+
+// decodeXLSBRkNumber provides a function to decode the RkNumber structure,
+// which stores the number as a 30 bits integer or the most significant 30
+// bits of the IEEE floating-point number, optionally multiplied by 100.
+func decodeXLSBRkNumber(rk uint32) float64 {
+	var num float64
+	if rk&0x02 != 0 {
+		num = float64(int32(rk) >> 2)
+	} else {
+		num = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		num /= 100
+	}
+	return num
+}
@@ -0,0 +1,266 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xlsbRecord provides a function to encode the BIFF12 record by given record
+// type and the fields of the payload.
+func xlsbRecord(typ int, fields ...interface{}) []byte {
+	var payload bytes.Buffer
+	for _, field := range fields {
+		switch val := field.(type) {
+		case string:
+			chars := utf16.Encode([]rune(val))
+			_ = binary.Write(&payload, binary.LittleEndian, uint32(len(chars)))
+			_ = binary.Write(&payload, binary.LittleEndian, chars)
+		default:
+			_ = binary.Write(&payload, binary.LittleEndian, val)
+		}
+	}
+	var buf bytes.Buffer
+	for _, val := range []int{typ, payload.Len()} {
+		for {
+			b := byte(val & 0x7F)
+			if val >>= 7; val > 0 {
+				b |= 0x80
+			}
+			buf.WriteByte(b)
+			if val == 0 {
+				break
+			}
+		}
+	}
+	buf.Write(payload.Bytes())
+	return buf.Bytes()
+}
+
+// newXLSB provides a function to create the XLSB binary workbook package by
+// given the parts of the package.
+func newXLSB(t *testing.T, parts map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		fw, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fw.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestOpenXLSB(t *testing.T) {
+	rels := []byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.bin"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.bin"/>` +
+		`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet" Target="chartsheets/sheet1.bin"/>` +
+		`<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.bin"/>` +
+		`<Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.bin"/></Relationships>`)
+	color := func(typ, index byte, tint int16, rgba ...byte) []byte {
+		buf := []byte{typ << 1, index, 0, 0, 0, 0, 0, 0}
+		binary.LittleEndian.PutUint16(buf[2:], uint16(tint))
+		copy(buf[4:], rgba)
+		return buf
+	}
+	blxf := func(style byte) []byte { return append([]byte{style, 0}, color(2, 0, 0, 0, 0, 0xFF, 0xFF)...) }
+	xf := func(parent, numFmt, font, fill, border uint16, flags uint16) []interface{} {
+		return []interface{}{parent, numFmt, font, fill, border, uint8(0), uint8(1), flags, uint16(0)}
+	}
+	workbook := bytes.Join([][]byte{
+		xlsbRecord(xlsbBundleSh, uint32(0), uint32(1), "rId1", "Data"),
+		xlsbRecord(xlsbBundleSh, uint32(1), uint32(2), "rId2", "Hidden"),
+		xlsbRecord(xlsbBundleSh, uint32(0), uint32(3), "rId3", "Chart"),
+	}, nil)
+	sharedStrings := bytes.Join([][]byte{
+		xlsbRecord(159, uint32(2), uint32(2)),
+		xlsbRecord(xlsbSSTItem, uint8(0), "Name"),
+		xlsbRecord(xlsbSSTItem, uint8(0), "Value"),
+	}, nil)
+	styles := bytes.Join([][]byte{
+		xlsbRecord(xlsbFmt, uint16(164), "0.000"),
+		xlsbRecord(xlsbFont, uint16(220), uint16(0), uint16(400), uint16(0), uint8(0), uint8(2), uint8(0), uint8(0), color(3, 1, 0), uint8(2), "Calibri"),
+		xlsbRecord(xlsbFont, uint16(240), uint16(0x0A), uint16(700), uint16(0), uint8(1), uint8(0), uint8(0), uint8(0), color(2, 0, 0, 0xFF, 0, 0, 0xFF), uint8(0), "Arial"),
+		xlsbRecord(xlsbFill, uint32(0), color(1, 64, 0), color(1, 65, 0)),
+		xlsbRecord(xlsbFill, uint32(1), color(3, 4, -16384), color(1, 64, 0)),
+		xlsbRecord(xlsbBorder, uint8(0), blxf(0), blxf(0), blxf(0), blxf(0), blxf(0)),
+		xlsbRecord(xlsbBorder, uint8(0x01), blxf(1), blxf(3), blxf(0), blxf(0), blxf(14)),
+		xlsbRecord(xlsbBeginCellStyleXF),
+		xlsbRecord(xlsbXF, xf(0xFFFF, 0, 0, 0, 0, 0x1000)...),
+		xlsbRecord(xlsbEndCellStyleXF),
+		xlsbRecord(xlsbXF, xf(0, 0, 0, 0, 0, 0x1000)...),
+		xlsbRecord(xlsbBeginCellXFs),
+		xlsbRecord(xlsbXF, xf(0, 0, 0, 0, 0, 0x1000|0x10)...),
+		xlsbRecord(xlsbXF, xf(0, 164, 1, 1, 1, 0x1000|0x40|0x0A)...),
+		xlsbRecord(xlsbXF, xf(0, 14, 0, 0, 0, 0x2000)...),
+		xlsbRecord(xlsbEndCellXFs),
+	}, nil)
+	sheet1 := bytes.Join([][]byte{
+		xlsbRecord(xlsbColInfo, uint32(1), uint32(2), uint32(20*256), uint32(1), uint16(0x02)),
+		xlsbRecord(xlsbColInfo, uint32(4), uint32(TotalColumns), uint32(0), uint32(9), uint16(0x01)),
+		xlsbRecord(145),
+		xlsbRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(300), uint16(0)),
+		xlsbRecord(xlsbCellIsst, uint32(0), uint32(1), uint32(0)),
+		xlsbRecord(xlsbCellIsst, uint32(1), uint32(0), uint32(1)),
+		xlsbRecord(xlsbRowHdr, uint32(1), uint32(2), uint16(600), uint16(0x6000)),
+		xlsbRecord(xlsbCellSt, uint32(0), uint32(0), "Multi\nline"),
+		xlsbRecord(xlsbCellReal, uint32(1), uint32(1), 1.5),
+		xlsbRecord(xlsbCellRk, uint32(2), uint32(0), uint32(100<<2|0x02)),
+		xlsbRecord(xlsbCellRk, uint32(3), uint32(0), uint32(123<<2|0x03)),
+		xlsbRecord(xlsbRowHdr, uint32(3), uint32(0), uint16(300), uint16(0x1000)),
+		xlsbRecord(xlsbCellBool, uint32(0), uint32(0), uint8(1)),
+		xlsbRecord(xlsbCellError, uint32(1), uint32(0), uint8(0x07)),
+		xlsbRecord(xlsbFmlaNum, uint32(2), uint32(0), 42.0, uint16(0), uint32(0)),
+		xlsbRecord(xlsbCellBlank, uint32(3), uint32(2)),
+		xlsbRecord(xlsbCellRString, uint32(4), uint32(0), uint8(0), "rich"),
+		xlsbRecord(xlsbFmlaString, uint32(5), uint32(0), "text", uint16(0)),
+		xlsbRecord(xlsbFmlaBool, uint32(6), uint32(0), uint8(0)),
+		xlsbRecord(xlsbFmlaError, uint32(7), uint32(0), uint8(0xFF)),
+		xlsbRecord(xlsbCellBlank, uint32(8), uint32(0)),
+		xlsbRecord(xlsbRowHdr, uint32(4), uint32(0), uint16(300), uint16(0)),
+		xlsbRecord(xlsbShortBlank+xlsbCellReal-xlsbCellBlank, uint32(0), 2.5),
+		xlsbRecord(xlsbShortIsst, uint32(0), uint32(0)),
+		xlsbRecord(xlsbRowHdr, uint32(5), uint32(0), uint16(300), uint16(0)),
+		xlsbRecord(xlsbCellReal, uint32(0), uint32(2), 44259.0),
+		xlsbRecord(xlsbCellSt, uint32(1), uint32(0), "Merged"),
+		xlsbRecord(146),
+		xlsbRecord(xlsbMergeCell, uint32(5), uint32(6), uint32(1), uint32(2)),
+	}, nil)
+	sheet2 := xlsbRecord(xlsbCellReal, uint32(0), uint32(0), 3.0)
+	sheet2 = append(xlsbRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(300), uint16(0)), sheet2...)
+	parts := map[string][]byte{
+		"xl/workbook.bin":            workbook,
+		"xl/_rels/workbook.bin.rels": rels,
+		"xl/sharedStrings.bin":       sharedStrings,
+		"xl/styles.bin":              styles,
+		"xl/worksheets/sheet1.bin":   sheet1,
+		"xl/worksheets/sheet2.bin":   sheet2,
+	}
+	f, err := OpenReader(bytes.NewReader(newXLSB(t, parts)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Hidden"}, f.GetSheetList())
+	assert.False(t, f.GetSheetVisible("Hidden"))
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Value"},
+		{"Multi\nline", "1.5", "100", "1.23"},
+		nil,
+		{"1", "#DIV/0!", "42", "", "rich", "text", "0", "#N/A"},
+		{"2.5", "Name"},
+		{"03-04-21", "Merged"},
+	}, rows)
+	val, err := f.GetCellValue("Hidden", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	cellType, err := f.GetCellType("Data", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"B6:C7", "Merged"}}, mergeCells)
+	width, err := f.GetColWidth("Data", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	visible, err := f.GetColVisible("Data", "E")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Data", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Data", 4)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	style, err := f.GetCellStyle("Data", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, style)
+	// Test the mapped styles of the workbook.
+	assert.Equal(t, []*xlsxNumFmt{{NumFmtID: 164, FormatCode: "0.000"}}, f.Styles.NumFmts.NumFmt)
+	assert.Equal(t, &xlsxFont{
+		B: boolPtr(true), I: boolPtr(true), Strike: boolPtr(true), U: &attrValString{Val: stringPtr("single")},
+		Sz: &attrValFloat{Val: float64Ptr(12)}, Color: &xlsxColor{RGB: "FFFF0000"}, Name: &attrValString{Val: stringPtr("Arial")},
+	}, f.Styles.Fonts.Font[1])
+	assert.Equal(t, &xlsxColor{Theme: intPtr(1)}, f.Styles.Fonts.Font[0].Color)
+	assert.Equal(t, "minor", *f.Styles.Fonts.Font[0].Scheme.Val)
+	assert.Equal(t, &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "none"}}, f.Styles.Fills.Fill[0])
+	assert.Equal(t, &xlsxPatternFill{PatternType: "solid", FgColor: &xlsxColor{Theme: intPtr(4), Tint: -0.500015}, BgColor: &xlsxColor{Indexed: 64}}, f.Styles.Fills.Fill[1].PatternFill)
+	assert.Equal(t, xlsxBorder{
+		DiagonalDown: true,
+		Top:          xlsxLine{Style: "thin", Color: &xlsxColor{RGB: "FF0000FF"}},
+		Bottom:       xlsxLine{Style: "dashed", Color: &xlsxColor{RGB: "FF0000FF"}},
+	}, *f.Styles.Borders.Border[1])
+	assert.Len(t, f.Styles.CellStyleXfs.Xf, 1)
+	assert.Nil(t, f.Styles.CellStyleXfs.Xf[0].XfID)
+	assert.Len(t, f.Styles.CellXfs.Xf, 3)
+	assert.Equal(t, &xlsxAlignment{Horizontal: "center", Vertical: "center", Indent: 1, WrapText: true}, f.Styles.CellXfs.Xf[1].Alignment)
+	assert.Equal(t, &xlsxProtection{Hidden: true}, f.Styles.CellXfs.Xf[2].Protection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenXLSB.xlsx")))
+
+	// Test open XLSB workbook with the 1904 date system and without the
+	// shared strings and styles.
+	f, err = OpenReader(bytes.NewReader(newXLSB(t, map[string][]byte{
+		"xl/workbook.bin":            append(xlsbRecord(xlsbWbProp, uint32(1)), xlsbRecord(xlsbBundleSh, uint32(0), uint32(1), "rId1", "Sheet1")...),
+		"xl/_rels/workbook.bin.rels": rels,
+		"xl/worksheets/sheet1.bin":   sheet2,
+	})))
+	assert.NoError(t, err)
+	assert.True(t, f.WorkBook.WorkbookPr.Date1904)
+	assert.Len(t, f.Styles.CellXfs.Xf, 1)
+
+	// Test open XLSB workbook with invalid parts.
+	for _, c := range []struct {
+		parts    map[string][]byte
+		expected string
+	}{
+		{map[string][]byte{"xl/workbook.bin": workbook}, "invalid XLSB workbook: xl/_rels/workbook.bin.rels is not exist"},
+		{map[string][]byte{"xl/workbook.bin": workbook, "xl/_rels/workbook.bin.rels": []byte("<Relationships")}, "XML syntax error on line 1: unexpected EOF"},
+		{map[string][]byte{"xl/workbook.bin": nil, "xl/_rels/workbook.bin.rels": rels}, "invalid XLSB workbook: no worksheet found"},
+		{map[string][]byte{"xl/workbook.bin": workbook, "xl/_rels/workbook.bin.rels": rels}, "invalid XLSB workbook: xl/worksheets/sheet1.bin is not exist"},
+		{map[string][]byte{"xl/workbook.bin": workbook, "xl/_rels/workbook.bin.rels": rels, "xl/worksheets/sheet1.bin": nil, "xl/worksheets/sheet2.bin": nil, "xl/sharedStrings.bin": {0x80}}, "invalid XLSB record"},
+		{map[string][]byte{"xl/workbook.bin": workbook, "xl/_rels/workbook.bin.rels": rels, "xl/worksheets/sheet1.bin": nil, "xl/worksheets/sheet2.bin": nil, "xl/styles.bin": xlsbRecord(xlsbFmt, uint16(164))}, "invalid XLSB record"},
+		{map[string][]byte{"xl/workbook.bin": {0x01, 0x05}, "xl/_rels/workbook.bin.rels": rels}, "invalid XLSB record"},
+		{map[string][]byte{"xl/workbook.bin": xlsbRecord(xlsbBundleSh, uint32(0)), "xl/_rels/workbook.bin.rels": rels}, "invalid XLSB record"},
+		{map[string][]byte{"xl/workbook.bin": bytes.Repeat(xlsbRecord(xlsbBundleSh, uint32(0), uint32(1), "rId1", "Sheet1"), 2), "xl/_rels/workbook.bin.rels": rels, "xl/worksheets/sheet1.bin": nil}, "invalid XLSB workbook: duplicate sheet name Sheet1"},
+	} {
+		_, err = OpenReader(bytes.NewReader(newXLSB(t, c.parts)))
+		assert.EqualError(t, err, c.expected)
+	}
+	for _, c := range []struct {
+		sheet    []byte
+		expected string
+	}{
+		{xlsbRecord(xlsbRowHdr, uint32(TotalRows), uint32(0), uint16(0), uint16(0)), "row number exceeds maximum limit"},
+		{append(xlsbRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(0), uint16(0)), xlsbRecord(xlsbCellReal, uint32(TotalColumns), uint32(0), 1.0)...), "column number exceeds maximum limit"},
+		{append(xlsbRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(0), uint16(0)), xlsbRecord(xlsbCellIsst, uint32(0), uint32(0), uint32(2))...), "invalid XLSB record"},
+		{append(xlsbRecord(xlsbRowHdr, uint32(0), uint32(0), uint16(0), uint16(0)), xlsbRecord(xlsbCellSt, uint32(0), uint32(0), uint32(10))...), "invalid XLSB record"},
+		{xlsbRecord(xlsbColInfo, uint32(TotalColumns), uint32(TotalColumns), uint32(0), uint32(0), uint16(0)), "column number exceeds maximum limit"},
+		{xlsbRecord(xlsbMergeCell, uint32(0), uint32(0), uint32(TotalColumns), uint32(0)), "column number exceeds maximum limit"},
+		{xlsbRecord(xlsbMergeCell, uint32(0), uint32(TotalRows), uint32(0), uint32(0)), "row number exceeds maximum limit"},
+	} {
+		parts["xl/worksheets/sheet1.bin"] = c.sheet
+		_, err = OpenReader(bytes.NewReader(newXLSB(t, parts)))
+		assert.EqualError(t, err, c.expected)
+	}
+	// Test read XLSB worksheet on not exists worksheet.
+	assert.EqualError(t, NewFile().readXLSBSheet("SheetN", nil, nil, 1), "sheet SheetN is not exist")
+}
+
+func TestDecodeXLSBRkNumber(t *testing.T) {
+	for rk, expected := range map[uint32]float64{
+		100<<2 | 0x02:                       100,
+		uint32(-5<<2&math.MaxUint32) | 0x02: -5,
+		123<<2 | 0x03:                       1.23,
+		0x3FF80000:                          1.5,
+		0x3FF80000 | 0x01:                   0.015,
+	} {
+		assert.Equal(t, expected, decodeXLSBRkNumber(rk))
+	}
+}
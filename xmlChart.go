@@ -80,32 +80,33 @@ type cRich struct {
 // aBodyPr (Body Properties) directly maps the a:bodyPr element. This element
 // defines the body properties for the text body within a shape.
 type aBodyPr struct {
-	Anchor           string  `xml:"anchor,attr,omitempty"`
-	AnchorCtr        bool    `xml:"anchorCtr,attr"`
-	Rot              int     `xml:"rot,attr"`
-	BIns             float64 `xml:"bIns,attr,omitempty"`
-	CompatLnSpc      bool    `xml:"compatLnSpc,attr,omitempty"`
-	ForceAA          bool    `xml:"forceAA,attr,omitempty"`
-	FromWordArt      bool    `xml:"fromWordArt,attr,omitempty"`
-	HorzOverflow     string  `xml:"horzOverflow,attr,omitempty"`
-	LIns             float64 `xml:"lIns,attr,omitempty"`
-	NumCol           int     `xml:"numCol,attr,omitempty"`
-	RIns             float64 `xml:"rIns,attr,omitempty"`
-	RtlCol           bool    `xml:"rtlCol,attr,omitempty"`
-	SpcCol           int     `xml:"spcCol,attr,omitempty"`
-	SpcFirstLastPara bool    `xml:"spcFirstLastPara,attr"`
-	TIns             float64 `xml:"tIns,attr,omitempty"`
-	Upright          bool    `xml:"upright,attr,omitempty"`
-	Vert             string  `xml:"vert,attr,omitempty"`
-	VertOverflow     string  `xml:"vertOverflow,attr,omitempty"`
-	Wrap             string  `xml:"wrap,attr,omitempty"`
+	Anchor           string    `xml:"anchor,attr,omitempty"`
+	AnchorCtr        bool      `xml:"anchorCtr,attr"`
+	Rot              int       `xml:"rot,attr"`
+	BIns             *int      `xml:"bIns,attr"`
+	CompatLnSpc      bool      `xml:"compatLnSpc,attr,omitempty"`
+	ForceAA          bool      `xml:"forceAA,attr,omitempty"`
+	FromWordArt      bool      `xml:"fromWordArt,attr,omitempty"`
+	HorzOverflow     string    `xml:"horzOverflow,attr,omitempty"`
+	LIns             *int      `xml:"lIns,attr"`
+	NumCol           int       `xml:"numCol,attr,omitempty"`
+	RIns             *int      `xml:"rIns,attr"`
+	RtlCol           bool      `xml:"rtlCol,attr,omitempty"`
+	SpcCol           int       `xml:"spcCol,attr,omitempty"`
+	SpcFirstLastPara bool      `xml:"spcFirstLastPara,attr"`
+	TIns             *int      `xml:"tIns,attr"`
+	Upright          bool      `xml:"upright,attr,omitempty"`
+	Vert             string    `xml:"vert,attr,omitempty"`
+	VertOverflow     string    `xml:"vertOverflow,attr,omitempty"`
+	Wrap             string    `xml:"wrap,attr,omitempty"`
+	SpAutoFit        *struct{} `xml:"a:spAutoFit"`
 }
 
 // aP (Paragraph) directly maps the a:p element. This element specifies a
 // paragraph of content in the document.
 type aP struct {
 	PPr        *aPPr        `xml:"a:pPr"`
-	R          *aR          `xml:"a:r"`
+	R          []*aR        `xml:"a:r"`
 	EndParaRPr *aEndParaRPr `xml:"a:endParaRPr"`
 }
 
@@ -116,15 +117,25 @@ type aP struct {
 // formatting, since they are directly applied to the paragraph and supersede
 // any formatting from styles.
 type aPPr struct {
-	DefRPr aRPr `xml:"a:defRPr"`
+	Algn   string `xml:"algn,attr,omitempty"`
+	DefRPr aRPr   `xml:"a:defRPr"`
 }
 
 // aSolidFill (Solid Fill) directly maps the solidFill element. This element
 // specifies a solid color fill. The shape is filled entirely with the specified
 // color.
 type aSolidFill struct {
-	SchemeClr *aSchemeClr    `xml:"a:schemeClr"`
-	SrgbClr   *attrValString `xml:"a:srgbClr"`
+	SchemeClr *aSchemeClr `xml:"a:schemeClr"`
+	SrgbClr   *aSrgbClr   `xml:"a:srgbClr"`
+}
+
+// aSrgbClr (RGB Color Model - Hex Variant) directly maps the a:srgbClr
+// element. This element specifies a color using the red, green, blue RGB
+// color model, the Alpha specifies the opacity of the color in thousandths of
+// a percent.
+type aSrgbClr struct {
+	Val   *string     `xml:"val,attr"`
+	Alpha *attrValInt `xml:"a:alpha"`
 }
 
 // aSchemeClr (Scheme Color) directly maps the a:schemeClr element. This
@@ -256,6 +267,16 @@ type aLn struct {
 	NoFill    string      `xml:"a:noFill,omitempty"`
 	Round     string      `xml:"a:round,omitempty"`
 	SolidFill *aSolidFill `xml:"a:solidFill"`
+	HeadEnd   *aLineEnd   `xml:"a:headEnd"`
+	TailEnd   *aLineEnd   `xml:"a:tailEnd"`
+}
+
+// aLineEnd directly maps the a:headEnd and a:tailEnd element. This element
+// specifies decorations which can be added to the head or tail of a line.
+type aLineEnd struct {
+	Type string `xml:"type,attr,omitempty"`
+	W    string `xml:"w,attr,omitempty"`
+	Len  string `xml:"len,attr,omitempty"`
 }
 
 // cTxPr (Text Properties) directly maps the txPr element. This element
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -74,14 +77,98 @@ type xlsxPhoneticRun struct {
 
 // formatComment directly maps the format settings of the comment.
 type formatComment struct {
-	Author string `json:"author"`
-	Text   string `json:"text"`
+	Author    string        `json:"author"`
+	Text      string        `json:"text"`
+	Runs      []RichTextRun `json:"runs"`
+	Width     int           `json:"width"`
+	Height    int           `json:"height"`
+	OffsetX   int           `json:"x_offset"`
+	OffsetY   int           `json:"y_offset"`
+	FillColor string        `json:"fill_color"`
+	Visible   bool          `json:"visible"`
 }
 
-// Comment directly maps the comment information.
+// Comment directly maps the comment information. The Runs is the rich text
+// runs of the comment text, and the Thread is the threaded comment with the
+// replies and timestamps in the same cell of the comment.
 type Comment struct {
-	Author   string `json:"author"`
-	AuthorID int    `json:"author_id"`
-	Ref      string `json:"ref"`
-	Text     string `json:"text"`
+	Author   string           `json:"author"`
+	AuthorID int              `json:"author_id"`
+	Ref      string           `json:"ref"`
+	Text     string           `json:"text"`
+	Runs     []RichTextRun    `json:"runs"`
+	Thread   *ThreadedComment `json:"thread,omitempty"`
+}
+
+// xlsxThreadedComments directly maps the ThreadedComments element in the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element is the root of the threaded comments part of the worksheet,
+// which contains the modern comments with replies, mentions and resolved
+// status.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a single comment or reply in a comment thread, the reply refers
+// to the first comment of the thread by the parentId attribute.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     bool          `xml:"done,attr,omitempty"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxMentions `xml:"mentions"`
+}
+
+// xlsxMentions directly maps the mentions element. This element is a
+// container that holds a list of person mentions in the threaded comment.
+type xlsxMentions struct {
+	Mention []xlsxMention `xml:"mention"`
+}
+
+// xlsxMention directly maps the mention element. This element specifies the
+// person mentioned in the text of the threaded comment by the start index and
+// length of the mention text.
+type xlsxMention struct {
+	MentionPersonID string `xml:"mentionpersonId,attr"`
+	MentionID       string `xml:"mentionId,attr"`
+	StartIndex      int    `xml:"startIndex,attr"`
+	Length          int    `xml:"length,attr"`
+}
+
+// xlsxPersonList directly maps the personList element in the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element holds the list of the persons who authored or are mentioned in
+// the threaded comments of the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element. This element represents a
+// single person in the person list.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
+// ThreadedComment directly maps the threaded comment information. The Replies
+// will be ignored when adding a threaded comment, use AddThreadedCommentReply
+// to reply a comment thread.
+type ThreadedComment struct {
+	ID       string            `json:"id"`
+	ParentID string            `json:"parent_id"`
+	Cell     string            `json:"cell"`
+	Author   string            `json:"author"`
+	Text     string            `json:"text"`
+	Mentions []string          `json:"mentions"`
+	Done     bool              `json:"done"`
+	Time     time.Time         `json:"time"`
+	Replies  []ThreadedComment `json:"replies"`
 }
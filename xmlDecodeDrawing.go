@@ -36,6 +36,52 @@ type decodeCellAnchor struct {
 type decodeSp struct {
 	NvSpPr *decodeNvSpPr `xml:"nvSpPr"`
 	SpPr   *decodeSpPr   `xml:"spPr"`
+	TxBody *decodeTxBody `xml:"txBody"`
+}
+
+// decodeTxBody directly maps the txBody element. This element specifies the
+// existence of text to be contained within the corresponding shape.
+type decodeTxBody struct {
+	P []struct {
+		R []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"p"`
+}
+
+// decodeCxnSp directly maps the cxnSp element. This element specifies a
+// connection shape that is used to connect two shapes.
+type decodeCxnSp struct {
+	NvCxnSpPr *decodeNvCxnSpPr `xml:"nvCxnSpPr"`
+	SpPr      *decodeSpPr      `xml:"spPr"`
+}
+
+// decodeNvCxnSpPr directly maps the nvCxnSpPr element.
+type decodeNvCxnSpPr struct {
+	CNvPr      *decodeCNvPr `xml:"cNvPr"`
+	CNvCxnSpPr *struct {
+		StCxn  *decodeCxn `xml:"stCxn"`
+		EndCxn *decodeCxn `xml:"endCxn"`
+	} `xml:"cNvCxnSpPr"`
+}
+
+// decodeCxn directly maps the stCxn and endCxn element.
+type decodeCxn struct {
+	ID  int `xml:"id,attr"`
+	Idx int `xml:"idx,attr"`
+}
+
+// decodeGrpSp directly maps the grpSp element. This element specifies a group
+// shape that represents many shapes grouped together.
+type decodeGrpSp struct {
+	NvGrpSpPr *struct {
+		CNvPr *decodeCNvPr `xml:"cNvPr"`
+	} `xml:"nvGrpSpPr"`
+	GrpSpPr *struct {
+		Xfrm decodeXfrm `xml:"xfrm"`
+	} `xml:"grpSpPr"`
+	Sp    []*decodeSp    `xml:"sp"`
+	CxnSp []*decodeCxnSp `xml:"cxnSp"`
 }
 
 // decodeSp (Non-Visual Properties for a Shape) directly maps the nvSpPr
@@ -63,12 +109,13 @@ type decodeCNvSpPr struct {
 // changed after serialization and deserialization, two different structures
 // are defined. decodeWsDr just for deserialization.
 type decodeWsDr struct {
-	A             string              `xml:"xmlns a,attr"`
-	Xdr           string              `xml:"xmlns xdr,attr"`
-	R             string              `xml:"xmlns r,attr"`
-	OneCellAnchor []*decodeCellAnchor `xml:"oneCellAnchor,omitempty"`
-	TwoCellAnchor []*decodeCellAnchor `xml:"twoCellAnchor,omitempty"`
-	XMLName       xml.Name            `xml:"http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing wsDr,omitempty"`
+	A              string              `xml:"xmlns a,attr"`
+	Xdr            string              `xml:"xmlns xdr,attr"`
+	R              string              `xml:"xmlns r,attr"`
+	AbsoluteAnchor []*decodeCellAnchor `xml:"absoluteAnchor,omitempty"`
+	OneCellAnchor  []*decodeCellAnchor `xml:"oneCellAnchor,omitempty"`
+	TwoCellAnchor  []*decodeCellAnchor `xml:"twoCellAnchor,omitempty"`
+	XMLName        xml.Name            `xml:"http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing wsDr,omitempty"`
 }
 
 // decodeTwoCellAnchor directly maps the oneCellAnchor (One Cell Anchor Shape
@@ -76,10 +123,29 @@ type decodeWsDr struct {
 // specifies a two cell anchor placeholder for a group, a shape, or a drawing
 // element. It moves with cells and its extents are in EMU units.
 type decodeTwoCellAnchor struct {
-	From       *decodeFrom       `xml:"from"`
-	To         *decodeTo         `xml:"to"`
-	Pic        *decodePic        `xml:"pic,omitempty"`
-	ClientData *decodeClientData `xml:"clientData"`
+	Pos          *decodePos          `xml:"pos"`
+	From         *decodeFrom         `xml:"from"`
+	To           *decodeTo           `xml:"to"`
+	Ext          *decodeExt          `xml:"ext"`
+	Sp           *decodeSp           `xml:"sp"`
+	GrpSp        *decodeGrpSp        `xml:"grpSp"`
+	CxnSp        *decodeCxnSp        `xml:"cxnSp"`
+	Pic          *decodePic          `xml:"pic,omitempty"`
+	GraphicFrame *decodeGraphicFrame `xml:"graphicFrame"`
+	ClientData   *decodeClientData   `xml:"clientData"`
+}
+
+// decodeGraphicFrame directly maps the graphicFrame element. This element
+// specifies the existence of a graphics frame, such as the chart.
+type decodeGraphicFrame struct {
+	NvGraphicFramePr struct {
+		CNvPr *decodeCNvPr `xml:"cNvPr"`
+	} `xml:"nvGraphicFramePr"`
+	Graphic struct {
+		GraphicData struct {
+			URI string `xml:"uri,attr"`
+		} `xml:"graphicData"`
+	} `xml:"graphic"`
 }
 
 // decodeCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
@@ -116,9 +182,40 @@ type decodePicLocks struct {
 // specifies the existence of an image (binary large image or picture) and
 // contains a reference to the image data.
 type decodeBlip struct {
-	Embed  string `xml:"embed,attr"`
-	Cstate string `xml:"cstate,attr,omitempty"`
-	R      string `xml:"r,attr"`
+	Embed  string            `xml:"embed,attr"`
+	Cstate string            `xml:"cstate,attr,omitempty"`
+	R      string            `xml:"r,attr"`
+	ExtLst *decodeBlipExtLst `xml:"extLst"`
+}
+
+// decodeBlipExtLst directly maps the extLst element of the blip.
+type decodeBlipExtLst struct {
+	Ext []decodeBlipExt `xml:"ext"`
+}
+
+// decodeBlipExt directly maps the ext element of the blip extension list.
+type decodeBlipExt struct {
+	URI     string         `xml:"uri,attr"`
+	SVGBlip *decodeSVGBlip `xml:"svgBlip"`
+}
+
+// decodeSVGBlip directly maps the svgBlip element. This element specifies
+// the reference to the SVG image of the picture.
+type decodeSVGBlip struct {
+	Embed string `xml:"embed,attr"`
+}
+
+// svgEmbed provides a function to get the relationship ID of the SVG image
+// of the blip, an empty string will be returned if the blip has no SVG image.
+func (blip *decodeBlip) svgEmbed() string {
+	if blip.ExtLst != nil {
+		for _, ext := range blip.ExtLst.Ext {
+			if ext.SVGBlip != nil {
+				return ext.SVGBlip.Embed
+			}
+		}
+	}
+	return ""
 }
 
 // decodeStretch directly maps the stretch element. This element specifies
@@ -141,6 +238,13 @@ type decodeExt struct {
 	Cy int `xml:"cy,attr"`
 }
 
+// decodePos directly maps the pos element of the absolute anchor. This
+// element specifies the position of the drawing element in EMUs.
+type decodePos struct {
+	X int `xml:"x,attr"`
+	Y int `xml:"y,attr"`
+}
+
 // decodePrstGeom directly maps the prstGeom (Preset geometry). This element
 // specifies when a preset geometric shape should be used instead of a custom
 // geometric shape. The generating application should be able to render all
@@ -154,8 +258,13 @@ type decodePrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type decodeXfrm struct {
-	Off decodeOff `xml:"off"`
-	Ext decodeExt `xml:"ext"`
+	Rot   int       `xml:"rot,attr"`
+	FlipH bool      `xml:"flipH,attr"`
+	FlipV bool      `xml:"flipV,attr"`
+	Off   decodeOff `xml:"off"`
+	Ext   decodeExt `xml:"ext"`
+	ChOff decodeOff `xml:"chOff"`
+	ChExt decodeExt `xml:"chExt"`
 }
 
 // decodeCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -233,3 +342,19 @@ type decodeClientData struct {
 	FLocksWithSheet  bool `xml:"fLocksWithSheet,attr"`
 	FPrintsWithSheet bool `xml:"fPrintsWithSheet,attr"`
 }
+
+// decodeShapeXML directly maps the sp and cxnSp element of the cell anchor
+// with the raw inner XML. This structure is used to move the shapes into the
+// group shape.
+type decodeShapeXML struct {
+	Sp    *decodeShapeInnerXML `xml:"sp"`
+	CxnSp *decodeShapeInnerXML `xml:"cxnSp"`
+}
+
+// decodeShapeInnerXML directly maps the attributes and the raw inner XML of
+// the sp and cxnSp element.
+type decodeShapeInnerXML struct {
+	Macro    string `xml:"macro,attr"`
+	Textlink string `xml:"textlink,attr,omitempty"`
+	Content  string `xml:",innerxml"`
+}
@@ -47,7 +47,17 @@ const (
 	SourceRelationshipPivotTable                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipPivotCache                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
 	SourceRelationshipSharedStrings              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipStyles                     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
 	SourceRelationshipVBAProject                 = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
+	SourceRelationshipCtrlProp                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/ctrlProp"
+	SourceRelationshipOLEObject                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
+	SourceRelationshipPackage                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/package"
+	SourceRelationshipThreadedComment            = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	SourceRelationshipPerson                     = "http://schemas.microsoft.com/office/2017/10/relationships/person"
+	SourceRelationshipSheetMetadata              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
+	SourceRelationshipRichValue                  = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValue"
+	SourceRelationshipRichValueStructure         = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueStructure"
+	SourceRelationshipRichValueRel               = "http://schemas.microsoft.com/office/2022/10/relationships/richValueRel"
 	NameSpaceXML                                 = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                   = "http://www.w3.org/2001/XMLSchema-instance"
 	StrictSourceRelationship                     = "http://purl.oclc.org/ooxml/officeDocument/relationships"
@@ -59,6 +69,10 @@ const (
 	NameSpaceDublinCore                          = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreTerms                     = "http://purl.org/dc/terms/"
 	NameSpaceDublinCoreMetadataIntiative         = "http://purl.org/dc/dcmitype/"
+	NameSpaceSpreadSheetThreadedComments         = "http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"
+	NameSpaceSpreadSheetDynamicArray             = "http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"
+	NameSpaceSpreadSheetRichData                 = "http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"
+	NameSpaceDrawing2016SVG                      = "http://schemas.microsoft.com/office/drawing/2016/SVG/main"
 	ContentTypeDrawing                           = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                         = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
 	ContentTypeMacro                             = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
@@ -69,6 +83,14 @@ const (
 	ContentTypeSpreadSheetMLSharedStrings        = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	ContentTypeSpreadSheetMLThreadedComments     = "application/vnd.ms-excel.threadedcomments+xml"
+	ContentTypeSpreadSheetMLPerson               = "application/vnd.ms-excel.person+xml"
+	ContentTypeSpreadSheetMLSheetMetadata        = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
+	ContentTypeSpreadSheetMLRichValue            = "application/vnd.ms-excel.rdrichvalue+xml"
+	ContentTypeSpreadSheetMLRichValueStructure   = "application/vnd.ms-excel.rdrichvaluestructure+xml"
+	ContentTypeSpreadSheetMLRichValueRel         = "application/vnd.ms-excel.richvaluerel+xml"
+	ContentTypeSpreadSheetMLCtrlProp             = "application/vnd.ms-excel.controlproperties+xml"
+	ContentTypeOLEObject                         = "application/vnd.openxmlformats-officedocument.oleObject"
 	ContentTypeVBA                               = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                               = "application/vnd.openxmlformats-officedocument.vmlDrawing"
 	// ExtURIConditionalFormattings is the extLst child element
@@ -86,6 +108,8 @@ const (
 	ExtURIWebExtensions          = "{F7C9EE02-42E1-4005-9D12-6889AFFD525C}"
 	ExtURITimelineRefs           = "{7E03D99C-DC04-49d9-9315-930204A7B6E9}"
 	ExtURIDrawingBlip            = "{28A0092B-C50C-407E-A947-70E740481C1C}"
+	ExtURISVG                    = "{96DAC541-7B7A-43D3-8B79-37D633B846F1}"
+	ExtURIRichValueBlock         = "{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"
 	ExtURIMacExcelMX             = "{64002731-A6B0-56B0-2670-7721B7C09600}"
 )
 
@@ -102,7 +126,7 @@ const (
 	TotalCellChars       = 32767
 )
 
-var supportImageTypes = map[string]string{".gif": ".gif", ".jpg": ".jpeg", ".jpeg": ".jpeg", ".png": ".png", ".tif": ".tiff", ".tiff": ".tiff"}
+var supportImageTypes = map[string]string{".emf": ".emf", ".gif": ".gif", ".jpg": ".jpeg", ".jpeg": ".jpeg", ".png": ".png", ".svg": ".svg", ".tif": ".tiff", ".tiff": ".tiff", ".wmf": ".wmf"}
 
 // xlsxCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
 // element specifies non-visual canvas properties. This allows for additional
@@ -153,9 +177,42 @@ type xlsxPicLocks struct {
 // specifies the existence of an image (binary large image or picture) and
 // contains a reference to the image data.
 type xlsxBlip struct {
-	Embed  string `xml:"r:embed,attr"`
-	Cstate string `xml:"cstate,attr,omitempty"`
-	R      string `xml:"xmlns:r,attr"`
+	Embed       string                        `xml:"r:embed,attr"`
+	Cstate      string                        `xml:"cstate,attr,omitempty"`
+	R           string                        `xml:"xmlns:r,attr"`
+	AlphaModFix *aAlphaModFix                 `xml:"a:alphaModFix"`
+	ExtLst      *xlsxEGOfficeArtExtensionList `xml:"a:extLst"`
+}
+
+// aAlphaModFix (Alpha Modulate Fixed Effect) directly maps the a:alphaModFix
+// element. This element specifies the opacity of the picture in thousandths
+// of a percent.
+type aAlphaModFix struct {
+	Amt int `xml:"amt,attr"`
+}
+
+// xlsxEGOfficeArtExtensionList directly maps the extLst element of the blip.
+// This element specifies the extension list of the blip, such as the SVG
+// image of the picture.
+type xlsxEGOfficeArtExtensionList struct {
+	Ext []xlsxCTOfficeArtExtension `xml:"a:ext"`
+}
+
+// xlsxCTOfficeArtExtension directly maps the ext element of the blip
+// extension list.
+type xlsxCTOfficeArtExtension struct {
+	URI     string         `xml:"uri,attr"`
+	SVGBlip *xlsxCTSVGBlip `xml:"asvg:svgBlip"`
+}
+
+// xlsxCTSVGBlip directly maps the svgBlip element in the namespace
+// http://schemas.microsoft.com/office/drawing/2016/SVG/main. This element
+// specifies the reference to the SVG image, the blip of the picture will be
+// used as the fallback image for the spreadsheet applications which don't
+// support the SVG image.
+type xlsxCTSVGBlip struct {
+	XMLNSASVG string `xml:"xmlns:asvg,attr"`
+	Embed     string `xml:"r:embed,attr"`
 }
 
 // xlsxStretch directly maps the stretch element. This element specifies that a
@@ -191,8 +248,13 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
-	Off xlsxOff `xml:"a:off"`
-	Ext xlsxExt `xml:"a:ext"`
+	Rot   int      `xml:"rot,attr,omitempty"`
+	FlipH bool     `xml:"flipH,attr,omitempty"`
+	FlipV bool     `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff  `xml:"a:off"`
+	Ext   xlsxExt  `xml:"a:ext"`
+	ChOff *xlsxOff `xml:"a:chOff"`
+	ChExt *xlsxExt `xml:"a:chExt"`
 }
 
 // xlsxCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -232,6 +294,8 @@ type xlsxBlipFill struct {
 type xlsxSpPr struct {
 	Xfrm     xlsxXfrm     `xml:"a:xfrm"`
 	PrstGeom xlsxPrstGeom `xml:"a:prstGeom"`
+	NoFill   *string      `xml:"a:noFill"`
+	Ln       *aLn         `xml:"a:ln"`
 }
 
 // xlsxPic elements encompass the definition of pictures within the DrawingML
@@ -282,6 +346,8 @@ type xdrCellAnchor struct {
 	To           *xlsxTo        `xml:"xdr:to"`
 	Ext          *xlsxExt       `xml:"xdr:ext"`
 	Sp           *xdrSp         `xml:"xdr:sp"`
+	GrpSp        *xdrGrpSp      `xml:"xdr:grpSp"`
+	CxnSp        *xdrCxnSp      `xml:"xdr:cxnSp"`
 	Pic          *xlsxPic       `xml:"xdr:pic,omitempty"`
 	GraphicFrame string         `xml:",innerxml"`
 	ClientData   *xdrClientData `xml:"xdr:clientData"`
@@ -387,7 +453,75 @@ type xdrNvSpPr struct {
 // for a connection shape. These properties specify all data about the
 // connection shape which do not affect its display within a spreadsheet.
 type xdrCNvSpPr struct {
-	TxBox bool `xml:"txBox,attr"`
+	TxBox   bool      `xml:"txBox,attr"`
+	SpLocks *aSpLocks `xml:"a:spLocks"`
+}
+
+// aSpLocks (Shape Locks) directly maps the a:spLocks element. This element
+// specifies all locking properties for a shape, such as prevent the shape
+// from being selected, moved, resized or rotated.
+type aSpLocks struct {
+	NoSelect   bool `xml:"noSelect,attr,omitempty"`
+	NoRot      bool `xml:"noRot,attr,omitempty"`
+	NoMove     bool `xml:"noMove,attr,omitempty"`
+	NoResize   bool `xml:"noResize,attr,omitempty"`
+	NoTextEdit bool `xml:"noTextEdit,attr,omitempty"`
+}
+
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two shapes,
+// such as the straight, bent and curved connectors.
+type xdrCxnSp struct {
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr     `xml:"xdr:cNvPr"`
+	CNvCxnSpPr *xdrCNvCxnSpPr `xml:"xdr:cNvCxnSpPr"`
+}
+
+// xdrCNvCxnSpPr (Non-Visual Connector Shape Drawing Properties) directly maps
+// the xdr:cNvCxnSpPr element. This element specifies the shapes and the
+// connection sites which the connection shape connected with.
+type xdrCNvCxnSpPr struct {
+	StCxn  *aCxn `xml:"a:stCxn"`
+	EndCxn *aCxn `xml:"a:endCxn"`
+}
+
+// aCxn directly maps the a:stCxn and a:endCxn element. The ID is the
+// identifier of the connected shape, and the Idx is the index of the
+// connection site of the connected shape.
+type aCxn struct {
+	ID  int `xml:"id,attr"`
+	Idx int `xml:"idx,attr"`
+}
+
+// xdrGrpSp (Group Shape) directly maps the xdr:grpSp element. This element
+// specifies a group shape that represents many shapes grouped together. The
+// shapes in the group are stored as raw XML in the Content field.
+type xdrGrpSp struct {
+	NvGrpSpPr *xdrNvGrpSpPr `xml:"xdr:nvGrpSpPr"`
+	GrpSpPr   *xdrGrpSpPr   `xml:"xdr:grpSpPr"`
+	Content   string        `xml:",innerxml"`
+}
+
+// xdrNvGrpSpPr (Non-Visual Properties for a Group Shape) directly maps the
+// xdr:nvGrpSpPr element.
+type xdrNvGrpSpPr struct {
+	CNvPr      *xlsxCNvPr `xml:"xdr:cNvPr"`
+	CNvGrpSpPr string     `xml:"xdr:cNvGrpSpPr"`
+}
+
+// xdrGrpSpPr (Group Shape Properties) directly maps the xdr:grpSpPr element.
+// This element specifies the transform of the group shape and the coordinate
+// space of the shapes in the group.
+type xdrGrpSpPr struct {
+	Xfrm *xlsxXfrm `xml:"a:xfrm"`
 }
 
 // xdrStyle (Shape Style) directly maps the xdr:style element. The element
@@ -449,18 +583,102 @@ type formatPicture struct {
 	Hyperlink        string  `json:"hyperlink"`
 	HyperlinkType    string  `json:"hyperlink_type"`
 	Positioning      string  `json:"positioning"`
+	FitCell          bool    `json:"fit_cell"`
+	AbsoluteX        *int    `json:"absolute_x"`
+	AbsoluteY        *int    `json:"absolute_y"`
+	Fallback         string  `json:"fallback"`
+	FallbackData     []byte  `json:"fallback_data"`
+	InCell           bool    `json:"in_cell"`
+}
+
+// Picture directly maps the picture of the worksheet. The Cell and the
+// OffsetX, OffsetY are the top-left cell and the offsets of the picture in
+// pixels, the Width and Height are the size of the picture in pixels. The
+// Name and Description are the name and the alternative text of the picture,
+// and the Extension is the file extension of the picture, such as ".png".
+// The InCell will be true if the picture is placed in the cell. The Cell will
+// be empty and the OffsetX, OffsetY are the position of the picture on the
+// worksheet in pixels if the picture is placed by absolute position.
+type Picture struct {
+	InCell      bool
+	Cell        string
+	OffsetX     int
+	OffsetY     int
+	Width       int
+	Height      int
+	Name        string
+	Description string
+	Extension   string
+	File        []byte
 }
 
 // formatShape directly maps the format settings of the shape.
 type formatShape struct {
 	Type      string                 `json:"type"`
+	Name      string                 `json:"name"`
 	Width     int                    `json:"width"`
 	Height    int                    `json:"height"`
+	Rotation  float64                `json:"rotation"`
+	FlipH     bool                   `json:"flip_h"`
+	FlipV     bool                   `json:"flip_v"`
 	Format    formatPicture          `json:"format"`
 	Color     formatShapeColor       `json:"color"`
+	Line      formatShapeLine        `json:"line"`
+	Connector formatShapeConnector   `json:"connector"`
 	Paragraph []formatShapeParagraph `json:"paragraph"`
 }
 
+// formatShapeLine directly maps the line settings of the shape. The Width is
+// the line width in points, the HeadArrow and TailArrow are the arrow types
+// of the line ends.
+type formatShapeLine struct {
+	Width     float64 `json:"width"`
+	HeadArrow string  `json:"head_arrow"`
+	TailArrow string  `json:"tail_arrow"`
+}
+
+// formatShapeConnector directly maps the settings of the connector shape. The
+// StartShape and EndShape are the names of the shapes which connected by the
+// connector.
+type formatShapeConnector struct {
+	StartShape string `json:"start_shape"`
+	EndShape   string `json:"end_shape"`
+}
+
+// formatTextBox directly maps the format settings of the text box.
+type formatTextBox struct {
+	Name          string                   `json:"name"`
+	Width         int                      `json:"width"`
+	Height        int                      `json:"height"`
+	Rotation      float64                  `json:"rotation"`
+	Format        formatPicture            `json:"format"`
+	Color         formatShapeColor         `json:"color"`
+	Line          formatShapeLine          `json:"line"`
+	Margin        formatTextBoxMargin      `json:"margin"`
+	AutoFit       bool                     `json:"autofit"`
+	WrapText      bool                     `json:"wrap_text"`
+	TextDirection string                   `json:"text_direction"`
+	VerticalAlign string                   `json:"vertical_align"`
+	Paragraph     []formatTextBoxParagraph `json:"paragraph"`
+}
+
+// formatTextBoxMargin directly maps the internal margins of the text box in
+// pixels.
+type formatTextBoxMargin struct {
+	Left   *int `json:"left"`
+	Right  *int `json:"right"`
+	Top    *int `json:"top"`
+	Bottom *int `json:"bottom"`
+}
+
+// formatTextBoxParagraph directly maps the format settings of the paragraph
+// in the text box, each paragraph contains the text runs with different font
+// settings.
+type formatTextBoxParagraph struct {
+	Align string                 `json:"align"`
+	Runs  []formatShapeParagraph `json:"runs"`
+}
+
 // formatShapeParagraph directly maps the format settings of the paragraph in
 // the shape.
 type formatShapeParagraph struct {
@@ -468,6 +686,62 @@ type formatShapeParagraph struct {
 	Text string `json:"text"`
 }
 
+// formatWatermark directly maps the format settings of the watermark. The
+// OffsetX and OffsetY are the position of the watermark in pixels from the
+// top-left corner of the worksheet.
+type formatWatermark struct {
+	Name         string   `json:"name"`
+	Text         string   `json:"text"`
+	Picture      string   `json:"picture"`
+	Font         Font     `json:"font"`
+	Width        int      `json:"width"`
+	Height       int      `json:"height"`
+	OffsetX      int      `json:"x_offset"`
+	OffsetY      int      `json:"y_offset"`
+	Scale        float64  `json:"scale"`
+	Rotation     *float64 `json:"rotation"`
+	Transparency int      `json:"transparency"`
+	PrintObject  bool     `json:"print_obj"`
+}
+
+// Drawing directly maps the drawing object of the worksheet. The Type is the
+// type of the object, the value will be one of "Picture", "Shape",
+// "Connector", "GroupShape", "Chart", "GraphicFrame" and "FormControl". The
+// Cell is the top-left cell of the object, the OffsetX and OffsetY are the
+// offsets of the object in the top-left cell in pixels, and the Width and
+// Height are the size of the object in pixels.
+type Drawing struct {
+	Type        string
+	Name        string
+	Cell        string
+	OffsetX     int
+	OffsetY     int
+	Width       int
+	Height      int
+	Positioning string
+}
+
+// Shape directly maps the shape of the worksheet. The Cell is the top-left
+// cell of the shape, the Width and Height are the size of the shape in
+// pixels, and the Rotation is the rotation angle of the shape in degrees.
+// The Group is the name of the group shape which contains the shape, and
+// the StartShape and EndShape are the names of the shapes which connected by
+// the connector shape.
+type Shape struct {
+	Cell       string
+	Type       string
+	Name       string
+	Group      string
+	Width      int
+	Height     int
+	Rotation   float64
+	FlipH      bool
+	FlipV      bool
+	Text       string
+	StartShape string
+	EndShape   string
+}
+
 // formatShapeColor directly maps the color settings of the shape.
 type formatShapeColor struct {
 	Line   string `json:"line"`
@@ -0,0 +1,172 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxFormControlPr directly maps the formControlPr element in the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2009/9/main. This element
+// is the root of the control properties part xl/ctrlProps/ctrlProp%d.xml,
+// which specifies the properties of a form control.
+type xlsxFormControlPr struct {
+	XMLName    xml.Name `xml:"http://schemas.microsoft.com/office/spreadsheetml/2009/9/main formControlPr"`
+	ObjectType string   `xml:"objectType,attr"`
+	Checked    string   `xml:"checked,attr,omitempty"`
+	Dx         int      `xml:"dx,attr,omitempty"`
+	FmlaLink   string   `xml:"fmlaLink,attr,omitempty"`
+	FmlaRange  string   `xml:"fmlaRange,attr,omitempty"`
+	Horiz      bool     `xml:"horiz,attr,omitempty"`
+	Inc        int      `xml:"inc,attr,omitempty"`
+	LockText   bool     `xml:"lockText,attr,omitempty"`
+	Max        int      `xml:"max,attr,omitempty"`
+	Min        int      `xml:"min,attr,omitempty"`
+	NoThreeD   bool     `xml:"noThreeD,attr,omitempty"`
+	Page       int      `xml:"page,attr,omitempty"`
+	Sel        int      `xml:"sel,attr,omitempty"`
+	SelType    string   `xml:"selType,attr,omitempty"`
+	Val        int      `xml:"val,attr,omitempty"`
+}
+
+// xlsxControlAlternateContent directly maps the mc:AlternateContent element
+// of the form control in the controls element of the worksheet. The form
+// control requires the x14 namespace, and the spreadsheet applications which
+// don't support it will use the VML shape of the control.
+type xlsxControlAlternateContent struct {
+	XMLName  xml.Name          `xml:"mc:AlternateContent"`
+	XMLNSMC  string            `xml:"xmlns:mc,attr"`
+	XMLNSX14 string            `xml:"xmlns:x14,attr"`
+	XMLNSXdr string            `xml:"xmlns:xdr,attr"`
+	Choice   xlsxControlChoice `xml:"mc:Choice"`
+}
+
+// xlsxControlChoice directly maps the mc:Choice element of the form control.
+type xlsxControlChoice struct {
+	Requires string      `xml:"Requires,attr"`
+	Control  xlsxControl `xml:"control"`
+}
+
+// xlsxControl directly maps the control element. This element specifies the
+// shape ID of the VML shape, the relationship ID of the control properties
+// part and the name of the form control.
+type xlsxControl struct {
+	ShapeID   int            `xml:"shapeId,attr"`
+	RID       string         `xml:"r:id,attr"`
+	Name      string         `xml:"name,attr"`
+	ControlPr *xlsxControlPr `xml:"controlPr"`
+}
+
+// xlsxControlPr directly maps the controlPr element. This element specifies
+// the properties and the anchor of the form control.
+type xlsxControlPr struct {
+	DefaultSize bool               `xml:"defaultSize,attr"`
+	Print       *bool              `xml:"print,attr"`
+	AutoFill    bool               `xml:"autoFill,attr"`
+	AutoLine    bool               `xml:"autoLine,attr"`
+	AutoPict    bool               `xml:"autoPict,attr"`
+	Macro       string             `xml:"macro,attr,omitempty"`
+	Anchor      *xlsxControlAnchor `xml:"anchor"`
+}
+
+// xlsxControlAnchor directly maps the anchor element of the form control.
+type xlsxControlAnchor struct {
+	MoveWithCells bool     `xml:"moveWithCells,attr,omitempty"`
+	SizeWithCells bool     `xml:"sizeWithCells,attr,omitempty"`
+	From          xlsxFrom `xml:"from"`
+	To            xlsxTo   `xml:"to"`
+}
+
+// xFormControlClientData directly maps the x:ClientData element of the VML
+// shape of the form control.
+type xFormControlClientData struct {
+	ObjectType  string    `xml:"ObjectType,attr"`
+	Anchor      string    `xml:"x:Anchor"`
+	PrintObject string    `xml:"x:PrintObject,omitempty"`
+	AutoFill    string    `xml:"x:AutoFill"`
+	AutoLine    string    `xml:"x:AutoLine,omitempty"`
+	FmlaMacro   string    `xml:"x:FmlaMacro,omitempty"`
+	TextHAlign  string    `xml:"x:TextHAlign,omitempty"`
+	TextVAlign  string    `xml:"x:TextVAlign,omitempty"`
+	FmlaLink    string    `xml:"x:FmlaLink,omitempty"`
+	FmlaRange   string    `xml:"x:FmlaRange,omitempty"`
+	Checked     int       `xml:"x:Checked,omitempty"`
+	NoThreeD    *struct{} `xml:"x:NoThreeD"`
+	Val         *int      `xml:"x:Val"`
+	Min         *int      `xml:"x:Min"`
+	Max         *int      `xml:"x:Max"`
+	Inc         *int      `xml:"x:Inc"`
+	Page        *int      `xml:"x:Page"`
+	Horiz       *struct{} `xml:"x:Horiz"`
+	Dx          int       `xml:"x:Dx,omitempty"`
+	SelType     string    `xml:"x:SelType,omitempty"`
+}
+
+// encodeFormControl defines the structure used to serialize the inner XML of
+// the VML shape of the form control.
+type encodeFormControl struct {
+	Textbox    *vFormControlTextbox    `xml:"v:textbox"`
+	ClientData *xFormControlClientData `xml:"x:ClientData"`
+}
+
+// vFormControlTextbox directly maps the v:textbox element of the VML shape of
+// the form control.
+type vFormControlTextbox struct {
+	Style       string `xml:"style,attr"`
+	SingleClick string `xml:"o:singleclick,attr"`
+	Div         struct {
+		Style string `xml:"style,attr"`
+		Font  struct {
+			Face  string `xml:"face,attr"`
+			Size  int    `xml:"size,attr"`
+			Color string `xml:"color,attr"`
+			Val   string `xml:",chardata"`
+		} `xml:"font"`
+	} `xml:"div"`
+}
+
+// FormControlType is the type of the form controls.
+type FormControlType byte
+
+// This section defines the currently supported form control types
+// enumeration.
+const (
+	FormControlButton FormControlType = iota
+	FormControlCheckBox
+	FormControlOptionButton
+	FormControlSpinButton
+	FormControlScrollBar
+	FormControlListBox
+)
+
+// FormControl directly maps the form controls information. The Cell is the
+// top-left cell of the control, the Width and Height are the size of the
+// control in pixels. The Macro is the name of the macro assigned to the
+// button. The CellLink is the reference of the cell which linked with the
+// value of the control. The CurrentVal, MinVal, MaxVal, IncChange and
+// PageChange are the value settings of the spin button and the scroll bar,
+// and the InputRange is the reference of the list items of the list box.
+type FormControl struct {
+	Cell         string          `json:"cell"`
+	Type         FormControlType `json:"type"`
+	Text         string          `json:"text"`
+	Macro        string          `json:"macro"`
+	Width        int             `json:"width"`
+	Height       int             `json:"height"`
+	Checked      bool            `json:"checked"`
+	CurrentVal   int             `json:"current_val"`
+	MinVal       int             `json:"min_val"`
+	MaxVal       int             `json:"max_val"`
+	IncChange    int             `json:"inc_change"`
+	PageChange   int             `json:"page_change"`
+	Horizontally bool            `json:"horizontally"`
+	CellLink     string          `json:"cell_link"`
+	InputRange   string          `json:"input_range"`
+}
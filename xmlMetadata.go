@@ -0,0 +1,228 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set
+// of additional properties about the particular cell, and this metadata is
+// stored in the metadata part. The cell metadata is referred by the cm
+// attribute of the cell, and the value metadata is referred by the vm
+// attribute of the cell.
+type xlsxMetadata struct {
+	XMLName         xml.Name             `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	XMLNSXDA        string               `xml:"xmlns:xda,attr,omitempty"`
+	XMLNSXLRD       string               `xml:"xmlns:xlrd,attr,omitempty"`
+	MetadataTypes   *xlsxMetadataTypes   `xml:"metadataTypes"`
+	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
+	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
+	FutureMetadata  []xlsxFutureMetadata `xml:"futureMetadata"`
+	CellMetadata    *xlsxMetadataBlocks  `xml:"cellMetadata"`
+	ValueMetadata   *xlsxMetadataBlocks  `xml:"valueMetadata"`
+	ExtLst          *xlsxExtLst          `xml:"extLst"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the collection of metadata types in the workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr,omitempty"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single metadata type, the name of the metadata type is used
+// to find the future metadata which contains the properties of the type.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information, the properties of the metadata
+// are stored in the extLst of each block.
+type xlsxFutureMetadata struct {
+	Name  string                    `xml:"name,attr"`
+	Count int                       `xml:"count,attr,omitempty"`
+	Bk    []xlsxFutureMetadataBlock `xml:"bk"`
+}
+
+// xlsxFutureMetadataBlock directly maps the bk element of the future
+// metadata.
+type xlsxFutureMetadataBlock struct {
+	ExtLst *xlsxFutureMetadataExtLst `xml:"extLst"`
+}
+
+// xlsxFutureMetadataExtLst directly maps the extLst element of the future
+// metadata block.
+type xlsxFutureMetadataExtLst struct {
+	Ext []xlsxFutureMetadataExt `xml:"ext"`
+}
+
+// xlsxFutureMetadataExt directly maps the ext element of the future metadata
+// block, which contains the dynamic array properties or the rich value block.
+type xlsxFutureMetadataExt struct {
+	URI     string `xml:"uri,attr"`
+	Content string `xml:",innerxml"`
+}
+
+// decodeFutureMetadataExt defined the structure used to parse the content of
+// the ext element of the future metadata block.
+type decodeFutureMetadataExt struct {
+	DynamicArrayProperties *xlsxDynamicArrayProperties `xml:"dynamicArrayProperties"`
+	RichValueBlock         *xlsxRichValueBlock         `xml:"rvb"`
+}
+
+// xlsxDynamicArrayProperties directly maps the dynamicArrayProperties
+// element. This element specifies the properties of the dynamic array
+// formula.
+type xlsxDynamicArrayProperties struct {
+	FDynamic   bool `xml:"fDynamic,attr"`
+	FCollapsed bool `xml:"fCollapsed,attr"`
+}
+
+// xlsxRichValueBlock directly maps the rvb element. This element specifies
+// the index of the rich value in the rich value part.
+type xlsxRichValueBlock struct {
+	I int `xml:"i,attr"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// element. Each block contains a list of metadata records.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr,omitempty"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element of the cell metadata and
+// value metadata.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element specifies
+// the metadata record, the T attribute is the one-based index of the metadata
+// type and the V attribute is the zero-based index of the metadata of the
+// type.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
+
+// xlsxRichValueData directly maps the rvData element in the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2017/richdata. This
+// element is the root of the rich value part, which contains the rich values
+// referenced by the value metadata of the cells.
+type xlsxRichValueData struct {
+	XMLName xml.Name        `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvData"`
+	Count   int             `xml:"count,attr"`
+	Rv      []xlsxRichValue `xml:"rv"`
+	ExtLst  *xlsxInnerXML   `xml:"extLst"`
+}
+
+// xlsxRichValue directly maps the rv element. This element specifies a rich
+// value, the S attribute is the zero-based index of the rich value structure
+// and the values are in the order of the keys of the structure.
+type xlsxRichValue struct {
+	S  int              `xml:"s,attr"`
+	V  []xlsxRichValueV `xml:"v"`
+	Fb *xlsxInnerXML    `xml:"fb"`
+}
+
+// xlsxRichValueV directly maps the v element of the rich value.
+type xlsxRichValueV struct {
+	XMLSpace xml.Attr `xml:"space,attr,omitempty"`
+	Val      string   `xml:",chardata"`
+}
+
+// xlsxRichValueStructures directly maps the rvStructures element. This
+// element is the root of the rich value structure part, which contains the
+// structures of the rich values.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvStructures"`
+	Count   int                      `xml:"count,attr"`
+	S       []xlsxRichValueStructure `xml:"s"`
+	ExtLst  *xlsxInnerXML            `xml:"extLst"`
+}
+
+// xlsxRichValueStructure directly maps the s element. This element specifies
+// the type and the keys of a rich value structure.
+type xlsxRichValueStructure struct {
+	T string             `xml:"t,attr"`
+	K []xlsxRichValueKey `xml:"k"`
+}
+
+// xlsxRichValueKey directly maps the k element. This element specifies the
+// name and the type of a key of the rich value structure.
+type xlsxRichValueKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr,omitempty"`
+}
+
+// xlsxRichValueRels directly maps the richValueRels element in the namespace
+// http://schemas.microsoft.com/office/spreadsheetml/2022/richvaluerel. This
+// element is the root of the rich value relationships part, which contains
+// the relationships referenced by the rich values, such as the images of the
+// pictures in cells.
+type xlsxRichValueRels struct {
+	XMLName xml.Name           `xml:"http://schemas.microsoft.com/office/spreadsheetml/2022/richvaluerel richValueRels"`
+	Rel     []xlsxRichValueRel `xml:"rel"`
+	ExtLst  *xlsxInnerXML      `xml:"extLst"`
+}
+
+// xlsxRichValueRel directly maps the rel element of the rich value
+// relationships.
+type xlsxRichValueRel struct {
+	ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// CellMetadata directly maps the metadata of the cell. The CellMetadataIndex
+// and ValueMetadataIndex are the one-based index of the cell metadata and the
+// value metadata of the cell, and the value zero means the cell has no such
+// metadata. The Types is the list of the metadata type names of the cell,
+// such as "XLDAPR" for the dynamic array formula and "XLRICHVALUE" for the
+// rich value (linked data types and pictures in cell). The RichValueIndex is
+// the zero-based index of the rich value of the cell, and the value -1 means
+// the cell has no rich value.
+type CellMetadata struct {
+	CellMetadataIndex  int
+	ValueMetadataIndex int
+	Types              []string
+	DynamicArray       bool
+	RichValueIndex     int
+}
@@ -0,0 +1,127 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxOleObjectAlternateContent directly maps the mc:AlternateContent element
+// of the OLE object in the oleObjects element of the worksheet. The spreadsheet
+// applications which don't support the x14 namespace will use the OLE object
+// in the mc:Fallback element with the VML shape.
+type xlsxOleObjectAlternateContent struct {
+	XMLName  xml.Name              `xml:"mc:AlternateContent"`
+	XMLNSMC  string                `xml:"xmlns:mc,attr"`
+	XMLNSX14 string                `xml:"xmlns:x14,attr"`
+	XMLNSXdr string                `xml:"xmlns:xdr,attr"`
+	Choice   xlsxOleObjectChoice   `xml:"mc:Choice"`
+	Fallback xlsxOleObjectFallback `xml:"mc:Fallback"`
+}
+
+// xlsxOleObjectChoice directly maps the mc:Choice element of the OLE object.
+type xlsxOleObjectChoice struct {
+	Requires  string        `xml:"Requires,attr"`
+	OleObject xlsxOleObject `xml:"oleObject"`
+}
+
+// xlsxOleObjectFallback directly maps the mc:Fallback element of the OLE
+// object.
+type xlsxOleObjectFallback struct {
+	OleObject xlsxOleObject `xml:"oleObject"`
+}
+
+// xlsxOleObject directly maps the oleObject element. This element specifies
+// the program ID of the OLE object, the shape ID of the VML shape and the
+// relationship ID of the embedded object part.
+type xlsxOleObject struct {
+	ProgID   string        `xml:"progId,attr"`
+	DvAspect string        `xml:"dvAspect,attr"`
+	ShapeID  int           `xml:"shapeId,attr"`
+	RID      string        `xml:"r:id,attr"`
+	ObjectPr *xlsxObjectPr `xml:"objectPr"`
+}
+
+// xlsxObjectPr directly maps the objectPr element. This element specifies the
+// properties, the anchor and the relationship ID of the fallback image of the
+// OLE object.
+type xlsxObjectPr struct {
+	DefaultSize bool               `xml:"defaultSize,attr"`
+	AutoPict    bool               `xml:"autoPict,attr"`
+	AltText     string             `xml:"altText,attr,omitempty"`
+	RID         string             `xml:"r:id,attr"`
+	Anchor      *xlsxControlAnchor `xml:"anchor"`
+}
+
+// decodeOleObjects defines the structure used to parse the oleObjects element
+// of the worksheet, the OLE objects may be wrapped with the
+// mc:AlternateContent element.
+type decodeOleObjects struct {
+	AlternateContent []struct {
+		Choice struct {
+			OleObject decodeOleObject `xml:"oleObject"`
+		} `xml:"Choice"`
+	} `xml:"AlternateContent"`
+	OleObject []decodeOleObject `xml:"oleObject"`
+}
+
+// decodeOleObject directly maps the oleObject element.
+type decodeOleObject struct {
+	ProgID   string `xml:"progId,attr"`
+	ShapeID  int    `xml:"shapeId,attr"`
+	RID      string `xml:"id,attr"`
+	ObjectPr *struct {
+		AltText string `xml:"altText,attr"`
+		RID     string `xml:"id,attr"`
+		Anchor  *struct {
+			From decodeFrom `xml:"from"`
+			To   decodeTo   `xml:"to"`
+		} `xml:"anchor"`
+	} `xml:"objectPr"`
+}
+
+// encodeOLEObjectShape defines the structure used to serialize the inner XML
+// of the VML shape of the OLE object.
+type encodeOLEObjectShape struct {
+	ImageData  *vImageData           `xml:"v:imagedata"`
+	ClientData *xOLEObjectClientData `xml:"x:ClientData"`
+}
+
+// vImageData directly maps the v:imagedata element. The RelID is the
+// relationship ID of the image in the relationships of the VML drawing.
+type vImageData struct {
+	RelID string `xml:"o:relid,attr"`
+	Title string `xml:"o:title,attr"`
+}
+
+// xOLEObjectClientData directly maps the x:ClientData element of the VML
+// shape of the OLE object.
+type xOLEObjectClientData struct {
+	ObjectType    string    `xml:"ObjectType,attr"`
+	SizeWithCells *struct{} `xml:"x:SizeWithCells"`
+	Anchor        string    `xml:"x:Anchor"`
+	CF            string    `xml:"x:CF"`
+	AutoPict      *struct{} `xml:"x:AutoPict"`
+}
+
+// OLEObject directly maps the embedded OLE object of the worksheet. The Cell
+// is the top-left cell of the object, the File and FileName are the content
+// and the file name of the embedded file. The Caption is the label of the
+// object, and the Icon is the PNG format image displayed for the object. The
+// Width and Height are the size of the icon in pixels.
+type OLEObject struct {
+	Cell     string
+	FileName string
+	Caption  string
+	File     []byte
+	Icon     []byte
+	Width    int
+	Height   int
+}
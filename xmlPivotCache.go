@@ -121,10 +121,10 @@ type xlsxCacheField struct {
 // those values that are referenced in multiple places across all the
 // PivotTable parts.
 type xlsxSharedItems struct {
-	ContainsSemiMixedTypes bool          `xml:"containsSemiMixedTypes,attr,omitempty"`
-	ContainsNonDate        bool          `xml:"containsNonDate,attr,omitempty"`
+	ContainsSemiMixedTypes *bool         `xml:"containsSemiMixedTypes,attr"`
+	ContainsNonDate        *bool         `xml:"containsNonDate,attr"`
 	ContainsDate           bool          `xml:"containsDate,attr,omitempty"`
-	ContainsString         bool          `xml:"containsString,attr,omitempty"`
+	ContainsString         *bool         `xml:"containsString,attr"`
 	ContainsBlank          bool          `xml:"containsBlank,attr,omitempty"`
 	ContainsMixedTypes     bool          `xml:"containsMixedTypes,attr,omitempty"`
 	ContainsNumber         bool          `xml:"containsNumber,attr,omitempty"`
@@ -136,10 +136,10 @@ type xlsxSharedItems struct {
 	Count                  int           `xml:"count,attr"`
 	LongText               bool          `xml:"longText,attr,omitempty"`
 	M                      *xlsxMissing  `xml:"m"`
-	N                      *xlsxNumber   `xml:"n"`
+	N                      []xlsxNumber  `xml:"n"`
 	B                      *xlsxBoolean  `xml:"b"`
 	E                      *xlsxError    `xml:"e"`
-	S                      *xlsxString   `xml:"s"`
+	S                      []xlsxString  `xml:"s"`
 	D                      *xlsxDateTime `xml:"d"`
 }
 
@@ -276,11 +276,21 @@ type xlsxTableStyles struct {
 // a single table style definition that indicates how a spreadsheet application
 // should format and display a table.
 type xlsxTableStyle struct {
-	Name              string `xml:"name,attr,omitempty"`
-	Pivot             int    `xml:"pivot,attr"`
-	Count             int    `xml:"count,attr,omitempty"`
-	Table             bool   `xml:"table,attr,omitempty"`
-	TableStyleElement string `xml:",innerxml"`
+	Name              string                   `xml:"name,attr,omitempty"`
+	Pivot             *bool                    `xml:"pivot,attr"`
+	Count             int                      `xml:"count,attr,omitempty"`
+	Table             *bool                    `xml:"table,attr"`
+	TableStyleElement []*xlsxTableStyleElement `xml:"tableStyleElement"`
+}
+
+// xlsxTableStyleElement directly maps the tableStyleElement element. This
+// element specifies the formatting of a particular area of the table or
+// PivotTable by the differential formatting record, such as the header row
+// and the grand total row.
+type xlsxTableStyleElement struct {
+	Type  string `xml:"type,attr"`
+	Size  int    `xml:"size,attr,omitempty"`
+	DxfID *int   `xml:"dxfId,attr"`
 }
 
 // xlsxNumFmts directly maps the numFmts element. This element defines the
@@ -365,4 +375,5 @@ type Style struct {
 	CustomNumFmt  *string     `json:"custom_number_format"`
 	Lang          string      `json:"lang"`
 	NegRed        bool        `json:"negred"`
+	QuotePrefix   bool        `json:"quote_prefix"`
 }
@@ -22,20 +22,22 @@ type xlsxTable struct {
 	XMLName              xml.Name            `xml:"table"`
 	XMLNS                string              `xml:"xmlns,attr"`
 	DataCellStyle        string              `xml:"dataCellStyle,attr,omitempty"`
-	DataDxfID            int                 `xml:"dataDxfId,attr,omitempty"`
+	DataDxfID            *int                `xml:"dataDxfId,attr"`
 	DisplayName          string              `xml:"displayName,attr,omitempty"`
-	HeaderRowBorderDxfID int                 `xml:"headerRowBorderDxfId,attr,omitempty"`
+	HeaderRowBorderDxfID *int                `xml:"headerRowBorderDxfId,attr"`
 	HeaderRowCellStyle   string              `xml:"headerRowCellStyle,attr,omitempty"`
 	HeaderRowCount       int                 `xml:"headerRowCount,attr,omitempty"`
-	HeaderRowDxfID       int                 `xml:"headerRowDxfId,attr,omitempty"`
+	HeaderRowDxfID       *int                `xml:"headerRowDxfId,attr"`
 	ID                   int                 `xml:"id,attr"`
 	InsertRow            bool                `xml:"insertRow,attr,omitempty"`
 	InsertRowShift       bool                `xml:"insertRowShift,attr,omitempty"`
 	Name                 string              `xml:"name,attr"`
 	Published            bool                `xml:"published,attr,omitempty"`
 	Ref                  string              `xml:"ref,attr"`
+	TableBorderDxfID     *int                `xml:"tableBorderDxfId,attr"`
+	TotalsRowBorderDxfID *int                `xml:"totalsRowBorderDxfId,attr"`
 	TotalsRowCount       int                 `xml:"totalsRowCount,attr,omitempty"`
-	TotalsRowDxfID       int                 `xml:"totalsRowDxfId,attr,omitempty"`
+	TotalsRowDxfID       *int                `xml:"totalsRowDxfId,attr"`
 	TotalsRowShown       bool                `xml:"totalsRowShown,attr"`
 	AutoFilter           *xlsxAutoFilter     `xml:"autoFilter"`
 	TableColumns         *xlsxTableColumns   `xml:"tableColumns"`
@@ -172,14 +174,14 @@ type xlsxTableColumns struct {
 // this table.
 type xlsxTableColumn struct {
 	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
+	DataDxfID          *int   `xml:"dataDxfId,attr"`
 	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
+	HeaderRowDxfID     *int   `xml:"headerRowDxfId,attr"`
 	ID                 int    `xml:"id,attr"`
 	Name               string `xml:"name,attr"`
 	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
 	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
+	TotalsRowDxfID     *int   `xml:"totalsRowDxfId,attr"`
 	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
 	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
 	UniqueName         string `xml:"uniqueName,attr,omitempty"`
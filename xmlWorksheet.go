@@ -462,9 +462,11 @@ type xlsxC struct {
 	R        string   `xml:"r,attr,omitempty"` // Cell ID, e.g. A1
 	S        int      `xml:"s,attr,omitempty"` // Style reference.
 	// Str string `xml:"str,attr,omitempty"` // Style reference.
-	T  string  `xml:"t,attr,omitempty"` // Type.
-	F  *xlsxF  `xml:"f,omitempty"`      // Formula
-	V  string  `xml:"v,omitempty"`      // Value
+	T  string  `xml:"t,attr,omitempty"`  // Type.
+	CM uint    `xml:"cm,attr,omitempty"` // Cell metadata index.
+	VM uint    `xml:"vm,attr,omitempty"` // Value metadata index.
+	F  *xlsxF  `xml:"f,omitempty"`       // Formula
+	V  string  `xml:"v,omitempty"`       // Value
 	IS *xlsxSI `xml:"is"`
 }
 
@@ -472,6 +474,12 @@ func (c *xlsxC) hasValue() bool {
 	return c.S != 0 || c.V != "" || c.F != nil || c.T != ""
 }
 
+// hasData provides a function to check if the cell contains a value, formula
+// or inline string, the cell only with style is not included.
+func (c *xlsxC) hasData() bool {
+	return c.V != "" || c.F != nil || c.IS != nil
+}
+
 // xlsxF represents a formula for the cell. The formula expression is
 // contained in the character node of this element.
 type xlsxF struct {
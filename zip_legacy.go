@@ -0,0 +1,29 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+//go:build !go1.17
+// +build !go1.17
+
+package excelize
+
+import "archive/zip"
+
+// writeZipFile provides a function to write the part which has not been
+// decompressed to the ZIP writer by given path of the part and the ZIP file
+// entry of the source package. The part will be decompressed and compressed
+// again, the compressed data will be copied verbatim in Go version 1.17 or
+// later.
+func writeZipFile(zw *zip.Writer, name string, file *zip.File) error {
+	fi, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	return copyZipFile(fi, file)
+}
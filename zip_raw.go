@@ -0,0 +1,47 @@
+// Copyright 2016 - 2021 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX / XLSM / XLTM files. Supports reading and writing
+// spreadsheet documents generated by Microsoft Exce™ 2007 and later. Supports
+// complex components by high compatibility, and provided streaming API for
+// generating or reading data from a worksheet with huge amounts of data. This
+// library needs Go version 1.10 or later.
+//go:build go1.17
+// +build go1.17
+
+package excelize
+
+import (
+	"archive/zip"
+	"io"
+)
+
+// writeZipFile provides a function to write the part which has not been
+// decompressed to the ZIP writer by given path of the part and the ZIP file
+// entry of the source package. The compressed data of the part will be
+// copied verbatim without decompression and compression if it is compressed
+// by the supported method. This function requires Go version 1.17 or later,
+// the part will be decompressed and compressed again in the earlier versions.
+func writeZipFile(zw *zip.Writer, name string, file *zip.File) error {
+	if file.Method != zip.Store && file.Method != zip.Deflate {
+		fi, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		return copyZipFile(fi, file)
+	}
+	header := file.FileHeader
+	header.Name = name
+	fi, err := zw.CreateRaw(&header)
+	if err != nil {
+		return err
+	}
+	r, err := file.OpenRaw()
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fi, r)
+	return err
+}